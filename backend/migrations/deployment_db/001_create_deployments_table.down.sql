@@ -0,0 +1,8 @@
+-- Rollback deployment tables
+DROP INDEX IF EXISTS idx_deployment_events_deployment_id;
+DROP TABLE IF EXISTS deployment_events;
+
+DROP INDEX IF EXISTS idx_deployments_status;
+DROP INDEX IF EXISTS idx_deployments_build_id;
+DROP INDEX IF EXISTS idx_deployments_project_created;
+DROP TABLE IF EXISTS deployments;
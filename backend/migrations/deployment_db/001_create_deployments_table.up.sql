@@ -0,0 +1,34 @@
+-- Create deployments table for Deployment Service
+CREATE TABLE IF NOT EXISTS deployments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL,
+    build_id VARCHAR(64),
+    image_tag VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'stopped', 'failed', 'restarting')),
+    container_id VARCHAR(128),
+    host_port INT NOT NULL DEFAULT 0,
+    domain VARCHAR(255),
+    public_url VARCHAR(255),
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    stopped_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes for fast queries
+CREATE INDEX idx_deployments_project_created ON deployments(project_id, created_at DESC);
+CREATE INDEX idx_deployments_build_id ON deployments(build_id);
+CREATE INDEX idx_deployments_status ON deployments(status);
+
+-- Create deployment_events table (status history)
+CREATE TABLE IF NOT EXISTS deployment_events (
+    id BIGSERIAL PRIMARY KEY,
+    deployment_id UUID NOT NULL REFERENCES deployments(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index
+CREATE INDEX idx_deployment_events_deployment_id ON deployment_events(deployment_id);
@@ -0,0 +1,3 @@
+-- Rollback spec snapshots and rollback tracking
+ALTER TABLE deployments DROP COLUMN IF EXISTS spec_snapshot;
+ALTER TABLE deployments DROP COLUMN IF EXISTS rollback_of;
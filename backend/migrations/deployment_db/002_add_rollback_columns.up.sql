@@ -0,0 +1,3 @@
+-- Spec snapshots and rollback tracking
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS rollback_of UUID;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS spec_snapshot TEXT;
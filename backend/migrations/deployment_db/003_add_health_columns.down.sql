@@ -0,0 +1,10 @@
+-- Container port, health check config, health and restart count
+ALTER TABLE deployments DROP COLUMN IF EXISTS restart_count;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health_check_retries;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health_check_timeout_seconds;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health_check_interval_seconds;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health_check_expected_status;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health_check_path;
+ALTER TABLE deployments DROP COLUMN IF EXISTS health_check_type;
+ALTER TABLE deployments DROP COLUMN IF EXISTS port;
@@ -0,0 +1,10 @@
+-- Container port, health check config, health and restart count
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS port INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health_check_type VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health_check_path VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health_check_expected_status INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health_check_interval_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health_check_timeout_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health_check_retries INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS restart_count INTEGER NOT NULL DEFAULT 0;
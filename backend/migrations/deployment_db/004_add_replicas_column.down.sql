@@ -0,0 +1,2 @@
+-- Desired number of containers of a deployment
+ALTER TABLE deployments DROP COLUMN IF EXISTS replicas;
@@ -0,0 +1,2 @@
+-- Desired number of containers of a deployment
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS replicas INTEGER NOT NULL DEFAULT 1;
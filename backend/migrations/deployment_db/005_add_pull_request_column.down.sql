@@ -0,0 +1,3 @@
+-- Pull request of a preview deployment, 0 for production
+DROP INDEX IF EXISTS idx_deployments_pull_request;
+ALTER TABLE deployments DROP COLUMN IF EXISTS pull_request;
@@ -0,0 +1,3 @@
+-- Pull request of a preview deployment, 0 for production
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS pull_request INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_deployments_pull_request ON deployments (pull_request);
@@ -0,0 +1,5 @@
+-- Environment of a deployment, '' for production, and the deployment a
+-- promotion took its image from
+DROP INDEX IF EXISTS idx_deployments_environment;
+ALTER TABLE deployments DROP COLUMN IF EXISTS promoted_from;
+ALTER TABLE deployments DROP COLUMN IF EXISTS environment;
@@ -0,0 +1,5 @@
+-- Environment of a deployment, '' for production, and the deployment a
+-- promotion took its image from
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS environment VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS promoted_from UUID;
+CREATE INDEX IF NOT EXISTS idx_deployments_environment ON deployments (environment);
@@ -57,6 +57,11 @@ type Config struct {
 	LLMAPIURL string
 	LLMModel  string
 
+	// Custom domains (Project Service)
+	TraefikDomainSuffix  string        // Domain of the generated project URLs, not usable as a custom domain
+	DomainVerifyResolver string        // DNS server (host:port) for verification lookups, "" = system resolver
+	DomainVerifyTimeout  time.Duration // Timeout of a verification lookup
+
 	// CORS
 	AllowedOrigins []string
 
@@ -111,6 +116,10 @@ func LoadConfig() (*Config, error) {
 		LLMAPIURL: getEnv("LLM_API_URL", "http://ollama:11434/api/generate"),
 		LLMModel:  getEnv("LLM_MODEL", "deepseek-coder:6.7b-q4_0"),
 
+		TraefikDomainSuffix:  getEnv("TRAEFIK_DOMAIN_SUFFIX", "localhost"),
+		DomainVerifyResolver: getEnv("DOMAIN_VERIFY_RESOLVER", ""),
+		DomainVerifyTimeout:  getEnvAsDuration("DOMAIN_VERIFY_TIMEOUT", 5*time.Second),
+
 		AllowedOrigins: parseCommaSeparated(getEnv("ALLOWED_ORIGINS", "")),
 
 		GRPCTLSEnabled:         getEnvAsBool("GRPC_TLS_ENABLED", false),
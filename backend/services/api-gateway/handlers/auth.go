@@ -309,10 +309,20 @@ func (h *AuthHandler) HandleGetUserPlan(w http.ResponseWriter, r *http.Request)
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"plan":                  resp.Plan,
-		"max_projects":          resp.MaxProjects,
-		"max_builds_per_month":  resp.MaxBuildsPerMonth,
-		"rate_limit_per_window": resp.RateLimitPerWindow,
+		"plan":                      resp.Plan,
+		"max_projects":              resp.MaxProjects,
+		"max_builds_per_month":      resp.MaxBuildsPerMonth,
+		"rate_limit_per_window":     resp.RateLimitPerWindow,
+		"max_build_memory_mb":       resp.MaxBuildMemoryMb,
+		"max_build_cpus":            resp.MaxBuildCpus,
+		"max_build_timeout_minutes": resp.MaxBuildTimeoutMinutes,
+		"max_build_disk_mb":         resp.MaxBuildDiskMb,
+		"max_replicas":              resp.MaxReplicas,
+		"max_previews":              resp.MaxPreviews,
+		"max_concurrent_builds":     resp.MaxConcurrentBuilds,
+		"max_environments":          resp.MaxEnvironments,
+		"max_environment_memory_mb": resp.MaxEnvironmentMemoryMb,
+		"max_environment_cpu_cores": resp.MaxEnvironmentCpuCores,
 	})
 }
 
@@ -22,6 +22,12 @@ type BuildServiceClient interface {
 	GetBuild(ctx context.Context, in *buildpb.GetBuildRequest, opts ...grpc.CallOption) (*buildpb.GetBuildResponse, error)
 	GetBuildLogs(ctx context.Context, in *buildpb.GetBuildLogsRequest, opts ...grpc.CallOption) (*buildpb.GetBuildLogsResponse, error)
 	DeleteBuildLogs(ctx context.Context, in *buildpb.DeleteBuildLogsRequest, opts ...grpc.CallOption) (*buildpb.DeleteBuildLogsResponse, error)
+	GetBuildTestReport(ctx context.Context, in *buildpb.GetBuildTestReportRequest, opts ...grpc.CallOption) (*buildpb.GetBuildTestReportResponse, error)
+	CancelBuild(ctx context.Context, in *buildpb.CancelBuildRequest, opts ...grpc.CallOption) (*buildpb.CancelBuildResponse, error)
+	GetQueuePosition(ctx context.Context, in *buildpb.GetQueuePositionRequest, opts ...grpc.CallOption) (*buildpb.GetQueuePositionResponse, error)
+	GetBuildUsage(ctx context.Context, in *buildpb.GetBuildUsageRequest, opts ...grpc.CallOption) (*buildpb.GetBuildUsageResponse, error)
+	ListDeadLetters(ctx context.Context, in *buildpb.ListDeadLettersRequest, opts ...grpc.CallOption) (*buildpb.ListDeadLettersResponse, error)
+	RequeueDeadLetter(ctx context.Context, in *buildpb.RequeueDeadLetterRequest, opts ...grpc.CallOption) (*buildpb.RequeueDeadLetterResponse, error)
 }
 
 // AIServiceClient defines the methods of AI Service
@@ -54,6 +60,18 @@ type Build struct {
 	FinishedAt *time.Time `json:"finished_at,omitempty"`
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
+
+	DeploymentID string `json:"deployment_id,omitempty"` // Set when the build was deployed automatically
+	PullRequest  int32  `json:"pull_request,omitempty"`  // Set for preview builds of a pull request
+	Environment  string `json:"environment,omitempty"`   // Set for builds of an environment besides production
+	Branch       string `json:"branch,omitempty"`
+	SupersededBy string `json:"superseded_by,omitempty"` // Newer build of the branch that superseded this one
+
+	// Build matrix, the parent build aggregates its variant builds
+	ParentID string `json:"parent_id,omitempty"` // Parent of a variant build
+	Variant  string `json:"variant,omitempty"`
+	Optional bool   `json:"optional,omitempty"` // A failed variant does not fail the parent
+	Matrix   bool   `json:"matrix,omitempty"`   // Parent build of a matrix
 }
 
 type BuildStep struct {
@@ -64,6 +82,14 @@ type BuildStep struct {
 	DurationMs int32  `json:"duration_ms,omitempty"`
 }
 
+type TestCase struct {
+	Suite          string `json:"suite,omitempty"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	DurationMs     int32  `json:"duration_ms"`
+	FailureMessage string `json:"failure_message,omitempty"`
+}
+
 type BuildLogEntry struct {
 	ID        int64     `json:"id"`
 	BuildID   string    `json:"build_id"`
@@ -153,9 +179,15 @@ func (h *BuildHandler) GetBuild(w http.ResponseWriter, r *http.Request) {
 		steps = append(steps, protoToStep(s))
 	}
 
+	variants := make([]Build, 0, len(resp.Variants))
+	for _, v := range resp.Variants {
+		variants = append(variants, protoToBuild(v))
+	}
+
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"build": protoToBuild(resp.Build),
-		"steps": steps,
+		"build":    protoToBuild(resp.Build),
+		"steps":    steps,
+		"variants": variants,
 	})
 }
 
@@ -247,6 +279,189 @@ func (h *BuildHandler) GetBuildLogs(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetBuildTestReport handles GET /api/builds/{id}/tests
+func (h *BuildHandler) GetBuildTestReport(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	buildID := extractBuildID(r.URL.Path)
+	if buildID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "build_id required"})
+		return
+	}
+
+	resp, err := h.Client.GetBuildTestReport(r.Context(), &buildpb.GetBuildTestReportRequest{
+		BuildId: buildID,
+		UserId:  userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		status := http.StatusBadRequest
+		if resp.Error == "build not found" {
+			status = http.StatusNotFound
+		}
+		writeJSON(w, status, map[string]string{"error": resp.Error})
+		return
+	}
+
+	tests := make([]TestCase, 0, len(resp.Results))
+	for _, tc := range resp.Results {
+		tests = append(tests, TestCase{
+			Suite:          tc.Suite,
+			Name:           tc.Name,
+			Status:         tc.Status,
+			DurationMs:     tc.DurationMs,
+			FailureMessage: tc.FailureMessage,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"summary": map[string]interface{}{
+			"total":       resp.Total,
+			"passed":      resp.Passed,
+			"failed":      resp.Failed,
+			"skipped":     resp.Skipped,
+			"duration_ms": resp.DurationMs,
+		},
+		"tests": tests,
+	})
+}
+
+// CancelBuild handles POST /api/builds/{id}/cancel
+func (h *BuildHandler) CancelBuild(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	buildID := extractBuildID(r.URL.Path)
+	if buildID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "build_id required"})
+		return
+	}
+
+	resp, err := h.Client.CancelBuild(r.Context(), &buildpb.CancelBuildRequest{
+		BuildId: buildID,
+		UserId:  userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		status := http.StatusConflict
+		if resp.Error == "build not found" {
+			status = http.StatusNotFound
+		}
+		writeJSON(w, status, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"build": protoToBuild(resp.Build),
+	})
+}
+
+// GetQueuePosition handles GET /api/builds/{id}/queue
+func (h *BuildHandler) GetQueuePosition(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	buildID := extractBuildID(r.URL.Path)
+	if buildID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "build_id required"})
+		return
+	}
+
+	resp, err := h.Client.GetQueuePosition(r.Context(), &buildpb.GetQueuePositionRequest{
+		BuildId: buildID,
+		UserId:  userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		status := http.StatusBadRequest
+		if resp.Error == "build not found" {
+			status = http.StatusNotFound
+		}
+		writeJSON(w, status, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"build_id":     resp.BuildId,
+		"queued":       resp.Queued,
+		"queue":        resp.Queue,
+		"state":        resp.State,
+		"position":     resp.Position,
+		"queue_length": resp.QueueLength,
+		"reason":       resp.Reason,
+	})
+}
+
+// GetBuildUsage handles GET /api/user/usage, the builds of the user this month and
+// running across all of their projects
+func (h *BuildHandler) GetBuildUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	resp, err := h.Client.GetBuildUsage(r.Context(), &buildpb.GetBuildUsageRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"plan":                  resp.Plan,
+		"period_start":          toTime(resp.PeriodStart),
+		"resets_at":             toTime(resp.ResetsAt),
+		"builds_used":           resp.BuildsUsed,
+		"max_builds_per_month":  resp.MaxBuildsPerMonth,
+		"builds_remaining":      resp.BuildsRemaining,
+		"active_builds":         resp.ActiveBuilds,
+		"max_concurrent_builds": resp.MaxConcurrentBuilds,
+	})
+}
+
 // TriggerBuild handles POST /api/projects/{id}/builds (manual trigger)
 func (h *BuildHandler) TriggerBuild(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -267,9 +482,10 @@ func (h *BuildHandler) TriggerBuild(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		CommitSHA string `json:"commit_sha"`
-		Branch    string `json:"branch"`
-		RepoURL   string `json:"repo_url"`
+		CommitSHA   string `json:"commit_sha"`
+		Branch      string `json:"branch"`
+		RepoURL     string `json:"repo_url"`
+		Environment string `json:"environment"` // Optional, builds the branch of an environment for it
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -278,11 +494,12 @@ func (h *BuildHandler) TriggerBuild(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := h.Client.TriggerBuild(r.Context(), &buildpb.TriggerBuildRequest{
-		ProjectId: projectID,
-		UserId:    userID,
-		CommitSha: req.CommitSHA,
-		Branch:    req.Branch,
-		RepoUrl:   req.RepoURL,
+		ProjectId:   projectID,
+		UserId:      userID,
+		CommitSha:   req.CommitSHA,
+		Branch:      req.Branch,
+		RepoUrl:     req.RepoURL,
+		Environment: normalizeEnvironment(req.Environment),
 	})
 	if err != nil {
 		statusCode, message, _ := commonmw.HandleGRPCError(err)
@@ -392,6 +609,17 @@ func protoToBuild(b *buildpb.Build) Build {
 		FinishedAt: toTimePtr(b.FinishedAt),
 		CreatedAt:  toTime(b.CreatedAt),
 		UpdatedAt:  toTime(b.UpdatedAt),
+
+		DeploymentID: b.DeploymentId,
+		PullRequest:  b.PullRequest,
+		Environment:  b.Environment,
+		Branch:       b.Branch,
+		SupersededBy: b.SupersededBy,
+
+		ParentID: b.ParentId,
+		Variant:  b.Variant,
+		Optional: b.Optional,
+		Matrix:   b.Matrix,
 	}
 }
 
@@ -438,6 +666,10 @@ func statusToString(status buildpb.BuildStatus) string {
 		return "success"
 	case buildpb.BuildStatus_BUILD_STATUS_DEPLOY_FAILED:
 		return "deploy_failed"
+	case buildpb.BuildStatus_BUILD_STATUS_CANCELLED:
+		return "cancelled"
+	case buildpb.BuildStatus_BUILD_STATUS_SUPERSEDED:
+		return "superseded"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
+	apimw "github.com/nexusdeploy/backend/services/api-gateway/middleware"
+	buildpb "github.com/nexusdeploy/backend/services/build-service/proto"
+)
+
+// DeadLetter is a build job the queue archived instead of retrying: the project
+// failed the build ("user"), or the runner failed it on every attempt
+// ("infrastructure"). Admins only.
+type DeadLetter struct {
+	ID         string     `json:"id"`
+	BuildID    string     `json:"build_id"`
+	ProjectID  string     `json:"project_id"`
+	Queue      string     `json:"queue"`
+	Kind       string     `json:"kind"`
+	Reason     string     `json:"reason"`
+	Attempts   int32      `json:"attempts"`
+	ArchivedAt time.Time  `json:"archived_at"`
+	RequeuedAt *time.Time `json:"requeued_at,omitempty"`
+	RequeuedBy string     `json:"requeued_by,omitempty"`
+}
+
+// ListDeadLetters handles GET /api/admin/dead-letters?kind=&include_requeued=
+func (h *BuildHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	query := r.URL.Query()
+	resp, err := h.Client.ListDeadLetters(r.Context(), &buildpb.ListDeadLettersRequest{
+		UserId:          userID,
+		Kind:            query.Get("kind"),
+		IncludeRequeued: query.Get("include_requeued") == "true",
+		Page:            int32(parseQueryInt(r, "page", 1)),
+		PageSize:        int32(parseQueryInt(r, "page_size", 20)),
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, deadLetterErrorStatus(resp.Error), map[string]string{"error": resp.Error})
+		return
+	}
+
+	deadLetters := make([]DeadLetter, 0, len(resp.DeadLetters))
+	for _, d := range resp.DeadLetters {
+		deadLetters = append(deadLetters, protoToDeadLetter(d))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"dead_letters": deadLetters,
+		"total":        resp.Total,
+	})
+}
+
+// RequeueDeadLetter handles POST /api/admin/dead-letters/{id}/requeue
+func (h *BuildHandler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	id := extractDeadLetterID(r.URL.Path)
+	if id == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "dead_letter_id required"})
+		return
+	}
+
+	resp, err := h.Client.RequeueDeadLetter(r.Context(), &buildpb.RequeueDeadLetterRequest{
+		Id:     id,
+		UserId: userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, deadLetterErrorStatus(resp.Error), map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"dead_letter": protoToDeadLetter(resp.DeadLetter),
+		"build":       protoToBuild(resp.Build),
+	})
+}
+
+// deadLetterErrorStatus maps an error of the dead-letter API of Build Service to
+// an HTTP status
+func deadLetterErrorStatus(message string) int {
+	switch {
+	case strings.HasSuffix(message, "requires admin permission"):
+		return http.StatusForbidden
+	case message == "dead letter not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(message, "invalid"), strings.HasSuffix(message, "is required"), strings.HasSuffix(message, "are required"):
+		return http.StatusBadRequest
+	case strings.HasPrefix(message, "failed"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusConflict
+	}
+}
+
+func extractDeadLetterID(path string) string {
+	// /api/admin/dead-letters/{id}/requeue
+	const prefix = "/api/admin/dead-letters/"
+	if !strings.HasPrefix(path, prefix) {
+		return ""
+	}
+	rest := strings.TrimPrefix(path, prefix)
+	if idx := strings.Index(rest, "/"); idx != -1 {
+		return rest[:idx]
+	}
+	return rest
+}
+
+func protoToDeadLetter(d *buildpb.DeadLetter) DeadLetter {
+	if d == nil {
+		return DeadLetter{}
+	}
+	return DeadLetter{
+		ID:         d.Id,
+		BuildID:    d.BuildId,
+		ProjectID:  d.ProjectId,
+		Queue:      d.Queue,
+		Kind:       d.Kind,
+		Reason:     d.Reason,
+		Attempts:   d.Attempts,
+		ArchivedAt: toTime(d.ArchivedAt),
+		RequeuedAt: toTimePtr(d.RequeuedAt),
+		RequeuedBy: d.RequeuedBy,
+	}
+}
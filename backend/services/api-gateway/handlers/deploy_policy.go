@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
+	apimw "github.com/nexusdeploy/backend/services/api-gateway/middleware"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
+)
+
+// DeployPolicy decides when production of a project may be deployed: inside its
+// deploy windows, if it has any, and not while frozen
+type DeployPolicy struct {
+	ProjectID    string             `json:"project_id"`
+	Windows      []DeployWindow     `json:"windows"`
+	Frozen       bool               `json:"frozen"`
+	FreezeReason string             `json:"freeze_reason,omitempty"`
+	FrozenBy     string             `json:"frozen_by,omitempty"`
+	FrozenAt     *time.Time         `json:"frozen_at,omitempty"`
+	Open         bool               `json:"open"`                  // Whether production can be deployed now
+	NextWindow   *time.Time         `json:"next_window,omitempty"` // Next opening of a window when outside all windows
+	Audit        []DeployAuditEntry `json:"audit"`
+}
+
+// DeployWindow is a recurring period in which production deploys are allowed
+type DeployWindow struct {
+	Schedule        string `json:"schedule"` // 5-field cron expression opening the window
+	DurationMinutes int32  `json:"duration_minutes"`
+	Timezone        string `json:"timezone,omitempty"` // IANA name, empty = UTC
+}
+
+// DeployAuditEntry is a change of the deploy policy or an admin override of it
+type DeployAuditEntry struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Action      string    `json:"action"` // "freeze", "unfreeze", "windows" or "override"
+	Environment string    `json:"environment,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GetDeployPolicy handles GET /api/projects/{id}/deploy-policy
+func (h *ProjectHandler) GetDeployPolicy(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	resp, err := h.Client.GetDeployPolicy(r.Context(), &projectpb.GetDeployPolicyRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	writeDeployPolicy(w, resp, err)
+}
+
+// UpdateDeployWindows handles PUT /api/projects/{id}/deploy-policy. The windows
+// in the body replace the existing ones, none allows deploys at any time.
+func (h *ProjectHandler) UpdateDeployWindows(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	var req struct {
+		Windows []DeployWindow `json:"windows"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	windows := make([]*projectpb.DeployWindow, len(req.Windows))
+	for i, win := range req.Windows {
+		windows[i] = &projectpb.DeployWindow{
+			Schedule:        win.Schedule,
+			DurationMinutes: win.DurationMinutes,
+			Timezone:        win.Timezone,
+		}
+	}
+
+	resp, err := h.Client.SetDeployWindows(r.Context(), &projectpb.SetDeployWindowsRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+		Windows:   windows,
+	})
+	writeDeployPolicy(w, resp, err)
+}
+
+// FreezeDeploys handles POST /api/projects/{id}/freeze with a reason, and
+// DELETE to lift the freeze
+func (h *ProjectHandler) FreezeDeploys(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	req := &projectpb.SetDeployFreezeRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+		Frozen:    r.Method == http.MethodPost,
+	}
+	if req.Frozen {
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+			return
+		}
+		req.Reason = body.Reason
+	}
+
+	resp, err := h.Client.SetDeployFreeze(r.Context(), req)
+	writeDeployPolicy(w, resp, err)
+}
+
+// writeDeployPolicy writes the response of a deploy policy call
+func writeDeployPolicy(w http.ResponseWriter, resp *projectpb.DeployPolicyResponse, err error) {
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"policy": protoToDeployPolicy(resp.Policy),
+	})
+}
+
+func protoToDeployPolicy(p *projectpb.DeployPolicy) DeployPolicy {
+	if p == nil {
+		return DeployPolicy{}
+	}
+	policy := DeployPolicy{
+		ProjectID:    p.ProjectId,
+		Windows:      make([]DeployWindow, 0, len(p.Windows)),
+		Frozen:       p.Frozen,
+		FreezeReason: p.FreezeReason,
+		FrozenBy:     p.FrozenBy,
+		FrozenAt:     toTimePtr(p.FrozenAt),
+		Open:         p.Open,
+		NextWindow:   toTimePtr(p.NextWindow),
+		Audit:        make([]DeployAuditEntry, 0, len(p.Audit)),
+	}
+	for _, win := range p.Windows {
+		policy.Windows = append(policy.Windows, DeployWindow{
+			Schedule:        win.Schedule,
+			DurationMinutes: win.DurationMinutes,
+			Timezone:        win.Timezone,
+		})
+	}
+	for _, e := range p.Audit {
+		policy.Audit = append(policy.Audit, DeployAuditEntry{
+			ID:          e.Id,
+			UserID:      e.UserId,
+			Action:      e.Action,
+			Environment: e.Environment,
+			Reason:      e.Reason,
+			CreatedAt:   toTime(e.CreatedAt),
+		})
+	}
+	return policy
+}
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
 	apimw "github.com/nexusdeploy/backend/services/api-gateway/middleware"
@@ -14,6 +19,7 @@ import (
 	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
 	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 // DeploymentServiceClient defines the methods of Deployment Service
@@ -22,6 +28,12 @@ type DeploymentServiceClient interface {
 	StopDeployment(ctx context.Context, in *deploymentpb.StopDeploymentRequest, opts ...grpc.CallOption) (*deploymentpb.StopDeploymentResponse, error)
 	RestartDeployment(ctx context.Context, in *deploymentpb.RestartDeploymentRequest, opts ...grpc.CallOption) (*deploymentpb.RestartDeploymentResponse, error)
 	GetDeploymentStatus(ctx context.Context, in *deploymentpb.GetDeploymentStatusRequest, opts ...grpc.CallOption) (*deploymentpb.GetDeploymentStatusResponse, error)
+	ListDeployments(ctx context.Context, in *deploymentpb.ListDeploymentsRequest, opts ...grpc.CallOption) (*deploymentpb.ListDeploymentsResponse, error)
+	RollbackDeployment(ctx context.Context, in *deploymentpb.RollbackDeploymentRequest, opts ...grpc.CallOption) (*deploymentpb.RollbackDeploymentResponse, error)
+	ScaleDeployment(ctx context.Context, in *deploymentpb.ScaleDeploymentRequest, opts ...grpc.CallOption) (*deploymentpb.ScaleDeploymentResponse, error)
+	ListPreviews(ctx context.Context, in *deploymentpb.ListPreviewsRequest, opts ...grpc.CallOption) (*deploymentpb.ListPreviewsResponse, error)
+	StopPreview(ctx context.Context, in *deploymentpb.StopPreviewRequest, opts ...grpc.CallOption) (*deploymentpb.StopPreviewResponse, error)
+	PromoteDeployment(ctx context.Context, in *deploymentpb.PromoteDeploymentRequest, opts ...grpc.CallOption) (*deploymentpb.PromoteDeploymentResponse, error)
 }
 
 // BuildServiceClientForDeployment defines methods needed from Build Service
@@ -33,6 +45,8 @@ type BuildServiceClientForDeployment interface {
 type ProjectServiceClientForDeployment interface {
 	GetProject(ctx context.Context, in *projectpb.GetProjectRequest, opts ...grpc.CallOption) (*projectpb.GetProjectResponse, error)
 	GetSecrets(ctx context.Context, in *projectpb.GetSecretsRequest, opts ...grpc.CallOption) (*projectpb.GetSecretsResponse, error)
+	UpdateProject(ctx context.Context, in *projectpb.UpdateProjectRequest, opts ...grpc.CallOption) (*projectpb.UpdateProjectResponse, error)
+	CheckDeploy(ctx context.Context, in *projectpb.CheckDeployRequest, opts ...grpc.CallOption) (*projectpb.CheckDeployResponse, error)
 }
 
 // AuthServiceClientForDeployment defines methods needed from Auth Service
@@ -73,16 +87,59 @@ func NewDeploymentHandler(
 // ==================== REST Response Types ====================
 
 type Deployment struct {
-	ID          string `json:"id"`
-	ProjectID   string `json:"project_id"`
-	ContainerID string `json:"container_id"`
-	Status      string `json:"status"`
-	PublicURL   string `json:"public_url"`
+	ID           string `json:"id"`
+	ProjectID    string `json:"project_id"`
+	ContainerID  string `json:"container_id"`
+	Status       string `json:"status"`
+	PublicURL    string `json:"public_url"`
+	Health       string `json:"health,omitempty"` // "starting", "healthy" or "unhealthy"
+	RestartCount int32  `json:"restart_count"`
+
+	Replicas        int32 `json:"replicas,omitempty"`
+	RunningReplicas int32 `json:"running_replicas,omitempty"`
+
+	Environment string `json:"environment,omitempty"` // Empty for production
+}
+
+// DeploymentRecord is a deployment in the history of a project
+type DeploymentRecord struct {
+	ID          string            `json:"id"`
+	ProjectID   string            `json:"project_id"`
+	BuildID     string            `json:"build_id,omitempty"`
+	ImageTag    string            `json:"image_tag"`
+	Status      string            `json:"status"`
+	ContainerID string            `json:"container_id,omitempty"`
+	HostPort    int32             `json:"host_port,omitempty"`
+	Domain      string            `json:"domain,omitempty"`
+	PublicURL   string            `json:"public_url,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	StartedAt   time.Time         `json:"started_at"`
+	StoppedAt   *time.Time        `json:"stopped_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	History     []DeploymentEvent `json:"history"`
+	RollbackOf  string            `json:"rollback_of,omitempty"`
+
+	Health       string `json:"health,omitempty"`
+	RestartCount int32  `json:"restart_count"`
+	Replicas     int32  `json:"replicas,omitempty"`
+	PullRequest  int32  `json:"pull_request,omitempty"` // Set for previews
+	Environment  string `json:"environment,omitempty"`  // Empty for production
+	PromotedFrom string `json:"promoted_from,omitempty"`
+}
+
+// DeploymentEvent is a status change of a deployment
+type DeploymentEvent struct {
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // ==================== Deployment Endpoints ====================
 
-// Deploy handles POST /api/projects/{id}/deploy
+// Deploy handles POST /api/projects/{id}/deploy[?environment=name]. It deploys
+// the latest successful build of production, or of the environment. Production
+// deploys are subject to the deploy policy of the project, see checkDeployPolicy.
 func (h *DeploymentHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
@@ -104,6 +161,8 @@ func (h *DeploymentHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 	// Remove trailing /deploy if present
 	projectID = strings.TrimSuffix(projectID, "/deploy")
 
+	environment := normalizeEnvironment(r.URL.Query().Get("environment"))
+
 	ctx := r.Context()
 
 	// Step 1: Get latest successful build
@@ -130,10 +189,10 @@ func (h *DeploymentHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Find latest successful build
+	// Find latest successful build of the environment, previews are not deployed here
 	var latestBuild *buildpb.Build
 	for _, b := range buildsResp.Builds {
-		if b.Status == buildpb.BuildStatus_BUILD_STATUS_SUCCESS {
+		if b.Status == buildpb.BuildStatus_BUILD_STATUS_SUCCESS && b.PullRequest == 0 && b.Environment == environment {
 			latestBuild = b
 			break
 		}
@@ -162,22 +221,15 @@ func (h *DeploymentHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 
 	project := projectResp.Project
 
-	// Step 3: Get secrets (decrypted)
-	secretsResp, err := h.ProjectClient.GetSecrets(ctx, &projectpb.GetSecretsRequest{
-		ProjectId: projectID,
-	})
-	if err != nil {
-		statusCode, message, _ := commonmw.HandleGRPCError(err)
-		writeJSON(w, statusCode, map[string]string{"error": message})
+	if !h.checkDeployPolicy(w, r, projectID, environment, userID) {
 		return
 	}
 
-	// Build secrets map
-	secretsMap := make(map[string]string)
-	if secretsResp.Secrets != nil {
-		for k, v := range secretsResp.Secrets {
-			secretsMap[k] = v
-		}
+	// Step 3: Create deployment spec with the settings and secrets of the environment
+	deploymentSpec, err := h.deploymentSpec(ctx, project, environment, userID)
+	if err != nil {
+		writeDeploymentSpecError(w, err)
+		return
 	}
 
 	// Step 4: Generate image tag
@@ -190,26 +242,10 @@ func (h *DeploymentHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 		shortSHA = shortSHA[:8]
 	}
 	// Use "nexus/" prefix to match runner-service local image tag
-	imageTag := fmt.Sprintf("nexus/%s:%s", projectID, shortSHA)
+	deploymentSpec.BuildId = latestBuild.Id
+	deploymentSpec.ImageTag = fmt.Sprintf("nexus/%s:%s", projectID, shortSHA)
 
-	// Step 5: Create deployment spec
-	// Domain will be auto-generated by deployment-service using TRAEFIK_DOMAIN_SUFFIX
-	// Don't set Domain here to let deployment-service handle it
-	deploymentSpec := &deploymentpb.DeploymentSpec{
-		ProjectId: projectID,
-		BuildId:   latestBuild.Id,
-		ImageTag:  imageTag,
-		Port:      project.Port,
-		// Domain: leave empty to let deployment-service generate from TRAEFIK_DOMAIN_SUFFIX
-		Secrets: secretsMap,
-		Resources: &deploymentpb.ResourceLimits{
-			MemoryMb: 512, // Default 512MB
-			CpuCores: 1,   // Default 1 core
-		},
-		UserId: userID,
-	}
-
-	// Step 8: Call Deployment Service
+	// Step 5: Call Deployment Service
 	deployResp, err := h.DeploymentClient.Deploy(ctx, &deploymentpb.DeployRequest{
 		Spec: deploymentSpec,
 	})
@@ -231,11 +267,12 @@ func (h *DeploymentHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 			ContainerID: deployResp.ContainerId,
 			Status:      deployResp.Status,
 			PublicURL:   deployResp.PublicUrl,
+			Environment: environment,
 		},
 	})
 }
 
-// StopDeployment handles POST /api/projects/{id}/stop
+// StopDeployment handles POST /api/projects/{id}/stop[?environment=name]
 func (h *DeploymentHandler) StopDeployment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
@@ -261,7 +298,8 @@ func (h *DeploymentHandler) StopDeployment(w http.ResponseWriter, r *http.Reques
 
 	// Get current deployment status to find deployment_id
 	statusResp, err := h.DeploymentClient.GetDeploymentStatus(ctx, &deploymentpb.GetDeploymentStatusRequest{
-		ProjectId: projectID,
+		ProjectId:   projectID,
+		Environment: normalizeEnvironment(r.URL.Query().Get("environment")),
 		// DeploymentId can be empty, service will find by project_id
 	})
 	if err != nil {
@@ -357,7 +395,7 @@ func (h *DeploymentHandler) RestartDeployment(w http.ResponseWriter, r *http.Req
 	})
 }
 
-// GetDeploymentStatus handles GET /api/projects/{id}/deployment
+// GetDeploymentStatus handles GET /api/projects/{id}/deployment[?environment=name]
 func (h *DeploymentHandler) GetDeploymentStatus(w http.ResponseWriter, r *http.Request) {
 	userID := apimw.GetUserID(r.Context())
 	if userID == "" {
@@ -374,10 +412,13 @@ func (h *DeploymentHandler) GetDeploymentStatus(w http.ResponseWriter, r *http.R
 	// Remove trailing /deployment if present
 	projectID = strings.TrimSuffix(projectID, "/deployment")
 
+	environment := normalizeEnvironment(r.URL.Query().Get("environment"))
+
 	ctx := r.Context()
 
 	statusResp, err := h.DeploymentClient.GetDeploymentStatus(ctx, &deploymentpb.GetDeploymentStatusRequest{
-		ProjectId: projectID,
+		ProjectId:   projectID,
+		Environment: environment,
 	})
 	if err != nil {
 		statusCode, message, _ := commonmw.HandleGRPCError(err)
@@ -413,11 +454,18 @@ func (h *DeploymentHandler) GetDeploymentStatus(w http.ResponseWriter, r *http.R
 	}
 
 	deployment := Deployment{
-		ID:          statusResp.DeploymentId,
-		ProjectID:   projectID,
-		ContainerID: statusResp.ContainerId,
-		Status:      deploymentStatusToString(statusResp.Status),
-		PublicURL:   statusResp.PublicUrl,
+		ID:           statusResp.DeploymentId,
+		ProjectID:    projectID,
+		ContainerID:  statusResp.ContainerId,
+		Status:       deploymentStatusToString(statusResp.Status),
+		PublicURL:    statusResp.PublicUrl,
+		Health:       statusResp.Health,
+		RestartCount: statusResp.RestartCount,
+
+		Replicas:        statusResp.Replicas,
+		RunningReplicas: statusResp.RunningReplicas,
+
+		Environment: environment,
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
@@ -425,8 +473,553 @@ func (h *DeploymentHandler) GetDeploymentStatus(w http.ResponseWriter, r *http.R
 	})
 }
 
+// RollbackDeployment handles POST /api/projects/{id}/rollback. Rollbacks are
+// production deploys and subject to the deploy policy.
+func (h *DeploymentHandler) RollbackDeployment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	// Remove trailing /rollback if present
+	projectID = strings.TrimSuffix(projectID, "/rollback")
+
+	// Body is optional, the default is the previous build
+	var req struct {
+		BuildID string `json:"build_id"` // Build ID or "previous"
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if req.BuildID == "" {
+		req.BuildID = "previous"
+	}
+
+	ctx := r.Context()
+
+	// Check ownership, Deployment Service does not know project owners
+	projectResp, err := h.ProjectClient.GetProject(ctx, &projectpb.GetProjectRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if projectResp.Error != "" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": projectResp.Error})
+		return
+	}
+
+	if !h.checkDeployPolicy(w, r, projectID, "", userID) {
+		return
+	}
+
+	rollbackResp, err := h.DeploymentClient.RollbackDeployment(ctx, &deploymentpb.RollbackDeploymentRequest{
+		ProjectId:     projectID,
+		BuildId:       req.BuildID,
+		UserId:        userID,
+		CustomDomains: projectResp.Project.GetCustomDomains(),
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if rollbackResp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": rollbackResp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deployment": Deployment{
+			ID:          rollbackResp.DeploymentId,
+			ProjectID:   projectID,
+			ContainerID: rollbackResp.ContainerId,
+			Status:      rollbackResp.Status,
+			PublicURL:   rollbackResp.PublicUrl,
+		},
+		"build_id":    rollbackResp.BuildId,
+		"rollback_of": rollbackResp.RollbackOf,
+	})
+}
+
+// PromoteDeployment handles POST /api/projects/{id}/promote. It deploys the image
+// running in one environment to another, e.g. from staging to production, with
+// the settings and secrets of the target environment and without a build.
+func (h *DeploymentHandler) PromoteDeployment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	var req struct {
+		From string `json:"from"` // Environment name, "production" or empty for production
+		To   string `json:"to"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	from, to := normalizeEnvironment(req.From), normalizeEnvironment(req.To)
+	if from == to {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "from and to must be different environments"})
+		return
+	}
+
+	ctx := r.Context()
+
+	// Check ownership, Deployment Service does not know project owners
+	projectResp, err := h.ProjectClient.GetProject(ctx, &projectpb.GetProjectRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if projectResp.Error != "" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": projectResp.Error})
+		return
+	}
+
+	if !h.checkDeployPolicy(w, r, projectID, to, userID) {
+		return
+	}
+
+	if from != "" && findEnvironment(projectResp.Project, from) == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": errEnvironmentNotFound.Error()})
+		return
+	}
+	spec, err := h.deploymentSpec(ctx, projectResp.Project, to, userID)
+	if err != nil {
+		writeDeploymentSpecError(w, err)
+		return
+	}
+
+	promoteResp, err := h.DeploymentClient.PromoteDeployment(ctx, &deploymentpb.PromoteDeploymentRequest{
+		FromEnvironment: from,
+		Spec:            spec,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if promoteResp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": promoteResp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deployment": Deployment{
+			ID:          promoteResp.DeploymentId,
+			ProjectID:   projectID,
+			ContainerID: promoteResp.ContainerId,
+			Status:      promoteResp.Status,
+			PublicURL:   promoteResp.PublicUrl,
+			Environment: to,
+		},
+		"build_id":      promoteResp.BuildId,
+		"image_tag":     promoteResp.ImageTag,
+		"promoted_from": promoteResp.PromotedFrom,
+	})
+}
+
+// ScaleDeployment handles POST /api/projects/{id}/scale. The replica count is
+// saved as a project setting, checked against the plan by Project Service, and
+// applied to the serving deployment if there is one.
+func (h *DeploymentHandler) ScaleDeployment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	// Remove trailing /scale if present
+	projectID = strings.TrimSuffix(projectID, "/scale")
+
+	var req struct {
+		Replicas int32 `json:"replicas"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	ctx := r.Context()
+
+	// Also checks ownership
+	projectResp, err := h.ProjectClient.UpdateProject(ctx, &projectpb.UpdateProjectRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+		Replicas:  &req.Replicas,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if projectResp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": projectResp.Error})
+		return
+	}
+
+	statusResp, err := h.DeploymentClient.GetDeploymentStatus(ctx, &deploymentpb.GetDeploymentStatusRequest{
+		ProjectId: projectID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	// Without a running deployment the count applies to the next one
+	if statusResp.Status != deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"replicas": req.Replicas,
+		})
+		return
+	}
+
+	scaleResp, err := h.DeploymentClient.ScaleDeployment(ctx, &deploymentpb.ScaleDeploymentRequest{
+		DeploymentId: statusResp.DeploymentId,
+		ProjectId:    projectID,
+		Replicas:     req.Replicas,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if scaleResp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": scaleResp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deployment_id":    scaleResp.DeploymentId,
+		"replicas":         scaleResp.Replicas,
+		"running_replicas": scaleResp.RunningReplicas,
+	})
+}
+
+// planReplicas caps the replicas of a project by the plan of its owner, so a plan
+// downgrade applies to the next deployment
+func (h *DeploymentHandler) planReplicas(ctx context.Context, ownerID string, replicas int32) int32 {
+	if h.AuthClient == nil || ownerID == "" {
+		return replicas
+	}
+	plan, err := h.AuthClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{UserId: ownerID})
+	if err != nil || plan.Error != "" {
+		return replicas
+	}
+	if plan.MaxReplicas > 0 && replicas > plan.MaxReplicas {
+		return plan.MaxReplicas
+	}
+	return replicas
+}
+
+// ListDeployments handles GET /api/projects/{id}/deployments
+func (h *DeploymentHandler) ListDeployments(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID := extractPathParam(r.URL.Path, "/api/projects/")
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	// Remove trailing /deployments if present
+	projectID = strings.TrimSuffix(projectID, "/deployments")
+
+	ctx := r.Context()
+
+	// Deployment Service does not know project owners, check ownership first
+	projectResp, err := h.ProjectClient.GetProject(ctx, &projectpb.GetProjectRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if projectResp.Error != "" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": projectResp.Error})
+		return
+	}
+
+	page := parseQueryInt(r, "page", 1)
+	pageSize := parseQueryInt(r, "page_size", 20)
+
+	resp, err := h.DeploymentClient.ListDeployments(ctx, &deploymentpb.ListDeploymentsRequest{
+		ProjectId: projectID,
+		Page:      int32(page),
+		PageSize:  int32(pageSize),
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	deployments := make([]DeploymentRecord, 0, len(resp.Deployments))
+	for _, d := range resp.Deployments {
+		deployments = append(deployments, protoToDeploymentRecord(d))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deployments": deployments,
+		"total":       resp.Total,
+	})
+}
+
 // ==================== Helper Functions ====================
 
+// errEnvironmentNotFound is returned for an environment the project does not have
+var errEnvironmentNotFound = errors.New("environment not found")
+
+// deploymentSpec returns the spec of a deployment of an environment of a project,
+// "" for production, without its build and image. Environments have secrets,
+// container resources and a custom domain of their own.
+func (h *DeploymentHandler) deploymentSpec(ctx context.Context, project *projectpb.Project, environment, userID string) (*deploymentpb.DeploymentSpec, error) {
+	// Domain will be auto-generated by deployment-service using TRAEFIK_DOMAIN_SUFFIX
+	// Don't set Domain here to let deployment-service handle it
+	spec := &deploymentpb.DeploymentSpec{
+		ProjectId: project.Id,
+		Port:      project.Port,
+		Resources: &deploymentpb.ResourceLimits{
+			MemoryMb: 512, // Default 512MB
+			CpuCores: 1,   // Default 1 core
+		},
+		UserId:        userID,
+		HealthCheck:   projectHealthCheck(project),
+		Replicas:      h.planReplicas(ctx, project.UserId, project.Replicas),
+		CustomDomains: project.CustomDomains,
+		Environment:   environment,
+	}
+
+	if environment != "" {
+		env := findEnvironment(project, environment)
+		if env == nil {
+			return nil, errEnvironmentNotFound
+		}
+		if env.MemoryMb > 0 {
+			spec.Resources.MemoryMb = int64(env.MemoryMb)
+		}
+		if env.CpuCores > 0 {
+			spec.Resources.CpuCores = env.CpuCores
+		}
+		spec.CustomDomains = nil
+		if env.Domain != "" {
+			spec.CustomDomains = []string{env.Domain}
+		}
+	}
+
+	// Secrets (decrypted), those of the environment override the project ones
+	secretsResp, err := h.ProjectClient.GetSecrets(ctx, &projectpb.GetSecretsRequest{
+		ProjectId:   project.Id,
+		Environment: environment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if secretsResp.Error != "" {
+		return nil, errors.New(secretsResp.Error)
+	}
+	spec.Secrets = make(map[string]string, len(secretsResp.Secrets))
+	for k, v := range secretsResp.Secrets {
+		spec.Secrets[k] = v
+	}
+	return spec, nil
+}
+
+// checkDeployPolicy asks Project Service whether the deploy windows and freeze of
+// a project allow deploying an environment now, and writes the rejection if not.
+// Only production is restricted. Admins deploy anyway with
+// ?override=true&override_reason=..., which is audited by Project Service.
+func (h *DeploymentHandler) checkDeployPolicy(w http.ResponseWriter, r *http.Request, projectID, environment, userID string) bool {
+	query := r.URL.Query()
+	override, _ := strconv.ParseBool(query.Get("override"))
+
+	resp, err := h.ProjectClient.CheckDeploy(r.Context(), &projectpb.CheckDeployRequest{
+		ProjectId:      projectID,
+		UserId:         userID,
+		Environment:    environment,
+		Override:       override,
+		OverrideReason: query.Get("override_reason"),
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return false
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return false
+	}
+
+	if resp.Allowed {
+		return true
+	}
+
+	statusCode := http.StatusLocked
+	switch resp.Code {
+	case "override_reason_required":
+		statusCode = http.StatusBadRequest
+	case "override_not_permitted":
+		statusCode = http.StatusForbidden
+	}
+	body := map[string]interface{}{
+		"error":   resp.Code, // "deploy_frozen" or "outside_deploy_window"
+		"message": resp.Reason,
+	}
+	if resp.NextWindow != nil {
+		body["next_window"] = toTime(resp.NextWindow)
+	}
+	writeJSON(w, statusCode, body)
+	return false
+}
+
+// writeDeploymentSpecError writes the error of deploymentSpec
+func writeDeploymentSpecError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errEnvironmentNotFound) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	}
+	if _, ok := status.FromError(err); ok {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+}
+
+// findEnvironment returns an environment of a project by name
+func findEnvironment(project *projectpb.Project, name string) *projectpb.Environment {
+	for _, env := range project.GetEnvironments() {
+		if env.Name == name {
+			return env
+		}
+	}
+	return nil
+}
+
+// normalizeEnvironment returns the name of an environment as Deployment Service
+// stores it, "" for production
+func normalizeEnvironment(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "production" {
+		return ""
+	}
+	return name
+}
+
+// projectHealthCheck returns the health check configured for a project
+func projectHealthCheck(p *projectpb.Project) *deploymentpb.HealthCheck {
+	return &deploymentpb.HealthCheck{
+		Type:            p.HealthCheckType,
+		Path:            p.HealthCheckPath,
+		ExpectedStatus:  p.HealthCheckExpectedStatus,
+		IntervalSeconds: p.HealthCheckIntervalSeconds,
+		TimeoutSeconds:  p.HealthCheckTimeoutSeconds,
+		Retries:         p.HealthCheckRetries,
+	}
+}
+
+func protoToDeploymentRecord(d *deploymentpb.DeploymentRecord) DeploymentRecord {
+	history := make([]DeploymentEvent, 0, len(d.History))
+	for _, e := range d.History {
+		history = append(history, DeploymentEvent{
+			Status:    deploymentStatusToString(e.Status),
+			Message:   e.Message,
+			CreatedAt: toTime(e.CreatedAt),
+		})
+	}
+
+	return DeploymentRecord{
+		ID:          d.DeploymentId,
+		ProjectID:   d.ProjectId,
+		BuildID:     d.BuildId,
+		ImageTag:    d.ImageTag,
+		Status:      deploymentStatusToString(d.Status),
+		ContainerID: d.ContainerId,
+		HostPort:    d.HostPort,
+		Domain:      d.Domain,
+		PublicURL:   d.PublicUrl,
+		Error:       d.Error,
+		StartedAt:   toTime(d.StartedAt),
+		StoppedAt:   toTimePtr(d.StoppedAt),
+		CreatedAt:   toTime(d.CreatedAt),
+		UpdatedAt:   toTime(d.UpdatedAt),
+		History:     history,
+		RollbackOf:  d.RollbackOf,
+
+		Health:       d.Health,
+		RestartCount: d.RestartCount,
+		Replicas:     d.Replicas,
+		PullRequest:  d.PullRequest,
+		Environment:  d.Environment,
+		PromotedFrom: d.PromotedFrom,
+	}
+}
+
 func deploymentStatusToString(status deploymentpb.DeploymentStatus) string {
 	switch status {
 	case deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_PENDING:
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
+	apimw "github.com/nexusdeploy/backend/services/api-gateway/middleware"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
+)
+
+// Domain is a custom domain of a project. Verified domains are routed to the
+// deployments of the project from the next deployment on.
+type Domain struct {
+	ID             string     `json:"id"`
+	ProjectID      string     `json:"project_id"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"` // "pending", "verified" or "failed"
+	TXTRecordName  string     `json:"txt_record_name"`
+	TXTRecordValue string     `json:"txt_record_value"`
+	Error          string     `json:"error,omitempty"`
+	VerifiedAt     *time.Time `json:"verified_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ListDomains handles GET /api/projects/{id}/domains
+func (h *ProjectHandler) ListDomains(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, _ := extractProjectAndDomainID(r.URL.Path)
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	resp, err := h.Client.ListDomains(r.Context(), &projectpb.ListDomainsRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	domains := make([]Domain, 0, len(resp.Domains))
+	for _, d := range resp.Domains {
+		domains = append(domains, protoToDomain(d))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains": domains,
+	})
+}
+
+// AddDomain handles POST /api/projects/{id}/domains
+func (h *ProjectHandler) AddDomain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, _ := extractProjectAndDomainID(r.URL.Path)
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	resp, err := h.Client.AddDomain(r.Context(), &projectpb.AddDomainRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+		Name:      req.Name,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"domain": protoToDomain(resp.Domain),
+	})
+}
+
+// VerifyDomain handles POST /api/projects/{project_id}/domains/{domain_id}/verify
+func (h *ProjectHandler) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, domainID := extractProjectAndDomainID(r.URL.Path)
+	if projectID == "" || domainID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id and domain_id required"})
+		return
+	}
+
+	resp, err := h.Client.VerifyDomain(r.Context(), &projectpb.VerifyDomainRequest{
+		DomainId:  domainID,
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	// A missing TXT record is reported in the domain, with status "failed"
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domain": protoToDomain(resp.Domain),
+	})
+}
+
+// RemoveDomain handles DELETE /api/projects/{project_id}/domains/{domain_id}
+func (h *ProjectHandler) RemoveDomain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, domainID := extractProjectAndDomainID(r.URL.Path)
+	if projectID == "" || domainID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id and domain_id required"})
+		return
+	}
+
+	resp, err := h.Client.RemoveDomain(r.Context(), &projectpb.RemoveDomainRequest{
+		DomainId:  domainID,
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "domain removed",
+	})
+}
+
+// extractProjectAndDomainID parses /api/projects/{project_id}/domains[/{domain_id}[/verify]]
+func extractProjectAndDomainID(path string) (projectID, domainID string) {
+	const prefix = "/api/projects/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", ""
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, prefix), "/"), "/")
+	if len(parts) < 2 || parts[1] != "domains" {
+		return "", ""
+	}
+	if len(parts) >= 3 {
+		domainID = parts[2]
+	}
+	return parts[0], domainID
+}
+
+func protoToDomain(d *projectpb.Domain) Domain {
+	if d == nil {
+		return Domain{}
+	}
+	domain := Domain{
+		ID:             d.Id,
+		ProjectID:      d.ProjectId,
+		Name:           d.Name,
+		Status:         d.Status,
+		TXTRecordName:  d.TxtRecordName,
+		TXTRecordValue: d.TxtRecordValue,
+		Error:          d.Error,
+		CreatedAt:      toTime(d.CreatedAt),
+	}
+	if d.VerifiedAt != nil {
+		verifiedAt := d.VerifiedAt.AsTime()
+		domain.VerifiedAt = &verifiedAt
+	}
+	return domain
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
+	apimw "github.com/nexusdeploy/backend/services/api-gateway/middleware"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Environment is a deployment of a project next to production, e.g. staging.
+// Pushes to its branch are built and, with auto deploy, deployed to it.
+type Environment struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	Name       string    `json:"name"`
+	Branch     string    `json:"branch"`
+	Domain     string    `json:"domain,omitempty"` // Verified custom domain routed here instead of production
+	MemoryMB   int32     `json:"memory_mb"`        // 0 = 512
+	CPUCores   int32     `json:"cpu_cores"`        // 0 = 1
+	AutoDeploy bool      `json:"auto_deploy"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// environmentRequest is the body of CreateEnvironment and UpdateEnvironment
+type environmentRequest struct {
+	Name       string `json:"name"`
+	Branch     string `json:"branch"`
+	Domain     string `json:"domain"`
+	MemoryMB   int32  `json:"memory_mb"`
+	CPUCores   int32  `json:"cpu_cores"`
+	AutoDeploy bool   `json:"auto_deploy"`
+}
+
+// ListEnvironments handles GET /api/projects/{id}/environments
+func (h *ProjectHandler) ListEnvironments(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, _ := extractProjectAndEnvironment(r.URL.Path)
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	resp, err := h.Client.ListEnvironments(r.Context(), &projectpb.ListEnvironmentsRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"environments": protoToEnvironments(resp.Environments),
+	})
+}
+
+// CreateEnvironment handles POST /api/projects/{id}/environments
+func (h *ProjectHandler) CreateEnvironment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, _ := extractProjectAndEnvironment(r.URL.Path)
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	var req environmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	resp, err := h.Client.CreateEnvironment(r.Context(), &projectpb.CreateEnvironmentRequest{
+		ProjectId:  projectID,
+		UserId:     userID,
+		Name:       req.Name,
+		Branch:     req.Branch,
+		Domain:     req.Domain,
+		MemoryMb:   req.MemoryMB,
+		CpuCores:   req.CPUCores,
+		AutoDeploy: req.AutoDeploy,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"environment": protoToEnvironment(resp.Environment),
+	})
+}
+
+// UpdateEnvironment handles PUT /api/projects/{project_id}/environments/{name}
+func (h *ProjectHandler) UpdateEnvironment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPatch {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, name := extractProjectAndEnvironment(r.URL.Path)
+	if projectID == "" || name == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id and environment required"})
+		return
+	}
+
+	var req environmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	resp, err := h.Client.UpdateEnvironment(r.Context(), &projectpb.UpdateEnvironmentRequest{
+		ProjectId:  projectID,
+		UserId:     userID,
+		Name:       name,
+		Branch:     req.Branch,
+		Domain:     req.Domain,
+		MemoryMb:   req.MemoryMB,
+		CpuCores:   req.CPUCores,
+		AutoDeploy: req.AutoDeploy,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"environment": protoToEnvironment(resp.Environment),
+	})
+}
+
+// DeleteEnvironment handles DELETE /api/projects/{project_id}/environments/{name}.
+// The deployment of the environment is stopped first.
+func (h *ProjectHandler) DeleteEnvironment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, name := extractProjectAndEnvironment(r.URL.Path)
+	if projectID == "" || name == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id and environment required"})
+		return
+	}
+
+	ctx := r.Context()
+
+	// Check ownership before stopping anything
+	listResp, err := h.Client.ListEnvironments(ctx, &projectpb.ListEnvironmentsRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if listResp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": listResp.Error})
+		return
+	}
+
+	exists := false
+	for _, env := range listResp.Environments {
+		exists = exists || env.Name == name
+	}
+
+	// An environment that was never deployed has nothing to stop
+	if exists && h.StopEnvironment != nil {
+		if err := h.StopEnvironment(ctx, projectID, name); err != nil && !strings.Contains(err.Error(), "not found") {
+			log.Warn().Err(err).
+				Str("project_id", projectID).
+				Str("environment", name).
+				Msg("Failed to stop the deployment of the environment")
+			writeJSON(w, http.StatusBadGateway, map[string]string{"error": "failed to stop the deployment of the environment"})
+			return
+		}
+	}
+
+	resp, err := h.Client.DeleteEnvironment(ctx, &projectpb.DeleteEnvironmentRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+		Name:      name,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "environment deleted",
+	})
+}
+
+// extractProjectAndEnvironment parses /api/projects/{project_id}/environments[/{name}]
+func extractProjectAndEnvironment(path string) (projectID, name string) {
+	const prefix = "/api/projects/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", ""
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, prefix), "/"), "/")
+	if len(parts) < 2 || parts[1] != "environments" {
+		return "", ""
+	}
+	if len(parts) >= 3 {
+		name = parts[2]
+	}
+	return parts[0], name
+}
+
+func protoToEnvironment(e *projectpb.Environment) Environment {
+	if e == nil {
+		return Environment{}
+	}
+	return Environment{
+		ID:         e.Id,
+		ProjectID:  e.ProjectId,
+		Name:       e.Name,
+		Branch:     e.Branch,
+		Domain:     e.Domain,
+		MemoryMB:   e.MemoryMb,
+		CPUCores:   e.CpuCores,
+		AutoDeploy: e.AutoDeploy,
+		CreatedAt:  toTime(e.CreatedAt),
+		UpdatedAt:  toTime(e.UpdatedAt),
+	}
+}
+
+func protoToEnvironments(list []*projectpb.Environment) []Environment {
+	environments := make([]Environment, 0, len(list))
+	for _, e := range list {
+		environments = append(environments, protoToEnvironment(e))
+	}
+	return environments
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
+	apimw "github.com/nexusdeploy/backend/services/api-gateway/middleware"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
+)
+
+// Preview is the running preview of a pull request. Previews are created and
+// removed by the pull request events of the GitHub webhook.
+type Preview struct {
+	PullRequest  int32     `json:"pull_request"`
+	DeploymentID string    `json:"deployment_id"`
+	BuildID      string    `json:"build_id,omitempty"`
+	Status       string    `json:"status"`
+	PublicURL    string    `json:"public_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ListPreviews handles GET /api/projects/{id}/previews
+func (h *DeploymentHandler) ListPreviews(w http.ResponseWriter, r *http.Request) {
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, _ := extractProjectAndPullRequest(r.URL.Path)
+	if projectID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id required"})
+		return
+	}
+
+	if !h.checkProjectOwner(w, r, projectID, userID) {
+		return
+	}
+
+	resp, err := h.DeploymentClient.ListPreviews(r.Context(), &deploymentpb.ListPreviewsRequest{
+		ProjectId: projectID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	previews := make([]Preview, 0, len(resp.Previews))
+	for _, p := range resp.Previews {
+		previews = append(previews, Preview{
+			PullRequest:  p.PullRequest,
+			DeploymentID: p.DeploymentId,
+			BuildID:      p.BuildId,
+			Status:       deploymentStatusToString(p.Status),
+			PublicURL:    p.PublicUrl,
+			CreatedAt:    toTime(p.CreatedAt),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"previews": previews,
+	})
+}
+
+// StopPreview handles DELETE /api/projects/{id}/previews/{pull_request}. The
+// preview is deployed again by the next push to the pull request.
+func (h *DeploymentHandler) StopPreview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method_not_allowed"})
+		return
+	}
+
+	userID := apimw.GetUserID(r.Context())
+	if userID == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	projectID, pullRequest := extractProjectAndPullRequest(r.URL.Path)
+	if projectID == "" || pullRequest <= 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project_id and pull request number required"})
+		return
+	}
+
+	if !h.checkProjectOwner(w, r, projectID, userID) {
+		return
+	}
+
+	resp, err := h.DeploymentClient.StopPreview(r.Context(), &deploymentpb.StopPreviewRequest{
+		ProjectId:   projectID,
+		PullRequest: int32(pullRequest),
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return
+	}
+
+	if resp.Error != "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": resp.Error})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"stopped": resp.Stopped,
+	})
+}
+
+// checkProjectOwner writes an error response and returns false unless userID
+// owns the project. Deployment Service does not know project owners.
+func (h *DeploymentHandler) checkProjectOwner(w http.ResponseWriter, r *http.Request, projectID, userID string) bool {
+	projectResp, err := h.ProjectClient.GetProject(r.Context(), &projectpb.GetProjectRequest{
+		ProjectId: projectID,
+		UserId:    userID,
+	})
+	if err != nil {
+		statusCode, message, _ := commonmw.HandleGRPCError(err)
+		writeJSON(w, statusCode, map[string]string{"error": message})
+		return false
+	}
+
+	if projectResp.Error != "" {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": projectResp.Error})
+		return false
+	}
+	return true
+}
+
+// extractProjectAndPullRequest parses /api/projects/{project_id}/previews[/{pull_request}]
+func extractProjectAndPullRequest(path string) (projectID string, pullRequest int) {
+	const prefix = "/api/projects/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", 0
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, prefix), "/"), "/")
+	if len(parts) < 2 || parts[1] != "previews" {
+		return "", 0
+	}
+	if len(parts) >= 3 {
+		pullRequest, _ = strconv.Atoi(parts[2])
+	}
+	return parts[0], pullRequest
+}
@@ -28,12 +28,26 @@ type ProjectServiceClient interface {
 	UpdateSecret(ctx context.Context, in *projectpb.UpdateSecretRequest, opts ...grpc.CallOption) (*projectpb.UpdateSecretResponse, error)
 	DeleteSecret(ctx context.Context, in *projectpb.DeleteSecretRequest, opts ...grpc.CallOption) (*projectpb.DeleteSecretResponse, error)
 	ListSecrets(ctx context.Context, in *projectpb.ListSecretsRequest, opts ...grpc.CallOption) (*projectpb.ListSecretsResponse, error)
+	AddDomain(ctx context.Context, in *projectpb.AddDomainRequest, opts ...grpc.CallOption) (*projectpb.AddDomainResponse, error)
+	VerifyDomain(ctx context.Context, in *projectpb.VerifyDomainRequest, opts ...grpc.CallOption) (*projectpb.VerifyDomainResponse, error)
+	RemoveDomain(ctx context.Context, in *projectpb.RemoveDomainRequest, opts ...grpc.CallOption) (*projectpb.RemoveDomainResponse, error)
+	ListDomains(ctx context.Context, in *projectpb.ListDomainsRequest, opts ...grpc.CallOption) (*projectpb.ListDomainsResponse, error)
+	CreateEnvironment(ctx context.Context, in *projectpb.CreateEnvironmentRequest, opts ...grpc.CallOption) (*projectpb.CreateEnvironmentResponse, error)
+	UpdateEnvironment(ctx context.Context, in *projectpb.UpdateEnvironmentRequest, opts ...grpc.CallOption) (*projectpb.UpdateEnvironmentResponse, error)
+	DeleteEnvironment(ctx context.Context, in *projectpb.DeleteEnvironmentRequest, opts ...grpc.CallOption) (*projectpb.DeleteEnvironmentResponse, error)
+	ListEnvironments(ctx context.Context, in *projectpb.ListEnvironmentsRequest, opts ...grpc.CallOption) (*projectpb.ListEnvironmentsResponse, error)
+	GetDeployPolicy(ctx context.Context, in *projectpb.GetDeployPolicyRequest, opts ...grpc.CallOption) (*projectpb.DeployPolicyResponse, error)
+	SetDeployWindows(ctx context.Context, in *projectpb.SetDeployWindowsRequest, opts ...grpc.CallOption) (*projectpb.DeployPolicyResponse, error)
+	SetDeployFreeze(ctx context.Context, in *projectpb.SetDeployFreezeRequest, opts ...grpc.CallOption) (*projectpb.DeployPolicyResponse, error)
 }
 
 // ProjectHandler handles project-related requests
 type ProjectHandler struct {
 	Client         ProjectServiceClient
 	GetGitHubToken func(ctx context.Context, userID string) (string, error) // Callback to get user's GitHub token
+
+	// Callback to stop the deployment of an environment before it is deleted
+	StopEnvironment func(ctx context.Context, projectID, environment string) error
 }
 
 // NewProjectHandler creates a new ProjectHandler
@@ -51,11 +65,55 @@ type Project struct {
 	Preset       string    `json:"preset"`
 	BuildCommand string    `json:"build_command,omitempty"`
 	StartCommand string    `json:"start_command,omitempty"`
+	TestCommand  string    `json:"test_command,omitempty"`
 	Port         int32     `json:"port"`
 	GitHubRepoID int64     `json:"github_repo_id"`
 	IsPrivate    bool      `json:"is_private"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	BuildMemoryMB       int32   `json:"build_memory_mb"`
+	BuildCPUs           float64 `json:"build_cpus"`
+	BuildTimeoutMinutes int32   `json:"build_timeout_minutes"`
+	BuildDiskMB         int32   `json:"build_disk_mb"`
+
+	RootDirectory string   `json:"root_directory,omitempty"`
+	WatchPaths    []string `json:"watch_paths,omitempty"`
+
+	CloneSubmodules  bool  `json:"clone_submodules"`
+	CloneLFS         bool  `json:"clone_lfs"`
+	CloneDepth       int32 `json:"clone_depth"`
+	CloneFullHistory bool  `json:"clone_full_history"`
+	CloneFetchTags   bool  `json:"clone_fetch_tags"`
+
+	AutoDeploy bool `json:"auto_deploy"`
+
+	// Auto-cancel policy, a new build of a branch supersedes its older builds
+	AutoCancelPending bool `json:"auto_cancel_pending"` // Supersede queued builds
+	AutoCancelRunning bool `json:"auto_cancel_running"` // Also stop running builds
+
+	BuildMatrix []MatrixVariant `json:"build_matrix,omitempty"` // Variants built for every trigger
+
+	HealthCheckType            string `json:"health_check_type"`
+	HealthCheckPath            string `json:"health_check_path"`
+	HealthCheckExpectedStatus  int32  `json:"health_check_expected_status"`
+	HealthCheckIntervalSeconds int32  `json:"health_check_interval_seconds"`
+	HealthCheckTimeoutSeconds  int32  `json:"health_check_timeout_seconds"`
+	HealthCheckRetries         int32  `json:"health_check_retries"`
+
+	Replicas int32 `json:"replicas"`
+
+	CustomDomains []string      `json:"custom_domains,omitempty"` // Verified custom domains routed to production
+	Environments  []Environment `json:"environments,omitempty"`
+}
+
+// MatrixVariant is one combination of the build matrix of a project, built and
+// tested on its own base image
+type MatrixVariant struct {
+	Name      string            `json:"name"`
+	BaseImage string            `json:"base_image,omitempty"` // "" uses the image of the preset
+	Env       map[string]string `json:"env,omitempty"`
+	Optional  bool              `json:"optional"` // A failure does not fail the build
 }
 
 type Repository struct {
@@ -76,6 +134,8 @@ type Secret struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	Environment string `json:"environment,omitempty"` // Empty for secrets shared by all environments
 }
 
 // ==================== Project Endpoints ====================
@@ -100,9 +160,40 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 		Preset       string `json:"preset"`
 		BuildCommand string `json:"build_command"`
 		StartCommand string `json:"start_command"`
+		TestCommand  string `json:"test_command"`
 		Port         int32  `json:"port"`
 		GitHubRepoID int64  `json:"github_repo_id"`
 		IsPrivate    bool   `json:"is_private"`
+
+		BuildMemoryMB       int32   `json:"build_memory_mb"`
+		BuildCPUs           float64 `json:"build_cpus"`
+		BuildTimeoutMinutes int32   `json:"build_timeout_minutes"`
+		BuildDiskMB         int32   `json:"build_disk_mb"`
+
+		RootDirectory string   `json:"root_directory"`
+		WatchPaths    []string `json:"watch_paths"`
+
+		CloneSubmodules  bool  `json:"clone_submodules"`
+		CloneLFS         bool  `json:"clone_lfs"`
+		CloneDepth       int32 `json:"clone_depth"`
+		CloneFullHistory bool  `json:"clone_full_history"`
+		CloneFetchTags   bool  `json:"clone_fetch_tags"`
+
+		AutoDeploy bool `json:"auto_deploy"`
+
+		AutoCancelPending bool `json:"auto_cancel_pending"`
+		AutoCancelRunning bool `json:"auto_cancel_running"`
+
+		BuildMatrix []MatrixVariant `json:"build_matrix"`
+
+		HealthCheckType            string `json:"health_check_type"`
+		HealthCheckPath            string `json:"health_check_path"`
+		HealthCheckExpectedStatus  int32  `json:"health_check_expected_status"`
+		HealthCheckIntervalSeconds int32  `json:"health_check_interval_seconds"`
+		HealthCheckTimeoutSeconds  int32  `json:"health_check_timeout_seconds"`
+		HealthCheckRetries         int32  `json:"health_check_retries"`
+
+		Replicas int32 `json:"replicas"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -124,10 +215,41 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 		Preset:            req.Preset,
 		BuildCommand:      req.BuildCommand,
 		StartCommand:      req.StartCommand,
+		TestCommand:       req.TestCommand,
 		Port:              req.Port,
 		GithubRepoId:      req.GitHubRepoID,
 		IsPrivate:         req.IsPrivate,
 		GithubAccessToken: githubToken,
+
+		BuildMemoryMb:       req.BuildMemoryMB,
+		BuildCpus:           req.BuildCPUs,
+		BuildTimeoutMinutes: req.BuildTimeoutMinutes,
+		BuildDiskMb:         req.BuildDiskMB,
+
+		RootDirectory: req.RootDirectory,
+		WatchPaths:    req.WatchPaths,
+
+		CloneSubmodules:  req.CloneSubmodules,
+		CloneLfs:         req.CloneLFS,
+		CloneDepth:       req.CloneDepth,
+		CloneFullHistory: req.CloneFullHistory,
+		CloneFetchTags:   req.CloneFetchTags,
+
+		AutoDeploy: req.AutoDeploy,
+
+		AutoCancelPending: req.AutoCancelPending,
+		AutoCancelRunning: req.AutoCancelRunning,
+
+		BuildMatrix: matrixToProto(req.BuildMatrix),
+
+		HealthCheckType:            req.HealthCheckType,
+		HealthCheckPath:            req.HealthCheckPath,
+		HealthCheckExpectedStatus:  req.HealthCheckExpectedStatus,
+		HealthCheckIntervalSeconds: req.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  req.HealthCheckTimeoutSeconds,
+		HealthCheckRetries:         req.HealthCheckRetries,
+
+		Replicas: req.Replicas,
 	})
 	if err != nil {
 		statusCode, message, _ := commonmw.HandleGRPCError(err)
@@ -243,6 +365,38 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		BuildCommand string `json:"build_command"`
 		StartCommand string `json:"start_command"`
 		Port         int32  `json:"port"`
+
+		BuildMemoryMB       int32   `json:"build_memory_mb"`
+		BuildCPUs           float64 `json:"build_cpus"`
+		BuildTimeoutMinutes int32   `json:"build_timeout_minutes"`
+		BuildDiskMB         int32   `json:"build_disk_mb"`
+
+		// Pointers tell an omitted field from one set to empty, which resets it
+		TestCommand   *string   `json:"test_command"`
+		RootDirectory *string   `json:"root_directory"`
+		WatchPaths    *[]string `json:"watch_paths"`
+
+		CloneSubmodules  *bool  `json:"clone_submodules"`
+		CloneLFS         *bool  `json:"clone_lfs"`
+		CloneDepth       *int32 `json:"clone_depth"`
+		CloneFullHistory *bool  `json:"clone_full_history"`
+		CloneFetchTags   *bool  `json:"clone_fetch_tags"`
+
+		AutoDeploy *bool `json:"auto_deploy"`
+
+		AutoCancelPending *bool `json:"auto_cancel_pending"`
+		AutoCancelRunning *bool `json:"auto_cancel_running"`
+
+		BuildMatrix *[]MatrixVariant `json:"build_matrix"`
+
+		HealthCheckType            *string `json:"health_check_type"`
+		HealthCheckPath            *string `json:"health_check_path"`
+		HealthCheckExpectedStatus  *int32  `json:"health_check_expected_status"`
+		HealthCheckIntervalSeconds *int32  `json:"health_check_interval_seconds"`
+		HealthCheckTimeoutSeconds  *int32  `json:"health_check_timeout_seconds"`
+		HealthCheckRetries         *int32  `json:"health_check_retries"`
+
+		Replicas *int32 `json:"replicas"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -250,6 +404,15 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var watchPaths []string
+	if req.WatchPaths != nil {
+		watchPaths = *req.WatchPaths
+	}
+	var buildMatrix []MatrixVariant
+	if req.BuildMatrix != nil {
+		buildMatrix = *req.BuildMatrix
+	}
+
 	resp, err := h.Client.UpdateProject(r.Context(), &projectpb.UpdateProjectRequest{
 		ProjectId:    projectID,
 		UserId:       userID,
@@ -258,7 +421,40 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		Preset:       req.Preset,
 		BuildCommand: req.BuildCommand,
 		StartCommand: req.StartCommand,
+		TestCommand:  req.TestCommand,
 		Port:         req.Port,
+
+		BuildMemoryMb:       req.BuildMemoryMB,
+		BuildCpus:           req.BuildCPUs,
+		BuildTimeoutMinutes: req.BuildTimeoutMinutes,
+		BuildDiskMb:         req.BuildDiskMB,
+
+		RootDirectory:   req.RootDirectory,
+		WatchPaths:      watchPaths,
+		ClearWatchPaths: req.WatchPaths != nil && len(watchPaths) == 0,
+
+		CloneSubmodules:  req.CloneSubmodules,
+		CloneLfs:         req.CloneLFS,
+		CloneDepth:       req.CloneDepth,
+		CloneFullHistory: req.CloneFullHistory,
+		CloneFetchTags:   req.CloneFetchTags,
+
+		AutoDeploy: req.AutoDeploy,
+
+		AutoCancelPending: req.AutoCancelPending,
+		AutoCancelRunning: req.AutoCancelRunning,
+
+		BuildMatrix:      matrixToProto(buildMatrix),
+		ClearBuildMatrix: req.BuildMatrix != nil && len(buildMatrix) == 0,
+
+		HealthCheckType:            req.HealthCheckType,
+		HealthCheckPath:            req.HealthCheckPath,
+		HealthCheckExpectedStatus:  req.HealthCheckExpectedStatus,
+		HealthCheckIntervalSeconds: req.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  req.HealthCheckTimeoutSeconds,
+		HealthCheckRetries:         req.HealthCheckRetries,
+
+		Replicas: req.Replicas,
 	})
 	if err != nil {
 		statusCode, message, _ := commonmw.HandleGRPCError(err)
@@ -422,8 +618,9 @@ func (h *ProjectHandler) AddSecret(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		Name  string `json:"name"`
-		Value string `json:"value"`
+		Name        string `json:"name"`
+		Value       string `json:"value"`
+		Environment string `json:"environment"` // Optional, overrides the project secret of the same name there
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -432,10 +629,11 @@ func (h *ProjectHandler) AddSecret(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := h.Client.AddSecret(r.Context(), &projectpb.AddSecretRequest{
-		ProjectId: projectID,
-		UserId:    userID,
-		Name:      req.Name,
-		Value:     req.Value,
+		ProjectId:   projectID,
+		UserId:      userID,
+		Name:        req.Name,
+		Value:       req.Value,
+		Environment: req.Environment,
 	})
 	if err != nil {
 		statusCode, message, _ := commonmw.HandleGRPCError(err)
@@ -606,14 +804,74 @@ func protoToProject(p *projectpb.Project) Project {
 		Preset:       p.Preset,
 		BuildCommand: p.BuildCommand,
 		StartCommand: p.StartCommand,
+		TestCommand:  p.TestCommand,
 		Port:         p.Port,
 		GitHubRepoID: p.GithubRepoId,
 		IsPrivate:    p.IsPrivate,
 		CreatedAt:    toTime(p.CreatedAt),
 		UpdatedAt:    toTime(p.UpdatedAt),
+
+		BuildMemoryMB:       p.BuildMemoryMb,
+		BuildCPUs:           p.BuildCpus,
+		BuildTimeoutMinutes: p.BuildTimeoutMinutes,
+		BuildDiskMB:         p.BuildDiskMb,
+
+		RootDirectory: p.RootDirectory,
+		WatchPaths:    p.WatchPaths,
+
+		CloneSubmodules:  p.CloneSubmodules,
+		CloneLFS:         p.CloneLfs,
+		CloneDepth:       p.CloneDepth,
+		CloneFullHistory: p.CloneFullHistory,
+		CloneFetchTags:   p.CloneFetchTags,
+
+		AutoDeploy: p.AutoDeploy,
+
+		AutoCancelPending: p.AutoCancelPending,
+		AutoCancelRunning: p.AutoCancelRunning,
+
+		BuildMatrix: protoToMatrix(p.BuildMatrix),
+
+		HealthCheckType:            p.HealthCheckType,
+		HealthCheckPath:            p.HealthCheckPath,
+		HealthCheckExpectedStatus:  p.HealthCheckExpectedStatus,
+		HealthCheckIntervalSeconds: p.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  p.HealthCheckTimeoutSeconds,
+		HealthCheckRetries:         p.HealthCheckRetries,
+
+		Replicas: p.Replicas,
+
+		CustomDomains: p.CustomDomains,
+		Environments:  protoToEnvironments(p.Environments),
 	}
 }
 
+func matrixToProto(variants []MatrixVariant) []*projectpb.MatrixVariant {
+	result := make([]*projectpb.MatrixVariant, 0, len(variants))
+	for _, v := range variants {
+		result = append(result, &projectpb.MatrixVariant{
+			Name:      v.Name,
+			BaseImage: v.BaseImage,
+			Env:       v.Env,
+			Optional:  v.Optional,
+		})
+	}
+	return result
+}
+
+func protoToMatrix(variants []*projectpb.MatrixVariant) []MatrixVariant {
+	result := make([]MatrixVariant, 0, len(variants))
+	for _, v := range variants {
+		result = append(result, MatrixVariant{
+			Name:      v.Name,
+			BaseImage: v.BaseImage,
+			Env:       v.Env,
+			Optional:  v.Optional,
+		})
+	}
+	return result
+}
+
 func protoToSecret(s *projectpb.Secret) Secret {
 	if s == nil {
 		return Secret{}
@@ -624,5 +882,7 @@ func protoToSecret(s *projectpb.Secret) Secret {
 		Name:      s.Name,
 		CreatedAt: toTime(s.CreatedAt),
 		UpdatedAt: toTime(s.UpdatedAt),
+
+		Environment: s.Environment,
 	}
 }
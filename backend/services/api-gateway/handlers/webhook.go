@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"io"
 	"net/http"
+	"path"
 	"strings"
 
 	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
@@ -124,3 +125,63 @@ func validateSignature(signature string, payload, secret []byte) bool {
 
 	return hmac.Equal(calculated, sigBytes)
 }
+
+// MaxPushCommits is the number of commits GitHub lists in a push event at most.
+// A push listing that many may have changed more files than the event shows.
+const MaxPushCommits = 20
+
+// ProjectWatchPaths returns the globs a push must touch to build a project. Without
+// explicit watch paths, a project with a root directory watches that directory.
+// No globs means every push builds.
+func ProjectWatchPaths(watchPaths []string, rootDirectory string) []string {
+	if len(watchPaths) > 0 {
+		return watchPaths
+	}
+	if rootDirectory != "" {
+		return []string{rootDirectory}
+	}
+	return nil
+}
+
+// MatchWatchPaths reports whether any changed file matches one of the globs. Globs
+// are matched per path segment with path.Match, "**" matches any number of
+// segments, and a glob matching a directory matches everything below it.
+func MatchWatchPaths(patterns, files []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		patternSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+		for _, file := range files {
+			if matchSegments(patternSegs, strings.Split(file, "/")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return true
+}
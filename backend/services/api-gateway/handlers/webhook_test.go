@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchWatchPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		files    []string
+		want     bool
+	}{
+		{"no patterns match everything", nil, []string{"README.md"}, true},
+		{"no files", []string{"services/api"}, nil, false},
+		{"exact file", []string{"go.mod"}, []string{"go.mod"}, true},
+		{"directory matches below it", []string{"services/api"}, []string{"services/api/cmd/main.go"}, true},
+		{"trailing slash", []string{"services/api/"}, []string{"services/api/main.go"}, true},
+		{"sibling directory", []string{"services/api"}, []string{"services/api-gateway/main.go"}, false},
+		{"star within a segment", []string{"services/*/main.go"}, []string{"services/web/main.go"}, true},
+		{"star does not cross segments", []string{"services/*.go"}, []string{"services/api/main.go"}, false},
+		{"any of several patterns", []string{"docs", "libs/shared"}, []string{"libs/shared/log.go"}, true},
+		{"any of several files", []string{"libs/shared"}, []string{"README.md", "libs/shared/log.go"}, true},
+		{"doublestar prefix at root", []string{"**/*.go"}, []string{"main.go"}, true},
+		{"doublestar prefix nested", []string{"**/*.go"}, []string{"services/api/internal/db/db.go"}, true},
+		{"doublestar prefix no match", []string{"**/*.go"}, []string{"services/api/README.md"}, false},
+		{"doublestar suffix", []string{"libs/**"}, []string{"libs/shared/log/log.go"}, true},
+		{"doublestar suffix matches the directory", []string{"libs/**"}, []string{"libs"}, true},
+		{"doublestar in the middle, zero segments", []string{"services/**/go.mod"}, []string{"services/go.mod"}, true},
+		{"doublestar in the middle, several segments", []string{"services/**/go.mod"}, []string{"services/api/tools/go.mod"}, true},
+		{"doublestar in the middle, other file", []string{"services/**/go.mod"}, []string{"services/api/go.sum"}, false},
+		{"doublestar in the middle, other root", []string{"services/**/go.mod"}, []string{"libs/api/go.mod"}, false},
+		{"two doublestars", []string{"**/testdata/**"}, []string{"services/api/testdata/golden/out.json"}, true},
+		{"two doublestars no match", []string{"**/testdata/**"}, []string{"services/api/data/out.json"}, false},
+		{"doublestar then star", []string{"apps/**/*.ts"}, []string{"apps/web/src/index.ts"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchWatchPaths(tt.patterns, tt.files); got != tt.want {
+				t.Errorf("MatchWatchPaths(%q, %q) = %v, want %v", tt.patterns, tt.files, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"**", "a/b/c", true},
+		{"**/c", "c", true},
+		{"**/c", "a/b/c", true},
+		{"**/c", "a/b/c/d", true},
+		{"**/b/**/d", "a/b/c/d", true},
+		{"**/b/**/d", "b/d", true},
+		{"**/b/**/d", "a/c/d", false},
+		{"a/**/**/d", "a/d", true},
+		{"a/?/c", "a/b/c", true},
+		{"a/?/c", "a/bb/c", false},
+		{"a/[bc]/d", "a/c/d", true},
+		{"a/b/c", "a/b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			got := matchSegments(strings.Split(tt.pattern, "/"), strings.Split(tt.name, "/"))
+			if got != tt.want {
+				t.Errorf("matchSegments(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectWatchPaths(t *testing.T) {
+	tests := []struct {
+		name          string
+		watchPaths    []string
+		rootDirectory string
+		want          []string
+	}{
+		{"none", nil, "", nil},
+		{"root directory", nil, "services/api", []string{"services/api"}},
+		{"watch paths win", []string{"libs/**"}, "services/api", []string{"libs/**"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProjectWatchPaths(tt.watchPaths, tt.rootDirectory)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || (got == nil) != (tt.want == nil) {
+				t.Errorf("ProjectWatchPaths(%q, %q) = %q, want %q", tt.watchPaths, tt.rootDirectory, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -133,12 +134,38 @@ func main() {
 			return "", fmt.Errorf("get github token: %w", err)
 		}
 		if resp.Error != "" {
-			return "", fmt.Errorf(resp.Error)
+			return "", errors.New(resp.Error)
 		}
 		return resp.GithubToken, nil
 	}
 
+	// Deleting an environment stops its deployment first
+	projectHandler.StopEnvironment = func(ctx context.Context, projectID, environment string) error {
+		resp, err := deploymentClient.StopDeployment(ctx, &deploymentpb.StopDeploymentRequest{
+			ProjectId:   projectID,
+			Environment: environment,
+		})
+		if err != nil {
+			return fmt.Errorf("stop deployment: %w", err)
+		}
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		return nil
+	}
+
+	previews := &previewProcessor{
+		authClient:       authClient,
+		projectClient:    projectClient,
+		buildClient:      buildClient,
+		deploymentClient: deploymentClient,
+	}
 	webhookHandler := handlers.NewWebhookHandler(cfg.GitHubWebhookSecret, handlers.WebhookProcessorFunc(func(r *http.Request, event handlers.WebhookEvent) error {
+		// Pull requests get a preview deployment of their head commit
+		if event.Event == "pull_request" {
+			return previews.process(r, event)
+		}
+
 		// Trigger build when receiving push event from GitHub
 		if event.Event == "push" {
 			corrID := commonmw.GetCorrelationID(r.Context())
@@ -158,7 +185,13 @@ func main() {
 				HeadCommit struct {
 					ID string `json:"id"`
 				} `json:"head_commit"`
-				Ref string `json:"ref"` // "refs/heads/branch-name"
+				Ref     string `json:"ref"`    // "refs/heads/branch-name"
+				Forced  bool   `json:"forced"` // Force push, the commits may not be the full change
+				Commits []struct {
+					Added    []string `json:"added"`
+					Modified []string `json:"modified"`
+					Removed  []string `json:"removed"`
+				} `json:"commits"`
 			}
 
 			if err := json.Unmarshal(event.Payload, &payload); err != nil {
@@ -177,9 +210,30 @@ func main() {
 				branch = strings.TrimPrefix(payload.Ref, "refs/tags/")
 			}
 
-			// Find project by repository
+			if payload.HeadCommit.ID == "" {
+				log.Warn().
+					Str(commonmw.CorrelationIDKey, corrID).
+					Str("delivery_id", event.DeliveryID).
+					Str("repo_full_name", payload.Repository.FullName).
+					Msg("No head_commit found in payload, skipping build trigger")
+				return nil
+			}
+
+			// The changed files are only complete for a regular push that lists all its
+			// commits; a push without commits (e.g. a new branch pointing at an
+			// existing commit) has none
+			filterPaths := len(payload.Commits) > 0 && len(payload.Commits) < handlers.MaxPushCommits && !payload.Forced
+
+			var changedFiles []string
+			for _, c := range payload.Commits {
+				changedFiles = append(changedFiles, c.Added...)
+				changedFiles = append(changedFiles, c.Modified...)
+				changedFiles = append(changedFiles, c.Removed...)
+			}
+
+			// Find the projects of the repository, a monorepo can have several
 			ctx := r.Context()
-			projectResp, err := projectClient.GetProjectByRepo(ctx, &projectpb.GetProjectByRepoRequest{
+			projectsResp, err := projectClient.ListProjectsByRepo(ctx, &projectpb.GetProjectByRepoRequest{
 				RepoUrl:      payload.Repository.CloneURL,
 				GithubRepoId: payload.Repository.ID,
 			})
@@ -190,77 +244,96 @@ func main() {
 					Str("delivery_id", event.DeliveryID).
 					Str("repo_full_name", payload.Repository.FullName).
 					Int64("github_repo_id", payload.Repository.ID).
-					Msg("Failed to find project by repository")
+					Msg("Failed to find projects by repository")
 				return nil // Don't fail webhook
 			}
 
-			if projectResp.Error != "" || projectResp.Project == nil {
+			if projectsResp.Error != "" || len(projectsResp.Projects) == 0 {
 				log.Warn().
 					Str(commonmw.CorrelationIDKey, corrID).
 					Str("delivery_id", event.DeliveryID).
 					Str("repo_full_name", payload.Repository.FullName).
-					Str("error", projectResp.Error).
+					Str("error", projectsResp.Error).
 					Msg("Project not found for repository")
 				return nil // Don't fail webhook
 			}
 
-			// Check if branch matches project's configured branch
-			if projectResp.Project.Branch != "" && projectResp.Project.Branch != branch {
-				log.Info().
-					Str(commonmw.CorrelationIDKey, corrID).
-					Str("delivery_id", event.DeliveryID).
-					Str("project_id", projectResp.Project.Id).
-					Str("project_branch", projectResp.Project.Branch).
-					Str("push_branch", branch).
-					Msg("Branch mismatch, skipping build trigger")
-				return nil
-			}
-
-			// Trigger build
-			if payload.HeadCommit.ID == "" {
-				log.Warn().
-					Str(commonmw.CorrelationIDKey, corrID).
-					Str("delivery_id", event.DeliveryID).
-					Str("project_id", projectResp.Project.Id).
-					Msg("No head_commit found in payload, skipping build trigger")
-				return nil
-			}
-
-			buildResp, err := buildClient.TriggerBuild(ctx, &buildpb.TriggerBuildRequest{
-				ProjectId: projectResp.Project.Id,
-				CommitSha: payload.HeadCommit.ID,
-				Branch:    branch,
-				RepoUrl:   payload.Repository.CloneURL,
-			})
-			if err != nil {
-				log.Error().
-					Err(err).
-					Str(commonmw.CorrelationIDKey, corrID).
-					Str("delivery_id", event.DeliveryID).
-					Str("project_id", projectResp.Project.Id).
-					Str("commit_sha", payload.HeadCommit.ID).
-					Msg("Failed to trigger build from webhook")
-				return nil // Don't fail webhook, GitHub will retry if we return error
-			}
-
-			if buildResp.Error != "" {
-				log.Error().
-					Str(commonmw.CorrelationIDKey, corrID).
-					Str("delivery_id", event.DeliveryID).
-					Str("project_id", projectResp.Project.Id).
-					Str("error", buildResp.Error).
-					Msg("Build Service returned error when triggering build")
-				return nil
+			for _, project := range projectsResp.Projects {
+				// Production builds the branch of the project, the other environments
+				// their own branch
+				var environments []string
+				if project.Branch == "" || project.Branch == branch {
+					environments = append(environments, "")
+				}
+				for _, env := range project.Environments {
+					if env.Branch == branch {
+						environments = append(environments, env.Name)
+					}
+				}
+				if len(environments) == 0 {
+					log.Info().
+						Str(commonmw.CorrelationIDKey, corrID).
+						Str("delivery_id", event.DeliveryID).
+						Str("project_id", project.Id).
+						Str("project_branch", project.Branch).
+						Str("push_branch", branch).
+						Msg("Branch mismatch, skipping build trigger")
+					continue
+				}
+
+				// Pushes whose changed files are incomplete always build
+				watchPaths := handlers.ProjectWatchPaths(project.WatchPaths, project.RootDirectory)
+				if filterPaths && !handlers.MatchWatchPaths(watchPaths, changedFiles) {
+					log.Info().
+						Str(commonmw.CorrelationIDKey, corrID).
+						Str("delivery_id", event.DeliveryID).
+						Str("project_id", project.Id).
+						Strs("watch_paths", watchPaths).
+						Int("changed_files", len(changedFiles)).
+						Msg("No changed file matches the watch paths, skipping build trigger")
+					continue
+				}
+
+				for _, environment := range environments {
+					buildResp, err := buildClient.TriggerBuild(ctx, &buildpb.TriggerBuildRequest{
+						ProjectId:   project.Id,
+						CommitSha:   payload.HeadCommit.ID,
+						Branch:      branch,
+						RepoUrl:     payload.Repository.CloneURL,
+						Environment: environment,
+					})
+					if err != nil {
+						log.Error().
+							Err(err).
+							Str(commonmw.CorrelationIDKey, corrID).
+							Str("delivery_id", event.DeliveryID).
+							Str("project_id", project.Id).
+							Str("commit_sha", payload.HeadCommit.ID).
+							Msg("Failed to trigger build from webhook")
+						continue // Don't fail webhook, GitHub will retry if we return error
+					}
+
+					if buildResp.Error != "" {
+						log.Error().
+							Str(commonmw.CorrelationIDKey, corrID).
+							Str("delivery_id", event.DeliveryID).
+							Str("project_id", project.Id).
+							Str("error", buildResp.Error).
+							Msg("Build Service returned error when triggering build")
+						continue
+					}
+
+					log.Info().
+						Str(commonmw.CorrelationIDKey, corrID).
+						Str("delivery_id", event.DeliveryID).
+						Str("project_id", project.Id).
+						Str("build_id", buildResp.Build.Id).
+						Str("commit_sha", payload.HeadCommit.ID).
+						Str("branch", branch).
+						Str("environment", environment).
+						Msg("Successfully triggered build from webhook")
+				}
 			}
-
-			log.Info().
-				Str(commonmw.CorrelationIDKey, corrID).
-				Str("delivery_id", event.DeliveryID).
-				Str("project_id", projectResp.Project.Id).
-				Str("build_id", buildResp.Build.Id).
-				Str("commit_sha", payload.HeadCommit.ID).
-				Str("branch", branch).
-				Msg("Successfully triggered build from webhook")
 		}
 		return nil
 	}))
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	commonmw "github.com/nexusdeploy/backend/pkg/middleware"
+	"github.com/nexusdeploy/backend/services/api-gateway/handlers"
+	authpb "github.com/nexusdeploy/backend/services/auth-service/proto"
+	buildpb "github.com/nexusdeploy/backend/services/build-service/proto"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// previewStatusContext names the commit status of pull request previews. Runner
+// Service sets the final state with the URL of the preview.
+const previewStatusContext = "nexusdeploy/preview"
+
+// previewProcessor handles the pull_request events of the GitHub webhook. The
+// head of an open pull request is built and deployed as a preview of each
+// project of the repository whose branch is the base of the pull request. The
+// preview is removed when the pull request is closed.
+type previewProcessor struct {
+	authClient       authpb.AuthServiceClient
+	projectClient    projectpb.ProjectServiceClient
+	buildClient      buildpb.BuildServiceClient
+	deploymentClient deploymentpb.DeploymentServiceClient
+}
+
+// pullRequestEvent is the part of a pull_request webhook payload used here
+type pullRequestEvent struct {
+	Action      string `json:"action"` // "opened", "synchronize", "reopened", "closed", ...
+	Number      int32  `json:"number"`
+	PullRequest struct {
+		Head struct {
+			SHA  string `json:"sha"`
+			Ref  string `json:"ref"`
+			Repo struct {
+				ID int64 `json:"id"`
+			} `json:"repo"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository struct {
+		ID       int64  `json:"id"`
+		FullName string `json:"full_name"`
+		CloneURL string `json:"clone_url"`
+	} `json:"repository"`
+}
+
+// process handles a pull_request event. Like push events, failures are logged
+// and never fail the webhook.
+func (p *previewProcessor) process(r *http.Request, event handlers.WebhookEvent) error {
+	corrID := commonmw.GetCorrelationID(r.Context())
+
+	var payload pullRequestEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		log.Error().
+			Err(err).
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("delivery_id", event.DeliveryID).
+			Msg("Failed to parse pull request webhook payload")
+		return nil
+	}
+
+	var deploy bool
+	switch payload.Action {
+	case "opened", "synchronize", "reopened":
+		deploy = true
+	case "closed":
+	default:
+		return nil // Labels, reviews, edits, ...
+	}
+
+	log.Info().
+		Str(commonmw.CorrelationIDKey, corrID).
+		Str("delivery_id", event.DeliveryID).
+		Str("action", payload.Action).
+		Int32("pull_request", payload.Number).
+		Str("repo_full_name", payload.Repository.FullName).
+		Msg("Processing pull request event from GitHub webhook")
+
+	// Pull requests from forks would run foreign code with the project secrets
+	if deploy && payload.PullRequest.Head.Repo.ID != payload.Repository.ID {
+		log.Info().
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("delivery_id", event.DeliveryID).
+			Int32("pull_request", payload.Number).
+			Msg("Pull request comes from a fork, skipping preview")
+		return nil
+	}
+
+	ctx := r.Context()
+	projectsResp, err := p.projectClient.ListProjectsByRepo(ctx, &projectpb.GetProjectByRepoRequest{
+		RepoUrl:      payload.Repository.CloneURL,
+		GithubRepoId: payload.Repository.ID,
+	})
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("delivery_id", event.DeliveryID).
+			Str("repo_full_name", payload.Repository.FullName).
+			Msg("Failed to find projects by repository")
+		return nil
+	}
+	if projectsResp.Error != "" || len(projectsResp.Projects) == 0 {
+		log.Warn().
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("delivery_id", event.DeliveryID).
+			Str("repo_full_name", payload.Repository.FullName).
+			Str("error", projectsResp.Error).
+			Msg("Project not found for repository")
+		return nil
+	}
+
+	for _, project := range projectsResp.Projects {
+		if !deploy {
+			p.stopPreview(ctx, corrID, project, payload.Number)
+			continue
+		}
+
+		// Previews show what merging would deploy
+		if project.Branch != "" && project.Branch != payload.PullRequest.Base.Ref {
+			continue
+		}
+		p.deployPreview(ctx, corrID, project, &payload)
+	}
+	return nil
+}
+
+// deployPreview triggers the build of the head of a pull request, unless the
+// project already runs as many previews of other pull requests as the plan of
+// its owner allows
+func (p *previewProcessor) deployPreview(ctx context.Context, corrID string, project *projectpb.Project, payload *pullRequestEvent) {
+	sha := payload.PullRequest.Head.SHA
+	logger := log.With().
+		Str(commonmw.CorrelationIDKey, corrID).
+		Str("project_id", project.Id).
+		Int32("pull_request", payload.Number).
+		Str("commit_sha", sha).
+		Logger()
+
+	if limit, plan, err := p.previewLimit(ctx, project); err != nil {
+		// Fail open, like the other plan checks of webhook builds
+		logger.Warn().Err(err).Msg("Failed to check preview limit")
+	} else if limit <= 0 {
+		p.setStatus(ctx, project.Id, sha, "error", fmt.Sprintf("Previews are not included in the %s plan", plan))
+		return
+	} else {
+		previews, err := p.deploymentClient.ListPreviews(ctx, &deploymentpb.ListPreviewsRequest{ProjectId: project.Id})
+		switch {
+		case err != nil:
+			logger.Warn().Err(err).Msg("Failed to list previews")
+		case previews.Error != "":
+			logger.Warn().Str("error", previews.Error).Msg("Failed to list previews")
+		default:
+			// A new commit replaces the preview of its pull request. Pull requests
+			// whose preview is still building count as well, or several opened at
+			// once would all pass.
+			others := make(map[int32]bool)
+			for _, preview := range previews.Previews {
+				if preview.PullRequest != payload.Number {
+					others[preview.PullRequest] = true
+				}
+			}
+			builds, err := p.buildClient.ListPreviewBuilds(ctx, &buildpb.ListPreviewBuildsRequest{ProjectId: project.Id})
+			if err == nil && builds.Error != "" {
+				err = errors.New(builds.Error)
+			}
+			if err != nil {
+				logger.Warn().Err(err).Msg("Failed to list preview builds")
+			} else {
+				for _, pullRequest := range builds.PullRequests {
+					if pullRequest != payload.Number {
+						others[pullRequest] = true
+					}
+				}
+			}
+			if len(others) >= int(limit) {
+				logger.Info().Int("previews", len(others)).Int32("max_previews", limit).Msg("Preview limit reached, skipping preview")
+				p.setStatus(ctx, project.Id, sha, "error",
+					fmt.Sprintf("%d previews are running or building, the limit of the %s plan; close a pull request to free one", len(others), plan))
+				return
+			}
+		}
+	}
+
+	buildResp, err := p.buildClient.TriggerBuild(ctx, &buildpb.TriggerBuildRequest{
+		ProjectId:   project.Id,
+		CommitSha:   sha,
+		Branch:      payload.PullRequest.Head.Ref,
+		RepoUrl:     payload.Repository.CloneURL,
+		PullRequest: payload.Number,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to trigger preview build from webhook")
+		return
+	}
+	if buildResp.Error != "" {
+		logger.Error().Str("error", buildResp.Error).Msg("Build Service returned error when triggering preview build")
+		p.setStatus(ctx, project.Id, sha, "error", buildResp.Error)
+		return
+	}
+
+	logger.Info().Str("build_id", buildResp.Build.Id).Msg("Successfully triggered preview build from webhook")
+	p.setStatus(ctx, project.Id, sha, "pending", "Building preview")
+}
+
+// stopPreview removes the preview of a closed pull request. Its builds that have
+// not finished are cancelled first, so that none of them deploys a new preview.
+func (p *previewProcessor) stopPreview(ctx context.Context, corrID string, project *projectpb.Project, pullRequest int32) {
+	builds, err := p.buildClient.CancelPreviewBuilds(ctx, &buildpb.CancelPreviewBuildsRequest{
+		ProjectId:   project.Id,
+		PullRequest: pullRequest,
+	})
+	if err == nil && builds.Error != "" {
+		err = errors.New(builds.Error)
+	}
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("project_id", project.Id).
+			Int32("pull_request", pullRequest).
+			Msg("Failed to cancel preview builds of closed pull request")
+	} else if builds.Cancelled > 0 {
+		log.Info().
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("project_id", project.Id).
+			Int32("pull_request", pullRequest).
+			Int32("cancelled", builds.Cancelled).
+			Msg("Cancelled preview builds of closed pull request")
+	}
+
+	resp, err := p.deploymentClient.StopPreview(ctx, &deploymentpb.StopPreviewRequest{
+		ProjectId:   project.Id,
+		PullRequest: pullRequest,
+	})
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("project_id", project.Id).
+			Int32("pull_request", pullRequest).
+			Msg("Failed to stop preview of closed pull request")
+		return
+	}
+
+	if resp.Stopped > 0 {
+		log.Info().
+			Str(commonmw.CorrelationIDKey, corrID).
+			Str("project_id", project.Id).
+			Int32("pull_request", pullRequest).
+			Msg("Stopped preview of closed pull request")
+	}
+}
+
+// previewLimit returns the number of previews the plan of the project owner
+// allows, and the name of the plan
+func (p *previewProcessor) previewLimit(ctx context.Context, project *projectpb.Project) (int32, string, error) {
+	resp, err := p.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{UserId: project.UserId})
+	if err != nil {
+		return 0, "", err
+	}
+	if resp.Error != "" {
+		return 0, "", errors.New(resp.Error)
+	}
+	return resp.MaxPreviews, resp.Plan, nil
+}
+
+// setStatus sets the preview commit status of a commit. Failures are logged.
+func (p *previewProcessor) setStatus(ctx context.Context, projectID, sha, state, description string) {
+	resp, err := p.projectClient.SetCommitStatus(ctx, &projectpb.SetCommitStatusRequest{
+		ProjectId:   projectID,
+		CommitSha:   sha,
+		State:       state,
+		Description: description,
+		Context:     previewStatusContext,
+	})
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("project_id", projectID).
+			Str("commit_sha", sha).
+			Msg("Failed to set preview commit status")
+	}
+}
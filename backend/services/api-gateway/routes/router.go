@@ -129,6 +129,41 @@ func NewRouter(cfg RouterConfig) http.Handler {
 			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				// Check if it's a deployment path
 				if cfg.DeploymentHandler != nil {
+					if containsDeployments(r.URL.Path) {
+						if r.Method == http.MethodGet {
+							cfg.DeploymentHandler.ListDeployments(w, r)
+							return
+						}
+					}
+					if containsRollback(r.URL.Path) {
+						if r.Method == http.MethodPost {
+							cfg.DeploymentHandler.RollbackDeployment(w, r)
+							return
+						}
+					}
+					if containsPromote(r.URL.Path) {
+						if r.Method == http.MethodPost {
+							cfg.DeploymentHandler.PromoteDeployment(w, r)
+							return
+						}
+					}
+					if containsScale(r.URL.Path) {
+						if r.Method == http.MethodPost {
+							cfg.DeploymentHandler.ScaleDeployment(w, r)
+							return
+						}
+					}
+					if containsPreviews(r.URL.Path) {
+						switch r.Method {
+						case http.MethodGet:
+							cfg.DeploymentHandler.ListPreviews(w, r)
+						case http.MethodDelete:
+							cfg.DeploymentHandler.StopPreview(w, r)
+						default:
+							w.WriteHeader(http.StatusMethodNotAllowed)
+						}
+						return
+					}
 					if containsDeploy(r.URL.Path) {
 						if r.Method == http.MethodPost {
 							cfg.DeploymentHandler.Deploy(w, r)
@@ -171,6 +206,59 @@ func NewRouter(cfg RouterConfig) http.Handler {
 					}
 					return
 				}
+				// Custom domains of the project
+				if containsDomains(r.URL.Path) {
+					switch {
+					case strings.HasSuffix(r.URL.Path, "/verify"):
+						cfg.ProjectHandler.VerifyDomain(w, r)
+					case r.Method == http.MethodGet:
+						cfg.ProjectHandler.ListDomains(w, r)
+					case r.Method == http.MethodPost:
+						cfg.ProjectHandler.AddDomain(w, r)
+					case r.Method == http.MethodDelete:
+						cfg.ProjectHandler.RemoveDomain(w, r)
+					default:
+						w.WriteHeader(http.StatusMethodNotAllowed)
+					}
+					return
+				}
+				// Deploy windows and freeze of production
+				if containsDeployPolicy(r.URL.Path) {
+					switch r.Method {
+					case http.MethodGet:
+						cfg.ProjectHandler.GetDeployPolicy(w, r)
+					case http.MethodPut:
+						cfg.ProjectHandler.UpdateDeployWindows(w, r)
+					default:
+						w.WriteHeader(http.StatusMethodNotAllowed)
+					}
+					return
+				}
+				if containsFreeze(r.URL.Path) {
+					switch r.Method {
+					case http.MethodPost, http.MethodDelete:
+						cfg.ProjectHandler.FreezeDeploys(w, r)
+					default:
+						w.WriteHeader(http.StatusMethodNotAllowed)
+					}
+					return
+				}
+				// Environments of the project besides production
+				if containsEnvironments(r.URL.Path) {
+					switch r.Method {
+					case http.MethodGet:
+						cfg.ProjectHandler.ListEnvironments(w, r)
+					case http.MethodPost:
+						cfg.ProjectHandler.CreateEnvironment(w, r)
+					case http.MethodPut, http.MethodPatch:
+						cfg.ProjectHandler.UpdateEnvironment(w, r)
+					case http.MethodDelete:
+						cfg.ProjectHandler.DeleteEnvironment(w, r)
+					default:
+						w.WriteHeader(http.StatusMethodNotAllowed)
+					}
+					return
+				}
 				// Check if it's a secrets path
 				if containsSecrets(r.URL.Path) {
 					switch r.Method {
@@ -211,19 +299,57 @@ func NewRouter(cfg RouterConfig) http.Handler {
 	if cfg.BuildHandler != nil && cfg.AuthClient != nil {
 		authMW := apimw.AuthMiddleware(cfg.AuthClient)
 
+		// Build quota of the user: GET /api/user/usage
+		mux.Handle("/api/user/usage", chain(
+			http.HandlerFunc(cfg.BuildHandler.GetBuildUsage),
+			authMW,
+		))
+
+		// Dead-lettered builds, admins only: GET /api/admin/dead-letters
+		// Requeue one: POST /api/admin/dead-letters/{id}/requeue
+		mux.Handle("/api/admin/dead-letters", chain(
+			http.HandlerFunc(cfg.BuildHandler.ListDeadLetters),
+			authMW,
+		))
+		mux.Handle("/api/admin/dead-letters/", chain(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/requeue") {
+					cfg.BuildHandler.RequeueDeadLetter(w, r)
+					return
+				}
+				http.NotFound(w, r)
+			}),
+			authMW,
+		))
+
 		// Single build details: GET /api/builds/{id}
 		// Build logs: GET /api/builds/{id}/logs
 		// Analyze build: POST /api/builds/{id}/analyze
+		// Test report: GET /api/builds/{id}/tests
+		// Cancel build: POST /api/builds/{id}/cancel
+		// Queue position: GET /api/builds/{id}/queue
 		mux.Handle("/api/builds/", chain(
 			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if strings.HasSuffix(r.URL.Path, "/analyze") && r.Method == http.MethodPost {
 					cfg.BuildHandler.AnalyzeBuild(w, r)
 					return
 				}
+				if strings.HasSuffix(r.URL.Path, "/cancel") {
+					cfg.BuildHandler.CancelBuild(w, r)
+					return
+				}
 				if containsLogs(r.URL.Path) {
 					cfg.BuildHandler.GetBuildLogs(w, r)
 					return
 				}
+				if strings.HasSuffix(r.URL.Path, "/tests") {
+					cfg.BuildHandler.GetBuildTestReport(w, r)
+					return
+				}
+				if strings.HasSuffix(r.URL.Path, "/queue") {
+					cfg.BuildHandler.GetQueuePosition(w, r)
+					return
+				}
 				cfg.BuildHandler.GetBuild(w, r)
 			}),
 			authMW,
@@ -288,6 +414,11 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// containsDomains checks if the path contains /domains
+func containsDomains(path string) bool {
+	return strings.Contains(path, "/domains")
+}
+
 // containsSecrets checks if the path contains /secrets
 func containsSecrets(path string) bool {
 	return strings.Contains(path, "/secrets")
@@ -323,6 +454,46 @@ func containsDeployment(path string) bool {
 	return strings.HasSuffix(path, "/deployment") || strings.Contains(path, "/deployment/")
 }
 
+// containsRollback checks if the path ends with /rollback
+func containsRollback(path string) bool {
+	return strings.HasSuffix(path, "/rollback")
+}
+
+// containsScale checks if the path ends with /scale
+func containsScale(path string) bool {
+	return strings.HasSuffix(path, "/scale")
+}
+
+// containsPromote checks if the path ends with /promote
+func containsPromote(path string) bool {
+	return strings.HasSuffix(path, "/promote")
+}
+
+// containsEnvironments checks if the path contains /environments
+func containsEnvironments(path string) bool {
+	return strings.Contains(path, "/environments")
+}
+
+// containsDeployPolicy checks if the path ends with /deploy-policy
+func containsDeployPolicy(path string) bool {
+	return strings.HasSuffix(path, "/deploy-policy")
+}
+
+// containsFreeze checks if the path ends with /freeze
+func containsFreeze(path string) bool {
+	return strings.HasSuffix(path, "/freeze")
+}
+
+// containsPreviews checks if the path contains /previews
+func containsPreviews(path string) bool {
+	return strings.Contains(path, "/previews")
+}
+
+// containsDeployments checks if the path ends with /deployments
+func containsDeployments(path string) bool {
+	return strings.HasSuffix(path, "/deployments")
+}
+
 // metricsMiddleware ghi lại metrics cho mỗi HTTP request
 func metricsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
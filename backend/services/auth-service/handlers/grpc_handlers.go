@@ -26,6 +26,8 @@ const (
 	blacklistKeyPrefix   = "auth:jwt:blacklist:"
 	defaultPlan          = "standard"
 	customDomainResource = "custom_domain"
+	deployPolicyResource = "deploy_policy" // Overriding deploy windows and freezes, admins only
+	buildQueueResource   = "build_queue"   // Listing and requeueing dead-lettered builds, admins only
 	refreshTokenTTL      = 7 * 24 * time.Hour
 )
 
@@ -41,20 +43,61 @@ type Claims struct {
 // planLimits contains limits by plan.
 type planLimits struct {
 	MaxProjects        int32
-	MaxBuildsPerMonth  int32
+	MaxBuildsPerMonth  int32 // 0 = no limit
 	RateLimitPerWindow int32 // Rate limit requests per window
+
+	// Builds of a user pending or running at the same time, across all projects
+	MaxConcurrentBuilds int32
+
+	// Upper bounds for the per-project build settings
+	MaxBuildMemoryMB       int32
+	MaxBuildCPUs           float64
+	MaxBuildTimeoutMinutes int32
+	MaxBuildDiskMB         int32
+
+	// Upper bound for the containers of a deployment
+	MaxReplicas int32
+
+	// Pull request previews of a project running at the same time
+	MaxPreviews int32
+
+	// Environments of a project besides production and the upper bounds for
+	// their containers
+	MaxEnvironments        int32
+	MaxEnvironmentMemoryMB int32
+	MaxEnvironmentCPUCores int32
 }
 
 var planMatrix = map[string]planLimits{
 	"standard": {
-		MaxProjects:        3,
-		MaxBuildsPerMonth:  1,
-		RateLimitPerWindow: 0, // 0 = no limit
+		MaxProjects:            3,
+		MaxBuildsPerMonth:      100,
+		RateLimitPerWindow:     0, // 0 = no limit
+		MaxConcurrentBuilds:    1,
+		MaxBuildMemoryMB:       4096,
+		MaxBuildCPUs:           1,
+		MaxBuildTimeoutMinutes: 30,
+		MaxBuildDiskMB:         10240,
+		MaxReplicas:            2,
+		MaxPreviews:            1,
+		MaxEnvironments:        2,
+		MaxEnvironmentMemoryMB: 1024,
+		MaxEnvironmentCPUCores: 1,
 	},
 	"premium": {
-		MaxProjects:        20,
-		MaxBuildsPerMonth:  5,
-		RateLimitPerWindow: 0, // 0 = no limit
+		MaxProjects:            20,
+		MaxBuildsPerMonth:      1000,
+		RateLimitPerWindow:     0, // 0 = no limit
+		MaxConcurrentBuilds:    5,
+		MaxBuildMemoryMB:       8192,
+		MaxBuildCPUs:           4,
+		MaxBuildTimeoutMinutes: 120,
+		MaxBuildDiskMB:         51200,
+		MaxReplicas:            10,
+		MaxPreviews:            5,
+		MaxEnvironments:        5,
+		MaxEnvironmentMemoryMB: 8192,
+		MaxEnvironmentCPUCores: 4,
 	},
 }
 
@@ -255,10 +298,20 @@ func (s *AuthServiceServer) GetUserPlan(ctx context.Context, req *pb.GetUserPlan
 	}
 
 	return &pb.GetUserPlanResponse{
-		Plan:               user.Plan,
-		MaxProjects:        limits.MaxProjects,
-		MaxBuildsPerMonth:  limits.MaxBuildsPerMonth,
-		RateLimitPerWindow: limits.RateLimitPerWindow,
+		Plan:                   user.Plan,
+		MaxProjects:            limits.MaxProjects,
+		MaxBuildsPerMonth:      limits.MaxBuildsPerMonth,
+		RateLimitPerWindow:     limits.RateLimitPerWindow,
+		MaxBuildMemoryMb:       limits.MaxBuildMemoryMB,
+		MaxBuildCpus:           limits.MaxBuildCPUs,
+		MaxBuildTimeoutMinutes: limits.MaxBuildTimeoutMinutes,
+		MaxBuildDiskMb:         limits.MaxBuildDiskMB,
+		MaxReplicas:            limits.MaxReplicas,
+		MaxPreviews:            limits.MaxPreviews,
+		MaxConcurrentBuilds:    limits.MaxConcurrentBuilds,
+		MaxEnvironments:        limits.MaxEnvironments,
+		MaxEnvironmentMemoryMb: limits.MaxEnvironmentMemoryMB,
+		MaxEnvironmentCpuCores: limits.MaxEnvironmentCPUCores,
 	}, nil
 }
 
@@ -306,6 +359,17 @@ func (s *AuthServiceServer) CheckPermission(ctx context.Context, req *pb.CheckPe
 				Reason:  "Custom domain requires Premium plan",
 			}, nil
 		}
+	case deployPolicyResource:
+		// No plan includes it, admins are granted an explicit permission
+		return &pb.CheckPermissionResponse{
+			Allowed: false,
+			Reason:  "overriding the deploy policy requires admin permission",
+		}, nil
+	case buildQueueResource:
+		return &pb.CheckPermissionResponse{
+			Allowed: false,
+			Reason:  "managing the build queue requires admin permission",
+		}, nil
 	}
 
 	return &pb.CheckPermissionResponse{
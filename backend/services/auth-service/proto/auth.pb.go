@@ -186,6 +186,7 @@ type HandleOAuthResponse struct {
 	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Plan          string                 `protobuf:"bytes,4,opt,name=plan,proto3" json:"plan,omitempty"`
 	ExpiresAtUnix int64                  `protobuf:"varint,5,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"` // epoch seconds
+	RedirectUrl   string                 `protobuf:"bytes,7,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`          // Original redirect URL from OAuth flow
 	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -256,6 +257,13 @@ func (x *HandleOAuthResponse) GetExpiresAtUnix() int64 {
 	return 0
 }
 
+func (x *HandleOAuthResponse) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
 func (x *HandleOAuthResponse) GetError() string {
 	if x != nil {
 		return x.Error
@@ -438,14 +446,24 @@ func (x *GetUserPlanRequest) GetUserId() string {
 }
 
 type GetUserPlanResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Plan               string                 `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"` // "standard" | "premium"
-	MaxProjects        int32                  `protobuf:"varint,2,opt,name=max_projects,json=maxProjects,proto3" json:"max_projects,omitempty"`
-	MaxBuildsPerMonth  int32                  `protobuf:"varint,3,opt,name=max_builds_per_month,json=maxBuildsPerMonth,proto3" json:"max_builds_per_month,omitempty"`
-	RateLimitPerWindow int32                  `protobuf:"varint,4,opt,name=rate_limit_per_window,json=rateLimitPerWindow,proto3" json:"rate_limit_per_window,omitempty"` // Rate limit requests per window
-	Error              string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Plan                   string                 `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"` // "standard" | "premium"
+	MaxProjects            int32                  `protobuf:"varint,2,opt,name=max_projects,json=maxProjects,proto3" json:"max_projects,omitempty"`
+	MaxBuildsPerMonth      int32                  `protobuf:"varint,3,opt,name=max_builds_per_month,json=maxBuildsPerMonth,proto3" json:"max_builds_per_month,omitempty"`
+	RateLimitPerWindow     int32                  `protobuf:"varint,4,opt,name=rate_limit_per_window,json=rateLimitPerWindow,proto3" json:"rate_limit_per_window,omitempty"` // Rate limit requests per window
+	Error                  string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	MaxBuildMemoryMb       int32                  `protobuf:"varint,6,opt,name=max_build_memory_mb,json=maxBuildMemoryMb,proto3" json:"max_build_memory_mb,omitempty"`
+	MaxBuildCpus           float64                `protobuf:"fixed64,7,opt,name=max_build_cpus,json=maxBuildCpus,proto3" json:"max_build_cpus,omitempty"`
+	MaxBuildTimeoutMinutes int32                  `protobuf:"varint,8,opt,name=max_build_timeout_minutes,json=maxBuildTimeoutMinutes,proto3" json:"max_build_timeout_minutes,omitempty"`
+	MaxBuildDiskMb         int32                  `protobuf:"varint,9,opt,name=max_build_disk_mb,json=maxBuildDiskMb,proto3" json:"max_build_disk_mb,omitempty"`
+	MaxReplicas            int32                  `protobuf:"varint,10,opt,name=max_replicas,json=maxReplicas,proto3" json:"max_replicas,omitempty"`                                      // Containers per deployment
+	MaxPreviews            int32                  `protobuf:"varint,11,opt,name=max_previews,json=maxPreviews,proto3" json:"max_previews,omitempty"`                                      // Pull request previews of a project running at the same time
+	MaxConcurrentBuilds    int32                  `protobuf:"varint,12,opt,name=max_concurrent_builds,json=maxConcurrentBuilds,proto3" json:"max_concurrent_builds,omitempty"`            // Builds of the user pending or running at once, across all projects
+	MaxEnvironments        int32                  `protobuf:"varint,13,opt,name=max_environments,json=maxEnvironments,proto3" json:"max_environments,omitempty"`                          // Environments of a project besides production
+	MaxEnvironmentMemoryMb int32                  `protobuf:"varint,14,opt,name=max_environment_memory_mb,json=maxEnvironmentMemoryMb,proto3" json:"max_environment_memory_mb,omitempty"` // Container memory of an environment
+	MaxEnvironmentCpuCores int32                  `protobuf:"varint,15,opt,name=max_environment_cpu_cores,json=maxEnvironmentCpuCores,proto3" json:"max_environment_cpu_cores,omitempty"` // Container CPU cores of an environment
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *GetUserPlanResponse) Reset() {
@@ -513,6 +531,76 @@ func (x *GetUserPlanResponse) GetError() string {
 	return ""
 }
 
+func (x *GetUserPlanResponse) GetMaxBuildMemoryMb() int32 {
+	if x != nil {
+		return x.MaxBuildMemoryMb
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxBuildCpus() float64 {
+	if x != nil {
+		return x.MaxBuildCpus
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxBuildTimeoutMinutes() int32 {
+	if x != nil {
+		return x.MaxBuildTimeoutMinutes
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxBuildDiskMb() int32 {
+	if x != nil {
+		return x.MaxBuildDiskMb
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxReplicas() int32 {
+	if x != nil {
+		return x.MaxReplicas
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxPreviews() int32 {
+	if x != nil {
+		return x.MaxPreviews
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxConcurrentBuilds() int32 {
+	if x != nil {
+		return x.MaxConcurrentBuilds
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxEnvironments() int32 {
+	if x != nil {
+		return x.MaxEnvironments
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxEnvironmentMemoryMb() int32 {
+	if x != nil {
+		return x.MaxEnvironmentMemoryMb
+	}
+	return 0
+}
+
+func (x *GetUserPlanResponse) GetMaxEnvironmentCpuCores() int32 {
+	if x != nil {
+		return x.MaxEnvironmentCpuCores
+	}
+	return 0
+}
+
 // UpdatePlan
 type UpdatePlanRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1187,13 +1275,14 @@ const file_proto_auth_proto_rawDesc = "" +
 	"\x05error\x18\x03 \x01(\tR\x05error\">\n" +
 	"\x12HandleOAuthRequest\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
-	"\x05state\x18\x02 \x01(\tR\x05state\"\xc8\x01\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"\xeb\x01\n" +
 	"\x13HandleOAuthResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x17\n" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04plan\x18\x04 \x01(\tR\x04plan\x12&\n" +
-	"\x0fexpires_at_unix\x18\x05 \x01(\x03R\rexpiresAtUnix\x12\x14\n" +
+	"\x0fexpires_at_unix\x18\x05 \x01(\x03R\rexpiresAtUnix\x12!\n" +
+	"\fredirect_url\x18\a \x01(\tR\vredirectUrl\x12\x14\n" +
 	"\x05error\x18\x06 \x01(\tR\x05error\",\n" +
 	"\x14ValidateTokenRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\"\xab\x01\n" +
@@ -1206,13 +1295,24 @@ const file_proto_auth_proto_rawDesc = "" +
 	"avatar_url\x18\x05 \x01(\tR\tavatarUrl\x12\x14\n" +
 	"\x05error\x18\x06 \x01(\tR\x05error\"-\n" +
 	"\x12GetUserPlanRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc6\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9c\x05\n" +
 	"\x13GetUserPlanResponse\x12\x12\n" +
 	"\x04plan\x18\x01 \x01(\tR\x04plan\x12!\n" +
 	"\fmax_projects\x18\x02 \x01(\x05R\vmaxProjects\x12/\n" +
 	"\x14max_builds_per_month\x18\x03 \x01(\x05R\x11maxBuildsPerMonth\x121\n" +
 	"\x15rate_limit_per_window\x18\x04 \x01(\x05R\x12rateLimitPerWindow\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"@\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12-\n" +
+	"\x13max_build_memory_mb\x18\x06 \x01(\x05R\x10maxBuildMemoryMb\x12$\n" +
+	"\x0emax_build_cpus\x18\a \x01(\x01R\fmaxBuildCpus\x129\n" +
+	"\x19max_build_timeout_minutes\x18\b \x01(\x05R\x16maxBuildTimeoutMinutes\x12)\n" +
+	"\x11max_build_disk_mb\x18\t \x01(\x05R\x0emaxBuildDiskMb\x12!\n" +
+	"\fmax_replicas\x18\n" +
+	" \x01(\x05R\vmaxReplicas\x12!\n" +
+	"\fmax_previews\x18\v \x01(\x05R\vmaxPreviews\x122\n" +
+	"\x15max_concurrent_builds\x18\f \x01(\x05R\x13maxConcurrentBuilds\x12)\n" +
+	"\x10max_environments\x18\r \x01(\x05R\x0fmaxEnvironments\x129\n" +
+	"\x19max_environment_memory_mb\x18\x0e \x01(\x05R\x16maxEnvironmentMemoryMb\x129\n" +
+	"\x19max_environment_cpu_cores\x18\x0f \x01(\x05R\x16maxEnvironmentCpuCores\"@\n" +
 	"\x11UpdatePlanRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04plan\x18\x02 \x01(\tR\x04plan\"D\n" +
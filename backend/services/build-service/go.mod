@@ -10,6 +10,8 @@ require (
 	github.com/nexusdeploy/backend/pkg/logger v0.0.0
 	github.com/nexusdeploy/backend/services/auth-service/proto v0.0.0
 	github.com/nexusdeploy/backend/services/build-service/proto v0.0.0
+	github.com/nexusdeploy/backend/services/project-service/proto v0.0.0
+	github.com/redis/go-redis/v9 v9.0.3
 	github.com/rs/zerolog v1.33.0
 	google.golang.org/grpc v1.72.1
 	google.golang.org/protobuf v1.36.10
@@ -30,7 +32,6 @@ require (
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
-	github.com/redis/go-redis/v9 v9.0.3 // indirect
 	github.com/robfig/cron/v3 v3.0.1 // indirect
 	github.com/spf13/cast v1.3.1 // indirect
 	golang.org/x/crypto v0.40.0 // indirect
@@ -48,4 +49,5 @@ replace (
 	github.com/nexusdeploy/backend/pkg/logger => ../../pkg/logger
 	github.com/nexusdeploy/backend/services/auth-service/proto => ../auth-service/proto
 	github.com/nexusdeploy/backend/services/build-service/proto => ./proto
+	github.com/nexusdeploy/backend/services/project-service/proto => ../project-service/proto
 )
@@ -16,11 +16,13 @@ import (
 	"github.com/nexusdeploy/backend/services/build-service/models"
 	pb "github.com/nexusdeploy/backend/services/build-service/proto"
 	"github.com/nexusdeploy/backend/services/build-service/queue"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var log zerolog.Logger
@@ -40,16 +42,19 @@ type BuildServiceServer struct {
 	producer   *queue.Producer
 	authClient authpb.AuthServiceClient
 	authConn   *grpc.ClientConn
+
+	projectClient projectpb.ProjectServiceClient // For project build settings
 }
 
 // NewBuildServiceServer creates a new BuildService server
-func NewBuildServiceServer(db *gorm.DB, cfg *cfgpkg.Config, producer *queue.Producer, authClient authpb.AuthServiceClient, authConn *grpc.ClientConn) *BuildServiceServer {
+func NewBuildServiceServer(db *gorm.DB, cfg *cfgpkg.Config, producer *queue.Producer, authClient authpb.AuthServiceClient, authConn *grpc.ClientConn, projectClient projectpb.ProjectServiceClient) *BuildServiceServer {
 	return &BuildServiceServer{
-		db:         db,
-		cfg:        cfg,
-		producer:   producer,
-		authClient: authClient,
-		authConn:   authConn,
+		db:            db,
+		cfg:           cfg,
+		producer:      producer,
+		authClient:    authClient,
+		authConn:      authConn,
+		projectClient: projectClient,
 	}
 }
 
@@ -84,96 +89,173 @@ func (s *BuildServiceServer) TriggerBuild(ctx context.Context, req *pb.TriggerBu
 		return &pb.TriggerBuildResponse{Error: "invalid project_id format"}, nil
 	}
 
-	// Check permission: enforce max_builds_per_month and concurrent builds (FR7.4)
-	if s.authClient != nil && req.UserId != "" {
-		planResp, err := s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{
-			UserId: req.UserId,
+	// Limits apply to the project owner, across all of their projects. Webhook
+	// builds have no user in the request.
+	project := s.getProject(ctx, corrID, req.ProjectId)
+	ownerID := req.UserId
+	if project != nil && project.UserId != "" {
+		ownerID = project.UserId
+	}
+
+	var planResp *authpb.GetUserPlanResponse
+	if s.authClient != nil && ownerID != "" {
+		planResp, err = s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{
+			UserId: ownerID,
 		})
-		if err != nil {
-			log.Error().Err(err).Str("correlation_id", corrID).Str("user_id", req.UserId).Msg("Failed to get user plan for permission check")
+		switch {
+		case err == nil && planResp.Error == "":
+		case req.UserId == "":
+			// A push is not dropped over a failed lookup, it builds with default limits
+			log.Warn().Err(err).Str("correlation_id", corrID).Str("user_id", ownerID).Str("error", planResp.GetError()).Msg("Failed to get owner plan for build limits")
+			planResp = nil
+		case err != nil:
+			log.Error().Err(err).Str("correlation_id", corrID).Str("user_id", ownerID).Msg("Failed to get user plan for permission check")
 			return &pb.TriggerBuildResponse{Error: "failed to check user plan"}, nil
-		}
-		if planResp.Error != "" {
-			log.Warn().Str("error", planResp.Error).Str("user_id", req.UserId).Msg("GetUserPlan returned error")
+		default:
+			log.Warn().Str("error", planResp.Error).Str("user_id", ownerID).Msg("GetUserPlan returned error")
 			return &pb.TriggerBuildResponse{Error: "failed to check user plan: " + planResp.Error}, nil
 		}
+	}
+
+	var userID *uuid.UUID
+	if id, err := uuid.Parse(ownerID); err == nil {
+		userID = &id
+	}
 
-		// Note: Ownership verification should be done by API Gateway before calling Build Service
-		// Build Service trusts that API Gateway has verified the user owns the project
-
-		// Check concurrent builds limit - count active builds across all user's projects
-		// Note: Build Service doesn't have access to project_db, so we trust API Gateway
-		// has verified ownership. We count builds for this project only (concurrent builds
-		// are limited per project in practice, but plan limit applies globally).
-		// For proper per-user counting, this would require Project Service client injection.
-		// Active builds: pending, running, building_image, pushing_image, deploying
-		var activeBuildCount int64
-		activeStatuses := []string{
-			string(models.BuildStatusPending),
-			string(models.BuildStatusRunning),
-			string(models.BuildStatusBuildingImage),
-			string(models.BuildStatusPushingImage),
-			string(models.BuildStatusDeploying),
-		}
-		// Count active builds for this project (API Gateway ensures user owns the project)
-		if err := s.db.Model(&models.Build{}).
-			Where("project_id = ? AND status IN ?", projectID, activeStatuses).
-			Count(&activeBuildCount).Error; err != nil {
+	// A build matrix queues a build per variant at once, and the build of the image
+	// after them
+	jobs := 1
+	if n := len(project.GetBuildMatrix()); n > 0 {
+		jobs = n + 1
+	}
+
+	// Manual builds over the concurrent builds limit (FR7.4) are rejected. Pushes
+	// are queued instead and wait on the runner for a free build slot of the user.
+	if planResp != nil && userID != nil && req.UserId != "" && planResp.MaxConcurrentBuilds > 0 {
+		activeBuildCount, err := s.countActiveBuilds(*userID)
+		if err != nil {
 			log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to count active builds")
 			return &pb.TriggerBuildResponse{Error: "failed to check build limits"}, nil
 		}
-
-		// Standard plan: max 1 concurrent build, Premium: max 5 concurrent builds (per SRS 4.6)
-		maxConcurrentBuilds := int32(1) // Standard default
-		if planResp.Plan == "premium" {
-			maxConcurrentBuilds = 5
-		}
-
-		// Note: This counts concurrent builds for current project only.
-		// To properly enforce per-user limit, we would need Project Service client
-		// to get all user's projects. For MVP, we trust API Gateway has verified ownership.
-		if activeBuildCount >= int64(maxConcurrentBuilds) {
+		// The variants are active together, the image is built once they finished
+		concurrent := int64(max(jobs-1, 1))
+		if activeBuildCount+concurrent > int64(planResp.MaxConcurrentBuilds) {
 			log.Warn().
 				Str("correlation_id", corrID).
-				Str("user_id", req.UserId).
+				Str("user_id", ownerID).
 				Int64("active_builds", activeBuildCount).
-				Int32("max_concurrent", maxConcurrentBuilds).
+				Int64("new_builds", concurrent).
+				Int32("max_concurrent", planResp.MaxConcurrentBuilds).
 				Str("plan", planResp.Plan).
 				Msg("User reached concurrent builds limit")
 			return &pb.TriggerBuildResponse{
-				Error: fmt.Sprintf("You have reached the concurrent builds limit for the %s plan (%d builds). Please wait for current builds to complete or upgrade your plan.", planResp.Plan, maxConcurrentBuilds),
+				Error: fmt.Sprintf("You have reached the concurrent builds limit for the %s plan (%d builds). Please wait for current builds to complete or upgrade your plan.", planResp.Plan, planResp.MaxConcurrentBuilds),
+			}, nil
+		}
+	}
+
+	// Every build counts against the monthly quota, pushes included
+	var period time.Time
+	if planResp != nil && userID != nil && planResp.MaxBuildsPerMonth > 0 {
+		period = models.UsagePeriod(time.Now())
+		counted, err := s.countBuilds(*userID, period, jobs, planResp.MaxBuildsPerMonth)
+		if err != nil {
+			log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to count build usage")
+			return &pb.TriggerBuildResponse{Error: "failed to check build limits"}, nil
+		}
+		if !counted {
+			log.Warn().
+				Str("correlation_id", corrID).
+				Str("user_id", ownerID).
+				Int("builds", jobs).
+				Int32("max_builds_per_month", planResp.MaxBuildsPerMonth).
+				Str("plan", planResp.Plan).
+				Msg("User reached monthly builds limit")
+			resets := period.AddDate(0, 1, 0).Format("January 2")
+			if jobs > 1 {
+				return &pb.TriggerBuildResponse{
+					Error: fmt.Sprintf("The build matrix needs %d of the %d builds of the %s plan this month and not as many are left. The quota resets on %s, or upgrade your plan.", jobs, planResp.MaxBuildsPerMonth, planResp.Plan, resets),
+				}, nil
+			}
+			return &pb.TriggerBuildResponse{
+				Error: fmt.Sprintf("You have used all %d builds of the %s plan this month. The quota resets on %s, or upgrade your plan.", planResp.MaxBuildsPerMonth, planResp.Plan, resets),
 			}, nil
 		}
 	}
+	// refund gives the quota back when the build is not queued after all
+	refund := func() {
+		if period.IsZero() {
+			return
+		}
+		if err := s.uncountBuilds(*userID, period, jobs); err != nil {
+			log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to give back build usage")
+		}
+	}
 
-	// Create build record
+	// Create build record. A project with a build matrix gets a variant build per
+	// variant and a parent build, which builds the image once they passed.
 	build := &models.Build{
-		ProjectID: projectID,
-		CommitSHA: req.CommitSha,
-		Status:    models.BuildStatusPending,
+		ProjectID:   projectID,
+		UserID:      userID,
+		CommitSHA:   req.CommitSha,
+		Branch:      req.Branch,
+		Status:      models.BuildStatusPending,
+		PullRequest: int(req.PullRequest),
+		Environment: req.Environment,
+		Matrix:      len(project.GetBuildMatrix()) > 0,
 	}
 
 	if err := s.db.Create(build).Error; err != nil {
 		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to create build")
+		refund()
 		return &pb.TriggerBuildResponse{Error: "failed to create build"}, nil
 	}
 
+	if build.Matrix {
+		err = s.triggerVariants(ctx, corrID, build, req, project, planResp)
+	} else {
+		err = s.enqueueBuild(ctx, corrID, build, req, project, planResp, nil)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to enqueue build job")
+		// Update build status to failed
+		s.db.Model(build).Update("status", models.BuildStatusFailed)
+		refund()
+		return &pb.TriggerBuildResponse{Error: "failed to enqueue build job"}, nil
+	}
+
+	// Only once the new build is queued, a failed trigger leaves the older ones be
+	s.supersedeBuilds(ctx, corrID, build, project)
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", build.ID.String()).
+		Msg("Build triggered successfully")
+
+	return &pb.TriggerBuildResponse{
+		Build: buildToProto(build),
+	}, nil
+}
+
+// enqueueBuild creates the steps of a build and enqueues its job for Runner
+// Service. A variant build of a matrix only builds and tests the project on the
+// base image of its variant: it has no image steps and is never deployed, not even
+// as the preview of a pull request.
+func (s *BuildServiceServer) enqueueBuild(ctx context.Context, corrID string, build *models.Build, req *pb.TriggerBuildRequest, project *projectpb.Project, plan *authpb.GetUserPlanResponse, variant *projectpb.MatrixVariant) error {
 	// Create initial build steps
-	steps := []models.BuildStep{
-		{BuildID: build.ID, StepName: models.StepClone, Status: models.StepStatusPending},
-		{BuildID: build.ID, StepName: models.StepInstall, Status: models.StepStatusPending},
-		{BuildID: build.ID, StepName: models.StepBuild, Status: models.StepStatusPending},
-		{BuildID: build.ID, StepName: models.StepTest, Status: models.StepStatusPending},
-		{BuildID: build.ID, StepName: models.StepDockerBuild, Status: models.StepStatusPending},
-		{BuildID: build.ID, StepName: models.StepDockerPush, Status: models.StepStatusPending},
-		{BuildID: build.ID, StepName: models.StepDeploy, Status: models.StepStatusPending},
+	stepNames := []string{models.StepClone, models.StepInstall, models.StepBuild, models.StepTest}
+	if variant == nil {
+		stepNames = append(stepNames, models.StepDockerBuild, models.StepDockerPush, models.StepDeploy)
+	}
+	steps := make([]models.BuildStep, len(stepNames))
+	for i, name := range stepNames {
+		steps[i] = models.BuildStep{BuildID: build.ID, StepName: name, Status: models.StepStatusPending}
 	}
 	if err := s.db.Create(&steps).Error; err != nil {
 		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to create build steps")
 	}
 
 	// Enqueue job for Runner Service
-	// Note: In production, we'd fetch project config from Project Service
 	payload := &queue.BuildJobPayload{
 		BuildID:   build.ID.String(),
 		ProjectID: req.ProjectId,
@@ -181,23 +263,229 @@ func (s *BuildServiceServer) TriggerBuild(ctx context.Context, req *pb.TriggerBu
 		Branch:    req.Branch,
 		CommitSHA: req.CommitSha,
 		Secrets:   make(map[string]string), // Will be populated by Runner Service
+
+		PullRequest: int(req.PullRequest),
+		Environment: req.Environment,
+	}
+	resolveBuildSettings(corrID, payload, project, plan)
+	if variant != nil {
+		payload.Variant = variant.Name
+		payload.BaseImage = variant.BaseImage
+		payload.VariantEnv = variant.Env
+		payload.AutoDeploy = false
+		payload.PullRequest = 0
 	}
 
-	if _, err := s.producer.EnqueueBuildJob(ctx, payload); err != nil {
-		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to enqueue build job")
-		// Update build status to failed
-		s.db.Model(build).Update("status", models.BuildStatusFailed)
-		return &pb.TriggerBuildResponse{Error: "failed to enqueue build job"}, nil
+	_, err := s.producer.EnqueueBuildJob(ctx, payload)
+	return err
+}
+
+// triggerVariants creates and enqueues a variant build per variant of the build
+// matrix of a project under their parent build. If one of them cannot be queued,
+// those already queued are cancelled and the trigger fails as a whole.
+func (s *BuildServiceServer) triggerVariants(ctx context.Context, corrID string, parent *models.Build, req *pb.TriggerBuildRequest, project *projectpb.Project, plan *authpb.GetUserPlanResponse) error {
+	var queued []*models.Build
+	for _, variant := range project.BuildMatrix {
+		child := &models.Build{
+			ProjectID:   parent.ProjectID,
+			UserID:      parent.UserID,
+			CommitSHA:   parent.CommitSHA,
+			Branch:      parent.Branch,
+			Status:      models.BuildStatusPending,
+			PullRequest: parent.PullRequest,
+			Environment: parent.Environment,
+			ParentID:    &parent.ID,
+			Variant:     variant.Name,
+			Optional:    variant.Optional,
+		}
+		err := s.db.Create(child).Error
+		if err == nil {
+			if err = s.enqueueBuild(ctx, corrID, child, req, project, plan, variant); err != nil {
+				s.db.Model(child).Update("status", models.BuildStatusFailed)
+			}
+		}
+		if err != nil {
+			for _, b := range queued {
+				if err := s.stopBuild(ctx, corrID, b, models.BuildStatusCancelled, nil, "Build matrix could not be queued"); err != nil {
+					log.Warn().Err(err).Str("correlation_id", corrID).Str("build_id", b.ID.String()).Msg("Failed to cancel variant build")
+				}
+			}
+			return fmt.Errorf("variant %s: %w", variant.Name, err)
+		}
+		queued = append(queued, child)
 	}
 
 	log.Info().
 		Str("correlation_id", corrID).
-		Str("build_id", build.ID.String()).
-		Msg("Build triggered successfully")
+		Str("build_id", parent.ID.String()).
+		Int("variants", len(queued)).
+		Msg("Build matrix queued")
+	return nil
+}
 
-	return &pb.TriggerBuildResponse{
-		Build: buildToProto(build),
-	}, nil
+// resolveBuildSettings sets the build directory, clone options, deploy settings and
+// resource limits of the job from the project, nil if it could not be loaded.
+// Unset limits use the defaults and every limit is capped by the plan of the
+// project owner, so a plan downgrade applies without editing the project.
+func resolveBuildSettings(corrID string, payload *queue.BuildJobPayload, project *projectpb.Project, plan *authpb.GetUserPlanResponse) {
+	payload.MemoryMB = queue.DefaultBuildMemoryMB
+	payload.CPUs = queue.DefaultBuildCPUs
+	payload.TimeoutMinutes = int(queue.DefaultBuildTimeout / time.Minute)
+	if project != nil {
+		if project.BuildMemoryMb > 0 {
+			payload.MemoryMB = int(project.BuildMemoryMb)
+		}
+		if project.BuildCpus > 0 {
+			payload.CPUs = project.BuildCpus
+		}
+		if project.BuildTimeoutMinutes > 0 {
+			payload.TimeoutMinutes = int(project.BuildTimeoutMinutes)
+		}
+		payload.DiskMB = int(project.BuildDiskMb)
+		payload.RootDir = project.RootDirectory
+		payload.Submodules = project.CloneSubmodules
+		payload.LFS = project.CloneLfs
+		payload.CloneDepth = int(project.CloneDepth)
+		payload.FullHistory = project.CloneFullHistory
+		payload.FetchTags = project.CloneFetchTags
+		payload.AutoDeploy = project.AutoDeploy
+		payload.UserID = project.UserId
+		payload.HealthCheck = queue.HealthCheckConfig{
+			Type:            project.HealthCheckType,
+			Path:            project.HealthCheckPath,
+			ExpectedStatus:  int(project.HealthCheckExpectedStatus),
+			IntervalSeconds: int(project.HealthCheckIntervalSeconds),
+			TimeoutSeconds:  int(project.HealthCheckTimeoutSeconds),
+			Retries:         int(project.HealthCheckRetries),
+		}
+		payload.Replicas = int(project.Replicas)
+		payload.CustomDomains = project.CustomDomains
+		if payload.Environment != "" {
+			applyEnvironmentSettings(corrID, payload, project)
+		}
+	}
+
+	if plan != nil {
+		payload.Plan = plan.Plan
+		payload.MaxConcurrentBuilds = int(plan.MaxConcurrentBuilds)
+		if plan.MaxBuildMemoryMb > 0 && payload.MemoryMB > int(plan.MaxBuildMemoryMb) {
+			payload.MemoryMB = int(plan.MaxBuildMemoryMb)
+		}
+		if plan.MaxBuildCpus > 0 && payload.CPUs > plan.MaxBuildCpus {
+			payload.CPUs = plan.MaxBuildCpus
+		}
+		if plan.MaxBuildTimeoutMinutes > 0 && payload.TimeoutMinutes > int(plan.MaxBuildTimeoutMinutes) {
+			payload.TimeoutMinutes = int(plan.MaxBuildTimeoutMinutes)
+		}
+		if plan.MaxBuildDiskMb > 0 && (payload.DiskMB == 0 || payload.DiskMB > int(plan.MaxBuildDiskMb)) {
+			payload.DiskMB = int(plan.MaxBuildDiskMb)
+		}
+		if plan.MaxReplicas > 0 && payload.Replicas > int(plan.MaxReplicas) {
+			payload.Replicas = int(plan.MaxReplicas)
+		}
+	}
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", payload.BuildID).
+		Int("memory_mb", payload.MemoryMB).
+		Float64("cpus", payload.CPUs).
+		Int("timeout_minutes", payload.TimeoutMinutes).
+		Int("disk_mb", payload.DiskMB).
+		Bool("auto_deploy", payload.AutoDeploy).
+		Str("plan", payload.Plan).
+		Msg("Resolved build settings")
+}
+
+// getProject returns the project of a build, nil if it could not be loaded
+func (s *BuildServiceServer) getProject(ctx context.Context, corrID, projectID string) *projectpb.Project {
+	if s.projectClient == nil {
+		return nil
+	}
+	resp, err := s.projectClient.GetProject(ctx, &projectpb.GetProjectRequest{ProjectId: projectID})
+	switch {
+	case err != nil:
+		log.Warn().Err(err).Str("correlation_id", corrID).Str("project_id", projectID).Msg("Failed to get project build settings, using defaults")
+		return nil
+	case resp.Error != "":
+		log.Warn().Str("correlation_id", corrID).Str("project_id", projectID).Str("error", resp.Error).Msg("Failed to get project build settings, using defaults")
+		return nil
+	}
+	return resp.Project
+}
+
+// supersedeBuilds stops the older builds of the same branch as a new build, as the
+// auto-cancel policy of the project asks. Queued builds are superseded and, with
+// auto_cancel_running, running ones too. Deploying builds are left to finish so
+// that no deployment is cut off half way.
+func (s *BuildServiceServer) supersedeBuilds(ctx context.Context, corrID string, build *models.Build, project *projectpb.Project) {
+	if project == nil || (!project.AutoCancelPending && !project.AutoCancelRunning) || build.Branch == "" {
+		return
+	}
+
+	statuses := []models.BuildStatus{models.BuildStatusPending}
+	if project.AutoCancelRunning {
+		statuses = append(statuses, models.BuildStatusRunning, models.BuildStatusBuildingImage, models.BuildStatusPushingImage)
+	}
+
+	// Previews and environments deploy elsewhere, only builds of the same target
+	// supersede each other
+	var stale []models.Build
+	if err := s.db.
+		Where("project_id = ? AND branch = ? AND pull_request = ? AND environment = ?", build.ProjectID, build.Branch, build.PullRequest, build.Environment).
+		Where("id <> ? AND created_at < ? AND status IN ?", build.ID, build.CreatedAt, statuses).
+		Where("parent_id IS NULL"). // Variants are stopped with their parent
+		Find(&stale).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("build_id", build.ID.String()).Msg("Failed to find builds to supersede")
+		return
+	}
+
+	reason := fmt.Sprintf("Superseded by build %s", build.ID)
+	if build.CommitSHA != "" {
+		reason = fmt.Sprintf("Superseded by build %s of commit %.7s", build.ID, build.CommitSHA)
+	}
+	for i := range stale {
+		old := &stale[i]
+		previous := old.Status
+		if err := s.stopBuild(ctx, corrID, old, models.BuildStatusSuperseded, &build.ID, reason); err != nil {
+			// A build that finished in the meantime has nothing left to supersede
+			log.Warn().Err(err).Str("correlation_id", corrID).Str("build_id", old.ID.String()).Msg("Failed to supersede build")
+			continue
+		}
+		log.Info().
+			Str("correlation_id", corrID).
+			Str("build_id", old.ID.String()).
+			Str("previous_status", string(previous)).
+			Str("superseded_by", build.ID.String()).
+			Msg("Build superseded")
+	}
+}
+
+// applyEnvironmentSettings replaces the deploy settings of the project with those
+// of the environment the job builds. An environment that no longer exists is not
+// deployed.
+func applyEnvironmentSettings(corrID string, payload *queue.BuildJobPayload, project *projectpb.Project) {
+	for _, env := range project.Environments {
+		if env.Name != payload.Environment {
+			continue
+		}
+		payload.AutoDeploy = env.AutoDeploy
+		payload.CustomDomains = nil
+		if env.Domain != "" {
+			payload.CustomDomains = []string{env.Domain}
+		}
+		payload.DeployMemoryMB = int(env.MemoryMb)
+		payload.DeployCPUCores = int(env.CpuCores)
+		return
+	}
+
+	log.Warn().
+		Str("correlation_id", corrID).
+		Str("project_id", payload.ProjectID).
+		Str("environment", payload.Environment).
+		Msg("Environment not found, the build will not be deployed")
+	payload.AutoDeploy = false
+	payload.CustomDomains = nil
 }
 
 // ==================== UpdateBuildStatus ====================
@@ -255,17 +543,30 @@ func (s *BuildServiceServer) UpdateBuildStatus(ctx context.Context, req *pb.Upda
 		newStatus == models.BuildStatusFailed || newStatus == models.BuildStatusDeployFailed {
 		updates["finished_at"] = now
 	}
+	if newStatus == models.BuildStatusPending {
+		// Put back for a retry, the next attempt starts over
+		updates["started_at"] = nil
+	}
 
 	// Lưu image_tag nếu có (từ Runner Service khi build image xong)
 	if req.ImageTag != "" {
 		updates["image_tag"] = req.ImageTag
 	}
+	if req.DeploymentId != "" {
+		updates["deployment_id"] = req.DeploymentId
+	}
 
 	if err := s.db.Model(&build).Updates(updates).Error; err != nil {
 		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to update build")
 		return &pb.UpdateBuildStatusResponse{Error: "failed to update build"}, nil
 	}
 
+	if newStatus == models.BuildStatusPending {
+		if err := s.resetBuildSteps(buildID); err != nil {
+			log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to reset build steps")
+		}
+	}
+
 	// Append logs if provided
 	if len(req.LogLines) > 0 {
 		if err := s.appendLogs(ctx, buildID, req.LogLines); err != nil {
@@ -273,6 +574,10 @@ func (s *BuildServiceServer) UpdateBuildStatus(ctx context.Context, req *pb.Upda
 		}
 	}
 
+	if build.ParentID != nil {
+		s.updateMatrixParent(ctx, corrID, *build.ParentID)
+	}
+
 	log.Info().
 		Str("correlation_id", corrID).
 		Str("build_id", req.BuildId).
@@ -318,7 +623,8 @@ func (s *BuildServiceServer) ListBuilds(ctx context.Context, req *pb.ListBuildsR
 	var builds []models.Build
 	var total int64
 
-	s.db.Model(&models.Build{}).Where("project_id = ?", projectID).Count(&total)
+	// Variant builds are listed with their matrix parent by GetBuild
+	s.db.Model(&models.Build{}).Where("project_id = ? AND parent_id IS NULL", projectID).Count(&total)
 
 	log.Debug().
 		Str("correlation_id", corrID).
@@ -329,7 +635,7 @@ func (s *BuildServiceServer) ListBuilds(ctx context.Context, req *pb.ListBuildsR
 		Int32("offset", offset).
 		Msg("Querying builds from database")
 
-	if err := s.db.Where("project_id = ?", projectID).
+	if err := s.db.Where("project_id = ? AND parent_id IS NULL", projectID).
 		Order("created_at DESC").
 		Offset(int(offset)).
 		Limit(int(pageSize)).
@@ -386,9 +692,23 @@ func (s *BuildServiceServer) GetBuild(ctx context.Context, req *pb.GetBuildReque
 		protoSteps[i] = stepToProto(&step)
 	}
 
+	var protoVariants []*pb.Build
+	if build.Matrix {
+		var variants []models.Build
+		if err := s.db.Where("parent_id = ?", build.ID).Order("created_at").Find(&variants).Error; err != nil {
+			log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to get variant builds")
+			return &pb.GetBuildResponse{Error: "failed to get build"}, nil
+		}
+		protoVariants = make([]*pb.Build, len(variants))
+		for i := range variants {
+			protoVariants[i] = buildToProto(&variants[i])
+		}
+	}
+
 	return &pb.GetBuildResponse{
-		Build: buildToProto(&build),
-		Steps: protoSteps,
+		Build:    buildToProto(&build),
+		Steps:    protoSteps,
+		Variants: protoVariants,
 	}, nil
 }
 
@@ -471,151 +791,1244 @@ func (s *BuildServiceServer) AppendBuildLogs(ctx context.Context, req *pb.Append
 	return &pb.AppendBuildLogsResponse{Acknowledged: true}, nil
 }
 
-// ==================== DeleteBuildLogs ====================
+// ==================== UpdateBuildStep ====================
 
-// DeleteBuildLogs deletes logs for builds in a project
-func (s *BuildServiceServer) DeleteBuildLogs(ctx context.Context, req *pb.DeleteBuildLogsRequest) (*pb.DeleteBuildLogsResponse, error) {
+// UpdateBuildStep creates or updates a step row of a build (called by Runner Service).
+// Steps declared in nexus.yaml are not known at trigger time, so unknown names are created.
+func (s *BuildServiceServer) UpdateBuildStep(ctx context.Context, req *pb.UpdateBuildStepRequest) (*pb.UpdateBuildStepResponse, error) {
 	corrID := getCorrelationID(ctx)
 	log.Info().
 		Str("correlation_id", corrID).
-		Str("project_id", req.ProjectId).
-		Str("user_id", req.UserId).
-		Int("build_count", len(req.BuildIds)).
-		Msg("DeleteBuildLogs called")
+		Str("build_id", req.BuildId).
+		Str("step_name", req.StepName).
+		Str("status", req.Status).
+		Msg("UpdateBuildStep called")
 
-	if req.ProjectId == "" || req.UserId == "" {
-		return &pb.DeleteBuildLogsResponse{Error: "project_id and user_id are required"}, nil
+	if req.BuildId == "" || req.StepName == "" {
+		return &pb.UpdateBuildStepResponse{Error: "build_id and step_name are required"}, nil
 	}
 
-	projectID, err := uuid.Parse(req.ProjectId)
+	buildID, err := uuid.Parse(req.BuildId)
 	if err != nil {
-		return &pb.DeleteBuildLogsResponse{Error: "invalid project_id format"}, nil
+		return &pb.UpdateBuildStepResponse{Error: "invalid build_id format"}, nil
 	}
 
-	_, err = uuid.Parse(req.UserId)
-	if err != nil {
-		return &pb.DeleteBuildLogsResponse{Error: "invalid user_id format"}, nil
+	status := models.StepStatus(req.Status)
+	if !status.IsValid() {
+		return &pb.UpdateBuildStepResponse{Error: "invalid step status"}, nil
 	}
 
-	// Get builds for this project (to verify ownership)
-	var buildModels []models.Build
-	query := s.db.Where("project_id = ?", projectID)
+	var step models.BuildStep
+	err = s.db.Where("build_id = ? AND step_name = ?", buildID, req.StepName).First(&step).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to get build step")
+		return &pb.UpdateBuildStepResponse{Error: "failed to get build step"}, nil
+	}
 
-	// If specific build IDs provided, filter by them
-	if len(req.BuildIds) > 0 {
-		buildUUIDs := make([]uuid.UUID, 0, len(req.BuildIds))
-		for _, bid := range req.BuildIds {
-			if buildUUID, err := uuid.Parse(bid); err == nil {
-				buildUUIDs = append(buildUUIDs, buildUUID)
-			}
+	var durationMs *int
+	if req.DurationMs > 0 {
+		d := int(req.DurationMs)
+		durationMs = &d
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		step = models.BuildStep{
+			BuildID:    buildID,
+			StepName:   req.StepName,
+			Status:     status,
+			DurationMs: durationMs,
 		}
-		if len(buildUUIDs) > 0 {
-			query = query.Where("id IN ?", buildUUIDs)
+		if err := s.db.Create(&step).Error; err != nil {
+			log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to create build step")
+			return &pb.UpdateBuildStepResponse{Error: "failed to create build step"}, nil
 		}
+		return &pb.UpdateBuildStepResponse{Acknowledged: true}, nil
 	}
 
-	if err := query.Find(&buildModels).Error; err != nil {
-		return &pb.DeleteBuildLogsResponse{Error: "failed to get builds"}, nil
+	updates := map[string]interface{}{
+		"status": status,
 	}
-
-	if len(buildModels) == 0 {
-		return &pb.DeleteBuildLogsResponse{
-			BuildsAffected: 0,
-			LogsDeleted:    0,
-		}, nil
+	if durationMs != nil {
+		updates["duration_ms"] = *durationMs
 	}
-
-	// Extract build IDs
-	buildIDs := make([]uuid.UUID, len(buildModels))
-	for i, b := range buildModels {
-		buildIDs[i] = b.ID
+	if err := s.db.Model(&step).Updates(updates).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to update build step")
+		return &pb.UpdateBuildStepResponse{Error: "failed to update build step"}, nil
 	}
 
-	// Count logs before deletion
-	var logsCount int64
-	s.db.Model(&models.BuildLog{}).Where("build_id IN ?", buildIDs).Count(&logsCount)
+	return &pb.UpdateBuildStepResponse{Acknowledged: true}, nil
+}
 
-	// Delete logs for these builds (CASCADE will handle build_steps)
-	if err := s.db.Where("build_id IN ?", buildIDs).Delete(&models.BuildLog{}).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to delete build logs")
-		return &pb.DeleteBuildLogsResponse{Error: "failed to delete logs"}, nil
-	}
+// ==================== Test Reports ====================
 
-	// Delete build steps (CASCADE should handle this, but explicit delete for safety)
-	if err := s.db.Where("build_id IN ?", buildIDs).Delete(&models.BuildStep{}).Error; err != nil {
-		log.Warn().Err(err).Msg("Failed to delete build steps (may not exist)")
-		// Continue anyway
+// ReportTestResults stores the test cases parsed by the runner's test step.
+// Results of a previous attempt of the same build are replaced.
+func (s *BuildServiceServer) ReportTestResults(ctx context.Context, req *pb.ReportTestResultsRequest) (*pb.ReportTestResultsResponse, error) {
+	corrID := getCorrelationID(ctx)
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", req.BuildId).
+		Int("result_count", len(req.Results)).
+		Msg("ReportTestResults called")
+
+	if req.BuildId == "" {
+		return &pb.ReportTestResultsResponse{Error: "build_id is required"}, nil
 	}
 
-	// Delete the builds themselves
-	if err := s.db.Where("id IN ?", buildIDs).Delete(&models.Build{}).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to delete builds")
-		return &pb.DeleteBuildLogsResponse{Error: "failed to delete builds"}, nil
+	buildID, err := uuid.Parse(req.BuildId)
+	if err != nil {
+		return &pb.ReportTestResultsResponse{Error: "invalid build_id format"}, nil
 	}
 
-	// Cleanup workspaces in runner-service
-	buildIDStrings := make([]string, len(buildIDs))
-	for i, id := range buildIDs {
-		buildIDStrings[i] = id.String()
+	results := make([]models.TestResult, 0, len(req.Results))
+	for _, tc := range req.Results {
+		if tc.Name == "" {
+			continue
+		}
+		status := models.TestStatus(tc.Status)
+		switch status {
+		case models.TestStatusPassed, models.TestStatusFailed, models.TestStatusSkipped:
+		default:
+			return &pb.ReportTestResultsResponse{Error: fmt.Sprintf("invalid test status %q", tc.Status)}, nil
+		}
+		results = append(results, models.TestResult{
+			BuildID:        buildID,
+			Suite:          tc.Suite,
+			Name:           tc.Name,
+			Status:         status,
+			DurationMs:     int(tc.DurationMs),
+			FailureMessage: tc.FailureMessage,
+		})
 	}
 
-	// Call runner-service to cleanup workspaces
-	if err := cleanupRunnerWorkspaces(ctx, buildIDStrings, s.cfg); err != nil {
-		log.Warn().
-			Err(err).
-			Str("correlation_id", corrID).
-			Msg("Failed to cleanup runner workspaces (non-fatal)")
-		// Continue anyway - database cleanup succeeded
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("build_id = ?", buildID).Delete(&models.TestResult{}).Error; err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&results, 500).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to store test results")
+		return &pb.ReportTestResultsResponse{Error: "failed to store test results"}, nil
 	}
 
+	return &pb.ReportTestResultsResponse{Acknowledged: true}, nil
+}
+
+// GetBuildTestReport returns the test results of a build with a summary
+func (s *BuildServiceServer) GetBuildTestReport(ctx context.Context, req *pb.GetBuildTestReportRequest) (*pb.GetBuildTestReportResponse, error) {
+	corrID := getCorrelationID(ctx)
 	log.Info().
 		Str("correlation_id", corrID).
-		Int("builds_affected", len(buildIDs)).
-		Int64("logs_deleted", logsCount).
-		Msg("Build history deleted successfully")
+		Str("build_id", req.BuildId).
+		Msg("GetBuildTestReport called")
 
-	return &pb.DeleteBuildLogsResponse{
-		BuildsAffected: int32(len(buildIDs)),
-		LogsDeleted:    logsCount,
-	}, nil
-}
+	if req.BuildId == "" || req.UserId == "" {
+		return &pb.GetBuildTestReportResponse{Error: "build_id and user_id are required"}, nil
+	}
 
-// ==================== Helper Functions ====================
+	buildID, err := uuid.Parse(req.BuildId)
+	if err != nil {
+		return &pb.GetBuildTestReportResponse{Error: "invalid build_id format"}, nil
+	}
 
-func (s *BuildServiceServer) appendLogs(ctx context.Context, buildID uuid.UUID, lines []string) error {
-	if len(lines) == 0 {
-		return nil
+	var build models.Build
+	if err := s.db.First(&build, "id = ?", buildID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.GetBuildTestReportResponse{Error: "build not found"}, nil
+		}
+		return &pb.GetBuildTestReportResponse{Error: "failed to get build"}, nil
 	}
 
-	now := time.Now()
-	logs := make([]models.BuildLog, len(lines))
-	for i, line := range lines {
-		logs[i] = models.BuildLog{
-			BuildID:   buildID,
-			Timestamp: now,
-			LogLine:   line,
+	// Test output of other users' builds is not found, as in CancelBuild
+	owner, err := s.isBuildOwner(ctx, &build, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to check build owner")
+		return &pb.GetBuildTestReportResponse{Error: "failed to get build"}, nil
+	}
+	if !owner {
+		return &pb.GetBuildTestReportResponse{Error: "build not found"}, nil
+	}
+
+	var results []models.TestResult
+	// Failed tests first so they are visible without scrolling
+	if err := s.db.Where("build_id = ?", buildID).
+		Order("CASE status WHEN 'failed' THEN 0 WHEN 'passed' THEN 1 ELSE 2 END, suite, name").
+		Find(&results).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to get test results")
+		return &pb.GetBuildTestReportResponse{Error: "failed to get test results"}, nil
+	}
+
+	resp := &pb.GetBuildTestReportResponse{
+		Total:   int32(len(results)),
+		Results: make([]*pb.TestCase, len(results)),
+	}
+	for i, r := range results {
+		switch r.Status {
+		case models.TestStatusPassed:
+			resp.Passed++
+		case models.TestStatusFailed:
+			resp.Failed++
+		case models.TestStatusSkipped:
+			resp.Skipped++
 		}
+		resp.DurationMs += int64(r.DurationMs)
+		resp.Results[i] = testResultToProto(&r)
 	}
 
-	return s.db.Create(&logs).Error
+	return resp, nil
 }
 
-func buildToProto(b *models.Build) *pb.Build {
-	if b == nil {
-		return nil
-	}
+// ==================== CancelBuild ====================
 
-	build := &pb.Build{
-		Id:        b.ID.String(),
-		ProjectId: b.ProjectID.String(),
-		CommitSha: b.CommitSHA,
-		Status:    modelStatusToProto(b.Status),
-		CreatedAt: timestamppb.New(b.CreatedAt),
-		UpdatedAt: timestamppb.New(b.UpdatedAt),
-		ImageTag:  b.ImageTag,
+// CancelBuild stops a build that has not finished yet. A queued job is removed from
+// the queue; a running one is cancelled on the runner, which cleans up after itself.
+func (s *BuildServiceServer) CancelBuild(ctx context.Context, req *pb.CancelBuildRequest) (*pb.CancelBuildResponse, error) {
+	corrID := getCorrelationID(ctx)
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", req.BuildId).
+		Str("user_id", req.UserId).
+		Msg("CancelBuild called")
+
+	if req.BuildId == "" || req.UserId == "" {
+		return &pb.CancelBuildResponse{Error: "build_id and user_id are required"}, nil
 	}
 
-	if b.StartedAt != nil {
+	buildID, err := uuid.Parse(req.BuildId)
+	if err != nil {
+		return &pb.CancelBuildResponse{Error: "invalid build_id format"}, nil
+	}
+
+	var build models.Build
+	if err := s.db.First(&build, "id = ?", buildID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.CancelBuildResponse{Error: "build not found"}, nil
+		}
+		return &pb.CancelBuildResponse{Error: "failed to get build"}, nil
+	}
+
+	// Builds of other users are not found, so build IDs cannot be probed
+	owner, err := s.isBuildOwner(ctx, &build, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to check build owner")
+		return &pb.CancelBuildResponse{Error: "failed to get build"}, nil
+	}
+	if !owner {
+		return &pb.CancelBuildResponse{Error: "build not found"}, nil
+	}
+
+	if build.IsTerminal() {
+		return &pb.CancelBuildResponse{Error: fmt.Sprintf("build already finished with status %s", build.Status)}, nil
+	}
+	if !build.CanTransitionTo(models.BuildStatusCancelled) {
+		return &pb.CancelBuildResponse{Error: "invalid status transition"}, nil
+	}
+
+	if err := s.stopBuild(ctx, corrID, &build, models.BuildStatusCancelled, nil, "Build cancelled by user"); err != nil {
+		if errors.Is(err, errBuildStatusChanged) {
+			return &pb.CancelBuildResponse{Error: err.Error()}, nil
+		}
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to cancel build")
+		return &pb.CancelBuildResponse{Error: "failed to cancel build"}, nil
+	}
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", req.BuildId).
+		Msg("Build cancelled")
+
+	return &pb.CancelBuildResponse{Build: buildToProto(&build)}, nil
+}
+
+// isBuildOwner reports whether a user owns the project of a build. Builds from
+// before their owner was stored are checked against the project.
+func (s *BuildServiceServer) isBuildOwner(ctx context.Context, build *models.Build, userID string) (bool, error) {
+	if build.UserID != nil {
+		return build.UserID.String() == userID, nil
+	}
+	if s.projectClient == nil {
+		return false, errors.New("project service is not available")
+	}
+	resp, err := s.projectClient.GetProject(ctx, &projectpb.GetProjectRequest{
+		ProjectId: build.ProjectID.String(),
+		UserId:    userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("get project: %w", err)
+	}
+	switch resp.Error {
+	case "":
+		return true, nil
+	case "permission denied", "project not found":
+		return false, nil
+	default:
+		return false, fmt.Errorf("project service error: %s", resp.Error)
+	}
+}
+
+// errBuildStatusChanged is returned by stopBuild when the runner moved the build
+// on in the meantime
+var errBuildStatusChanged = errors.New("build status changed, please retry")
+
+// stopBuild ends a build that has not finished with status, cancelled or
+// superseded. The build is marked first so that status updates from the runner are
+// rejected, then its queued job is removed or its running job cancelled.
+func (s *BuildServiceServer) stopBuild(ctx context.Context, corrID string, build *models.Build, status models.BuildStatus, supersededBy *uuid.UUID, reason string) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      status,
+		"finished_at": now,
+	}
+	if supersededBy != nil {
+		updates["superseded_by"] = *supersededBy
+	}
+	result := s.db.Model(&models.Build{}).
+		Where("id = ? AND status = ?", build.ID, build.Status).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errBuildStatusChanged
+	}
+	build.Status = status
+	build.FinishedAt = &now
+	build.SupersededBy = supersededBy
+
+	if err := s.db.Model(&models.BuildStep{}).
+		Where("build_id = ? AND status IN ?", build.ID, []models.StepStatus{models.StepStatusPending, models.StepStatusRunning}).
+		Update("status", models.StepStatusSkipped).Error; err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to skip remaining build steps")
+	}
+
+	if err := s.appendLogs(ctx, build.ID, []string{"[cancel] " + reason}); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to append logs")
+	}
+
+	if err := s.producer.CancelBuildJob(ctx, build.ID.String()); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to cancel build job")
+	}
+
+	if err := s.producer.PublishBuildEvent(ctx, build.ProjectID.String(), queue.BuildEvent{
+		BuildID: build.ID.String(),
+		Event:   "cancelled",
+		Status:  string(status),
+		Message: reason,
+	}); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to publish cancelled event")
+	}
+
+	if build.Matrix && !build.ImageJob {
+		s.stopVariants(ctx, corrID, build, reason)
+		if status == models.BuildStatusCancelled {
+			s.reportMatrixPreview(ctx, corrID, build, reason)
+		}
+	}
+	if build.ParentID != nil {
+		s.updateMatrixParent(ctx, corrID, *build.ParentID)
+	}
+
+	return nil
+}
+
+// ==================== Previews ====================
+
+// previewBuildStatuses are the statuses of preview builds that can still deploy
+var previewBuildStatuses = append([]models.BuildStatus{models.BuildStatusDeploying}, activeBuildStatuses...)
+
+// ListPreviewBuilds lists the pull requests of a project with a build that has not
+// finished, the previews about to start
+func (s *BuildServiceServer) ListPreviewBuilds(ctx context.Context, req *pb.ListPreviewBuildsRequest) (*pb.ListPreviewBuildsResponse, error) {
+	if req.ProjectId == "" {
+		return &pb.ListPreviewBuildsResponse{Error: "project_id is required"}, nil
+	}
+	projectID, err := uuid.Parse(req.ProjectId)
+	if err != nil {
+		return &pb.ListPreviewBuildsResponse{Error: "invalid project_id format"}, nil
+	}
+
+	var pullRequests []int32
+	if err := s.db.WithContext(ctx).Model(&models.Build{}).
+		Where("project_id = ? AND pull_request > 0 AND status IN ?", projectID, previewBuildStatuses).
+		Distinct().Pluck("pull_request", &pullRequests).Error; err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to list preview builds")
+		return &pb.ListPreviewBuildsResponse{Error: "failed to list preview builds"}, nil
+	}
+
+	return &pb.ListPreviewBuildsResponse{PullRequests: pullRequests}, nil
+}
+
+// CancelPreviewBuilds cancels the builds of a closed pull request that have not
+// finished, so that no preview is deployed for it afterwards. Deploying builds are
+// cancelled too, their deployment is aborted with the job.
+func (s *BuildServiceServer) CancelPreviewBuilds(ctx context.Context, req *pb.CancelPreviewBuildsRequest) (*pb.CancelPreviewBuildsResponse, error) {
+	corrID := getCorrelationID(ctx)
+
+	if req.ProjectId == "" || req.PullRequest <= 0 {
+		return &pb.CancelPreviewBuildsResponse{Error: "project_id and pull_request are required"}, nil
+	}
+	projectID, err := uuid.Parse(req.ProjectId)
+	if err != nil {
+		return &pb.CancelPreviewBuildsResponse{Error: "invalid project_id format"}, nil
+	}
+
+	var builds []models.Build
+	if err := s.db.WithContext(ctx).
+		Where("project_id = ? AND pull_request = ? AND status IN ?", projectID, req.PullRequest, previewBuildStatuses).
+		Where("parent_id IS NULL"). // Variants are stopped with their parent
+		Find(&builds).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("project_id", req.ProjectId).Msg("Failed to find preview builds")
+		return &pb.CancelPreviewBuildsResponse{Error: "failed to find preview builds"}, nil
+	}
+
+	reason := fmt.Sprintf("Build cancelled, pull request #%d was closed", req.PullRequest)
+	var cancelled int32
+	for i := range builds {
+		build := &builds[i]
+		if err := s.stopBuild(ctx, corrID, build, models.BuildStatusCancelled, nil, reason); err != nil {
+			// A build that finished in the meantime has nothing left to cancel
+			log.Warn().Err(err).Str("correlation_id", corrID).Str("build_id", build.ID.String()).Msg("Failed to cancel preview build")
+			continue
+		}
+		cancelled++
+	}
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("project_id", req.ProjectId).
+		Int32("pull_request", req.PullRequest).
+		Int32("cancelled", cancelled).
+		Msg("Preview builds of closed pull request cancelled")
+
+	return &pb.CancelPreviewBuildsResponse{Cancelled: cancelled}, nil
+}
+
+// ==================== Build Matrix ====================
+
+// stopVariants stops the unfinished variant builds of a matrix parent that was
+// stopped, with the status of the parent
+func (s *BuildServiceServer) stopVariants(ctx context.Context, corrID string, parent *models.Build, reason string) {
+	var variants []models.Build
+	if err := s.db.Where("parent_id = ? AND status IN ?", parent.ID, activeBuildStatuses).Find(&variants).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("build_id", parent.ID.String()).Msg("Failed to find variant builds to stop")
+		return
+	}
+	for i := range variants {
+		variant := &variants[i]
+		if err := s.stopBuild(ctx, corrID, variant, parent.Status, parent.SupersededBy, reason); err != nil {
+			log.Warn().Err(err).Str("correlation_id", corrID).Str("build_id", variant.ID.String()).Msg("Failed to stop variant build")
+		}
+	}
+}
+
+// updateMatrixParent sets the status of a matrix parent from its variant builds,
+// after one of them changed. A parent that was cancelled or superseded keeps its
+// status. Once every required variant passed, the parent goes back to pending
+// and its own job builds and deploys the image like any other build.
+func (s *BuildServiceServer) updateMatrixParent(ctx context.Context, corrID string, parentID uuid.UUID) {
+	var parent models.Build
+	var summary string
+	changed := false
+	queueImageJob := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Variants finishing at once update the parent one after the other
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&parent, "id = ?", parentID).Error; err != nil {
+			return err
+		}
+		if parent.ImageJob || parent.Status == models.BuildStatusCancelled || parent.Status == models.BuildStatusSuperseded {
+			return nil
+		}
+
+		var variants []models.Build
+		if err := tx.Where("parent_id = ?", parentID).Find(&variants).Error; err != nil {
+			return err
+		}
+		var status models.BuildStatus
+		status, summary = matrixStatus(variants)
+		if status == models.BuildStatusPending && parent.StartedAt != nil {
+			// A variant went back to the queue for a retry
+			status = models.BuildStatusRunning
+		}
+		if status == models.BuildStatusSuccess {
+			status = models.BuildStatusPending
+			queueImageJob = true
+		}
+		if status == parent.Status && !queueImageJob {
+			return nil
+		}
+
+		now := time.Now()
+		parent.Status = status
+		parent.ImageJob = queueImageJob
+		updates := map[string]interface{}{
+			"status":      status,
+			"finished_at": nil,
+			"image_job":   queueImageJob,
+		}
+		if status != models.BuildStatusPending && parent.StartedAt == nil {
+			updates["started_at"] = now
+		}
+		if parent.IsTerminal() {
+			updates["finished_at"] = now
+		}
+		changed = true
+		return tx.Model(&parent).Updates(updates).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("build_id", parentID.String()).Msg("Failed to update build matrix")
+		return
+	}
+	if queueImageJob {
+		s.queueMatrixImage(ctx, corrID, &parent, summary)
+		return
+	}
+	if !changed || !parent.IsTerminal() {
+		return
+	}
+
+	if err := s.appendLogs(ctx, parent.ID, []string{"[matrix] " + summary}); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to append logs")
+	}
+	s.reportMatrixPreview(ctx, corrID, &parent, summary)
+	if err := s.producer.PublishBuildEvent(ctx, parent.ProjectID.String(), queue.BuildEvent{
+		BuildID: parent.ID.String(),
+		Event:   "completed",
+		Status:  string(parent.Status),
+		Message: summary,
+	}); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to publish completed event")
+	}
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", parent.ID.String()).
+		Str("status", string(parent.Status)).
+		Msg("Build matrix finished")
+}
+
+// queueMatrixImage enqueues the job of a matrix parent whose required variants
+// passed. The job builds and tests the project with the settings of the project,
+// then pushes and deploys the image, or the preview of a pull request.
+func (s *BuildServiceServer) queueMatrixImage(ctx context.Context, corrID string, parent *models.Build, summary string) {
+	if err := s.appendLogs(ctx, parent.ID, []string{"[matrix] " + summary + ", building the image"}); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to append logs")
+	}
+
+	project := s.getProject(ctx, corrID, parent.ProjectID.String())
+
+	// Like pushes, the image is not held back over a failed plan lookup
+	var plan *authpb.GetUserPlanResponse
+	if s.authClient != nil && parent.UserID != nil {
+		resp, err := s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{UserId: parent.UserID.String()})
+		if err == nil && resp.Error == "" {
+			plan = resp
+		} else {
+			log.Warn().Err(err).Str("correlation_id", corrID).Str("error", resp.GetError()).Msg("Failed to get owner plan for build limits")
+		}
+	}
+
+	req := &pb.TriggerBuildRequest{
+		ProjectId:   parent.ProjectID.String(),
+		CommitSha:   parent.CommitSHA,
+		Branch:      parent.Branch,
+		PullRequest: int32(parent.PullRequest),
+		Environment: parent.Environment,
+	}
+	if project != nil {
+		req.RepoUrl = project.RepoUrl
+	}
+	if err := s.enqueueBuild(ctx, corrID, parent, req, project, plan, nil); err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("build_id", parent.ID.String()).Msg("Failed to enqueue build job")
+		now := time.Now()
+		s.db.Model(parent).Updates(map[string]interface{}{"status": models.BuildStatusFailed, "finished_at": now})
+		parent.Status = models.BuildStatusFailed
+		s.reportMatrixPreview(ctx, corrID, parent, "Image build could not be queued")
+		return
+	}
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", parent.ID.String()).
+		Msg("Build matrix passed, image build queued")
+}
+
+// previewStatusContext names the commit status of pull request previews, the
+// one API Gateway and Runner Service set
+const previewStatusContext = "nexusdeploy/preview"
+
+// reportMatrixPreview resolves the preview commit status of a matrix parent of a
+// pull request that finished without running its job, which would report it
+func (s *BuildServiceServer) reportMatrixPreview(ctx context.Context, corrID string, parent *models.Build, message string) {
+	if parent.PullRequest == 0 || parent.CommitSHA == "" || s.projectClient == nil {
+		return
+	}
+	state := "failure"
+	if parent.Status == models.BuildStatusCancelled {
+		state = "error"
+	}
+	resp, err := s.projectClient.SetCommitStatus(ctx, &projectpb.SetCommitStatusRequest{
+		ProjectId:   parent.ProjectID.String(),
+		CommitSha:   parent.CommitSHA,
+		State:       state,
+		Description: message,
+		Context:     previewStatusContext,
+	})
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Str("build_id", parent.ID.String()).Msg("Failed to set preview commit status")
+	}
+}
+
+// matrixStatus returns the status of a matrix parent from those of its variant
+// builds, with a summary of them. The matrix runs until every variant finished and
+// succeeds if every required variant passed, optional ones may fail.
+func matrixStatus(variants []models.Build) (models.BuildStatus, string) {
+	started, running := false, false
+	failed, stopped := false, false
+	passed := 0
+	for i := range variants {
+		v := &variants[i]
+		if v.Status != models.BuildStatusPending {
+			started = true
+		}
+		if !v.IsTerminal() {
+			running = true
+			continue
+		}
+		switch {
+		case v.Status == models.BuildStatusSuccess:
+			passed++
+		case v.Optional:
+			// An optional variant may fail
+		case v.Status == models.BuildStatusCancelled, v.Status == models.BuildStatusSuperseded:
+			stopped = true
+		default:
+			failed = true
+		}
+	}
+
+	summary := fmt.Sprintf("%d of %d variants passed", passed, len(variants))
+	switch {
+	case running && started:
+		return models.BuildStatusRunning, summary
+	case running:
+		return models.BuildStatusPending, summary
+	case failed:
+		return models.BuildStatusFailed, summary + ", a required variant failed"
+	case stopped:
+		return models.BuildStatusCancelled, summary + ", a required variant was cancelled"
+	default:
+		return models.BuildStatusSuccess, summary
+	}
+}
+
+// ==================== GetQueuePosition ====================
+
+// GetQueuePosition returns where a pending build sits in the queue of its plan.
+// Builds put back because their user has no free build slot wait in the retry
+// state and rejoin the end of the queue shortly.
+func (s *BuildServiceServer) GetQueuePosition(ctx context.Context, req *pb.GetQueuePositionRequest) (*pb.GetQueuePositionResponse, error) {
+	corrID := getCorrelationID(ctx)
+
+	if req.BuildId == "" || req.UserId == "" {
+		return &pb.GetQueuePositionResponse{Error: "build_id and user_id are required"}, nil
+	}
+
+	buildID, err := uuid.Parse(req.BuildId)
+	if err != nil {
+		return &pb.GetQueuePositionResponse{Error: "invalid build_id format"}, nil
+	}
+
+	var build models.Build
+	if err := s.db.First(&build, "id = ?", buildID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.GetQueuePositionResponse{Error: "build not found"}, nil
+		}
+		return &pb.GetQueuePositionResponse{Error: "failed to get build"}, nil
+	}
+
+	// Builds of other users are not found, as in CancelBuild
+	owner, err := s.isBuildOwner(ctx, &build, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to check build owner")
+		return &pb.GetQueuePositionResponse{Error: "failed to get build"}, nil
+	}
+	if !owner {
+		return &pb.GetQueuePositionResponse{Error: "build not found"}, nil
+	}
+
+	// Only pending builds are queued, later ones were taken by a runner
+	resp := &pb.GetQueuePositionResponse{BuildId: req.BuildId}
+	if build.Status != models.BuildStatusPending {
+		return resp, nil
+	}
+
+	position, err := s.producer.QueuePosition(ctx, req.BuildId)
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("build_id", req.BuildId).Msg("Failed to get queue position")
+		return &pb.GetQueuePositionResponse{Error: "failed to get queue position"}, nil
+	}
+	if position == nil || position.State == "active" {
+		return resp, nil
+	}
+
+	resp.Queued = true
+	resp.Queue = position.Queue
+	resp.State = position.State
+	resp.Position = int32(position.Position)
+	resp.QueueLength = int32(position.Pending)
+	if position.State != "pending" {
+		resp.Reason = position.LastErr
+	}
+	return resp, nil
+}
+
+// ==================== Build Usage ====================
+
+// activeBuildStatuses are the statuses of builds that hold a concurrent build
+var activeBuildStatuses = []models.BuildStatus{
+	models.BuildStatusPending,
+	models.BuildStatusRunning,
+	models.BuildStatusBuildingImage,
+	models.BuildStatusPushingImage,
+	models.BuildStatusDeploying,
+}
+
+// GetBuildUsage returns the builds a user triggered this month and has pending or
+// running, against the limits of their plan
+func (s *BuildServiceServer) GetBuildUsage(ctx context.Context, req *pb.GetBuildUsageRequest) (*pb.GetBuildUsageResponse, error) {
+	corrID := getCorrelationID(ctx)
+
+	if req.UserId == "" {
+		return &pb.GetBuildUsageResponse{Error: "user_id is required"}, nil
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return &pb.GetBuildUsageResponse{Error: "invalid user_id format"}, nil
+	}
+
+	if s.authClient == nil {
+		return &pb.GetBuildUsageResponse{Error: "plan lookup is not available"}, nil
+	}
+	plan, err := s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{UserId: req.UserId})
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Str("user_id", req.UserId).Msg("Failed to get user plan for build usage")
+		return &pb.GetBuildUsageResponse{Error: "failed to get user plan"}, nil
+	}
+	if plan.Error != "" {
+		return &pb.GetBuildUsageResponse{Error: "failed to get user plan: " + plan.Error}, nil
+	}
+
+	period := models.UsagePeriod(time.Now())
+	var usage models.BuildUsage
+	if err := s.db.Where("user_id = ? AND period = ?", userID, period).Limit(1).Find(&usage).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to get build usage")
+		return &pb.GetBuildUsageResponse{Error: "failed to get build usage"}, nil
+	}
+
+	active, err := s.countActiveBuilds(userID)
+	if err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to count active builds")
+		return &pb.GetBuildUsageResponse{Error: "failed to get build usage"}, nil
+	}
+
+	remaining := int32(-1)
+	if plan.MaxBuildsPerMonth > 0 {
+		remaining = max(plan.MaxBuildsPerMonth-int32(usage.Builds), 0)
+	}
+
+	return &pb.GetBuildUsageResponse{
+		Plan:                plan.Plan,
+		PeriodStart:         timestamppb.New(period),
+		ResetsAt:            timestamppb.New(period.AddDate(0, 1, 0)),
+		BuildsUsed:          int32(usage.Builds),
+		MaxBuildsPerMonth:   plan.MaxBuildsPerMonth,
+		BuildsRemaining:     remaining,
+		ActiveBuilds:        int32(active),
+		MaxConcurrentBuilds: plan.MaxConcurrentBuilds,
+	}, nil
+}
+
+// countActiveBuilds counts the builds of a user pending or running, across all of
+// their projects. Matrix parents count once their own job is queued, before that
+// their variants are counted instead.
+func (s *BuildServiceServer) countActiveBuilds(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Build{}).
+		Where("user_id = ? AND status IN ? AND (NOT matrix OR image_job)", userID, activeBuildStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+// countBuilds adds n builds to the monthly usage of a user, unless that takes them
+// over limit builds in the period. It reports whether the builds were counted. The
+// check and the increment are a single statement, so concurrent triggers cannot
+// both take the last builds.
+func (s *BuildServiceServer) countBuilds(userID uuid.UUID, period time.Time, n int, limit int32) (bool, error) {
+	result := s.db.Exec(`
+		INSERT INTO build_usage (user_id, period, builds, updated_at)
+		SELECT ?::uuid, ?::date, ?::int, now() WHERE ?::int <= ?::int
+		ON CONFLICT (user_id, period) DO UPDATE
+		SET builds = build_usage.builds + EXCLUDED.builds, updated_at = now()
+		WHERE build_usage.builds + EXCLUDED.builds <= ?`,
+		userID, period, n, n, limit, limit)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// uncountBuilds gives back n builds counted by countBuilds
+func (s *BuildServiceServer) uncountBuilds(userID uuid.UUID, period time.Time, n int) error {
+	return s.db.Model(&models.BuildUsage{}).
+		Where("user_id = ? AND period = ? AND builds > 0", userID, period).
+		Updates(map[string]interface{}{
+			"builds":     gorm.Expr("GREATEST(builds - ?, 0)", n),
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// ==================== Dead Letters ====================
+
+// buildQueueResource is the auth resource of the dead-letter admin API. No plan
+// includes it, admins are granted an explicit permission.
+const buildQueueResource = "build_queue"
+
+// RecordDeadLetter stores a build job that the queue archived instead of retrying.
+// The runner has already marked the build failed.
+func (s *BuildServiceServer) RecordDeadLetter(ctx context.Context, req *pb.RecordDeadLetterRequest) (*pb.RecordDeadLetterResponse, error) {
+	corrID := getCorrelationID(ctx)
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", req.BuildId).
+		Str("kind", req.Kind).
+		Int32("attempts", req.Attempts).
+		Msg("RecordDeadLetter called")
+
+	if req.BuildId == "" {
+		return &pb.RecordDeadLetterResponse{Error: "build_id is required"}, nil
+	}
+
+	buildID, err := uuid.Parse(req.BuildId)
+	if err != nil {
+		return &pb.RecordDeadLetterResponse{Error: "invalid build_id format"}, nil
+	}
+
+	kind := models.FailureKind(req.Kind)
+	switch kind {
+	case models.FailureKindUser, models.FailureKindInfrastructure:
+	default:
+		return &pb.RecordDeadLetterResponse{Error: fmt.Sprintf("invalid kind %q", req.Kind)}, nil
+	}
+
+	var build models.Build
+	if err := s.db.First(&build, "id = ?", buildID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.RecordDeadLetterResponse{Error: "build not found"}, nil
+		}
+		return &pb.RecordDeadLetterResponse{Error: "failed to get build"}, nil
+	}
+
+	taskID := req.TaskId
+	if taskID == "" {
+		taskID = req.BuildId
+	}
+	deadLetter := models.DeadLetter{
+		BuildID:    build.ID,
+		ProjectID:  build.ProjectID,
+		Queue:      req.Queue,
+		TaskID:     taskID,
+		Kind:       kind,
+		Reason:     req.Reason,
+		Attempts:   max(int(req.Attempts), 1),
+		ArchivedAt: time.Now(),
+	}
+	if err := s.db.Create(&deadLetter).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to record dead letter")
+		return &pb.RecordDeadLetterResponse{Error: "failed to record dead letter"}, nil
+	}
+
+	return &pb.RecordDeadLetterResponse{Acknowledged: true}, nil
+}
+
+// ListDeadLetters returns the archived build jobs, newest first. Requeued ones are
+// left out unless asked for.
+func (s *BuildServiceServer) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	corrID := getCorrelationID(ctx)
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("user_id", req.UserId).
+		Str("kind", req.Kind).
+		Msg("ListDeadLetters called")
+
+	if req.UserId == "" {
+		return &pb.ListDeadLettersResponse{Error: "user_id is required"}, nil
+	}
+	if reason := s.checkDeadLettersPermission(ctx, req.UserId, "read"); reason != "" {
+		return &pb.ListDeadLettersResponse{Error: reason}, nil
+	}
+
+	query := s.db.Model(&models.DeadLetter{})
+	if req.Kind != "" {
+		query = query.Where("kind = ?", req.Kind)
+	}
+	if !req.IncludeRequeued {
+		query = query.Where("requeued_at IS NULL")
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to count dead letters")
+		return &pb.ListDeadLettersResponse{Error: "failed to list dead letters"}, nil
+	}
+
+	var deadLetters []models.DeadLetter
+	if err := query.Order("archived_at DESC").
+		Offset(int((page - 1) * pageSize)).
+		Limit(int(pageSize)).
+		Find(&deadLetters).Error; err != nil {
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to list dead letters")
+		return &pb.ListDeadLettersResponse{Error: "failed to list dead letters"}, nil
+	}
+
+	protoDeadLetters := make([]*pb.DeadLetter, len(deadLetters))
+	for i := range deadLetters {
+		protoDeadLetters[i] = deadLetterToProto(&deadLetters[i])
+	}
+
+	return &pb.ListDeadLettersResponse{
+		DeadLetters: protoDeadLetters,
+		Total:       int32(total),
+	}, nil
+}
+
+// RequeueDeadLetter runs an archived build job again. The build goes back to
+// pending with its steps reset; the runner picks it up like a new build.
+func (s *BuildServiceServer) RequeueDeadLetter(ctx context.Context, req *pb.RequeueDeadLetterRequest) (*pb.RequeueDeadLetterResponse, error) {
+	corrID := getCorrelationID(ctx)
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("dead_letter_id", req.Id).
+		Str("user_id", req.UserId).
+		Msg("RequeueDeadLetter called")
+
+	if req.Id == "" || req.UserId == "" {
+		return &pb.RequeueDeadLetterResponse{Error: "id and user_id are required"}, nil
+	}
+
+	deadLetterID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return &pb.RequeueDeadLetterResponse{Error: "invalid id format"}, nil
+	}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return &pb.RequeueDeadLetterResponse{Error: "invalid user_id format"}, nil
+	}
+
+	if reason := s.checkDeadLettersPermission(ctx, req.UserId, "requeue"); reason != "" {
+		return &pb.RequeueDeadLetterResponse{Error: reason}, nil
+	}
+
+	var deadLetter models.DeadLetter
+	if err := s.db.First(&deadLetter, "id = ?", deadLetterID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.RequeueDeadLetterResponse{Error: "dead letter not found"}, nil
+		}
+		return &pb.RequeueDeadLetterResponse{Error: "failed to get dead letter"}, nil
+	}
+	if deadLetter.RequeuedAt != nil {
+		return &pb.RequeueDeadLetterResponse{Error: "dead letter was already requeued"}, nil
+	}
+
+	var build models.Build
+	if err := s.db.First(&build, "id = ?", deadLetter.BuildID).Error; err != nil {
+		return &pb.RequeueDeadLetterResponse{Error: "failed to get build"}, nil
+	}
+	if build.Status != models.BuildStatusFailed {
+		return &pb.RequeueDeadLetterResponse{Error: fmt.Sprintf("build is %s, only failed builds can be requeued", build.Status)}, nil
+	}
+
+	// The build is reset first, a runner taking the job right away must find it
+	// pending
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Build{}).
+			Where("id = ? AND status = ?", build.ID, models.BuildStatusFailed).
+			Updates(map[string]interface{}{
+				"status":      models.BuildStatusPending,
+				"started_at":  nil,
+				"finished_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errBuildStatusChanged
+		}
+		return tx.Model(&deadLetter).Updates(map[string]interface{}{
+			"requeued_at": now,
+			"requeued_by": userID,
+		}).Error
+	})
+	if err != nil {
+		if errors.Is(err, errBuildStatusChanged) {
+			return &pb.RequeueDeadLetterResponse{Error: err.Error()}, nil
+		}
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to reset build for requeue")
+		return &pb.RequeueDeadLetterResponse{Error: "failed to requeue build"}, nil
+	}
+
+	if err := s.producer.RequeueBuildJob(ctx, build.ID.String()); err != nil {
+		// Put the build and the dead letter back as they were
+		s.db.Model(&models.Build{}).Where("id = ?", build.ID).Updates(map[string]interface{}{
+			"status":      models.BuildStatusFailed,
+			"started_at":  build.StartedAt,
+			"finished_at": build.FinishedAt,
+		})
+		s.db.Model(&deadLetter).Updates(map[string]interface{}{"requeued_at": nil, "requeued_by": nil})
+		if errors.Is(err, queue.ErrJobNotArchived) {
+			return &pb.RequeueDeadLetterResponse{Error: "build job is no longer archived, trigger a new build instead"}, nil
+		}
+		log.Error().Err(err).Str("correlation_id", corrID).Msg("Failed to requeue build job")
+		return &pb.RequeueDeadLetterResponse{Error: "failed to requeue build"}, nil
+	}
+	deadLetter.RequeuedAt = &now
+	deadLetter.RequeuedBy = &userID
+
+	if err := s.resetBuildSteps(build.ID); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to reset build steps")
+	}
+	if err := s.appendLogs(ctx, build.ID, []string{"[requeue] Build requeued by an admin"}); err != nil {
+		log.Warn().Err(err).Str("correlation_id", corrID).Msg("Failed to append logs")
+	}
+
+	build.Status = models.BuildStatusPending
+	build.StartedAt = nil
+	build.FinishedAt = nil
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("build_id", build.ID.String()).
+		Str("requeued_by", req.UserId).
+		Msg("Dead-lettered build requeued")
+
+	return &pb.RequeueDeadLetterResponse{
+		DeadLetter: deadLetterToProto(&deadLetter),
+		Build:      buildToProto(&build),
+	}, nil
+}
+
+// checkDeadLettersPermission returns why a user may not use the dead-letter admin
+// API, or "" if they may
+func (s *BuildServiceServer) checkDeadLettersPermission(ctx context.Context, userID, action string) string {
+	if s.authClient == nil {
+		return "permission check is not available"
+	}
+	resp, err := s.authClient.CheckPermission(ctx, &authpb.CheckPermissionRequest{
+		UserId:       userID,
+		ResourceType: buildQueueResource,
+		Action:       action,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to check dead letters permission")
+		return "failed to check permission"
+	}
+	if !resp.Allowed {
+		return resp.Reason
+	}
+	return ""
+}
+
+// resetBuildSteps puts the steps of a build back to pending for another attempt
+func (s *BuildServiceServer) resetBuildSteps(buildID uuid.UUID) error {
+	return s.db.Model(&models.BuildStep{}).
+		Where("build_id = ?", buildID).
+		Updates(map[string]interface{}{
+			"status":      models.StepStatusPending,
+			"duration_ms": nil,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// ==================== DeleteBuildLogs ====================
+
+// DeleteBuildLogs deletes logs for builds in a project
+func (s *BuildServiceServer) DeleteBuildLogs(ctx context.Context, req *pb.DeleteBuildLogsRequest) (*pb.DeleteBuildLogsResponse, error) {
+	corrID := getCorrelationID(ctx)
+	log.Info().
+		Str("correlation_id", corrID).
+		Str("project_id", req.ProjectId).
+		Str("user_id", req.UserId).
+		Int("build_count", len(req.BuildIds)).
+		Msg("DeleteBuildLogs called")
+
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.DeleteBuildLogsResponse{Error: "project_id and user_id are required"}, nil
+	}
+
+	projectID, err := uuid.Parse(req.ProjectId)
+	if err != nil {
+		return &pb.DeleteBuildLogsResponse{Error: "invalid project_id format"}, nil
+	}
+
+	_, err = uuid.Parse(req.UserId)
+	if err != nil {
+		return &pb.DeleteBuildLogsResponse{Error: "invalid user_id format"}, nil
+	}
+
+	// Get builds for this project (to verify ownership)
+	var buildModels []models.Build
+	query := s.db.Where("project_id = ?", projectID)
+
+	// If specific build IDs provided, filter by them
+	if len(req.BuildIds) > 0 {
+		buildUUIDs := make([]uuid.UUID, 0, len(req.BuildIds))
+		for _, bid := range req.BuildIds {
+			if buildUUID, err := uuid.Parse(bid); err == nil {
+				buildUUIDs = append(buildUUIDs, buildUUID)
+			}
+		}
+		if len(buildUUIDs) > 0 {
+			query = query.Where("id IN ?", buildUUIDs)
+		}
+	}
+
+	if err := query.Find(&buildModels).Error; err != nil {
+		return &pb.DeleteBuildLogsResponse{Error: "failed to get builds"}, nil
+	}
+
+	if len(buildModels) == 0 {
+		return &pb.DeleteBuildLogsResponse{
+			BuildsAffected: 0,
+			LogsDeleted:    0,
+		}, nil
+	}
+
+	// Extract build IDs
+	buildIDs := make([]uuid.UUID, len(buildModels))
+	for i, b := range buildModels {
+		buildIDs[i] = b.ID
+	}
+
+	// Count logs before deletion
+	var logsCount int64
+	s.db.Model(&models.BuildLog{}).Where("build_id IN ?", buildIDs).Count(&logsCount)
+
+	// Delete logs for these builds (CASCADE will handle build_steps)
+	if err := s.db.Where("build_id IN ?", buildIDs).Delete(&models.BuildLog{}).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to delete build logs")
+		return &pb.DeleteBuildLogsResponse{Error: "failed to delete logs"}, nil
+	}
+
+	// Delete build steps (CASCADE should handle this, but explicit delete for safety)
+	if err := s.db.Where("build_id IN ?", buildIDs).Delete(&models.BuildStep{}).Error; err != nil {
+		log.Warn().Err(err).Msg("Failed to delete build steps (may not exist)")
+		// Continue anyway
+	}
+
+	if err := s.db.Where("build_id IN ?", buildIDs).Delete(&models.TestResult{}).Error; err != nil {
+		log.Warn().Err(err).Msg("Failed to delete test results")
+	}
+
+	// Delete the builds themselves
+	if err := s.db.Where("id IN ?", buildIDs).Delete(&models.Build{}).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to delete builds")
+		return &pb.DeleteBuildLogsResponse{Error: "failed to delete builds"}, nil
+	}
+
+	// Cleanup workspaces in runner-service
+	buildIDStrings := make([]string, len(buildIDs))
+	for i, id := range buildIDs {
+		buildIDStrings[i] = id.String()
+	}
+
+	// Call runner-service to cleanup workspaces
+	if err := cleanupRunnerWorkspaces(ctx, buildIDStrings, s.cfg); err != nil {
+		log.Warn().
+			Err(err).
+			Str("correlation_id", corrID).
+			Msg("Failed to cleanup runner workspaces (non-fatal)")
+		// Continue anyway - database cleanup succeeded
+	}
+
+	log.Info().
+		Str("correlation_id", corrID).
+		Int("builds_affected", len(buildIDs)).
+		Int64("logs_deleted", logsCount).
+		Msg("Build history deleted successfully")
+
+	return &pb.DeleteBuildLogsResponse{
+		BuildsAffected: int32(len(buildIDs)),
+		LogsDeleted:    logsCount,
+	}, nil
+}
+
+// ==================== Helper Functions ====================
+
+func (s *BuildServiceServer) appendLogs(ctx context.Context, buildID uuid.UUID, lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	logs := make([]models.BuildLog, len(lines))
+	for i, line := range lines {
+		logs[i] = models.BuildLog{
+			BuildID:   buildID,
+			Timestamp: now,
+			LogLine:   line,
+		}
+	}
+
+	return s.db.Create(&logs).Error
+}
+
+func buildToProto(b *models.Build) *pb.Build {
+	if b == nil {
+		return nil
+	}
+
+	build := &pb.Build{
+		Id:        b.ID.String(),
+		ProjectId: b.ProjectID.String(),
+		CommitSha: b.CommitSHA,
+		Status:    modelStatusToProto(b.Status),
+		CreatedAt: timestamppb.New(b.CreatedAt),
+		UpdatedAt: timestamppb.New(b.UpdatedAt),
+		ImageTag:  b.ImageTag,
+
+		DeploymentId: b.DeploymentID,
+		PullRequest:  int32(b.PullRequest),
+		Environment:  b.Environment,
+		Branch:       b.Branch,
+	}
+	if b.SupersededBy != nil {
+		build.SupersededBy = b.SupersededBy.String()
+	}
+	build.Variant = b.Variant
+	build.Optional = b.Optional
+	build.Matrix = b.Matrix
+	if b.ParentID != nil {
+		build.ParentId = b.ParentID.String()
+	}
+
+	if b.StartedAt != nil {
 		build.StartedAt = timestamppb.New(*b.StartedAt)
 	}
 	if b.FinishedAt != nil {
@@ -669,6 +2082,26 @@ func cleanupRunnerWorkspaces(ctx context.Context, buildIDs []string, cfg *cfgpkg
 	return nil
 }
 
+func deadLetterToProto(d *models.DeadLetter) *pb.DeadLetter {
+	deadLetter := &pb.DeadLetter{
+		Id:         d.ID.String(),
+		BuildId:    d.BuildID.String(),
+		ProjectId:  d.ProjectID.String(),
+		Queue:      d.Queue,
+		Kind:       string(d.Kind),
+		Reason:     d.Reason,
+		Attempts:   int32(d.Attempts),
+		ArchivedAt: timestamppb.New(d.ArchivedAt),
+	}
+	if d.RequeuedAt != nil {
+		deadLetter.RequeuedAt = timestamppb.New(*d.RequeuedAt)
+	}
+	if d.RequeuedBy != nil {
+		deadLetter.RequeuedBy = d.RequeuedBy.String()
+	}
+	return deadLetter
+}
+
 func stepToProto(s *models.BuildStep) *pb.BuildStep {
 	if s == nil {
 		return nil
@@ -688,6 +2121,20 @@ func stepToProto(s *models.BuildStep) *pb.BuildStep {
 	return step
 }
 
+func testResultToProto(r *models.TestResult) *pb.TestCase {
+	if r == nil {
+		return nil
+	}
+
+	return &pb.TestCase{
+		Suite:          r.Suite,
+		Name:           r.Name,
+		Status:         string(r.Status),
+		DurationMs:     int32(r.DurationMs),
+		FailureMessage: r.FailureMessage,
+	}
+}
+
 func logToProto(l *models.BuildLog) *pb.BuildLog {
 	if l == nil {
 		return nil
@@ -719,6 +2166,10 @@ func modelStatusToProto(status models.BuildStatus) pb.BuildStatus {
 		return pb.BuildStatus_BUILD_STATUS_SUCCESS
 	case models.BuildStatusDeployFailed:
 		return pb.BuildStatus_BUILD_STATUS_DEPLOY_FAILED
+	case models.BuildStatusCancelled:
+		return pb.BuildStatus_BUILD_STATUS_CANCELLED
+	case models.BuildStatusSuperseded:
+		return pb.BuildStatus_BUILD_STATUS_SUPERSEDED
 	default:
 		return pb.BuildStatus_BUILD_STATUS_UNSPECIFIED
 	}
@@ -742,6 +2193,10 @@ func protoStatusToModel(status pb.BuildStatus) models.BuildStatus {
 		return models.BuildStatusSuccess
 	case pb.BuildStatus_BUILD_STATUS_DEPLOY_FAILED:
 		return models.BuildStatusDeployFailed
+	case pb.BuildStatus_BUILD_STATUS_CANCELLED:
+		return models.BuildStatusCancelled
+	case pb.BuildStatus_BUILD_STATUS_SUPERSEDED:
+		return models.BuildStatusSuperseded
 	default:
 		return models.BuildStatusPending
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/nexusdeploy/backend/services/build-service/models"
 	pb "github.com/nexusdeploy/backend/services/build-service/proto"
 	"github.com/nexusdeploy/backend/services/build-service/queue"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -59,7 +60,7 @@ func main() {
 	log.Info().Msg("Connected to PostgreSQL")
 
 	// Auto-migrate models
-	if err := db.AutoMigrate(&models.Build{}, &models.BuildLog{}, &models.BuildStep{}); err != nil {
+	if err := db.AutoMigrate(&models.Build{}, &models.BuildLog{}, &models.BuildStep{}, &models.TestResult{}, &models.BuildUsage{}, &models.DeadLetter{}); err != nil {
 		log.Fatal().Err(err).Msg("Failed to auto-migrate models")
 	}
 	log.Info().Msg("Database migration completed")
@@ -93,8 +94,25 @@ func main() {
 	authClient := authpb.NewAuthServiceClient(authConn)
 	log.Info().Str("address", cfg.AuthServiceAddr).Msg("Connected to Auth Service")
 
+	// Connect to Project Service for project build settings
+	projectConn, err := grpcpkg.NewClient(ctx, grpcpkg.ClientConfig{
+		Address:            cfg.ProjectServiceAddr,
+		Timeout:            5 * time.Second,
+		MaxRetries:         3,
+		ServiceName:        "project-service",
+		TLSEnabled:         cfg.GRPCTLSEnabled,
+		TLSCertPath:        cfg.GRPCTLSCertPath,
+		InsecureSkipVerify: cfg.GRPCInsecureSkipVerify,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to Project Service")
+	}
+	defer projectConn.Close()
+	projectClient := projectpb.NewProjectServiceClient(projectConn)
+	log.Info().Str("address", cfg.ProjectServiceAddr).Msg("Connected to Project Service")
+
 	// Start servers
-	go startGRPCServer(ctx, producer, authClient, authConn)
+	go startGRPCServer(ctx, producer, authClient, authConn, projectClient)
 	go startHTTPServer(ctx)
 
 	// Wait for shutdown signal
@@ -112,7 +130,7 @@ func connectDB() (*gorm.DB, error) {
 	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
 }
 
-func startGRPCServer(ctx context.Context, producer *queue.Producer, authClient authpb.AuthServiceClient, authConn *grpc.ClientConn) {
+func startGRPCServer(ctx context.Context, producer *queue.Producer, authClient authpb.AuthServiceClient, authConn *grpc.ClientConn, projectClient projectpb.ProjectServiceClient) {
 	grpcAddr := ":50053"
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
@@ -122,7 +140,7 @@ func startGRPCServer(ctx context.Context, producer *queue.Producer, authClient a
 	grpcServer := grpc.NewServer()
 
 	// Register Build Service
-	buildServer := handlers.NewBuildServiceServer(db, cfg, producer, authClient, authConn)
+	buildServer := handlers.NewBuildServiceServer(db, cfg, producer, authClient, authConn, projectClient)
 	pb.RegisterBuildServiceServer(grpcServer, buildServer)
 
 	// Register health check
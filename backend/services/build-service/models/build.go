@@ -18,13 +18,17 @@ const (
 	BuildStatusDeploying     BuildStatus = "deploying"
 	BuildStatusSuccess       BuildStatus = "success"
 	BuildStatusDeployFailed  BuildStatus = "deploy_failed"
+	BuildStatusCancelled     BuildStatus = "cancelled"
+	BuildStatusSuperseded    BuildStatus = "superseded" // Cancelled by a newer build of the same branch
 )
 
 // Build represents a CI/CD build job (SRS B.3)
 type Build struct {
 	ID         uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	ProjectID  uuid.UUID   `gorm:"type:uuid;not null;index"`
+	UserID     *uuid.UUID  `gorm:"type:uuid;index"` // Owner of the project, nil for builds from before it was stored
 	CommitSHA  string      `gorm:"type:varchar(40)"`
+	Branch     string      `gorm:"type:varchar(255)"` // Branch built, "" for builds triggered without one
 	Status     BuildStatus `gorm:"type:varchar(50);not null;default:pending"`
 	ImageTag   string      `gorm:"type:varchar(255)"` // Docker image tag được tạo bởi Runner
 	StartedAt  *time.Time  `gorm:"type:timestamptz"`
@@ -32,9 +36,29 @@ type Build struct {
 	CreatedAt  time.Time   `gorm:"not null;default:now()"`
 	UpdatedAt  time.Time   `gorm:"not null;default:now()"`
 
+	DeploymentID string `gorm:"type:varchar(64)"` // Set when the build was deployed automatically
+
+	PullRequest int `gorm:"not null;default:0"` // Pull request whose head was built, 0 for branch builds
+
+	Environment string `gorm:"type:varchar(32);not null;default:''"` // Environment whose branch was built, "" for production
+
+	SupersededBy *uuid.UUID `gorm:"type:uuid"` // Newer build of the branch that superseded this one
+
+	// Build matrix: each variant build of a trigger builds and tests the project on
+	// the base image of the variant. Once the required variants passed, the parent
+	// build runs the regular job that builds, pushes and deploys the image.
+	ParentID *uuid.UUID `gorm:"type:uuid;index"`
+	Variant  string     `gorm:"type:varchar(64);not null;default:''"`
+	Optional bool       `gorm:"not null;default:false"` // A failed variant does not fail the parent
+	Matrix   bool       `gorm:"not null;default:false"` // Parent build of a matrix
+	ImageJob bool       `gorm:"not null;default:false"` // The variants passed and the parent job was queued
+
 	// Associations
-	Logs  []BuildLog  `gorm:"foreignKey:BuildID;constraint:OnDelete:CASCADE"`
-	Steps []BuildStep `gorm:"foreignKey:BuildID;constraint:OnDelete:CASCADE"`
+	Logs        []BuildLog   `gorm:"foreignKey:BuildID;constraint:OnDelete:CASCADE"`
+	Steps       []BuildStep  `gorm:"foreignKey:BuildID;constraint:OnDelete:CASCADE"`
+	TestResults []TestResult `gorm:"foreignKey:BuildID;constraint:OnDelete:CASCADE"`
+	DeadLetters []DeadLetter `gorm:"foreignKey:BuildID;constraint:OnDelete:CASCADE"`
+	Variants    []Build      `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
 }
 
 // TableName specifies the table name for Build
@@ -45,7 +69,7 @@ func (Build) TableName() string {
 // IsTerminal returns true if the build is in a terminal state
 func (b *Build) IsTerminal() bool {
 	switch b.Status {
-	case BuildStatusFailed, BuildStatusSuccess, BuildStatusDeployFailed:
+	case BuildStatusFailed, BuildStatusSuccess, BuildStatusDeployFailed, BuildStatusCancelled, BuildStatusSuperseded:
 		return true
 	default:
 		return false
@@ -54,12 +78,14 @@ func (b *Build) IsTerminal() bool {
 
 // CanTransitionTo checks if a status transition is valid
 func (b *Build) CanTransitionTo(newStatus BuildStatus) bool {
+	// Running builds go back to pending when the runner failed and the queue
+	// retries them. Matrix variants build no image and succeed once tested.
 	transitions := map[BuildStatus][]BuildStatus{
-		BuildStatusPending:       {BuildStatusRunning},
-		BuildStatusRunning:       {BuildStatusFailed, BuildStatusBuildingImage},
-		BuildStatusBuildingImage: {BuildStatusFailed, BuildStatusPushingImage},
-		BuildStatusPushingImage:  {BuildStatusFailed, BuildStatusSuccess, BuildStatusDeploying},
-		BuildStatusDeploying:     {BuildStatusSuccess, BuildStatusDeployFailed},
+		BuildStatusPending:       {BuildStatusRunning, BuildStatusCancelled, BuildStatusSuperseded},
+		BuildStatusRunning:       {BuildStatusFailed, BuildStatusBuildingImage, BuildStatusCancelled, BuildStatusSuperseded, BuildStatusPending, BuildStatusSuccess},
+		BuildStatusBuildingImage: {BuildStatusFailed, BuildStatusPushingImage, BuildStatusCancelled, BuildStatusSuperseded, BuildStatusPending},
+		BuildStatusPushingImage:  {BuildStatusFailed, BuildStatusSuccess, BuildStatusDeploying, BuildStatusCancelled, BuildStatusSuperseded, BuildStatusPending},
+		BuildStatusDeploying:     {BuildStatusSuccess, BuildStatusDeployFailed, BuildStatusCancelled},
 	}
 
 	allowed, ok := transitions[b.Status]
@@ -17,11 +17,21 @@ const (
 	StepStatusSkipped  StepStatus = "skipped"
 )
 
+// IsValid reports whether s is a known step status
+func (s StepStatus) IsValid() bool {
+	switch s {
+	case StepStatusPending, StepStatusRunning, StepStatusSuccess, StepStatusFailed, StepStatusSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
 // BuildStep represents a step within a build (SRS B.3)
 type BuildStep struct {
 	ID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	BuildID    uuid.UUID  `gorm:"type:uuid;not null;index"`
-	StepName   string     `gorm:"type:varchar(100);not null"`
+	StepName   string     `gorm:"type:varchar(100);not null"` // Built-in name or a step declared in nexus.yaml
 	Status     StepStatus `gorm:"type:varchar(50);not null;default:pending"`
 	DurationMs *int       `gorm:"type:integer"`
 	CreatedAt  time.Time  `gorm:"not null;default:now()"`
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BuildUsage counts the builds a user triggered in a calendar month (UTC), across
+// all of their projects. It is kept apart from the builds, which are deleted with
+// their project, so that deleting builds does not give back quota.
+type BuildUsage struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Period    time.Time `gorm:"type:date;primaryKey"` // First day of the month
+	Builds    int       `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for BuildUsage
+func (BuildUsage) TableName() string {
+	return "build_usage"
+}
+
+// UsagePeriod returns the first day of the month of t in UTC, the period its
+// builds are counted in
+func UsagePeriod(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FailureKind tells why the queue gave up on a build job
+type FailureKind string
+
+// Must match runner-service/queue/retry.go
+const (
+	FailureKindUser           FailureKind = "user"           // The project failed the build, e.g. a command exited non-zero
+	FailureKindInfrastructure FailureKind = "infrastructure" // The runner failed the build on every attempt
+)
+
+// DeadLetter is a build job that the queue archived instead of retrying. Admins can
+// requeue it once the cause is fixed; a build archived again gets a new row.
+type DeadLetter struct {
+	ID         uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	BuildID    uuid.UUID   `gorm:"type:uuid;not null;index"`
+	ProjectID  uuid.UUID   `gorm:"type:uuid;not null"`
+	Queue      string      `gorm:"type:varchar(64);not null"`
+	TaskID     string      `gorm:"type:varchar(64);not null"`
+	Kind       FailureKind `gorm:"type:varchar(20);not null;index"`
+	Reason     string      `gorm:"type:text"`
+	Attempts   int         `gorm:"not null;default:1"`
+	ArchivedAt time.Time   `gorm:"not null;default:now();index"`
+	RequeuedAt *time.Time  `gorm:"type:timestamptz"`
+	RequeuedBy *uuid.UUID  `gorm:"type:uuid"` // Admin who requeued the job
+}
+
+// TableName specifies the table name for DeadLetter
+func (DeadLetter) TableName() string {
+	return "build_dead_letters"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestStatus represents the outcome of a single test case
+type TestStatus string
+
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+// TestResult is a single test case reported by the runner's test step
+type TestResult struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement"`
+	BuildID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Suite          string     `gorm:"type:varchar(255)"`
+	Name           string     `gorm:"type:text;not null"`
+	Status         TestStatus `gorm:"type:varchar(20);not null"`
+	DurationMs     int        `gorm:"not null;default:0"`
+	FailureMessage string     `gorm:"type:text"`
+	CreatedAt      time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for TestResult
+func (TestResult) TableName() string {
+	return "build_test_results"
+}
@@ -35,20 +35,24 @@ const (
 	BuildStatus_BUILD_STATUS_DEPLOYING      BuildStatus = 6
 	BuildStatus_BUILD_STATUS_SUCCESS        BuildStatus = 7
 	BuildStatus_BUILD_STATUS_DEPLOY_FAILED  BuildStatus = 8
+	BuildStatus_BUILD_STATUS_CANCELLED      BuildStatus = 9
+	BuildStatus_BUILD_STATUS_SUPERSEDED     BuildStatus = 10 // Cancelled by a newer build of the same branch
 )
 
 // Enum value maps for BuildStatus.
 var (
 	BuildStatus_name = map[int32]string{
-		0: "BUILD_STATUS_UNSPECIFIED",
-		1: "BUILD_STATUS_PENDING",
-		2: "BUILD_STATUS_RUNNING",
-		3: "BUILD_STATUS_FAILED",
-		4: "BUILD_STATUS_BUILDING_IMAGE",
-		5: "BUILD_STATUS_PUSHING_IMAGE",
-		6: "BUILD_STATUS_DEPLOYING",
-		7: "BUILD_STATUS_SUCCESS",
-		8: "BUILD_STATUS_DEPLOY_FAILED",
+		0:  "BUILD_STATUS_UNSPECIFIED",
+		1:  "BUILD_STATUS_PENDING",
+		2:  "BUILD_STATUS_RUNNING",
+		3:  "BUILD_STATUS_FAILED",
+		4:  "BUILD_STATUS_BUILDING_IMAGE",
+		5:  "BUILD_STATUS_PUSHING_IMAGE",
+		6:  "BUILD_STATUS_DEPLOYING",
+		7:  "BUILD_STATUS_SUCCESS",
+		8:  "BUILD_STATUS_DEPLOY_FAILED",
+		9:  "BUILD_STATUS_CANCELLED",
+		10: "BUILD_STATUS_SUPERSEDED",
 	}
 	BuildStatus_value = map[string]int32{
 		"BUILD_STATUS_UNSPECIFIED":    0,
@@ -60,6 +64,8 @@ var (
 		"BUILD_STATUS_DEPLOYING":      6,
 		"BUILD_STATUS_SUCCESS":        7,
 		"BUILD_STATUS_DEPLOY_FAILED":  8,
+		"BUILD_STATUS_CANCELLED":      9,
+		"BUILD_STATUS_SUPERSEDED":     10,
 	}
 )
 
@@ -92,16 +98,28 @@ func (BuildStatus) EnumDescriptor() ([]byte, []int) {
 
 // Build message
 type Build struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	CommitSha     string                 `protobuf:"bytes,3,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
-	Status        BuildStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=build.BuildStatus" json:"status,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ImageTag      string                 `protobuf:"bytes,9,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"` // Image tag được tạo bởi Runner Service
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId    string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	CommitSha    string                 `protobuf:"bytes,3,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	Status       BuildStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=build.BuildStatus" json:"status,omitempty"`
+	StartedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ImageTag     string                 `protobuf:"bytes,9,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"`              // Image tag được tạo bởi Runner Service
+	DeploymentId string                 `protobuf:"bytes,10,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Deployment of the image when the project auto-deploys
+	PullRequest  int32                  `protobuf:"varint,11,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`   // Set for builds of a pull request, deployed as a preview
+	Environment  string                 `protobuf:"bytes,12,opt,name=environment,proto3" json:"environment,omitempty"`                       // Environment whose branch was built, empty for production
+	Branch       string                 `protobuf:"bytes,13,opt,name=branch,proto3" json:"branch,omitempty"`
+	SupersededBy string                 `protobuf:"bytes,14,opt,name=superseded_by,json=supersededBy,proto3" json:"superseded_by,omitempty"` // Newer build of the branch that superseded this one
+	// Build matrix: a trigger of a project with a matrix creates a parent build and
+	// a child build per variant. Once every required variant passed, the parent
+	// builds and deploys the image like a build without a matrix.
+	ParentId      string `protobuf:"bytes,15,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // Parent of a variant build
+	Variant       string `protobuf:"bytes,16,opt,name=variant,proto3" json:"variant,omitempty"`                   // Matrix variant built, e.g. "node-20"
+	Optional      bool   `protobuf:"varint,17,opt,name=optional,proto3" json:"optional,omitempty"`                // A failure of this variant does not fail the parent
+	Matrix        bool   `protobuf:"varint,18,opt,name=matrix,proto3" json:"matrix,omitempty"`                    // Parent build of a matrix
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -199,6 +217,69 @@ func (x *Build) GetImageTag() string {
 	return ""
 }
 
+func (x *Build) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *Build) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
+	}
+	return 0
+}
+
+func (x *Build) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *Build) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *Build) GetSupersededBy() string {
+	if x != nil {
+		return x.SupersededBy
+	}
+	return ""
+}
+
+func (x *Build) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Build) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Build) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+func (x *Build) GetMatrix() bool {
+	if x != nil {
+		return x.Matrix
+	}
+	return false
+}
+
 // BuildStep message
 type BuildStep struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -276,6 +357,83 @@ func (x *BuildStep) GetDurationMs() int32 {
 	return 0
 }
 
+// TestCase message
+type TestCase struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Suite          string                 `protobuf:"bytes,1,opt,name=suite,proto3" json:"suite,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // passed, failed, skipped
+	DurationMs     int32                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	FailureMessage string                 `protobuf:"bytes,5,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TestCase) Reset() {
+	*x = TestCase{}
+	mi := &file_proto_build_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase) ProtoMessage() {}
+
+func (x *TestCase) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase.ProtoReflect.Descriptor instead.
+func (*TestCase) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TestCase) GetSuite() string {
+	if x != nil {
+		return x.Suite
+	}
+	return ""
+}
+
+func (x *TestCase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestCase) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TestCase) GetDurationMs() int32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TestCase) GetFailureMessage() string {
+	if x != nil {
+		return x.FailureMessage
+	}
+	return ""
+}
+
 // BuildLog message
 type BuildLog struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -289,7 +447,7 @@ type BuildLog struct {
 
 func (x *BuildLog) Reset() {
 	*x = BuildLog{}
-	mi := &file_proto_build_proto_msgTypes[2]
+	mi := &file_proto_build_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -301,7 +459,7 @@ func (x *BuildLog) String() string {
 func (*BuildLog) ProtoMessage() {}
 
 func (x *BuildLog) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[2]
+	mi := &file_proto_build_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -314,7 +472,7 @@ func (x *BuildLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildLog.ProtoReflect.Descriptor instead.
 func (*BuildLog) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{2}
+	return file_proto_build_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BuildLog) GetId() int64 {
@@ -352,14 +510,16 @@ type TriggerBuildRequest struct {
 	CommitSha     string                 `protobuf:"bytes,2,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
 	Branch        string                 `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
 	RepoUrl       string                 `protobuf:"bytes,4,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
-	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                 // For permission check
+	PullRequest   int32                  `protobuf:"varint,6,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"` // Builds the head of a pull request and deploys it as a preview
+	Environment   string                 `protobuf:"bytes,7,opt,name=environment,proto3" json:"environment,omitempty"`                     // Builds for an environment of the project, empty for production
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TriggerBuildRequest) Reset() {
 	*x = TriggerBuildRequest{}
-	mi := &file_proto_build_proto_msgTypes[3]
+	mi := &file_proto_build_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -371,7 +531,7 @@ func (x *TriggerBuildRequest) String() string {
 func (*TriggerBuildRequest) ProtoMessage() {}
 
 func (x *TriggerBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[3]
+	mi := &file_proto_build_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +544,7 @@ func (x *TriggerBuildRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerBuildRequest.ProtoReflect.Descriptor instead.
 func (*TriggerBuildRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{3}
+	return file_proto_build_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TriggerBuildRequest) GetProjectId() string {
@@ -422,6 +582,20 @@ func (x *TriggerBuildRequest) GetUserId() string {
 	return ""
 }
 
+func (x *TriggerBuildRequest) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
+	}
+	return 0
+}
+
+func (x *TriggerBuildRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
 type TriggerBuildResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Build         *Build                 `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
@@ -432,7 +606,7 @@ type TriggerBuildResponse struct {
 
 func (x *TriggerBuildResponse) Reset() {
 	*x = TriggerBuildResponse{}
-	mi := &file_proto_build_proto_msgTypes[4]
+	mi := &file_proto_build_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -444,7 +618,7 @@ func (x *TriggerBuildResponse) String() string {
 func (*TriggerBuildResponse) ProtoMessage() {}
 
 func (x *TriggerBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[4]
+	mi := &file_proto_build_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +631,7 @@ func (x *TriggerBuildResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerBuildResponse.ProtoReflect.Descriptor instead.
 func (*TriggerBuildResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{4}
+	return file_proto_build_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TriggerBuildResponse) GetBuild() *Build {
@@ -479,15 +653,16 @@ type UpdateBuildStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
 	Status        BuildStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=build.BuildStatus" json:"status,omitempty"`
-	LogLines      []string               `protobuf:"bytes,3,rep,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"` // Optional: append logs when updating
-	ImageTag      string                 `protobuf:"bytes,4,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"` // Set when status is PUSHING_IMAGE or later
+	LogLines      []string               `protobuf:"bytes,3,rep,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"`             // Optional: append logs when updating
+	ImageTag      string                 `protobuf:"bytes,4,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"`             // Set when status is PUSHING_IMAGE or later
+	DeploymentId  string                 `protobuf:"bytes,5,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Set when an automatic deploy succeeded
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateBuildStatusRequest) Reset() {
 	*x = UpdateBuildStatusRequest{}
-	mi := &file_proto_build_proto_msgTypes[5]
+	mi := &file_proto_build_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +674,7 @@ func (x *UpdateBuildStatusRequest) String() string {
 func (*UpdateBuildStatusRequest) ProtoMessage() {}
 
 func (x *UpdateBuildStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[5]
+	mi := &file_proto_build_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +687,7 @@ func (x *UpdateBuildStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBuildStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBuildStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{5}
+	return file_proto_build_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateBuildStatusRequest) GetBuildId() string {
@@ -543,6 +718,13 @@ func (x *UpdateBuildStatusRequest) GetImageTag() string {
 	return ""
 }
 
+func (x *UpdateBuildStatusRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
 type UpdateBuildStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Acknowledged  bool                   `protobuf:"varint,1,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
@@ -553,7 +735,7 @@ type UpdateBuildStatusResponse struct {
 
 func (x *UpdateBuildStatusResponse) Reset() {
 	*x = UpdateBuildStatusResponse{}
-	mi := &file_proto_build_proto_msgTypes[6]
+	mi := &file_proto_build_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -565,7 +747,7 @@ func (x *UpdateBuildStatusResponse) String() string {
 func (*UpdateBuildStatusResponse) ProtoMessage() {}
 
 func (x *UpdateBuildStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[6]
+	mi := &file_proto_build_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,7 +760,7 @@ func (x *UpdateBuildStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBuildStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateBuildStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{6}
+	return file_proto_build_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateBuildStatusResponse) GetAcknowledged() bool {
@@ -608,7 +790,7 @@ type ListBuildsRequest struct {
 
 func (x *ListBuildsRequest) Reset() {
 	*x = ListBuildsRequest{}
-	mi := &file_proto_build_proto_msgTypes[7]
+	mi := &file_proto_build_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -620,7 +802,7 @@ func (x *ListBuildsRequest) String() string {
 func (*ListBuildsRequest) ProtoMessage() {}
 
 func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[7]
+	mi := &file_proto_build_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,7 +815,7 @@ func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
 func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{7}
+	return file_proto_build_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListBuildsRequest) GetProjectId() string {
@@ -675,7 +857,7 @@ type ListBuildsResponse struct {
 
 func (x *ListBuildsResponse) Reset() {
 	*x = ListBuildsResponse{}
-	mi := &file_proto_build_proto_msgTypes[8]
+	mi := &file_proto_build_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -687,7 +869,7 @@ func (x *ListBuildsResponse) String() string {
 func (*ListBuildsResponse) ProtoMessage() {}
 
 func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[8]
+	mi := &file_proto_build_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,7 +882,7 @@ func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
 func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{8}
+	return file_proto_build_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListBuildsResponse) GetBuilds() []*Build {
@@ -735,7 +917,7 @@ type GetBuildRequest struct {
 
 func (x *GetBuildRequest) Reset() {
 	*x = GetBuildRequest{}
-	mi := &file_proto_build_proto_msgTypes[9]
+	mi := &file_proto_build_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -747,7 +929,7 @@ func (x *GetBuildRequest) String() string {
 func (*GetBuildRequest) ProtoMessage() {}
 
 func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[9]
+	mi := &file_proto_build_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -760,7 +942,7 @@ func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
 func (*GetBuildRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{9}
+	return file_proto_build_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetBuildRequest) GetBuildId() string {
@@ -782,13 +964,14 @@ type GetBuildResponse struct {
 	Build         *Build                 `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
 	Steps         []*BuildStep           `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Variants      []*Build               `protobuf:"bytes,4,rep,name=variants,proto3" json:"variants,omitempty"` // Variant builds of a matrix parent
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBuildResponse) Reset() {
 	*x = GetBuildResponse{}
-	mi := &file_proto_build_proto_msgTypes[10]
+	mi := &file_proto_build_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -800,7 +983,7 @@ func (x *GetBuildResponse) String() string {
 func (*GetBuildResponse) ProtoMessage() {}
 
 func (x *GetBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[10]
+	mi := &file_proto_build_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -813,7 +996,7 @@ func (x *GetBuildResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildResponse.ProtoReflect.Descriptor instead.
 func (*GetBuildResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{10}
+	return file_proto_build_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetBuildResponse) GetBuild() *Build {
@@ -837,6 +1020,13 @@ func (x *GetBuildResponse) GetError() string {
 	return ""
 }
 
+func (x *GetBuildResponse) GetVariants() []*Build {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
 // --- GetBuildLogs ---
 type GetBuildLogsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -849,7 +1039,7 @@ type GetBuildLogsRequest struct {
 
 func (x *GetBuildLogsRequest) Reset() {
 	*x = GetBuildLogsRequest{}
-	mi := &file_proto_build_proto_msgTypes[11]
+	mi := &file_proto_build_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -861,7 +1051,7 @@ func (x *GetBuildLogsRequest) String() string {
 func (*GetBuildLogsRequest) ProtoMessage() {}
 
 func (x *GetBuildLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[11]
+	mi := &file_proto_build_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -874,7 +1064,7 @@ func (x *GetBuildLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetBuildLogsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{11}
+	return file_proto_build_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetBuildLogsRequest) GetBuildId() string {
@@ -909,7 +1099,7 @@ type GetBuildLogsResponse struct {
 
 func (x *GetBuildLogsResponse) Reset() {
 	*x = GetBuildLogsResponse{}
-	mi := &file_proto_build_proto_msgTypes[12]
+	mi := &file_proto_build_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -921,7 +1111,7 @@ func (x *GetBuildLogsResponse) String() string {
 func (*GetBuildLogsResponse) ProtoMessage() {}
 
 func (x *GetBuildLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[12]
+	mi := &file_proto_build_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -934,7 +1124,7 @@ func (x *GetBuildLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBuildLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetBuildLogsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{12}
+	return file_proto_build_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetBuildLogsResponse) GetLogs() []*BuildLog {
@@ -969,7 +1159,7 @@ type AppendBuildLogsRequest struct {
 
 func (x *AppendBuildLogsRequest) Reset() {
 	*x = AppendBuildLogsRequest{}
-	mi := &file_proto_build_proto_msgTypes[13]
+	mi := &file_proto_build_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -981,7 +1171,7 @@ func (x *AppendBuildLogsRequest) String() string {
 func (*AppendBuildLogsRequest) ProtoMessage() {}
 
 func (x *AppendBuildLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[13]
+	mi := &file_proto_build_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -994,7 +1184,7 @@ func (x *AppendBuildLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendBuildLogsRequest.ProtoReflect.Descriptor instead.
 func (*AppendBuildLogsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{13}
+	return file_proto_build_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AppendBuildLogsRequest) GetBuildId() string {
@@ -1021,7 +1211,7 @@ type AppendBuildLogsResponse struct {
 
 func (x *AppendBuildLogsResponse) Reset() {
 	*x = AppendBuildLogsResponse{}
-	mi := &file_proto_build_proto_msgTypes[14]
+	mi := &file_proto_build_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1033,7 +1223,7 @@ func (x *AppendBuildLogsResponse) String() string {
 func (*AppendBuildLogsResponse) ProtoMessage() {}
 
 func (x *AppendBuildLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[14]
+	mi := &file_proto_build_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1046,7 +1236,7 @@ func (x *AppendBuildLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendBuildLogsResponse.ProtoReflect.Descriptor instead.
 func (*AppendBuildLogsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{14}
+	return file_proto_build_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AppendBuildLogsResponse) GetAcknowledged() bool {
@@ -1063,31 +1253,32 @@ func (x *AppendBuildLogsResponse) GetError() string {
 	return ""
 }
 
-// --- DeleteBuildLogs ---
-type DeleteBuildLogsRequest struct {
+// --- UpdateBuildStep ---
+type UpdateBuildStepRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // For permission check
-	BuildIds      []string               `protobuf:"bytes,3,rep,name=build_ids,json=buildIds,proto3" json:"build_ids,omitempty"` // Optional: specific build IDs, if empty delete all for project
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	StepName      string                 `protobuf:"bytes,2,opt,name=step_name,json=stepName,proto3" json:"step_name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                            // pending, running, success, failed, skipped
+	DurationMs    int32                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // Set when the step has finished
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBuildLogsRequest) Reset() {
-	*x = DeleteBuildLogsRequest{}
-	mi := &file_proto_build_proto_msgTypes[15]
+func (x *UpdateBuildStepRequest) Reset() {
+	*x = UpdateBuildStepRequest{}
+	mi := &file_proto_build_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBuildLogsRequest) String() string {
+func (x *UpdateBuildStepRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBuildLogsRequest) ProtoMessage() {}
+func (*UpdateBuildStepRequest) ProtoMessage() {}
 
-func (x *DeleteBuildLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[15]
+func (x *UpdateBuildStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1098,56 +1289,531 @@ func (x *DeleteBuildLogsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBuildLogsRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBuildLogsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use UpdateBuildStepRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBuildStepRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeleteBuildLogsRequest) GetProjectId() string {
+func (x *UpdateBuildStepRequest) GetBuildId() string {
 	if x != nil {
-		return x.ProjectId
+		return x.BuildId
 	}
 	return ""
 }
 
-func (x *DeleteBuildLogsRequest) GetUserId() string {
+func (x *UpdateBuildStepRequest) GetStepName() string {
 	if x != nil {
-		return x.UserId
+		return x.StepName
 	}
 	return ""
 }
 
-func (x *DeleteBuildLogsRequest) GetBuildIds() []string {
+func (x *UpdateBuildStepRequest) GetStatus() string {
 	if x != nil {
-		return x.BuildIds
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-type DeleteBuildLogsResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	BuildsAffected int32                  `protobuf:"varint,1,opt,name=builds_affected,json=buildsAffected,proto3" json:"builds_affected,omitempty"`
-	LogsDeleted    int64                  `protobuf:"varint,2,opt,name=logs_deleted,json=logsDeleted,proto3" json:"logs_deleted,omitempty"`
-	Error          string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *UpdateBuildStepRequest) GetDurationMs() int32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
 }
 
-func (x *DeleteBuildLogsResponse) Reset() {
-	*x = DeleteBuildLogsResponse{}
-	mi := &file_proto_build_proto_msgTypes[16]
+type UpdateBuildStepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acknowledged  bool                   `protobuf:"varint,1,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBuildStepResponse) Reset() {
+	*x = UpdateBuildStepResponse{}
+	mi := &file_proto_build_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBuildLogsResponse) String() string {
+func (x *UpdateBuildStepResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBuildLogsResponse) ProtoMessage() {}
+func (*UpdateBuildStepResponse) ProtoMessage() {}
 
-func (x *DeleteBuildLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_proto_msgTypes[16]
+func (x *UpdateBuildStepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBuildStepResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBuildStepResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateBuildStepResponse) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *UpdateBuildStepResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- ReportTestResults ---
+type ReportTestResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Results       []*TestCase            `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTestResultsRequest) Reset() {
+	*x = ReportTestResultsRequest{}
+	mi := &file_proto_build_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTestResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTestResultsRequest) ProtoMessage() {}
+
+func (x *ReportTestResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTestResultsRequest.ProtoReflect.Descriptor instead.
+func (*ReportTestResultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReportTestResultsRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *ReportTestResultsRequest) GetResults() []*TestCase {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ReportTestResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acknowledged  bool                   `protobuf:"varint,1,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTestResultsResponse) Reset() {
+	*x = ReportTestResultsResponse{}
+	mi := &file_proto_build_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTestResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTestResultsResponse) ProtoMessage() {}
+
+func (x *ReportTestResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTestResultsResponse.ProtoReflect.Descriptor instead.
+func (*ReportTestResultsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReportTestResultsResponse) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *ReportTestResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- GetBuildTestReport ---
+type GetBuildTestReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildTestReportRequest) Reset() {
+	*x = GetBuildTestReportRequest{}
+	mi := &file_proto_build_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildTestReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildTestReportRequest) ProtoMessage() {}
+
+func (x *GetBuildTestReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildTestReportRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildTestReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBuildTestReportRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *GetBuildTestReportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBuildTestReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Passed        int32                  `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped       int32                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Results       []*TestCase            `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildTestReportResponse) Reset() {
+	*x = GetBuildTestReportResponse{}
+	mi := &file_proto_build_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildTestReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildTestReportResponse) ProtoMessage() {}
+
+func (x *GetBuildTestReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildTestReportResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildTestReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetBuildTestReportResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetBuildTestReportResponse) GetPassed() int32 {
+	if x != nil {
+		return x.Passed
+	}
+	return 0
+}
+
+func (x *GetBuildTestReportResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetBuildTestReportResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *GetBuildTestReportResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *GetBuildTestReportResponse) GetResults() []*TestCase {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *GetBuildTestReportResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- CancelBuild ---
+type CancelBuildRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBuildRequest) Reset() {
+	*x = CancelBuildRequest{}
+	mi := &file_proto_build_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildRequest) ProtoMessage() {}
+
+func (x *CancelBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBuildRequest.ProtoReflect.Descriptor instead.
+func (*CancelBuildRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CancelBuildRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *CancelBuildRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CancelBuildResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Build         *Build                 `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBuildResponse) Reset() {
+	*x = CancelBuildResponse{}
+	mi := &file_proto_build_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildResponse) ProtoMessage() {}
+
+func (x *CancelBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBuildResponse.ProtoReflect.Descriptor instead.
+func (*CancelBuildResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelBuildResponse) GetBuild() *Build {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *CancelBuildResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- DeleteBuildLogs ---
+type DeleteBuildLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // For permission check
+	BuildIds      []string               `protobuf:"bytes,3,rep,name=build_ids,json=buildIds,proto3" json:"build_ids,omitempty"` // Optional: specific build IDs, if empty delete all for project
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBuildLogsRequest) Reset() {
+	*x = DeleteBuildLogsRequest{}
+	mi := &file_proto_build_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildLogsRequest) ProtoMessage() {}
+
+func (x *DeleteBuildLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildLogsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBuildLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteBuildLogsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeleteBuildLogsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteBuildLogsRequest) GetBuildIds() []string {
+	if x != nil {
+		return x.BuildIds
+	}
+	return nil
+}
+
+type DeleteBuildLogsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BuildsAffected int32                  `protobuf:"varint,1,opt,name=builds_affected,json=buildsAffected,proto3" json:"builds_affected,omitempty"`
+	LogsDeleted    int64                  `protobuf:"varint,2,opt,name=logs_deleted,json=logsDeleted,proto3" json:"logs_deleted,omitempty"`
+	Error          string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteBuildLogsResponse) Reset() {
+	*x = DeleteBuildLogsResponse{}
+	mi := &file_proto_build_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildLogsResponse) ProtoMessage() {}
+
+func (x *DeleteBuildLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1160,24 +1826,1036 @@ func (x *DeleteBuildLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBuildLogsResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBuildLogsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_build_proto_rawDescGZIP(), []int{16}
+	return file_proto_build_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteBuildLogsResponse) GetBuildsAffected() int32 {
+	if x != nil {
+		return x.BuildsAffected
+	}
+	return 0
+}
+
+func (x *DeleteBuildLogsResponse) GetLogsDeleted() int64 {
+	if x != nil {
+		return x.LogsDeleted
+	}
+	return 0
+}
+
+func (x *DeleteBuildLogsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- GetQueuePosition ---
+type GetQueuePositionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueuePositionRequest) Reset() {
+	*x = GetQueuePositionRequest{}
+	mi := &file_proto_build_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueuePositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueuePositionRequest) ProtoMessage() {}
+
+func (x *GetQueuePositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueuePositionRequest.ProtoReflect.Descriptor instead.
+func (*GetQueuePositionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetQueuePositionRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *GetQueuePositionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetQueuePositionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Queued        bool                   `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`                              // False once a runner took the build
+	Queue         string                 `protobuf:"bytes,3,opt,name=queue,proto3" json:"queue,omitempty"`                                 // "builds:premium" or "builds:standard"
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`                                 // "pending", or "retry" while waiting for a free build slot of the user
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`                          // 1-based among the pending builds of the queue, 0 if not pending
+	QueueLength   int32                  `protobuf:"varint,6,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"` // Pending builds in the queue
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`                               // Why the build is put back, if it is
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueuePositionResponse) Reset() {
+	*x = GetQueuePositionResponse{}
+	mi := &file_proto_build_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueuePositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueuePositionResponse) ProtoMessage() {}
+
+func (x *GetQueuePositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueuePositionResponse.ProtoReflect.Descriptor instead.
+func (*GetQueuePositionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetQueuePositionResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *GetQueuePositionResponse) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
+
+func (x *GetQueuePositionResponse) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *GetQueuePositionResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetQueuePositionResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *GetQueuePositionResponse) GetQueueLength() int32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *GetQueuePositionResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GetQueuePositionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- GetBuildUsage ---
+type GetBuildUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildUsageRequest) Reset() {
+	*x = GetBuildUsageRequest{}
+	mi := &file_proto_build_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildUsageRequest) ProtoMessage() {}
+
+func (x *GetBuildUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetBuildUsageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBuildUsageResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Plan                string                 `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	PeriodStart         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"` // First day of the month, UTC
+	ResetsAt            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+	BuildsUsed          int32                  `protobuf:"varint,4,opt,name=builds_used,json=buildsUsed,proto3" json:"builds_used,omitempty"`                              // Builds triggered this month across all projects
+	MaxBuildsPerMonth   int32                  `protobuf:"varint,5,opt,name=max_builds_per_month,json=maxBuildsPerMonth,proto3" json:"max_builds_per_month,omitempty"`     // 0 = no limit
+	BuildsRemaining     int32                  `protobuf:"varint,6,opt,name=builds_remaining,json=buildsRemaining,proto3" json:"builds_remaining,omitempty"`               // -1 when the plan has no monthly limit
+	ActiveBuilds        int32                  `protobuf:"varint,7,opt,name=active_builds,json=activeBuilds,proto3" json:"active_builds,omitempty"`                        // Builds pending or running across all projects
+	MaxConcurrentBuilds int32                  `protobuf:"varint,8,opt,name=max_concurrent_builds,json=maxConcurrentBuilds,proto3" json:"max_concurrent_builds,omitempty"` // 0 = no limit
+	Error               string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetBuildUsageResponse) Reset() {
+	*x = GetBuildUsageResponse{}
+	mi := &file_proto_build_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildUsageResponse) ProtoMessage() {}
+
+func (x *GetBuildUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetBuildUsageResponse) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *GetBuildUsageResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *GetBuildUsageResponse) GetResetsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return nil
+}
+
+func (x *GetBuildUsageResponse) GetBuildsUsed() int32 {
+	if x != nil {
+		return x.BuildsUsed
+	}
+	return 0
+}
+
+func (x *GetBuildUsageResponse) GetMaxBuildsPerMonth() int32 {
+	if x != nil {
+		return x.MaxBuildsPerMonth
+	}
+	return 0
+}
+
+func (x *GetBuildUsageResponse) GetBuildsRemaining() int32 {
+	if x != nil {
+		return x.BuildsRemaining
+	}
+	return 0
+}
+
+func (x *GetBuildUsageResponse) GetActiveBuilds() int32 {
+	if x != nil {
+		return x.ActiveBuilds
+	}
+	return 0
+}
+
+func (x *GetBuildUsageResponse) GetMaxConcurrentBuilds() int32 {
+	if x != nil {
+		return x.MaxConcurrentBuilds
+	}
+	return 0
+}
+
+func (x *GetBuildUsageResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// DeadLetter message, a build job the queue archived instead of retrying
+type DeadLetter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BuildId       string                 `protobuf:"bytes,2,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Queue         string                 `protobuf:"bytes,4,opt,name=queue,proto3" json:"queue,omitempty"`
+	Kind          string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"` // "user" when the project failed the build, "infrastructure" when the runner did
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	RequeuedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=requeued_at,json=requeuedAt,proto3" json:"requeued_at,omitempty"` // Unset until requeued
+	RequeuedBy    string                 `protobuf:"bytes,10,opt,name=requeued_by,json=requeuedBy,proto3" json:"requeued_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_proto_build_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetRequeuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequeuedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetRequeuedBy() string {
+	if x != nil {
+		return x.RequeuedBy
+	}
+	return ""
+}
+
+// --- RecordDeadLetter ---
+type RecordDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildId       string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Queue         string                 `protobuf:"bytes,2,opt,name=queue,proto3" json:"queue,omitempty"`
+	TaskId        string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Attempts      int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDeadLetterRequest) Reset() {
+	*x = RecordDeadLetterRequest{}
+	mi := &file_proto_build_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDeadLetterRequest) ProtoMessage() {}
+
+func (x *RecordDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*RecordDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RecordDeadLetterRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *RecordDeadLetterRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *RecordDeadLetterRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *RecordDeadLetterRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RecordDeadLetterRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RecordDeadLetterRequest) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+type RecordDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acknowledged  bool                   `protobuf:"varint,1,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDeadLetterResponse) Reset() {
+	*x = RecordDeadLetterResponse{}
+	mi := &file_proto_build_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDeadLetterResponse) ProtoMessage() {}
+
+func (x *RecordDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*RecordDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecordDeadLetterResponse) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *RecordDeadLetterResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- ListDeadLetters ---
+type ListDeadLettersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	Kind            string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                   // Optional: "user" or "infrastructure"
+	IncludeRequeued bool                   `protobuf:"varint,3,opt,name=include_requeued,json=includeRequeued,proto3" json:"include_requeued,omitempty"`
+	Page            int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize        int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_proto_build_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListDeadLettersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetIncludeRequeued() bool {
+	if x != nil {
+		return x.IncludeRequeued
+	}
+	return false
+}
+
+func (x *ListDeadLettersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_proto_build_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *DeleteBuildLogsResponse) GetBuildsAffected() int32 {
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
 	if x != nil {
-		return x.BuildsAffected
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
 	}
 	return 0
 }
 
-func (x *DeleteBuildLogsResponse) GetLogsDeleted() int64 {
+func (x *ListDeadLettersResponse) GetError() string {
 	if x != nil {
-		return x.LogsDeleted
+		return x.Error
+	}
+	return ""
+}
+
+// --- RequeueDeadLetter ---
+type RequeueDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueDeadLetterRequest) Reset() {
+	*x = RequeueDeadLetterRequest{}
+	mi := &file_proto_build_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueDeadLetterRequest) ProtoMessage() {}
+
+func (x *RequeueDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*RequeueDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RequeueDeadLetterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RequeueDeadLetterRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RequeueDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter    *DeadLetter            `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	Build         *Build                 `protobuf:"bytes,2,opt,name=build,proto3" json:"build,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueDeadLetterResponse) Reset() {
+	*x = RequeueDeadLetterResponse{}
+	mi := &file_proto_build_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueDeadLetterResponse) ProtoMessage() {}
+
+func (x *RequeueDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*RequeueDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RequeueDeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+func (x *RequeueDeadLetterResponse) GetBuild() *Build {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *RequeueDeadLetterResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- ListPreviewBuilds ---
+type ListPreviewBuildsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPreviewBuildsRequest) Reset() {
+	*x = ListPreviewBuildsRequest{}
+	mi := &file_proto_build_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPreviewBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPreviewBuildsRequest) ProtoMessage() {}
+
+func (x *ListPreviewBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPreviewBuildsRequest.ProtoReflect.Descriptor instead.
+func (*ListPreviewBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListPreviewBuildsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListPreviewBuildsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []int32                `protobuf:"varint,1,rep,packed,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"` // Pull requests with a build pending, running or deploying
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPreviewBuildsResponse) Reset() {
+	*x = ListPreviewBuildsResponse{}
+	mi := &file_proto_build_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPreviewBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPreviewBuildsResponse) ProtoMessage() {}
+
+func (x *ListPreviewBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPreviewBuildsResponse.ProtoReflect.Descriptor instead.
+func (*ListPreviewBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListPreviewBuildsResponse) GetPullRequests() []int32 {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListPreviewBuildsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// --- CancelPreviewBuilds ---
+type CancelPreviewBuildsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	PullRequest   int32                  `protobuf:"varint,2,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPreviewBuildsRequest) Reset() {
+	*x = CancelPreviewBuildsRequest{}
+	mi := &file_proto_build_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPreviewBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPreviewBuildsRequest) ProtoMessage() {}
+
+func (x *CancelPreviewBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPreviewBuildsRequest.ProtoReflect.Descriptor instead.
+func (*CancelPreviewBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CancelPreviewBuildsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CancelPreviewBuildsRequest) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
 	}
 	return 0
 }
 
-func (x *DeleteBuildLogsResponse) GetError() string {
+type CancelPreviewBuildsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancelled     int32                  `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPreviewBuildsResponse) Reset() {
+	*x = CancelPreviewBuildsResponse{}
+	mi := &file_proto_build_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPreviewBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPreviewBuildsResponse) ProtoMessage() {}
+
+func (x *CancelPreviewBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPreviewBuildsResponse.ProtoReflect.Descriptor instead.
+func (*CancelPreviewBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_build_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CancelPreviewBuildsResponse) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+func (x *CancelPreviewBuildsResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
@@ -1188,7 +2866,7 @@ var File_proto_build_proto protoreflect.FileDescriptor
 
 const file_proto_build_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/build.proto\x12\x05build\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8c\x03\n" +
+	"\x11proto/build.proto\x12\x05build\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x05\n" +
 	"\x05Build\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1204,19 +2882,36 @@ const file_proto_build_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
-	"\timage_tag\x18\t \x01(\tR\bimageTag\"\x8c\x01\n" +
+	"\timage_tag\x18\t \x01(\tR\bimageTag\x12#\n" +
+	"\rdeployment_id\x18\n" +
+	" \x01(\tR\fdeploymentId\x12!\n" +
+	"\fpull_request\x18\v \x01(\x05R\vpullRequest\x12 \n" +
+	"\venvironment\x18\f \x01(\tR\venvironment\x12\x16\n" +
+	"\x06branch\x18\r \x01(\tR\x06branch\x12#\n" +
+	"\rsuperseded_by\x18\x0e \x01(\tR\fsupersededBy\x12\x1b\n" +
+	"\tparent_id\x18\x0f \x01(\tR\bparentId\x12\x18\n" +
+	"\avariant\x18\x10 \x01(\tR\avariant\x12\x1a\n" +
+	"\boptional\x18\x11 \x01(\bR\boptional\x12\x16\n" +
+	"\x06matrix\x18\x12 \x01(\bR\x06matrix\"\x8c\x01\n" +
 	"\tBuildStep\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12\x1b\n" +
 	"\tstep_name\x18\x03 \x01(\tR\bstepName\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
 	"\vduration_ms\x18\x05 \x01(\x05R\n" +
-	"durationMs\"\x8a\x01\n" +
+	"durationMs\"\x96\x01\n" +
+	"\bTestCase\x12\x14\n" +
+	"\x05suite\x18\x01 \x01(\tR\x05suite\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x05R\n" +
+	"durationMs\x12'\n" +
+	"\x0ffailure_message\x18\x05 \x01(\tR\x0efailureMessage\"\x8a\x01\n" +
 	"\bBuildLog\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x128\n" +
 	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
-	"\blog_line\x18\x04 \x01(\tR\alogLine\"\x9f\x01\n" +
+	"\blog_line\x18\x04 \x01(\tR\alogLine\"\xe4\x01\n" +
 	"\x13TriggerBuildRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1d\n" +
@@ -1224,15 +2919,18 @@ const file_proto_build_proto_rawDesc = "" +
 	"commit_sha\x18\x02 \x01(\tR\tcommitSha\x12\x16\n" +
 	"\x06branch\x18\x03 \x01(\tR\x06branch\x12\x19\n" +
 	"\brepo_url\x18\x04 \x01(\tR\arepoUrl\x12\x17\n" +
-	"\auser_id\x18\x05 \x01(\tR\x06userId\"P\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12!\n" +
+	"\fpull_request\x18\x06 \x01(\x05R\vpullRequest\x12 \n" +
+	"\venvironment\x18\a \x01(\tR\venvironment\"P\n" +
 	"\x14TriggerBuildResponse\x12\"\n" +
 	"\x05build\x18\x01 \x01(\v2\f.build.BuildR\x05build\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\x9b\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xc0\x01\n" +
 	"\x18UpdateBuildStatusRequest\x12\x19\n" +
 	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12*\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x12.build.BuildStatusR\x06status\x12\x1b\n" +
 	"\tlog_lines\x18\x03 \x03(\tR\blogLines\x12\x1b\n" +
-	"\timage_tag\x18\x04 \x01(\tR\bimageTag\"U\n" +
+	"\timage_tag\x18\x04 \x01(\tR\bimageTag\x12#\n" +
+	"\rdeployment_id\x18\x05 \x01(\tR\fdeploymentId\"U\n" +
 	"\x19UpdateBuildStatusResponse\x12\"\n" +
 	"\facknowledged\x18\x01 \x01(\bR\facknowledged\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"|\n" +
@@ -1248,11 +2946,12 @@ const file_proto_build_proto_rawDesc = "" +
 	"\x05error\x18\x03 \x01(\tR\x05error\"E\n" +
 	"\x0fGetBuildRequest\x12\x19\n" +
 	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"t\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x9e\x01\n" +
 	"\x10GetBuildResponse\x12\"\n" +
 	"\x05build\x18\x01 \x01(\v2\f.build.BuildR\x05build\x12&\n" +
 	"\x05steps\x18\x02 \x03(\v2\x10.build.BuildStepR\x05steps\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"a\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12(\n" +
+	"\bvariants\x18\x04 \x03(\v2\f.build.BuildR\bvariants\"a\n" +
 	"\x13GetBuildLogsRequest\x12\x19\n" +
 	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x19\n" +
@@ -1266,6 +2965,39 @@ const file_proto_build_proto_rawDesc = "" +
 	"\tlog_lines\x18\x02 \x03(\tR\blogLines\"S\n" +
 	"\x17AppendBuildLogsResponse\x12\"\n" +
 	"\facknowledged\x18\x01 \x01(\bR\facknowledged\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x89\x01\n" +
+	"\x16UpdateBuildStepRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x1b\n" +
+	"\tstep_name\x18\x02 \x01(\tR\bstepName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x05R\n" +
+	"durationMs\"S\n" +
+	"\x17UpdateBuildStepResponse\x12\"\n" +
+	"\facknowledged\x18\x01 \x01(\bR\facknowledged\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"`\n" +
+	"\x18ReportTestResultsRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12)\n" +
+	"\aresults\x18\x02 \x03(\v2\x0f.build.TestCaseR\aresults\"U\n" +
+	"\x19ReportTestResultsResponse\x12\"\n" +
+	"\facknowledged\x18\x01 \x01(\bR\facknowledged\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"O\n" +
+	"\x19GetBuildTestReportRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xde\x01\n" +
+	"\x1aGetBuildTestReportResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\x05R\x06passed\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12)\n" +
+	"\aresults\x18\x06 \x03(\v2\x0f.build.TestCaseR\aresults\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"H\n" +
+	"\x12CancelBuildRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"O\n" +
+	"\x13CancelBuildResponse\x12\"\n" +
+	"\x05build\x18\x01 \x01(\v2\f.build.BuildR\x05build\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"m\n" +
 	"\x16DeleteBuildLogsRequest\x12\x1d\n" +
 	"\n" +
@@ -1275,7 +3007,90 @@ const file_proto_build_proto_rawDesc = "" +
 	"\x17DeleteBuildLogsResponse\x12'\n" +
 	"\x0fbuilds_affected\x18\x01 \x01(\x05R\x0ebuildsAffected\x12!\n" +
 	"\flogs_deleted\x18\x02 \x01(\x03R\vlogsDeleted\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error*\x8f\x02\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"M\n" +
+	"\x17GetQueuePositionRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xe6\x01\n" +
+	"\x18GetQueuePositionResponse\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x16\n" +
+	"\x06queued\x18\x02 \x01(\bR\x06queued\x12\x14\n" +
+	"\x05queue\x18\x03 \x01(\tR\x05queue\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12!\n" +
+	"\fqueue_length\x18\x06 \x01(\x05R\vqueueLength\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"/\n" +
+	"\x14GetBuildUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x8f\x03\n" +
+	"\x15GetBuildUsageResponse\x12\x12\n" +
+	"\x04plan\x18\x01 \x01(\tR\x04plan\x12=\n" +
+	"\fperiod_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x127\n" +
+	"\tresets_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bresetsAt\x12\x1f\n" +
+	"\vbuilds_used\x18\x04 \x01(\x05R\n" +
+	"buildsUsed\x12/\n" +
+	"\x14max_builds_per_month\x18\x05 \x01(\x05R\x11maxBuildsPerMonth\x12)\n" +
+	"\x10builds_remaining\x18\x06 \x01(\x05R\x0fbuildsRemaining\x12#\n" +
+	"\ractive_builds\x18\a \x01(\x05R\factiveBuilds\x122\n" +
+	"\x15max_concurrent_builds\x18\b \x01(\x05R\x13maxConcurrentBuilds\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xcf\x02\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\tR\tprojectId\x12\x14\n" +
+	"\x05queue\x18\x04 \x01(\tR\x05queue\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12;\n" +
+	"\varchived_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x12;\n" +
+	"\vrequeued_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"requeuedAt\x12\x1f\n" +
+	"\vrequeued_by\x18\n" +
+	" \x01(\tR\n" +
+	"requeuedBy\"\xab\x01\n" +
+	"\x17RecordDeadLetterRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x14\n" +
+	"\x05queue\x18\x02 \x01(\tR\x05queue\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\"T\n" +
+	"\x18RecordDeadLetterResponse\x12\"\n" +
+	"\facknowledged\x18\x01 \x01(\bR\facknowledged\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xa1\x01\n" +
+	"\x16ListDeadLettersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12)\n" +
+	"\x10include_requeued\x18\x03 \x01(\bR\x0fincludeRequeued\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"{\n" +
+	"\x17ListDeadLettersResponse\x124\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x11.build.DeadLetterR\vdeadLetters\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"C\n" +
+	"\x18RequeueDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x89\x01\n" +
+	"\x19RequeueDeadLetterResponse\x122\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x11.build.DeadLetterR\n" +
+	"deadLetter\x12\"\n" +
+	"\x05build\x18\x02 \x01(\v2\f.build.BuildR\x05build\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"9\n" +
+	"\x18ListPreviewBuildsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"V\n" +
+	"\x19ListPreviewBuildsResponse\x12#\n" +
+	"\rpull_requests\x18\x01 \x03(\x05R\fpullRequests\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"^\n" +
+	"\x1aCancelPreviewBuildsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12!\n" +
+	"\fpull_request\x18\x02 \x01(\x05R\vpullRequest\"Q\n" +
+	"\x1bCancelPreviewBuildsResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\x05R\tcancelled\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error*\xc8\x02\n" +
 	"\vBuildStatus\x12\x1c\n" +
 	"\x18BUILD_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14BUILD_STATUS_PENDING\x10\x01\x12\x18\n" +
@@ -1285,7 +3100,10 @@ const file_proto_build_proto_rawDesc = "" +
 	"\x1aBUILD_STATUS_PUSHING_IMAGE\x10\x05\x12\x1a\n" +
 	"\x16BUILD_STATUS_DEPLOYING\x10\x06\x12\x18\n" +
 	"\x14BUILD_STATUS_SUCCESS\x10\a\x12\x1e\n" +
-	"\x1aBUILD_STATUS_DEPLOY_FAILED\x10\b2\x9c\x04\n" +
+	"\x1aBUILD_STATUS_DEPLOY_FAILED\x10\b\x12\x1a\n" +
+	"\x16BUILD_STATUS_CANCELLED\x10\t\x12\x1b\n" +
+	"\x17BUILD_STATUS_SUPERSEDED\x10\n" +
+	"2\xbd\v\n" +
 	"\fBuildService\x12G\n" +
 	"\fTriggerBuild\x12\x1a.build.TriggerBuildRequest\x1a\x1b.build.TriggerBuildResponse\x12V\n" +
 	"\x11UpdateBuildStatus\x12\x1f.build.UpdateBuildStatusRequest\x1a .build.UpdateBuildStatusResponse\x12A\n" +
@@ -1294,7 +3112,18 @@ const file_proto_build_proto_rawDesc = "" +
 	"\bGetBuild\x12\x16.build.GetBuildRequest\x1a\x17.build.GetBuildResponse\x12G\n" +
 	"\fGetBuildLogs\x12\x1a.build.GetBuildLogsRequest\x1a\x1b.build.GetBuildLogsResponse\x12P\n" +
 	"\x0fAppendBuildLogs\x12\x1d.build.AppendBuildLogsRequest\x1a\x1e.build.AppendBuildLogsResponse\x12P\n" +
-	"\x0fDeleteBuildLogs\x12\x1d.build.DeleteBuildLogsRequest\x1a\x1e.build.DeleteBuildLogsResponseB=Z;github.com/nexusdeploy/backend/services/build-service/protob\x06proto3"
+	"\x0fUpdateBuildStep\x12\x1d.build.UpdateBuildStepRequest\x1a\x1e.build.UpdateBuildStepResponse\x12V\n" +
+	"\x11ReportTestResults\x12\x1f.build.ReportTestResultsRequest\x1a .build.ReportTestResultsResponse\x12Y\n" +
+	"\x12GetBuildTestReport\x12 .build.GetBuildTestReportRequest\x1a!.build.GetBuildTestReportResponse\x12D\n" +
+	"\vCancelBuild\x12\x19.build.CancelBuildRequest\x1a\x1a.build.CancelBuildResponse\x12P\n" +
+	"\x0fDeleteBuildLogs\x12\x1d.build.DeleteBuildLogsRequest\x1a\x1e.build.DeleteBuildLogsResponse\x12S\n" +
+	"\x10GetQueuePosition\x12\x1e.build.GetQueuePositionRequest\x1a\x1f.build.GetQueuePositionResponse\x12J\n" +
+	"\rGetBuildUsage\x12\x1b.build.GetBuildUsageRequest\x1a\x1c.build.GetBuildUsageResponse\x12S\n" +
+	"\x10RecordDeadLetter\x12\x1e.build.RecordDeadLetterRequest\x1a\x1f.build.RecordDeadLetterResponse\x12P\n" +
+	"\x0fListDeadLetters\x12\x1d.build.ListDeadLettersRequest\x1a\x1e.build.ListDeadLettersResponse\x12V\n" +
+	"\x11RequeueDeadLetter\x12\x1f.build.RequeueDeadLetterRequest\x1a .build.RequeueDeadLetterResponse\x12V\n" +
+	"\x11ListPreviewBuilds\x12\x1f.build.ListPreviewBuildsRequest\x1a .build.ListPreviewBuildsResponse\x12\\\n" +
+	"\x13CancelPreviewBuilds\x12!.build.CancelPreviewBuildsRequest\x1a\".build.CancelPreviewBuildsResponseB=Z;github.com/nexusdeploy/backend/services/build-service/protob\x06proto3"
 
 var (
 	file_proto_build_proto_rawDescOnce sync.Once
@@ -1309,60 +3138,117 @@ func file_proto_build_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_build_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_build_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_build_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_proto_build_proto_goTypes = []any{
-	(BuildStatus)(0),                  // 0: build.BuildStatus
-	(*Build)(nil),                     // 1: build.Build
-	(*BuildStep)(nil),                 // 2: build.BuildStep
-	(*BuildLog)(nil),                  // 3: build.BuildLog
-	(*TriggerBuildRequest)(nil),       // 4: build.TriggerBuildRequest
-	(*TriggerBuildResponse)(nil),      // 5: build.TriggerBuildResponse
-	(*UpdateBuildStatusRequest)(nil),  // 6: build.UpdateBuildStatusRequest
-	(*UpdateBuildStatusResponse)(nil), // 7: build.UpdateBuildStatusResponse
-	(*ListBuildsRequest)(nil),         // 8: build.ListBuildsRequest
-	(*ListBuildsResponse)(nil),        // 9: build.ListBuildsResponse
-	(*GetBuildRequest)(nil),           // 10: build.GetBuildRequest
-	(*GetBuildResponse)(nil),          // 11: build.GetBuildResponse
-	(*GetBuildLogsRequest)(nil),       // 12: build.GetBuildLogsRequest
-	(*GetBuildLogsResponse)(nil),      // 13: build.GetBuildLogsResponse
-	(*AppendBuildLogsRequest)(nil),    // 14: build.AppendBuildLogsRequest
-	(*AppendBuildLogsResponse)(nil),   // 15: build.AppendBuildLogsResponse
-	(*DeleteBuildLogsRequest)(nil),    // 16: build.DeleteBuildLogsRequest
-	(*DeleteBuildLogsResponse)(nil),   // 17: build.DeleteBuildLogsResponse
-	(*timestamppb.Timestamp)(nil),     // 18: google.protobuf.Timestamp
+	(BuildStatus)(0),                    // 0: build.BuildStatus
+	(*Build)(nil),                       // 1: build.Build
+	(*BuildStep)(nil),                   // 2: build.BuildStep
+	(*TestCase)(nil),                    // 3: build.TestCase
+	(*BuildLog)(nil),                    // 4: build.BuildLog
+	(*TriggerBuildRequest)(nil),         // 5: build.TriggerBuildRequest
+	(*TriggerBuildResponse)(nil),        // 6: build.TriggerBuildResponse
+	(*UpdateBuildStatusRequest)(nil),    // 7: build.UpdateBuildStatusRequest
+	(*UpdateBuildStatusResponse)(nil),   // 8: build.UpdateBuildStatusResponse
+	(*ListBuildsRequest)(nil),           // 9: build.ListBuildsRequest
+	(*ListBuildsResponse)(nil),          // 10: build.ListBuildsResponse
+	(*GetBuildRequest)(nil),             // 11: build.GetBuildRequest
+	(*GetBuildResponse)(nil),            // 12: build.GetBuildResponse
+	(*GetBuildLogsRequest)(nil),         // 13: build.GetBuildLogsRequest
+	(*GetBuildLogsResponse)(nil),        // 14: build.GetBuildLogsResponse
+	(*AppendBuildLogsRequest)(nil),      // 15: build.AppendBuildLogsRequest
+	(*AppendBuildLogsResponse)(nil),     // 16: build.AppendBuildLogsResponse
+	(*UpdateBuildStepRequest)(nil),      // 17: build.UpdateBuildStepRequest
+	(*UpdateBuildStepResponse)(nil),     // 18: build.UpdateBuildStepResponse
+	(*ReportTestResultsRequest)(nil),    // 19: build.ReportTestResultsRequest
+	(*ReportTestResultsResponse)(nil),   // 20: build.ReportTestResultsResponse
+	(*GetBuildTestReportRequest)(nil),   // 21: build.GetBuildTestReportRequest
+	(*GetBuildTestReportResponse)(nil),  // 22: build.GetBuildTestReportResponse
+	(*CancelBuildRequest)(nil),          // 23: build.CancelBuildRequest
+	(*CancelBuildResponse)(nil),         // 24: build.CancelBuildResponse
+	(*DeleteBuildLogsRequest)(nil),      // 25: build.DeleteBuildLogsRequest
+	(*DeleteBuildLogsResponse)(nil),     // 26: build.DeleteBuildLogsResponse
+	(*GetQueuePositionRequest)(nil),     // 27: build.GetQueuePositionRequest
+	(*GetQueuePositionResponse)(nil),    // 28: build.GetQueuePositionResponse
+	(*GetBuildUsageRequest)(nil),        // 29: build.GetBuildUsageRequest
+	(*GetBuildUsageResponse)(nil),       // 30: build.GetBuildUsageResponse
+	(*DeadLetter)(nil),                  // 31: build.DeadLetter
+	(*RecordDeadLetterRequest)(nil),     // 32: build.RecordDeadLetterRequest
+	(*RecordDeadLetterResponse)(nil),    // 33: build.RecordDeadLetterResponse
+	(*ListDeadLettersRequest)(nil),      // 34: build.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),     // 35: build.ListDeadLettersResponse
+	(*RequeueDeadLetterRequest)(nil),    // 36: build.RequeueDeadLetterRequest
+	(*RequeueDeadLetterResponse)(nil),   // 37: build.RequeueDeadLetterResponse
+	(*ListPreviewBuildsRequest)(nil),    // 38: build.ListPreviewBuildsRequest
+	(*ListPreviewBuildsResponse)(nil),   // 39: build.ListPreviewBuildsResponse
+	(*CancelPreviewBuildsRequest)(nil),  // 40: build.CancelPreviewBuildsRequest
+	(*CancelPreviewBuildsResponse)(nil), // 41: build.CancelPreviewBuildsResponse
+	(*timestamppb.Timestamp)(nil),       // 42: google.protobuf.Timestamp
 }
 var file_proto_build_proto_depIdxs = []int32{
 	0,  // 0: build.Build.status:type_name -> build.BuildStatus
-	18, // 1: build.Build.started_at:type_name -> google.protobuf.Timestamp
-	18, // 2: build.Build.finished_at:type_name -> google.protobuf.Timestamp
-	18, // 3: build.Build.created_at:type_name -> google.protobuf.Timestamp
-	18, // 4: build.Build.updated_at:type_name -> google.protobuf.Timestamp
-	18, // 5: build.BuildLog.timestamp:type_name -> google.protobuf.Timestamp
+	42, // 1: build.Build.started_at:type_name -> google.protobuf.Timestamp
+	42, // 2: build.Build.finished_at:type_name -> google.protobuf.Timestamp
+	42, // 3: build.Build.created_at:type_name -> google.protobuf.Timestamp
+	42, // 4: build.Build.updated_at:type_name -> google.protobuf.Timestamp
+	42, // 5: build.BuildLog.timestamp:type_name -> google.protobuf.Timestamp
 	1,  // 6: build.TriggerBuildResponse.build:type_name -> build.Build
 	0,  // 7: build.UpdateBuildStatusRequest.status:type_name -> build.BuildStatus
 	1,  // 8: build.ListBuildsResponse.builds:type_name -> build.Build
 	1,  // 9: build.GetBuildResponse.build:type_name -> build.Build
 	2,  // 10: build.GetBuildResponse.steps:type_name -> build.BuildStep
-	3,  // 11: build.GetBuildLogsResponse.logs:type_name -> build.BuildLog
-	4,  // 12: build.BuildService.TriggerBuild:input_type -> build.TriggerBuildRequest
-	6,  // 13: build.BuildService.UpdateBuildStatus:input_type -> build.UpdateBuildStatusRequest
-	8,  // 14: build.BuildService.ListBuilds:input_type -> build.ListBuildsRequest
-	10, // 15: build.BuildService.GetBuild:input_type -> build.GetBuildRequest
-	12, // 16: build.BuildService.GetBuildLogs:input_type -> build.GetBuildLogsRequest
-	14, // 17: build.BuildService.AppendBuildLogs:input_type -> build.AppendBuildLogsRequest
-	16, // 18: build.BuildService.DeleteBuildLogs:input_type -> build.DeleteBuildLogsRequest
-	5,  // 19: build.BuildService.TriggerBuild:output_type -> build.TriggerBuildResponse
-	7,  // 20: build.BuildService.UpdateBuildStatus:output_type -> build.UpdateBuildStatusResponse
-	9,  // 21: build.BuildService.ListBuilds:output_type -> build.ListBuildsResponse
-	11, // 22: build.BuildService.GetBuild:output_type -> build.GetBuildResponse
-	13, // 23: build.BuildService.GetBuildLogs:output_type -> build.GetBuildLogsResponse
-	15, // 24: build.BuildService.AppendBuildLogs:output_type -> build.AppendBuildLogsResponse
-	17, // 25: build.BuildService.DeleteBuildLogs:output_type -> build.DeleteBuildLogsResponse
-	19, // [19:26] is the sub-list for method output_type
-	12, // [12:19] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	1,  // 11: build.GetBuildResponse.variants:type_name -> build.Build
+	4,  // 12: build.GetBuildLogsResponse.logs:type_name -> build.BuildLog
+	3,  // 13: build.ReportTestResultsRequest.results:type_name -> build.TestCase
+	3,  // 14: build.GetBuildTestReportResponse.results:type_name -> build.TestCase
+	1,  // 15: build.CancelBuildResponse.build:type_name -> build.Build
+	42, // 16: build.GetBuildUsageResponse.period_start:type_name -> google.protobuf.Timestamp
+	42, // 17: build.GetBuildUsageResponse.resets_at:type_name -> google.protobuf.Timestamp
+	42, // 18: build.DeadLetter.archived_at:type_name -> google.protobuf.Timestamp
+	42, // 19: build.DeadLetter.requeued_at:type_name -> google.protobuf.Timestamp
+	31, // 20: build.ListDeadLettersResponse.dead_letters:type_name -> build.DeadLetter
+	31, // 21: build.RequeueDeadLetterResponse.dead_letter:type_name -> build.DeadLetter
+	1,  // 22: build.RequeueDeadLetterResponse.build:type_name -> build.Build
+	5,  // 23: build.BuildService.TriggerBuild:input_type -> build.TriggerBuildRequest
+	7,  // 24: build.BuildService.UpdateBuildStatus:input_type -> build.UpdateBuildStatusRequest
+	9,  // 25: build.BuildService.ListBuilds:input_type -> build.ListBuildsRequest
+	11, // 26: build.BuildService.GetBuild:input_type -> build.GetBuildRequest
+	13, // 27: build.BuildService.GetBuildLogs:input_type -> build.GetBuildLogsRequest
+	15, // 28: build.BuildService.AppendBuildLogs:input_type -> build.AppendBuildLogsRequest
+	17, // 29: build.BuildService.UpdateBuildStep:input_type -> build.UpdateBuildStepRequest
+	19, // 30: build.BuildService.ReportTestResults:input_type -> build.ReportTestResultsRequest
+	21, // 31: build.BuildService.GetBuildTestReport:input_type -> build.GetBuildTestReportRequest
+	23, // 32: build.BuildService.CancelBuild:input_type -> build.CancelBuildRequest
+	25, // 33: build.BuildService.DeleteBuildLogs:input_type -> build.DeleteBuildLogsRequest
+	27, // 34: build.BuildService.GetQueuePosition:input_type -> build.GetQueuePositionRequest
+	29, // 35: build.BuildService.GetBuildUsage:input_type -> build.GetBuildUsageRequest
+	32, // 36: build.BuildService.RecordDeadLetter:input_type -> build.RecordDeadLetterRequest
+	34, // 37: build.BuildService.ListDeadLetters:input_type -> build.ListDeadLettersRequest
+	36, // 38: build.BuildService.RequeueDeadLetter:input_type -> build.RequeueDeadLetterRequest
+	38, // 39: build.BuildService.ListPreviewBuilds:input_type -> build.ListPreviewBuildsRequest
+	40, // 40: build.BuildService.CancelPreviewBuilds:input_type -> build.CancelPreviewBuildsRequest
+	6,  // 41: build.BuildService.TriggerBuild:output_type -> build.TriggerBuildResponse
+	8,  // 42: build.BuildService.UpdateBuildStatus:output_type -> build.UpdateBuildStatusResponse
+	10, // 43: build.BuildService.ListBuilds:output_type -> build.ListBuildsResponse
+	12, // 44: build.BuildService.GetBuild:output_type -> build.GetBuildResponse
+	14, // 45: build.BuildService.GetBuildLogs:output_type -> build.GetBuildLogsResponse
+	16, // 46: build.BuildService.AppendBuildLogs:output_type -> build.AppendBuildLogsResponse
+	18, // 47: build.BuildService.UpdateBuildStep:output_type -> build.UpdateBuildStepResponse
+	20, // 48: build.BuildService.ReportTestResults:output_type -> build.ReportTestResultsResponse
+	22, // 49: build.BuildService.GetBuildTestReport:output_type -> build.GetBuildTestReportResponse
+	24, // 50: build.BuildService.CancelBuild:output_type -> build.CancelBuildResponse
+	26, // 51: build.BuildService.DeleteBuildLogs:output_type -> build.DeleteBuildLogsResponse
+	28, // 52: build.BuildService.GetQueuePosition:output_type -> build.GetQueuePositionResponse
+	30, // 53: build.BuildService.GetBuildUsage:output_type -> build.GetBuildUsageResponse
+	33, // 54: build.BuildService.RecordDeadLetter:output_type -> build.RecordDeadLetterResponse
+	35, // 55: build.BuildService.ListDeadLetters:output_type -> build.ListDeadLettersResponse
+	37, // 56: build.BuildService.RequeueDeadLetter:output_type -> build.RequeueDeadLetterResponse
+	39, // 57: build.BuildService.ListPreviewBuilds:output_type -> build.ListPreviewBuildsResponse
+	41, // 58: build.BuildService.CancelPreviewBuilds:output_type -> build.CancelPreviewBuildsResponse
+	41, // [41:59] is the sub-list for method output_type
+	23, // [23:41] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_proto_build_proto_init() }
@@ -1376,7 +3262,7 @@ func file_proto_build_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_build_proto_rawDesc), len(file_proto_build_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   17,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BuildService_TriggerBuild_FullMethodName      = "/build.BuildService/TriggerBuild"
-	BuildService_UpdateBuildStatus_FullMethodName = "/build.BuildService/UpdateBuildStatus"
-	BuildService_ListBuilds_FullMethodName        = "/build.BuildService/ListBuilds"
-	BuildService_GetBuild_FullMethodName          = "/build.BuildService/GetBuild"
-	BuildService_GetBuildLogs_FullMethodName      = "/build.BuildService/GetBuildLogs"
-	BuildService_AppendBuildLogs_FullMethodName   = "/build.BuildService/AppendBuildLogs"
-	BuildService_DeleteBuildLogs_FullMethodName   = "/build.BuildService/DeleteBuildLogs"
+	BuildService_TriggerBuild_FullMethodName        = "/build.BuildService/TriggerBuild"
+	BuildService_UpdateBuildStatus_FullMethodName   = "/build.BuildService/UpdateBuildStatus"
+	BuildService_ListBuilds_FullMethodName          = "/build.BuildService/ListBuilds"
+	BuildService_GetBuild_FullMethodName            = "/build.BuildService/GetBuild"
+	BuildService_GetBuildLogs_FullMethodName        = "/build.BuildService/GetBuildLogs"
+	BuildService_AppendBuildLogs_FullMethodName     = "/build.BuildService/AppendBuildLogs"
+	BuildService_UpdateBuildStep_FullMethodName     = "/build.BuildService/UpdateBuildStep"
+	BuildService_ReportTestResults_FullMethodName   = "/build.BuildService/ReportTestResults"
+	BuildService_GetBuildTestReport_FullMethodName  = "/build.BuildService/GetBuildTestReport"
+	BuildService_CancelBuild_FullMethodName         = "/build.BuildService/CancelBuild"
+	BuildService_DeleteBuildLogs_FullMethodName     = "/build.BuildService/DeleteBuildLogs"
+	BuildService_GetQueuePosition_FullMethodName    = "/build.BuildService/GetQueuePosition"
+	BuildService_GetBuildUsage_FullMethodName       = "/build.BuildService/GetBuildUsage"
+	BuildService_RecordDeadLetter_FullMethodName    = "/build.BuildService/RecordDeadLetter"
+	BuildService_ListDeadLetters_FullMethodName     = "/build.BuildService/ListDeadLetters"
+	BuildService_RequeueDeadLetter_FullMethodName   = "/build.BuildService/RequeueDeadLetter"
+	BuildService_ListPreviewBuilds_FullMethodName   = "/build.BuildService/ListPreviewBuilds"
+	BuildService_CancelPreviewBuilds_FullMethodName = "/build.BuildService/CancelPreviewBuilds"
 )
 
 // BuildServiceClient is the client API for BuildService service.
@@ -46,8 +57,30 @@ type BuildServiceClient interface {
 	GetBuildLogs(ctx context.Context, in *GetBuildLogsRequest, opts ...grpc.CallOption) (*GetBuildLogsResponse, error)
 	// Append logs to a build (called by Runner Service)
 	AppendBuildLogs(ctx context.Context, in *AppendBuildLogsRequest, opts ...grpc.CallOption) (*AppendBuildLogsResponse, error)
+	// Create or update a pipeline step of a build (called by Runner Service)
+	UpdateBuildStep(ctx context.Context, in *UpdateBuildStepRequest, opts ...grpc.CallOption) (*UpdateBuildStepResponse, error)
+	// Store test results parsed from the test step (called by Runner Service)
+	ReportTestResults(ctx context.Context, in *ReportTestResultsRequest, opts ...grpc.CallOption) (*ReportTestResultsResponse, error)
+	// Get the test report of a build (called by API Gateway)
+	GetBuildTestReport(ctx context.Context, in *GetBuildTestReportRequest, opts ...grpc.CallOption) (*GetBuildTestReportResponse, error)
+	// Cancel a pending or running build (called by API Gateway)
+	CancelBuild(ctx context.Context, in *CancelBuildRequest, opts ...grpc.CallOption) (*CancelBuildResponse, error)
 	// Delete logs for builds in a project (called by API Gateway)
 	DeleteBuildLogs(ctx context.Context, in *DeleteBuildLogsRequest, opts ...grpc.CallOption) (*DeleteBuildLogsResponse, error)
+	// Get where a pending build sits in the queue (called by API Gateway)
+	GetQueuePosition(ctx context.Context, in *GetQueuePositionRequest, opts ...grpc.CallOption) (*GetQueuePositionResponse, error)
+	// Get the builds a user has used this month and running (called by API Gateway)
+	GetBuildUsage(ctx context.Context, in *GetBuildUsageRequest, opts ...grpc.CallOption) (*GetBuildUsageResponse, error)
+	// Record a build job archived by the queue (called by Runner Service)
+	RecordDeadLetter(ctx context.Context, in *RecordDeadLetterRequest, opts ...grpc.CallOption) (*RecordDeadLetterResponse, error)
+	// List archived build jobs, admins only (called by API Gateway)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	// Run an archived build job again, admins only (called by API Gateway)
+	RequeueDeadLetter(ctx context.Context, in *RequeueDeadLetterRequest, opts ...grpc.CallOption) (*RequeueDeadLetterResponse, error)
+	// List the pull requests of a project with a preview build in flight (called by API Gateway)
+	ListPreviewBuilds(ctx context.Context, in *ListPreviewBuildsRequest, opts ...grpc.CallOption) (*ListPreviewBuildsResponse, error)
+	// Cancel the unfinished builds of a closed pull request (called by API Gateway)
+	CancelPreviewBuilds(ctx context.Context, in *CancelPreviewBuildsRequest, opts ...grpc.CallOption) (*CancelPreviewBuildsResponse, error)
 }
 
 type buildServiceClient struct {
@@ -118,6 +151,46 @@ func (c *buildServiceClient) AppendBuildLogs(ctx context.Context, in *AppendBuil
 	return out, nil
 }
 
+func (c *buildServiceClient) UpdateBuildStep(ctx context.Context, in *UpdateBuildStepRequest, opts ...grpc.CallOption) (*UpdateBuildStepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBuildStepResponse)
+	err := c.cc.Invoke(ctx, BuildService_UpdateBuildStep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) ReportTestResults(ctx context.Context, in *ReportTestResultsRequest, opts ...grpc.CallOption) (*ReportTestResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportTestResultsResponse)
+	err := c.cc.Invoke(ctx, BuildService_ReportTestResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) GetBuildTestReport(ctx context.Context, in *GetBuildTestReportRequest, opts ...grpc.CallOption) (*GetBuildTestReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBuildTestReportResponse)
+	err := c.cc.Invoke(ctx, BuildService_GetBuildTestReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) CancelBuild(ctx context.Context, in *CancelBuildRequest, opts ...grpc.CallOption) (*CancelBuildResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBuildResponse)
+	err := c.cc.Invoke(ctx, BuildService_CancelBuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *buildServiceClient) DeleteBuildLogs(ctx context.Context, in *DeleteBuildLogsRequest, opts ...grpc.CallOption) (*DeleteBuildLogsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteBuildLogsResponse)
@@ -128,6 +201,76 @@ func (c *buildServiceClient) DeleteBuildLogs(ctx context.Context, in *DeleteBuil
 	return out, nil
 }
 
+func (c *buildServiceClient) GetQueuePosition(ctx context.Context, in *GetQueuePositionRequest, opts ...grpc.CallOption) (*GetQueuePositionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQueuePositionResponse)
+	err := c.cc.Invoke(ctx, BuildService_GetQueuePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) GetBuildUsage(ctx context.Context, in *GetBuildUsageRequest, opts ...grpc.CallOption) (*GetBuildUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBuildUsageResponse)
+	err := c.cc.Invoke(ctx, BuildService_GetBuildUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) RecordDeadLetter(ctx context.Context, in *RecordDeadLetterRequest, opts ...grpc.CallOption) (*RecordDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordDeadLetterResponse)
+	err := c.cc.Invoke(ctx, BuildService_RecordDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, BuildService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) RequeueDeadLetter(ctx context.Context, in *RequeueDeadLetterRequest, opts ...grpc.CallOption) (*RequeueDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequeueDeadLetterResponse)
+	err := c.cc.Invoke(ctx, BuildService_RequeueDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) ListPreviewBuilds(ctx context.Context, in *ListPreviewBuildsRequest, opts ...grpc.CallOption) (*ListPreviewBuildsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPreviewBuildsResponse)
+	err := c.cc.Invoke(ctx, BuildService_ListPreviewBuilds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildServiceClient) CancelPreviewBuilds(ctx context.Context, in *CancelPreviewBuildsRequest, opts ...grpc.CallOption) (*CancelPreviewBuildsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelPreviewBuildsResponse)
+	err := c.cc.Invoke(ctx, BuildService_CancelPreviewBuilds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BuildServiceServer is the server API for BuildService service.
 // All implementations must embed UnimplementedBuildServiceServer
 // for forward compatibility.
@@ -146,8 +289,30 @@ type BuildServiceServer interface {
 	GetBuildLogs(context.Context, *GetBuildLogsRequest) (*GetBuildLogsResponse, error)
 	// Append logs to a build (called by Runner Service)
 	AppendBuildLogs(context.Context, *AppendBuildLogsRequest) (*AppendBuildLogsResponse, error)
+	// Create or update a pipeline step of a build (called by Runner Service)
+	UpdateBuildStep(context.Context, *UpdateBuildStepRequest) (*UpdateBuildStepResponse, error)
+	// Store test results parsed from the test step (called by Runner Service)
+	ReportTestResults(context.Context, *ReportTestResultsRequest) (*ReportTestResultsResponse, error)
+	// Get the test report of a build (called by API Gateway)
+	GetBuildTestReport(context.Context, *GetBuildTestReportRequest) (*GetBuildTestReportResponse, error)
+	// Cancel a pending or running build (called by API Gateway)
+	CancelBuild(context.Context, *CancelBuildRequest) (*CancelBuildResponse, error)
 	// Delete logs for builds in a project (called by API Gateway)
 	DeleteBuildLogs(context.Context, *DeleteBuildLogsRequest) (*DeleteBuildLogsResponse, error)
+	// Get where a pending build sits in the queue (called by API Gateway)
+	GetQueuePosition(context.Context, *GetQueuePositionRequest) (*GetQueuePositionResponse, error)
+	// Get the builds a user has used this month and running (called by API Gateway)
+	GetBuildUsage(context.Context, *GetBuildUsageRequest) (*GetBuildUsageResponse, error)
+	// Record a build job archived by the queue (called by Runner Service)
+	RecordDeadLetter(context.Context, *RecordDeadLetterRequest) (*RecordDeadLetterResponse, error)
+	// List archived build jobs, admins only (called by API Gateway)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	// Run an archived build job again, admins only (called by API Gateway)
+	RequeueDeadLetter(context.Context, *RequeueDeadLetterRequest) (*RequeueDeadLetterResponse, error)
+	// List the pull requests of a project with a preview build in flight (called by API Gateway)
+	ListPreviewBuilds(context.Context, *ListPreviewBuildsRequest) (*ListPreviewBuildsResponse, error)
+	// Cancel the unfinished builds of a closed pull request (called by API Gateway)
+	CancelPreviewBuilds(context.Context, *CancelPreviewBuildsRequest) (*CancelPreviewBuildsResponse, error)
 	mustEmbedUnimplementedBuildServiceServer()
 }
 
@@ -176,9 +341,42 @@ func (UnimplementedBuildServiceServer) GetBuildLogs(context.Context, *GetBuildLo
 func (UnimplementedBuildServiceServer) AppendBuildLogs(context.Context, *AppendBuildLogsRequest) (*AppendBuildLogsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AppendBuildLogs not implemented")
 }
+func (UnimplementedBuildServiceServer) UpdateBuildStep(context.Context, *UpdateBuildStepRequest) (*UpdateBuildStepResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateBuildStep not implemented")
+}
+func (UnimplementedBuildServiceServer) ReportTestResults(context.Context, *ReportTestResultsRequest) (*ReportTestResultsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportTestResults not implemented")
+}
+func (UnimplementedBuildServiceServer) GetBuildTestReport(context.Context, *GetBuildTestReportRequest) (*GetBuildTestReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBuildTestReport not implemented")
+}
+func (UnimplementedBuildServiceServer) CancelBuild(context.Context, *CancelBuildRequest) (*CancelBuildResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelBuild not implemented")
+}
 func (UnimplementedBuildServiceServer) DeleteBuildLogs(context.Context, *DeleteBuildLogsRequest) (*DeleteBuildLogsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteBuildLogs not implemented")
 }
+func (UnimplementedBuildServiceServer) GetQueuePosition(context.Context, *GetQueuePositionRequest) (*GetQueuePositionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQueuePosition not implemented")
+}
+func (UnimplementedBuildServiceServer) GetBuildUsage(context.Context, *GetBuildUsageRequest) (*GetBuildUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBuildUsage not implemented")
+}
+func (UnimplementedBuildServiceServer) RecordDeadLetter(context.Context, *RecordDeadLetterRequest) (*RecordDeadLetterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordDeadLetter not implemented")
+}
+func (UnimplementedBuildServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedBuildServiceServer) RequeueDeadLetter(context.Context, *RequeueDeadLetterRequest) (*RequeueDeadLetterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequeueDeadLetter not implemented")
+}
+func (UnimplementedBuildServiceServer) ListPreviewBuilds(context.Context, *ListPreviewBuildsRequest) (*ListPreviewBuildsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPreviewBuilds not implemented")
+}
+func (UnimplementedBuildServiceServer) CancelPreviewBuilds(context.Context, *CancelPreviewBuildsRequest) (*CancelPreviewBuildsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelPreviewBuilds not implemented")
+}
 func (UnimplementedBuildServiceServer) mustEmbedUnimplementedBuildServiceServer() {}
 func (UnimplementedBuildServiceServer) testEmbeddedByValue()                      {}
 
@@ -308,6 +506,78 @@ func _BuildService_AppendBuildLogs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BuildService_UpdateBuildStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBuildStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).UpdateBuildStep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_UpdateBuildStep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).UpdateBuildStep(ctx, req.(*UpdateBuildStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_ReportTestResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportTestResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).ReportTestResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_ReportTestResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).ReportTestResults(ctx, req.(*ReportTestResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_GetBuildTestReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuildTestReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).GetBuildTestReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_GetBuildTestReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).GetBuildTestReport(ctx, req.(*GetBuildTestReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_CancelBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).CancelBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_CancelBuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).CancelBuild(ctx, req.(*CancelBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BuildService_DeleteBuildLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteBuildLogsRequest)
 	if err := dec(in); err != nil {
@@ -326,6 +596,132 @@ func _BuildService_DeleteBuildLogs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BuildService_GetQueuePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueuePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).GetQueuePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_GetQueuePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).GetQueuePosition(ctx, req.(*GetQueuePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_GetBuildUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuildUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).GetBuildUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_GetBuildUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).GetBuildUsage(ctx, req.(*GetBuildUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_RecordDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).RecordDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_RecordDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).RecordDeadLetter(ctx, req.(*RecordDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_RequeueDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).RequeueDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_RequeueDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).RequeueDeadLetter(ctx, req.(*RequeueDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_ListPreviewBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPreviewBuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).ListPreviewBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_ListPreviewBuilds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).ListPreviewBuilds(ctx, req.(*ListPreviewBuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildService_CancelPreviewBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPreviewBuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).CancelPreviewBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_CancelPreviewBuilds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).CancelPreviewBuilds(ctx, req.(*CancelPreviewBuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BuildService_ServiceDesc is the grpc.ServiceDesc for BuildService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -357,10 +753,54 @@ var BuildService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AppendBuildLogs",
 			Handler:    _BuildService_AppendBuildLogs_Handler,
 		},
+		{
+			MethodName: "UpdateBuildStep",
+			Handler:    _BuildService_UpdateBuildStep_Handler,
+		},
+		{
+			MethodName: "ReportTestResults",
+			Handler:    _BuildService_ReportTestResults_Handler,
+		},
+		{
+			MethodName: "GetBuildTestReport",
+			Handler:    _BuildService_GetBuildTestReport_Handler,
+		},
+		{
+			MethodName: "CancelBuild",
+			Handler:    _BuildService_CancelBuild_Handler,
+		},
 		{
 			MethodName: "DeleteBuildLogs",
 			Handler:    _BuildService_DeleteBuildLogs_Handler,
 		},
+		{
+			MethodName: "GetQueuePosition",
+			Handler:    _BuildService_GetQueuePosition_Handler,
+		},
+		{
+			MethodName: "GetBuildUsage",
+			Handler:    _BuildService_GetBuildUsage_Handler,
+		},
+		{
+			MethodName: "RecordDeadLetter",
+			Handler:    _BuildService_RecordDeadLetter_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _BuildService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RequeueDeadLetter",
+			Handler:    _BuildService_RequeueDeadLetter_Handler,
+		},
+		{
+			MethodName: "ListPreviewBuilds",
+			Handler:    _BuildService_ListPreviewBuilds_Handler,
+		},
+		{
+			MethodName: "CancelPreviewBuilds",
+			Handler:    _BuildService_CancelPreviewBuilds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/build.proto",
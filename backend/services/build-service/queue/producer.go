@@ -3,10 +3,12 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 )
 
@@ -14,13 +16,39 @@ const (
 	// TaskTypeBuildJob is the type name for build jobs
 	TaskTypeBuildJob = "build:execute"
 
-	// Default queue name
+	// QueueBuilds is the queue of jobs enqueued before the plan queues existed,
+	// still drained by runners
 	QueueBuilds = "builds"
 
+	// Plan queues, consumed with weighted priority so that premium builds start
+	// first without starving standard ones
+	QueueBuildsPremium  = "builds:premium"
+	QueueBuildsStandard = "builds:standard"
+
 	// Task retention period
 	TaskRetention = 24 * time.Hour
+
+	// EventChannelPrefix is the prefix of the project event channels relayed to
+	// WebSocket clients by Notification Service (events:<project_id>:<build_id>)
+	EventChannelPrefix = "events:"
+
+	// Build settings used when the project leaves them unset
+	DefaultBuildMemoryMB = 4096
+	DefaultBuildCPUs     = 1.0
+	DefaultBuildTimeout  = 30 * time.Minute
 )
 
+// HealthCheckConfig is the health check of the deployments of a project, 0
+// values use the Deployment Service defaults
+type HealthCheckConfig struct {
+	Type            string `json:"type"` // "tcp" or "http"
+	Path            string `json:"path"`
+	ExpectedStatus  int    `json:"expected_status"`
+	IntervalSeconds int    `json:"interval_seconds"`
+	TimeoutSeconds  int    `json:"timeout_seconds"`
+	Retries         int    `json:"retries"`
+}
+
 // BuildJobPayload represents the job payload sent to Runner Service
 // Matches SRS C.2 Message Queue Format
 type BuildJobPayload struct {
@@ -31,29 +59,100 @@ type BuildJobPayload struct {
 	CommitSHA    string            `json:"commit_sha"`
 	BuildCommand string            `json:"build_command"`
 	StartCommand string            `json:"start_command"`
+	TestCommand  string            `json:"test_command"`
 	Preset       string            `json:"preset"`
 	Port         int               `json:"port"`
 	Secrets      map[string]string `json:"secrets"`
+	RootDir      string            `json:"root_dir"` // Subdirectory to build, "" = repository root
+
+	// Clone options
+	Submodules  bool `json:"submodules"`
+	LFS         bool `json:"lfs"`
+	CloneDepth  int  `json:"clone_depth"` // 0 = shallow clone of depth 1
+	FullHistory bool `json:"full_history"`
+	FetchTags   bool `json:"fetch_tags"`
+
+	// Deploy the image once the build succeeds, as the project owner
+	AutoDeploy  bool              `json:"auto_deploy"`
+	UserID      string            `json:"user_id"`
+	HealthCheck HealthCheckConfig `json:"health_check"`
+	Replicas    int               `json:"replicas"` // Already capped by the plan, 0 = 1
+
+	CustomDomains []string `json:"custom_domains,omitempty"` // Verified custom domains to route
+
+	// Builds of a pull request are deployed as a preview of it, whether or not
+	// the project auto-deploys
+	PullRequest int `json:"pull_request,omitempty"`
+
+	// Environment of the project whose branch is built, "" for production. Its
+	// deployments use its own secrets, domain and container resources.
+	Environment    string `json:"environment,omitempty"`
+	DeployMemoryMB int    `json:"deploy_memory_mb,omitempty"` // 0 = 512
+	DeployCPUCores int    `json:"deploy_cpu_cores,omitempty"` // 0 = 1
+
+	// Resource limits for the build containers, already capped by the plan
+	MemoryMB       int     `json:"memory_mb"`
+	CPUs           float64 `json:"cpus"`
+	TimeoutMinutes int     `json:"timeout_minutes"`
+	DiskMB         int     `json:"disk_mb"` // 0 = no disk quota
+
+	Plan string `json:"plan,omitempty"` // Plan of the project owner, selects the queue
+
+	// Builds of the project owner running at once, enforced by the runners. 0 uses
+	// the runner default.
+	MaxConcurrentBuilds int `json:"max_concurrent_builds,omitempty"`
+
+	// Variant of the build matrix of the project. Variant builds only build and
+	// test the project on their own base image, they push no image and are not
+	// deployed.
+	Variant    string            `json:"variant,omitempty"`
+	BaseImage  string            `json:"base_image,omitempty"` // "" uses the image of the preset
+	VariantEnv map[string]string `json:"variant_env,omitempty"`
+}
+
+// Timeout returns how long the runner may work on the job
+func (p *BuildJobPayload) Timeout() time.Duration {
+	if p.TimeoutMinutes <= 0 {
+		return DefaultBuildTimeout
+	}
+	return time.Duration(p.TimeoutMinutes) * time.Minute
+}
+
+// buildQueues are the queues a build job can be in
+var buildQueues = []string{QueueBuildsPremium, QueueBuildsStandard, QueueBuilds}
+
+// QueueForPlan returns the queue of the build jobs of a plan
+func QueueForPlan(plan string) string {
+	if plan == "premium" {
+		return QueueBuildsPremium
+	}
+	return QueueBuildsStandard
 }
 
 // Producer handles pushing jobs to the Redis queue via Asynq
 type Producer struct {
-	client *asynq.Client
+	client    *asynq.Client
+	inspector *asynq.Inspector
+	redis     *redis.Client // For publishing build events
 }
 
 // NewProducer creates a new queue producer
 func NewProducer(redisAddr string) (*Producer, error) {
-	client := asynq.NewClient(asynq.RedisClientOpt{
+	opt := asynq.RedisClientOpt{
 		Addr: redisAddr,
-	})
+	}
 
 	return &Producer{
-		client: client,
+		client:    asynq.NewClient(opt),
+		inspector: asynq.NewInspector(opt),
+		redis:     redis.NewClient(&redis.Options{Addr: redisAddr}),
 	}, nil
 }
 
 // Close closes the producer connection
 func (p *Producer) Close() error {
+	p.inspector.Close()
+	p.redis.Close()
 	return p.client.Close()
 }
 
@@ -65,9 +164,9 @@ func (p *Producer) EnqueueBuildJob(ctx context.Context, payload *BuildJobPayload
 	}
 
 	task := asynq.NewTask(TaskTypeBuildJob, data,
-		asynq.Queue(QueueBuilds),
+		asynq.Queue(QueueForPlan(payload.Plan)),
 		asynq.MaxRetry(3),
-		asynq.Timeout(30*time.Minute),
+		asynq.Timeout(payload.Timeout()),
 		asynq.Retention(TaskRetention),
 		asynq.TaskID(payload.BuildID), // Ensure idempotency
 	)
@@ -82,11 +181,162 @@ func (p *Producer) EnqueueBuildJob(ctx context.Context, payload *BuildJobPayload
 		Str("project_id", payload.ProjectID).
 		Str("task_id", info.ID).
 		Str("queue", info.Queue).
+		Dur("timeout", payload.Timeout()).
 		Msg("Build job enqueued")
 
 	return info, nil
 }
 
+// CancelBuildJob removes a build job that has not started yet, or asks the worker
+// running it to cancel its context. A job that is already gone is not an error.
+func (p *Producer) CancelBuildJob(ctx context.Context, buildID string) error {
+	info, err := p.findBuildJob(buildID)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return nil
+	}
+
+	switch info.State {
+	case asynq.TaskStateActive:
+		// Delivered to the runner through asynq's cancelation pub/sub channel
+		if err := p.inspector.CancelProcessing(buildID); err != nil {
+			return fmt.Errorf("cancel processing: %w", err)
+		}
+	case asynq.TaskStatePending, asynq.TaskStateScheduled, asynq.TaskStateRetry:
+		if err := p.inspector.DeleteTask(info.Queue, buildID); err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
+			return fmt.Errorf("delete task: %w", err)
+		}
+	}
+
+	log.Info().
+		Str("build_id", buildID).
+		Str("state", info.State.String()).
+		Msg("Build job cancelled")
+
+	return nil
+}
+
+// ErrJobNotArchived is returned by RequeueBuildJob for a build job that is not in
+// the archive, e.g. because it expired from it
+var ErrJobNotArchived = errors.New("build job is not archived")
+
+// RequeueBuildJob moves the archived job of a build back to its queue. The job
+// keeps its retry count, so a job that ran out of attempts gets one more.
+func (p *Producer) RequeueBuildJob(ctx context.Context, buildID string) error {
+	info, err := p.findBuildJob(buildID)
+	if err != nil {
+		return err
+	}
+	if info == nil || info.State != asynq.TaskStateArchived {
+		return ErrJobNotArchived
+	}
+
+	if err := p.inspector.RunTask(info.Queue, buildID); err != nil {
+		return fmt.Errorf("run task: %w", err)
+	}
+
+	log.Info().
+		Str("build_id", buildID).
+		Str("queue", info.Queue).
+		Msg("Build job requeued")
+
+	return nil
+}
+
+// findBuildJob returns the task of a build job in any build queue, or nil if it is
+// gone
+func (p *Producer) findBuildJob(buildID string) (*asynq.TaskInfo, error) {
+	for _, queue := range buildQueues {
+		info, err := p.inspector.GetTaskInfo(queue, buildID)
+		if err == nil {
+			return info, nil
+		}
+		if !errors.Is(err, asynq.ErrTaskNotFound) && !errors.Is(err, asynq.ErrQueueNotFound) {
+			return nil, fmt.Errorf("get task info: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// QueuePosition is where a build job sits in its queue
+type QueuePosition struct {
+	Queue    string
+	State    string // asynq task state: "pending", "active", "retry", ...
+	Position int    // 1-based among the pending jobs of the queue, 0 if not pending
+	Pending  int    // Pending jobs in the queue
+	LastErr  string // Why the job was put back, e.g. its user has no free build slot
+}
+
+// positionPageSize is the page size when scanning a queue for a pending job
+const positionPageSize = 100
+
+// QueuePosition returns where the job of a build sits, or nil if it is no longer
+// queued. Jobs put back because their user has no free slot are in the retry
+// state and join the end of the queue when they are due.
+func (p *Producer) QueuePosition(ctx context.Context, buildID string) (*QueuePosition, error) {
+	info, err := p.findBuildJob(buildID)
+	if err != nil || info == nil {
+		return nil, err
+	}
+
+	position := &QueuePosition{
+		Queue:   info.Queue,
+		State:   info.State.String(),
+		LastErr: info.LastErr,
+	}
+	if queueInfo, err := p.inspector.GetQueueInfo(info.Queue); err == nil {
+		position.Pending = queueInfo.Pending
+	}
+	if info.State != asynq.TaskStatePending {
+		return position, nil
+	}
+
+	// Pending jobs are listed in the order runners take them
+	for page := 1; ; page++ {
+		tasks, err := p.inspector.ListPendingTasks(info.Queue, asynq.PageSize(positionPageSize), asynq.Page(page))
+		if err != nil {
+			return nil, fmt.Errorf("list pending tasks: %w", err)
+		}
+		for i, task := range tasks {
+			if task.ID == buildID {
+				position.Position = (page-1)*positionPageSize + i + 1
+				return position, nil
+			}
+		}
+		if len(tasks) < positionPageSize {
+			// Taken by a runner while scanning
+			return position, nil
+		}
+	}
+}
+
+// BuildEvent is published on the project event channel
+type BuildEvent struct {
+	BuildID   string    `json:"build_id"`
+	Event     string    `json:"event"`
+	Status    string    `json:"status,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PublishBuildEvent publishes a build event for WebSocket clients of the project
+func (p *Producer) PublishBuildEvent(ctx context.Context, projectID string, event BuildEvent) error {
+	event.Timestamp = time.Now()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	channel := EventChannelPrefix + projectID + ":" + event.BuildID
+	if err := p.redis.Publish(ctx, channel, data).Err(); err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+	return nil
+}
+
 // ParseBuildJobPayload deserializes a build job payload (used by Runner Service)
 func ParseBuildJobPayload(data []byte) (*BuildJobPayload, error) {
 	var payload BuildJobPayload
@@ -0,0 +1,187 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexusdeploy/backend/services/deployment-service/models"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	"github.com/nexusdeploy/backend/services/deployment-service/store"
+)
+
+var statusToModel = map[deploymentpb.DeploymentStatus]models.DeploymentStatus{
+	deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_PENDING:    models.DeploymentStatusPending,
+	deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING:    models.DeploymentStatusRunning,
+	deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED:    models.DeploymentStatusStopped,
+	deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED:     models.DeploymentStatusFailed,
+	deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RESTARTING: models.DeploymentStatusRestarting,
+}
+
+// StatusFromModel converts a stored deployment status to the proto enum
+func StatusFromModel(status models.DeploymentStatus) deploymentpb.DeploymentStatus {
+	for pbStatus, s := range statusToModel {
+		if s == status {
+			return pbStatus
+		}
+	}
+	return deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_UNSPECIFIED
+}
+
+// toModel converts the deployment to its stored form
+func (d *Deployment) toModel() (*models.Deployment, error) {
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment id %q: %w", d.ID, err)
+	}
+	projectID, err := uuid.Parse(d.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project id %q: %w", d.ProjectID, err)
+	}
+
+	status, ok := statusToModel[d.Status]
+	if !ok {
+		status = models.DeploymentStatusPending
+	}
+
+	var rollbackOf *uuid.UUID
+	if d.RollbackOf != "" {
+		if id, err := uuid.Parse(d.RollbackOf); err == nil {
+			rollbackOf = &id
+		}
+	}
+	var promotedFrom *uuid.UUID
+	if d.PromotedFrom != "" {
+		if id, err := uuid.Parse(d.PromotedFrom); err == nil {
+			promotedFrom = &id
+		}
+	}
+
+	return &models.Deployment{
+		ID:          id,
+		ProjectID:   projectID,
+		BuildID:     d.BuildID,
+		ImageTag:    d.ImageTag,
+		Status:      status,
+		ContainerID: d.ContainerID,
+		HostPort:    int(d.HostPort),
+		Domain:      d.Domain,
+		PublicURL:   d.PublicURL,
+		Error:       d.Error,
+		StartedAt:   d.StartedAt,
+		StoppedAt:   d.StoppedAt,
+		RollbackOf:  rollbackOf,
+
+		SpecSnapshot: d.SpecSnapshot,
+
+		Port: int(d.Port),
+		HealthCheck: models.HealthCheck{
+			Type:            d.HealthCheck.Type,
+			Path:            d.HealthCheck.Path,
+			ExpectedStatus:  d.HealthCheck.ExpectedStatus,
+			IntervalSeconds: int(d.HealthCheck.Interval / time.Second),
+			TimeoutSeconds:  int(d.HealthCheck.Timeout / time.Second),
+			Retries:         d.HealthCheck.Retries,
+		},
+		Health:       d.Health,
+		RestartCount: int(d.RestartCount),
+
+		Replicas: int(d.Replicas),
+
+		PullRequest: int(d.PullRequest),
+
+		Environment:  d.Environment,
+		PromotedFrom: promotedFrom,
+	}, nil
+}
+
+// deploymentFromModel converts a stored deployment
+func deploymentFromModel(m *models.Deployment) *Deployment {
+	var rollbackOf string
+	if m.RollbackOf != nil {
+		rollbackOf = m.RollbackOf.String()
+	}
+	var promotedFrom string
+	if m.PromotedFrom != nil {
+		promotedFrom = m.PromotedFrom.String()
+	}
+
+	return &Deployment{
+		ID:          m.ID.String(),
+		ProjectID:   m.ProjectID.String(),
+		BuildID:     m.BuildID,
+		ContainerID: m.ContainerID,
+		ImageTag:    m.ImageTag,
+		HostPort:    int32(m.HostPort),
+		Domain:      m.Domain,
+		Status:      StatusFromModel(m.Status),
+		PublicURL:   m.PublicURL,
+		StartedAt:   m.StartedAt,
+		StoppedAt:   m.StoppedAt,
+		Error:       m.Error,
+		RollbackOf:  rollbackOf,
+
+		SpecSnapshot: m.SpecSnapshot,
+
+		Port: int32(m.Port),
+		HealthCheck: newHealthCheck(m.HealthCheck.Type, m.HealthCheck.Path, m.HealthCheck.ExpectedStatus,
+			time.Duration(m.HealthCheck.IntervalSeconds)*time.Second,
+			time.Duration(m.HealthCheck.TimeoutSeconds)*time.Second,
+			m.HealthCheck.Retries),
+		Health:       m.Health,
+		RestartCount: int32(m.RestartCount),
+
+		Replicas: int32(max(m.Replicas, 1)),
+
+		PullRequest: int32(m.PullRequest),
+
+		Environment:  m.Environment,
+		PromotedFrom: promotedFrom,
+	}
+}
+
+// findDeployment returns a deployment by ID. Without a known ID it returns the
+// deployment serving an environment of the project, or else its latest deployment.
+func (e *Executor) findDeployment(ctx context.Context, deploymentID, projectID, environment string) (*Deployment, error) {
+	if deploymentID != "" {
+		m, err := e.store.Get(ctx, deploymentID)
+		if err == nil {
+			return deploymentFromModel(m), nil
+		}
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	serving, err := e.serving(ctx, projectID, environment, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(serving) > 0 {
+		return serving[0], nil
+	}
+
+	m, err := e.store.Latest(ctx, projectID, environment)
+	if err != nil {
+		return nil, err
+	}
+	return deploymentFromModel(m), nil
+}
+
+// storeDeployment persists the deployment, recording message in its status
+// history when the status changed. Failures are logged: the container already
+// reflects the change, and the next status check stores it again.
+func (e *Executor) storeDeployment(ctx context.Context, d *Deployment, message string) {
+	m, err := d.toModel()
+	if err == nil {
+		err = e.store.Save(ctx, m, message)
+	}
+	if err != nil {
+		e.log.Error().Err(err).
+			Str("deployment_id", d.ID).
+			Str("project_id", d.ProjectID).
+			Msg("Failed to store deployment")
+	}
+}
@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+)
+
+// productionEnvironment names the environment of the project itself, stored as ""
+const productionEnvironment = "production"
+
+// environmentPattern matches the names of environments, used as DNS labels in
+// their domain and in container names
+var environmentPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,30}[a-z0-9]$`)
+
+// normalizeEnvironment returns the stored form of an environment name, "" for
+// production
+func normalizeEnvironment(environment string) string {
+	environment = strings.ToLower(strings.TrimSpace(environment))
+	if environment == productionEnvironment {
+		return ""
+	}
+	return environment
+}
+
+// validateEnvironment checks a normalized environment name
+func validateEnvironment(environment string) error {
+	if environment == "" {
+		return nil
+	}
+	if !environmentPattern.MatchString(environment) || strings.HasPrefix(environment, "pr-") {
+		return fmt.Errorf("invalid environment %q", environment)
+	}
+	return nil
+}
+
+// environmentName returns the display name of a normalized environment
+func environmentName(environment string) string {
+	if environment == "" {
+		return productionEnvironment
+	}
+	return environment
+}
+
+// Promote deploys the image serving fromEnvironment of the project to the
+// environment of spec, without a build. spec holds the configuration of the
+// target environment, its image and build are replaced by those of the serving
+// deployment. The promotion goes through the same health-checked cutover as a
+// regular deployment.
+func (e *Executor) Promote(ctx context.Context, fromEnvironment string, spec *deploymentpb.DeploymentSpec) (*Deployment, error) {
+	if spec.PullRequest > 0 {
+		return nil, errors.New("previews cannot be promoted to")
+	}
+
+	fromEnvironment = normalizeEnvironment(fromEnvironment)
+	spec.Environment = normalizeEnvironment(spec.Environment)
+	if err := validateEnvironment(fromEnvironment); err != nil {
+		return nil, err
+	}
+	if fromEnvironment == spec.Environment {
+		return nil, fmt.Errorf("cannot promote %s to itself", environmentName(fromEnvironment))
+	}
+
+	serving, err := e.serving(ctx, spec.ProjectId, fromEnvironment, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(serving) == 0 {
+		return nil, fmt.Errorf("%s has no running deployment to promote", environmentName(fromEnvironment))
+	}
+	source := serving[0]
+
+	if err := e.checkImage(ctx, source.ImageTag); err != nil {
+		return nil, fmt.Errorf("image of build %s is no longer available: %w", source.BuildID, err)
+	}
+	spec.ImageTag = source.ImageTag
+	spec.BuildId = source.BuildID
+
+	e.log.Info().
+		Str("project_id", spec.ProjectId).
+		Str("build_id", source.BuildID).
+		Str("promoted_from", source.ID).
+		Str("environment", environmentName(spec.Environment)).
+		Msg("Promoting deployment")
+
+	return e.deploy(ctx, spec, nil, source)
+}
@@ -2,15 +2,18 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
@@ -18,23 +21,70 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/google/uuid"
+	"github.com/nexusdeploy/backend/services/deployment-service/events"
+	"github.com/nexusdeploy/backend/services/deployment-service/models"
 	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	"github.com/nexusdeploy/backend/services/deployment-service/store"
 	"github.com/rs/zerolog"
 )
 
-// Deployment represents an active deployment
+// Deployment represents a deployment and the state of its container
 type Deployment struct {
 	ID          string
 	ProjectID   string
+	BuildID     string
 	ContainerID string
 	ImageTag    string
 	HostPort    int32
+	Domain      string
 	Status      deploymentpb.DeploymentStatus
 	PublicURL   string
 	StartedAt   time.Time
+	StoppedAt   *time.Time
 	Error       string
+	RollbackOf  string // Deployment whose spec a rollback redeployed
+
+	// Encrypted spec, see snapshotSpec
+	SpecSnapshot string
+
+	// Container health, see MonitorHealth
+	Port         int32
+	HealthCheck  HealthCheck
+	Health       string
+	RestartCount int32
+
+	// Desired and live containers, see reconcileReplicas. RunningReplicas is
+	// only set by GetStatus and Scale.
+	Replicas        int32
+	RunningReplicas int32
+
+	// Pull request a preview deployment was built from, 0 for production.
+	// Previews have a domain of their own and never replace production.
+	PullRequest int32
+
+	// Environment of the project, "" for production. Each environment has
+	// containers and a domain of its own. PromotedFrom is the deployment of
+	// another environment whose image a promotion deployed.
+	Environment  string
+	PromotedFrom string
 }
 
+// baseRouterPriority is the Traefik router priority of the first deployment of
+// a project. Every deployment gets a higher priority than the ones still
+// serving. Traefik ignores the new container until its Docker health check
+// passed, then moves the traffic to it while the old one finishes its requests.
+const baseRouterPriority = 1 << 10
+
+// routerPriorityLabel records the router priority of a deployment container
+const routerPriorityLabel = "nexus.router_priority"
+
+// pullRequestLabel marks the containers of a preview deployment
+const pullRequestLabel = "nexus.pull_request"
+
+// environmentLabel records the environment of the containers of a deployment
+// outside production
+const environmentLabel = "nexus.environment"
+
 // Executor handles Docker operations for deployments
 type Executor struct {
 	client              *client.Client
@@ -43,9 +93,26 @@ type Executor struct {
 	traefikEntrypoint   string
 	traefikDomainSuffix string
 
-	// In-memory store for deployments (MVP)
-	deployments map[string]*Deployment
-	mu          sync.RWMutex
+	// Key of the spec snapshots, which contain the secrets
+	encryptionKey string
+
+	// Blue/green cutover
+	healthCheckTimeout  time.Duration
+	healthCheckInterval time.Duration
+	drainTimeout        time.Duration
+
+	// Deployments and their status history are kept in deployment_db
+	store *store.Store
+	mu    sync.Mutex // Guards usedPorts
+
+	// Per deployment *sync.Mutex, see lockReplicas
+	replicaLocks sync.Map
+
+	// Per project environment or pull request *sync.Mutex, see lockDeploy
+	deployLocks sync.Map
+
+	// Health transitions and restarts are published for the dashboard
+	publisher *events.Publisher
 
 	// Port allocation
 	portRangeStart int32
@@ -60,10 +127,14 @@ type ExecutorConfig struct {
 	TraefikDomainSuffix string
 	PortRangeStart      int32
 	PortRangeEnd        int32
+	HealthCheckTimeout  time.Duration // Default time a new container has to become healthy
+	HealthCheckInterval time.Duration
+	DrainTimeout        time.Duration // Time a replaced container gets to finish its requests
+	EncryptionKey       string        // Encrypts spec snapshots; rollbacks need it
 }
 
 // NewExecutor creates a new Docker executor
-func NewExecutor(cfg ExecutorConfig, log zerolog.Logger) (*Executor, error) {
+func NewExecutor(cfg ExecutorConfig, store *store.Store, publisher *events.Publisher, log zerolog.Logger) (*Executor, error) {
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("create docker client: %w", err)
@@ -94,6 +165,15 @@ func NewExecutor(cfg ExecutorConfig, log zerolog.Logger) (*Executor, error) {
 	if cfg.PortRangeEnd == 0 {
 		cfg.PortRangeEnd = 12999
 	}
+	if cfg.HealthCheckTimeout <= 0 {
+		cfg.HealthCheckTimeout = 60 * time.Second
+	}
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = 2 * time.Second
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = 30 * time.Second
+	}
 
 	executor := &Executor{
 		client:              cli,
@@ -101,13 +181,18 @@ func NewExecutor(cfg ExecutorConfig, log zerolog.Logger) (*Executor, error) {
 		traefikNetwork:      cfg.TraefikNetwork,
 		traefikEntrypoint:   cfg.TraefikEntrypoint,
 		traefikDomainSuffix: cfg.TraefikDomainSuffix,
+		encryptionKey:       cfg.EncryptionKey,
+		healthCheckTimeout:  cfg.HealthCheckTimeout,
+		healthCheckInterval: cfg.HealthCheckInterval,
+		drainTimeout:        cfg.DrainTimeout,
 		portRangeStart:      cfg.PortRangeStart,
 		portRangeEnd:        cfg.PortRangeEnd,
-		deployments:         make(map[string]*Deployment),
+		store:               store,
+		publisher:           publisher,
 		usedPorts:           make(map[int32]bool),
 	}
 
-	// Recover existing deployments from Docker containers
+	// Reconcile stored deployments with the Docker containers
 	recoverCtx, recoverCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer recoverCancel()
 	if err := executor.recoverExistingDeployments(recoverCtx); err != nil {
@@ -128,42 +213,96 @@ func (e *Executor) IsHealthy(ctx context.Context) bool {
 	return err == nil
 }
 
-// Deploy creates and starts a new container
+// Deploy starts a new container next to the serving one (blue/green). The new
+// container takes over the traffic once it passes the health check, and the
+// containers it replaces are drained and removed. If the health check fails, the
+// new container is removed and the previous deployment keeps serving.
 func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec) (*Deployment, error) {
+	return e.deploy(ctx, spec, nil, nil)
+}
+
+// deploy runs a blue/green deployment of spec. rollbackOf is the earlier
+// deployment a rollback redeploys and promotedFrom the deployment a promotion
+// takes the image of, both nil for regular deployments.
+func (e *Executor) deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec, rollbackOf, promotedFrom *Deployment) (*Deployment, error) {
 	deploymentID := uuid.New().String()
 
+	spec.Environment = normalizeEnvironment(spec.Environment)
+	if err := validateEnvironment(spec.Environment); err != nil {
+		return nil, err
+	}
+
 	e.log.Info().
 		Str("deployment_id", deploymentID).
 		Str("project_id", spec.ProjectId).
+		Str("environment", spec.Environment).
 		Str("image", spec.ImageTag).
 		Msg("Starting deployment")
 
+	// A preview is a single container on its own domain, custom domains stay
+	// with production
+	if spec.PullRequest > 0 {
+		spec.Replicas = 1
+		spec.CustomDomains = nil
+		spec.Environment = ""
+	}
+
+	// Concurrent deployments of the environment would both replace the same
+	// deployments and leave each other serving
+	unlock := e.lockDeploy(spec.ProjectId, spec.Environment, spec.PullRequest)
+	defer unlock()
+
+	// Deployments serving the environment, or the pull request, before this one
+	previous, err := e.serving(ctx, spec.ProjectId, spec.Environment, spec.PullRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create deployment record
 	deployment := &Deployment{
 		ID:        deploymentID,
 		ProjectID: spec.ProjectId,
+		BuildID:   spec.BuildId,
 		ImageTag:  spec.ImageTag,
+		Domain:    e.getDomain(spec),
 		Status:    deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_PENDING,
 		StartedAt: time.Now(),
 	}
+	deployment.SpecSnapshot = e.snapshotSpec(spec)
+	deployment.Port = spec.Port
+	deployment.HealthCheck = healthCheckFromSpec(spec)
+	deployment.Health = models.HealthStarting
+	deployment.Replicas = max(spec.Replicas, 1)
+	deployment.PullRequest = spec.PullRequest
+	deployment.Environment = spec.Environment
+
+	message := "Deployment started"
+	if spec.PullRequest > 0 {
+		message = fmt.Sprintf("Preview of pull request #%d started", spec.PullRequest)
+	}
+	if spec.Environment != "" {
+		message = fmt.Sprintf("Deployment to %s started", spec.Environment)
+	}
+	if rollbackOf != nil {
+		deployment.RollbackOf = rollbackOf.ID
+		message = fmt.Sprintf("Rollback to build %s of deployment %s", rollbackOf.BuildID, rollbackOf.ID)
+	}
+	if promotedFrom != nil {
+		deployment.PromotedFrom = promotedFrom.ID
+		message = fmt.Sprintf("Promotion of build %s from deployment %s of %s", promotedFrom.BuildID, promotedFrom.ID, environmentName(promotedFrom.Environment))
+	}
+	e.storeDeployment(ctx, deployment, message)
 
 	// Check local image first, if not available then pull from registry
 	e.log.Debug().Str("image", spec.ImageTag).Msg("Checking local image")
-	_, _, err := e.client.ImageInspectWithRaw(ctx, spec.ImageTag)
+	_, _, err = e.client.ImageInspectWithRaw(ctx, spec.ImageTag)
 	if err != nil {
 		// Image không có local, thử pull từ registry
 		e.log.Debug().Str("image", spec.ImageTag).Msg("Image not found locally, trying to pull...")
 		reader, pullErr := e.client.ImagePull(ctx, spec.ImageTag, image.PullOptions{})
 		if pullErr != nil {
 			// Pull fail, thử tìm với prefix nexus/ (image được build local)
-			localTag := spec.ImageTag
-			if !strings.HasPrefix(localTag, "nexus/") {
-				// Thử chuyển từ registry_url/project:tag sang nexus/project:tag
-				parts := strings.SplitN(localTag, "/", 2)
-				if len(parts) == 2 {
-					localTag = "nexus/" + parts[1]
-				}
-			}
+			localTag := localImageTag(spec.ImageTag)
 
 			e.log.Debug().Str("local_tag", localTag).Msg("Trying local image with nexus/ prefix")
 			_, _, localErr := e.client.ImageInspectWithRaw(ctx, localTag)
@@ -178,7 +317,7 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 				// Không tìm thấy image nào
 				deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
 				deployment.Error = fmt.Sprintf("pull image: %v", pullErr)
-				e.storeDeployment(deployment)
+				e.storeDeployment(ctx, deployment, deployment.Error)
 				return deployment, fmt.Errorf("pull image: %w (local check also failed: %v)", pullErr, localErr)
 			}
 		} else {
@@ -190,8 +329,8 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 	}
 
 	// Build container configuration
-	containerName := e.getContainerName(spec.ProjectId, deploymentID)
-	domain := e.getDomain(spec)
+	containerName := e.getContainerName(spec.ProjectId, spec.Environment, deploymentID)
+	domain := deployment.Domain
 
 	// Environment variables
 	envVars := e.buildEnvVars(spec)
@@ -200,10 +339,11 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 	resources := e.buildResourceLimits(spec.Resources)
 
 	// Traefik labels for routing
-	labels := e.buildTraefikLabels(containerName, domain, spec.Port)
+	priority := e.routerPriority(ctx, domain, previous)
+	labels := e.buildTraefikLabels(containerName, domain, spec.CustomDomains, spec.Port, priority)
 
 	// Add Nexus labels for recovery
-	nexusLabels := e.buildNexusLabels(spec.ProjectId, deploymentID, domain)
+	nexusLabels := e.buildNexusLabels(spec.ProjectId, deploymentID, domain, spec.Environment, spec.PullRequest)
 	for k, v := range nexusLabels {
 		labels[k] = v
 	}
@@ -219,7 +359,7 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 	if err != nil {
 		deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
 		deployment.Error = fmt.Sprintf("allocate port: %v", err)
-		e.storeDeployment(deployment)
+		e.storeDeployment(ctx, deployment, deployment.Error)
 		return deployment, fmt.Errorf("allocate port: %w", err)
 	}
 	hostPortStr := fmt.Sprintf("%d", hostPort)
@@ -238,6 +378,7 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 			Env:          envVars,
 			ExposedPorts: exposedPorts,
 			Labels:       labels,
+			Healthcheck:  e.dockerHealthcheck(deployment.HealthCheck, spec.Port),
 		},
 		&container.HostConfig{
 			Resources:     resources,
@@ -253,7 +394,7 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 		e.releasePort(hostPort)
 		deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
 		deployment.Error = fmt.Sprintf("create container: %v", err)
-		e.storeDeployment(deployment)
+		e.storeDeployment(ctx, deployment, deployment.Error)
 		return deployment, fmt.Errorf("create container: %w", err)
 	}
 
@@ -266,18 +407,75 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 		e.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
 		e.releasePort(hostPort)
 		deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
+		deployment.ContainerID = ""
 		deployment.Error = fmt.Sprintf("start container: %v", err)
-		e.storeDeployment(deployment)
+		e.storeDeployment(ctx, deployment, deployment.Error)
 		return deployment, fmt.Errorf("start container: %w", err)
 	}
 
-	// Wait for container to be healthy
-	time.Sleep(2 * time.Second) // Give container time to start
+	// The other replicas copy the configuration of the first container
+	if deployment.Replicas > 1 {
+		if err := e.startReplicas(ctx, deployment); err != nil {
+			e.removeReplicas(context.WithoutCancel(ctx), deployment, 0)
+			deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
+			deployment.ContainerID = ""
+			deployment.Error = fmt.Sprintf("start replicas: %v", err)
+			e.storeDeployment(context.WithoutCancel(ctx), deployment, deployment.Error)
+			return deployment, fmt.Errorf("start replicas: %w", err)
+		}
+	}
+
+	// Wait for the new container to be healthy, the previous one keeps serving
+	// until then
+	e.log.Info().
+		Str("deployment_id", deploymentID).
+		Str("health_check", deployment.HealthCheck.Type).
+		Dur("timeout", e.healthCheckTimeout).
+		Int32("replicas", deployment.Replicas).
+		Msg("Waiting for containers to become healthy")
+
+	if err := e.waitReplicasHealthy(ctx, deployment); err != nil {
+		// Roll back: remove the new containers and leave the old version serving
+		e.removeReplicas(context.WithoutCancel(ctx), deployment, 0)
+		deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
+		deployment.ContainerID = ""
+		deployment.Health = models.HealthUnhealthy
+		deployment.Error = fmt.Sprintf("health check failed: %v", err)
+		if len(previous) > 0 {
+			deployment.Error += fmt.Sprintf("; deployment %s is still serving", previous[0].ID)
+		}
+		e.storeDeployment(context.WithoutCancel(ctx), deployment, deployment.Error)
+		e.publishHealth(context.WithoutCancel(ctx), deployment, err.Error())
+		return deployment, fmt.Errorf("health check failed: %w", err)
+	}
 
 	// Update status
 	deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING
+	deployment.Health = models.HealthHealthy
 	deployment.PublicURL = fmt.Sprintf("https://%s", domain)
-	e.storeDeployment(deployment)
+	e.storeDeployment(ctx, deployment, "Health check passed")
+	e.publishHealth(ctx, deployment, "")
+
+	// Cutover: Traefik routes new requests to the container with the higher
+	// priority, the old containers get the drain timeout to finish theirs
+	if len(previous) > 0 {
+		timer := time.NewTimer(e.drainTimeout)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+	for _, old := range previous {
+		e.log.Info().
+			Str("deployment_id", old.ID).
+			Str("replaced_by", deploymentID).
+			Msg("Draining replaced deployment")
+
+		if err := e.removeContainer(context.WithoutCancel(ctx), old, e.drainTimeout, fmt.Sprintf("Replaced by deployment %s", deploymentID)); err != nil {
+			e.log.Warn().Err(err).Str("deployment_id", old.ID).Msg("Failed to remove replaced deployment")
+		}
+	}
 
 	e.log.Info().
 		Str("deployment_id", deploymentID).
@@ -291,22 +489,12 @@ func (e *Executor) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec
 	return deployment, nil
 }
 
-// Stop stops and removes a deployment
-func (e *Executor) Stop(ctx context.Context, deploymentID, projectID string) error {
-	e.mu.RLock()
-	deployment, exists := e.deployments[deploymentID]
-	e.mu.RUnlock()
-
-	if !exists {
-		// Try to find by project ID
-		e.mu.RLock()
-		for _, d := range e.deployments {
-			if d.ProjectID == projectID {
-				deployment = d
-				break
-			}
-		}
-		e.mu.RUnlock()
+// Stop stops and removes a deployment. Without a deployment ID it stops the
+// deployment of an environment of the project.
+func (e *Executor) Stop(ctx context.Context, deploymentID, projectID, environment string) error {
+	deployment, err := e.findDeployment(ctx, deploymentID, projectID, normalizeEnvironment(environment))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
 	}
 
 	if deployment == nil || deployment.ContainerID == "" {
@@ -318,60 +506,79 @@ func (e *Executor) Stop(ctx context.Context, deploymentID, projectID string) err
 		Str("container_id", deployment.ContainerID).
 		Msg("Stopping deployment")
 
-	// Stop container with timeout
-	timeout := 30
-	if err := e.client.ContainerStop(ctx, deployment.ContainerID, container.StopOptions{Timeout: &timeout}); err != nil {
-		e.log.Warn().Err(err).Msg("Failed to stop container gracefully")
+	if err := e.removeContainer(ctx, deployment, 30*time.Second, "Stopped"); err != nil {
+		return err
 	}
 
-	// Remove container
-	if err := e.client.ContainerRemove(ctx, deployment.ContainerID, container.RemoveOptions{Force: true}); err != nil {
-		return fmt.Errorf("remove container: %w", err)
-	}
+	e.log.Info().Str("deployment_id", deploymentID).Msg("Deployment stopped")
+	return nil
+}
+
+// removeContainer stops the containers of a deployment, giving them timeout to
+// shut down gracefully, removes them and marks the deployment stopped
+func (e *Executor) removeContainer(ctx context.Context, deployment *Deployment, timeout time.Duration, message string) error {
+	defer e.lockReplicas(deployment.ID)()
 
-	// Release allocated port
-	if deployment.HostPort > 0 {
-		e.releasePort(deployment.HostPort)
+	if err := e.removeReplicas(ctx, deployment, timeout); err != nil {
+		return err
 	}
 
 	// Update status and clear container ID to prevent future inspect attempts
-	e.mu.Lock()
+	now := time.Now()
 	deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED
 	deployment.ContainerID = "" // Clear container ID to prevent inspect attempts on removed container
-	e.deployments[deploymentID] = deployment
-	e.mu.Unlock()
-
-	e.log.Info().Str("deployment_id", deploymentID).Msg("Deployment stopped")
+	deployment.StoppedAt = &now
+	e.storeDeployment(ctx, deployment, message)
 	return nil
 }
 
-// GetStatus returns the status of a deployment
-func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string) (*Deployment, error) {
-	e.mu.RLock()
-	deployment, exists := e.deployments[deploymentID]
-	if !exists {
-		// Try to find by project ID - get the latest one (most recent StartedAt)
-		var latestDeployment *Deployment
-		for _, d := range e.deployments {
-			if d.ProjectID == projectID {
-				if latestDeployment == nil || d.StartedAt.After(latestDeployment.StartedAt) {
-					latestDeployment = d
-				}
+// serving returns the deployments of an environment of a project, or of the
+// preview of a pull request, that currently own a container
+func (e *Executor) serving(ctx context.Context, projectID, environment string, pullRequest int32) ([]*Deployment, error) {
+	stored, err := e.store.Serving(ctx, projectID, environment, int(pullRequest))
+	if err != nil {
+		return nil, err
+	}
+	deployments := make([]*Deployment, 0, len(stored))
+	for i := range stored {
+		deployments = append(deployments, deploymentFromModel(&stored[i]))
+	}
+	return deployments, nil
+}
+
+// routerPriority returns a router priority above the routers of the serving
+// deployments. Containers created before priorities were set use Traefik's
+// default, the length of the rule.
+func (e *Executor) routerPriority(ctx context.Context, domain string, serving []*Deployment) int {
+	priority := baseRouterPriority
+	for _, d := range serving {
+		current := len(fmt.Sprintf("Host(`%s`)", domain)) // Traefik default
+		if inspect, err := e.client.ContainerInspect(ctx, d.ContainerID); err == nil && inspect.Config != nil {
+			if v, err := strconv.Atoi(inspect.Config.Labels[routerPriorityLabel]); err == nil {
+				current = v
 			}
 		}
-		if latestDeployment != nil {
-			deployment = latestDeployment
+		if current+1 > priority {
+			priority = current + 1
 		}
 	}
-	e.mu.RUnlock()
+	return priority
+}
+
+// GetStatus returns the status of a deployment. Without a deployment ID it
+// returns the deployment of an environment of the project.
+func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID, environment string) (*Deployment, error) {
+	environment = normalizeEnvironment(environment)
+	deployment, err := e.findDeployment(ctx, deploymentID, projectID, environment)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
 
-	// If not found in memory, try to recover from Docker containers
+	// If not stored, try to recover from Docker containers
 	if deployment == nil {
-		recovered, err := e.recoverDeploymentFromContainer(ctx, projectID)
+		recovered, err := e.recoverDeploymentFromContainer(ctx, projectID, environment)
 		if err == nil && recovered != nil {
-			e.mu.Lock()
-			e.deployments[recovered.ID] = recovered
-			e.mu.Unlock()
+			e.storeDeployment(ctx, recovered, "Recovered from container")
 			deployment = recovered
 		} else {
 			return nil, fmt.Errorf("deployment not found")
@@ -382,6 +589,14 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 	if deployment.ContainerID != "" {
 		inspect, err := e.client.ContainerInspect(ctx, deployment.ContainerID)
 		if err == nil {
+			// Restarts and the health of images with a HEALTHCHECK come from Docker,
+			// the health monitor stores them
+			deployment.RestartCount = int32(inspect.RestartCount)
+			if inspect.State.Health != nil && inspect.State.Health.Status != types.NoHealthcheck {
+				deployment.Health = inspect.State.Health.Status
+			}
+			deployment.RunningReplicas = e.runningReplicas(ctx, deployment)
+
 			// Determine actual status from Docker
 			var actualStatus deploymentpb.DeploymentStatus
 			if inspect.State.Running {
@@ -427,7 +642,7 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 				}
 			}
 
-			// Update in-memory store if status or URL changed
+			// Update the stored deployment if status or URL changed
 			if statusChanged || publicURLUpdated {
 				if statusChanged {
 					e.log.Info().
@@ -437,9 +652,7 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 						Msg("Updating deployment status from Docker")
 				}
 				deployment.Status = actualStatus
-				e.mu.Lock()
-				e.deployments[deploymentID] = deployment
-				e.mu.Unlock()
+				e.storeDeployment(ctx, deployment, fmt.Sprintf("Container is %s", inspect.State.Status))
 			}
 
 			// Update container ID if it changed
@@ -448,10 +661,7 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 			}
 		} else {
 			// Container not found - check if it was intentionally stopped
-			isContainerNotFound := strings.Contains(err.Error(), "No such container") ||
-				strings.Contains(err.Error(), "container not found")
-
-			if isContainerNotFound {
+			if isContainerNotFound(err) {
 				// Container was removed (likely intentionally stopped)
 				// Only update status if it's not already STOPPED to avoid spam
 				if deployment.Status != deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED {
@@ -460,13 +670,13 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 						Str("container_id", deployment.ContainerID).
 						Msg("Container not found, marking deployment as stopped")
 
+					now := time.Now()
 					deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED
 					// Clear container ID to prevent future inspect attempts
 					deployment.ContainerID = ""
+					deployment.StoppedAt = &now
 
-					e.mu.Lock()
-					e.deployments[deploymentID] = deployment
-					e.mu.Unlock()
+					e.storeDeployment(ctx, deployment, "Container no longer exists")
 				}
 			} else {
 				// Other error - try to recover from labels (only once, not repeatedly)
@@ -478,12 +688,10 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 
 				// Only attempt recovery if status is not STOPPED
 				if deployment.Status != deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED {
-					recovered, recoverErr := e.recoverDeploymentFromContainer(ctx, deployment.ProjectID)
+					recovered, recoverErr := e.recoverDeploymentFromContainer(ctx, deployment.ProjectID, deployment.Environment)
 					if recoverErr == nil && recovered != nil && recovered.ContainerID != deployment.ContainerID {
 						// Found a different container, update deployment
-						e.mu.Lock()
-						e.deployments[deploymentID] = recovered
-						e.mu.Unlock()
+						e.storeDeployment(ctx, recovered, "Recovered from container")
 						deployment = recovered
 					}
 				}
@@ -494,8 +702,8 @@ func (e *Executor) GetStatus(ctx context.Context, deploymentID, projectID string
 	return deployment, nil
 }
 
-// recoverDeploymentFromContainer tries to find a running container for the project and restore deployment state
-func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID string) (*Deployment, error) {
+// recoverDeploymentFromContainer tries to find a running container for an environment of the project and restore deployment state
+func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID, environment string) (*Deployment, error) {
 	e.log.Debug().
 		Str("project_id", projectID).
 		Msg("Attempting to recover deployment from container")
@@ -519,6 +727,12 @@ func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID
 
 	// Find the first running container for this project
 	for _, c := range containers {
+		// Previews are never recovered as the production deployment, nor are the
+		// containers of other environments
+		if c.Labels[pullRequestLabel] != "" || c.Labels[environmentLabel] != environment {
+			continue
+		}
+
 		// Check if container is running or restarting
 		if c.State == "running" || c.State == "restarting" {
 			// Inspect container to get full details
@@ -539,7 +753,8 @@ func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID
 			var hostPort int32
 
 			// Try to get domain from nexus.domain label first
-			if domain, ok := inspect.Config.Labels["nexus.domain"]; ok {
+			domain, ok := inspect.Config.Labels["nexus.domain"]
+			if ok {
 				publicURL = fmt.Sprintf("http://%s", domain)
 			} else {
 				// Try to extract public URL from Traefik labels
@@ -557,15 +772,7 @@ func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID
 				}
 			}
 
-			// Try to capture host port from port bindings
-			for _, bindings := range inspect.NetworkSettings.Ports {
-				if len(bindings) > 0 && len(bindings[0].HostPort) > 0 {
-					if hp, err := nat.ParsePort(bindings[0].HostPort); err == nil {
-						hostPort = int32(hp)
-						break
-					}
-				}
-			}
+			hostPort = containerHostPort(inspect)
 
 			// Determine status
 			status := deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED
@@ -587,9 +794,11 @@ func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID
 				ContainerID: c.ID,
 				ImageTag:    imageTag,
 				HostPort:    hostPort,
+				Domain:      domain,
 				Status:      status,
 				PublicURL:   publicURL,
 				StartedAt:   startedAt,
+				Environment: environment,
 			}
 
 			e.log.Info().
@@ -615,8 +824,42 @@ func (e *Executor) recoverDeploymentFromContainer(ctx context.Context, projectID
 	return nil, fmt.Errorf("no running container found for project")
 }
 
-// recoverExistingDeployments recovers all existing deployments from Docker containers on startup
+// recoverExistingDeployments reconciles the stored deployments with the Docker
+// containers on startup. Deployments whose container is gone are marked stopped,
+// and containers without a stored deployment, e.g. created before deployments
+// were stored, are recorded.
 func (e *Executor) recoverExistingDeployments(ctx context.Context) error {
+	active, err := e.store.Active(ctx)
+	if err != nil {
+		return fmt.Errorf("list active deployments: %w", err)
+	}
+	for i := range active {
+		deployment := deploymentFromModel(&active[i])
+		if deployment.ContainerID == "" {
+			// The service stopped in the middle of the deployment
+			deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_FAILED
+			deployment.Error = "deployment interrupted by a restart of Deployment Service"
+			e.storeDeployment(ctx, deployment, deployment.Error)
+			continue
+		}
+
+		if _, err := e.client.ContainerInspect(ctx, deployment.ContainerID); err != nil {
+			if isContainerNotFound(err) {
+				now := time.Now()
+				deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_STOPPED
+				deployment.ContainerID = ""
+				deployment.StoppedAt = &now
+				e.storeDeployment(ctx, deployment, "Container no longer exists")
+			}
+			continue
+		}
+		if deployment.HostPort > 0 {
+			e.mu.Lock()
+			e.usedPorts[deployment.HostPort] = true
+			e.mu.Unlock()
+		}
+	}
+
 	// List all containers with nexus.project_id label
 	containers, err := e.client.ContainerList(ctx, container.ListOptions{
 		All: true,
@@ -644,18 +887,26 @@ func (e *Executor) recoverExistingDeployments(ctx context.Context) error {
 			continue
 		}
 
+		// Every replica has a host port of its own
+		if hostPort := containerHostPort(inspect); hostPort > 0 {
+			e.mu.Lock()
+			e.usedPorts[hostPort] = true
+			e.mu.Unlock()
+		}
+
 		// Only recover once per project (get the latest running container)
 		if projectIDs[projectID] {
 			continue
 		}
 
 		// Try to recover this project's deployment
-		recovered, err := e.recoverDeploymentFromContainer(ctx, projectID)
+		recovered, err := e.recoverDeploymentFromContainer(ctx, projectID, "")
 		if err == nil && recovered != nil {
-			e.mu.Lock()
-			e.deployments[recovered.ID] = recovered
-			e.mu.Unlock()
 			projectIDs[projectID] = true
+			if _, err := e.store.Get(ctx, recovered.ID); !errors.Is(err, store.ErrNotFound) {
+				continue // Already stored
+			}
+			e.storeDeployment(ctx, recovered, "Recovered from container")
 			recoveredCount++
 			e.log.Info().
 				Str("deployment_id", recovered.ID).
@@ -728,7 +979,7 @@ func (e *Executor) releasePort(port int32) {
 
 // Restart restarts a deployment
 func (e *Executor) Restart(ctx context.Context, deploymentID, projectID string) (*Deployment, error) {
-	deployment, err := e.GetStatus(ctx, deploymentID, projectID)
+	deployment, err := e.GetStatus(ctx, deploymentID, projectID, "")
 	if err != nil {
 		return nil, err
 	}
@@ -742,18 +993,25 @@ func (e *Executor) Restart(ctx context.Context, deploymentID, projectID string)
 		Str("container_id", deployment.ContainerID).
 		Msg("Restarting deployment")
 
+	replicas, err := e.listReplicas(ctx, deployment)
+	if err != nil || !containsReplica(replicas, deployment.ContainerID) {
+		replicas = append(replicas, replica{containerID: deployment.ContainerID})
+	}
 	timeout := 10
-	if err := e.client.ContainerRestart(ctx, deployment.ContainerID, container.StopOptions{Timeout: &timeout}); err != nil {
-		return nil, fmt.Errorf("restart container: %w", err)
+	for _, r := range replicas {
+		if err := e.client.ContainerRestart(ctx, r.containerID, container.StopOptions{Timeout: &timeout}); err != nil {
+			return nil, fmt.Errorf("restart container: %w", err)
+		}
 	}
 
 	deployment.Status = deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING
+	e.storeDeployment(ctx, deployment, "Restarted")
 	return deployment, nil
 }
 
 // GetLogs returns the last N lines of container logs
 func (e *Executor) GetLogs(ctx context.Context, deploymentID, projectID string, tailLines int32) ([]string, error) {
-	deployment, err := e.GetStatus(ctx, deploymentID, projectID)
+	deployment, err := e.GetStatus(ctx, deploymentID, projectID, "")
 	if err != nil {
 		return nil, err
 	}
@@ -798,13 +1056,41 @@ func (e *Executor) GetLogs(ctx context.Context, deploymentID, projectID string,
 
 // Helper methods
 
-func (e *Executor) storeDeployment(d *Deployment) {
-	e.mu.Lock()
-	e.deployments[d.ID] = d
-	e.mu.Unlock()
+// localImageTag returns the tag the runner gives an image it built locally
+func localImageTag(tag string) string {
+	if strings.HasPrefix(tag, "nexus/") {
+		return tag
+	}
+	// Thử chuyển từ registry_url/project:tag sang nexus/project:tag
+	parts := strings.SplitN(tag, "/", 2)
+	if len(parts) == 2 {
+		return "nexus/" + parts[1]
+	}
+	return tag
 }
 
-func (e *Executor) getContainerName(projectID, deploymentID string) string {
+// containerHostPort returns the host port a container is published on, 0 if none
+func containerHostPort(inspect types.ContainerJSON) int32 {
+	if inspect.NetworkSettings == nil {
+		return 0
+	}
+	for _, bindings := range inspect.NetworkSettings.Ports {
+		if len(bindings) > 0 && len(bindings[0].HostPort) > 0 {
+			if hp, err := nat.ParsePort(bindings[0].HostPort); err == nil {
+				return int32(hp)
+			}
+		}
+	}
+	return 0
+}
+
+// isContainerNotFound reports whether a Docker error is about a removed container
+func isContainerNotFound(err error) bool {
+	return strings.Contains(err.Error(), "No such container") ||
+		strings.Contains(err.Error(), "container not found")
+}
+
+func (e *Executor) getContainerName(projectID, environment, deploymentID string) string {
 	// Shorten IDs for cleaner names
 	shortProject := projectID
 	if len(shortProject) > 8 {
@@ -814,6 +1100,9 @@ func (e *Executor) getContainerName(projectID, deploymentID string) string {
 	if len(shortDeploy) > 8 {
 		shortDeploy = shortDeploy[:8]
 	}
+	if environment != "" {
+		return fmt.Sprintf("nexus-app-%s-%s-%s", shortProject, environment, shortDeploy)
+	}
 	return fmt.Sprintf("nexus-app-%s-%s", shortProject, shortDeploy)
 }
 
@@ -824,7 +1113,15 @@ func (e *Executor) getDomain(spec *deploymentpb.DeploymentSpec) string {
 	// Generate subdomain from project ID
 	// Use full project ID, but replace hyphens with dots for valid subdomain format
 	projectID := strings.ReplaceAll(spec.ProjectId, "-", ".")
-	return fmt.Sprintf("%s.%s", projectID, e.traefikDomainSuffix)
+	domain := fmt.Sprintf("%s.%s", projectID, e.traefikDomainSuffix)
+	if spec.PullRequest > 0 {
+		// Previews live below the domain of the project
+		return fmt.Sprintf("pr-%d.%s", spec.PullRequest, domain)
+	}
+	if spec.Environment != "" {
+		return fmt.Sprintf("%s.%s", spec.Environment, domain)
+	}
+	return domain
 }
 
 func (e *Executor) buildEnvVars(spec *deploymentpb.DeploymentSpec) []string {
@@ -871,12 +1168,16 @@ func (e *Executor) buildResourceLimits(limits *deploymentpb.ResourceLimits) cont
 	return resources
 }
 
-func (e *Executor) buildTraefikLabels(containerName, domain string, port int32) map[string]string {
+// buildTraefikLabels routes domain and the custom domains to the container. Each
+// custom domain has a router of its own, so its certificate is requested apart
+// from the others and a failing one does not hold up the rest.
+func (e *Executor) buildTraefikLabels(containerName, domain string, customDomains []string, port int32, priority int) map[string]string {
 	routerName := strings.ReplaceAll(containerName, "-", "_")
 
-	return map[string]string{
+	labels := map[string]string{
 		"traefik.enable": "true",
 		fmt.Sprintf("traefik.http.routers.%s.rule", routerName):                      fmt.Sprintf("Host(`%s`)", domain),
+		fmt.Sprintf("traefik.http.routers.%s.priority", routerName):                  strconv.Itoa(priority),
 		fmt.Sprintf("traefik.http.routers.%s.entrypoints", routerName):               e.traefikEntrypoint,
 		fmt.Sprintf("traefik.http.routers.%s.tls.certresolver", routerName):          "letsencrypt",
 		fmt.Sprintf("traefik.http.services.%s.loadbalancer.server.port", routerName): fmt.Sprintf("%d", port),
@@ -885,14 +1186,40 @@ func (e *Executor) buildTraefikLabels(containerName, domain string, port int32)
 		// NexusDeploy metadata
 		"io.nexusdeploy.managed": "true",
 		"io.nexusdeploy.domain":  domain,
+		routerPriorityLabel:      strconv.Itoa(priority),
 	}
+
+	seen := map[string]bool{domain: true}
+	for _, d := range customDomains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d == "" || seen[d] {
+			continue
+		}
+		seen[d] = true
+
+		router := fmt.Sprintf("%s_domain%d", routerName, len(seen)-1)
+		labels[fmt.Sprintf("traefik.http.routers.%s.rule", router)] = fmt.Sprintf("Host(`%s`)", d)
+		labels[fmt.Sprintf("traefik.http.routers.%s.priority", router)] = strconv.Itoa(priority)
+		labels[fmt.Sprintf("traefik.http.routers.%s.entrypoints", router)] = e.traefikEntrypoint
+		labels[fmt.Sprintf("traefik.http.routers.%s.tls.certresolver", router)] = "letsencrypt"
+		labels[fmt.Sprintf("traefik.http.routers.%s.service", router)] = routerName
+	}
+	return labels
 }
 
-func (e *Executor) buildNexusLabels(projectID, deploymentID, domain string) map[string]string {
-	return map[string]string{
+func (e *Executor) buildNexusLabels(projectID, deploymentID, domain, environment string, pullRequest int32) map[string]string {
+	labels := map[string]string{
 		"nexus.project_id":       projectID,
 		"nexus.deployment_id":    deploymentID,
 		"nexus.domain":           domain,
 		"io.nexusdeploy.managed": "true",
+		replicaLabel:             "0",
+	}
+	if pullRequest > 0 {
+		labels[pullRequestLabel] = strconv.Itoa(int(pullRequest))
+	}
+	if environment != "" {
+		labels[environmentLabel] = environment
 	}
+	return labels
 }
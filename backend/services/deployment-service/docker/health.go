@@ -0,0 +1,229 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+)
+
+// Health check types
+const (
+	HealthCheckTCP  = "tcp"
+	HealthCheckHTTP = "http"
+)
+
+// Health check defaults
+const (
+	DefaultHealthCheckInterval = 10 * time.Second
+	DefaultHealthCheckTimeout  = 3 * time.Second
+	DefaultHealthCheckRetries  = 3
+)
+
+// HealthCheck is the probe of a deployment. A new container has to pass it before
+// it receives traffic, and the health monitor keeps running it afterwards.
+type HealthCheck struct {
+	Type           string
+	Path           string
+	ExpectedStatus int // 0 = any 2xx or 3xx
+	Interval       time.Duration
+	Timeout        time.Duration // Of a single probe
+	Retries        int           // Failed probes in a row before unhealthy
+}
+
+// newHealthCheck applies the defaults to a health check config
+func newHealthCheck(kind, path string, expectedStatus int, interval, timeout time.Duration, retries int) HealthCheck {
+	hc := HealthCheck{
+		Type:           HealthCheckTCP,
+		Path:           "/",
+		ExpectedStatus: expectedStatus,
+		Interval:       interval,
+		Timeout:        timeout,
+		Retries:        retries,
+	}
+	if strings.EqualFold(kind, HealthCheckHTTP) {
+		hc.Type = HealthCheckHTTP
+	}
+	if path != "" {
+		hc.Path = "/" + strings.TrimPrefix(path, "/")
+	}
+	if hc.Interval <= 0 {
+		hc.Interval = DefaultHealthCheckInterval
+	}
+	if hc.Timeout <= 0 {
+		hc.Timeout = DefaultHealthCheckTimeout
+	}
+	if hc.Retries <= 0 {
+		hc.Retries = DefaultHealthCheckRetries
+	}
+	return hc
+}
+
+// healthCheckFromSpec returns the health check of a deployment spec
+func healthCheckFromSpec(spec *deploymentpb.DeploymentSpec) HealthCheck {
+	c := spec.HealthCheck
+	if c == nil {
+		return newHealthCheck("", "", 0, 0, 0, 0)
+	}
+	return newHealthCheck(c.Type, c.Path, int(c.ExpectedStatus),
+		time.Duration(c.IntervalSeconds)*time.Second,
+		time.Duration(c.TimeoutSeconds)*time.Second,
+		int(c.Retries))
+}
+
+// Docker health checks, run inside the container with the first tool the image
+// has. Images with none of them pass, the probe of the cutover still checks them
+// from outside. A TCP check only needs the connection, curl exits with 7 and
+// wget says "refused" when there is none.
+const (
+	dockerHTTPCheck = `url='http://127.0.0.1:%[1]d%[2]s'
+if command -v wget >/dev/null 2>&1; then wget -q -O /dev/null "$url"
+elif command -v curl >/dev/null 2>&1; then curl -fsS -o /dev/null "$url"
+elif command -v bash >/dev/null 2>&1; then bash -c '</dev/tcp/127.0.0.1/%[1]d'
+fi`
+	dockerTCPCheck = `if command -v nc >/dev/null 2>&1; then nc -z 127.0.0.1 %[1]d
+elif command -v bash >/dev/null 2>&1; then bash -c '</dev/tcp/127.0.0.1/%[1]d'
+elif command -v curl >/dev/null 2>&1; then curl -s -o /dev/null http://127.0.0.1:%[1]d/; [ $? -ne 7 ]
+elif command -v wget >/dev/null 2>&1; then ! wget -q -O /dev/null http://127.0.0.1:%[1]d/ 2>&1 | grep -qi refused
+fi`
+)
+
+// dockerHealthcheck returns the Docker health check of the containers of a
+// deployment. Traefik does not route to a container until Docker reports it
+// healthy, so a new container receives no traffic before it passes. HTTP checks
+// expecting an error status only check the port inside the container.
+func (e *Executor) dockerHealthcheck(hc HealthCheck, port int32) *container.HealthConfig {
+	script := fmt.Sprintf(dockerTCPCheck, port)
+	if hc.Type == HealthCheckHTTP && hc.ExpectedStatus < 400 {
+		script = fmt.Sprintf(dockerHTTPCheck, port, strings.ReplaceAll(hc.Path, "'", `'\''`))
+	}
+	return &container.HealthConfig{
+		Test:          []string{"CMD-SHELL", script},
+		Interval:      hc.Interval,
+		Timeout:       hc.Timeout,
+		Retries:       hc.Retries,
+		StartPeriod:   e.healthCheckTimeout,
+		StartInterval: e.healthCheckInterval,
+	}
+}
+
+// waitHealthy probes a new container until it passes the health check and Docker
+// reports it healthy, which is when Traefik starts routing to it. It gives up when
+// the container exits or does not become healthy in time.
+func (e *Executor) waitHealthy(ctx context.Context, containerID string, port, hostPort int32, hc HealthCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, e.healthCheckTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(e.healthCheckInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		inspect, err := e.client.ContainerInspect(ctx, containerID)
+		switch {
+		case err != nil:
+			lastErr = fmt.Errorf("inspect container: %w", err)
+		case !inspect.State.Running:
+			return fmt.Errorf("container exited with code %d", inspect.State.ExitCode)
+		default:
+			lastErr = probe(ctx, hc, e.probeAddress(inspect, port, hostPort))
+			if lastErr == nil {
+				lastErr = dockerHealth(inspect)
+			}
+			if lastErr == nil {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("not healthy after %s: %v", e.healthCheckTimeout, lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitReplicasHealthy waits until every container of a new deployment passes the
+// health check
+func (e *Executor) waitReplicasHealthy(ctx context.Context, deployment *Deployment) error {
+	replicas, err := e.listReplicas(ctx, deployment)
+	if err != nil {
+		return err
+	}
+	for _, r := range replicas {
+		if err := e.waitHealthy(ctx, r.containerID, deployment.Port, r.hostPort, deployment.HealthCheck); err != nil {
+			if len(replicas) > 1 {
+				return fmt.Errorf("replica %d: %w", r.index, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// dockerHealth returns an error until Docker reports a container with a health
+// check healthy
+func dockerHealth(inspect types.ContainerJSON) error {
+	health := inspect.State.Health
+	if health == nil || health.Status == types.Healthy || health.Status == types.NoHealthcheck {
+		return nil
+	}
+	if n := len(health.Log); n > 0 && health.Log[n-1].ExitCode != 0 {
+		return fmt.Errorf("docker health check is %s: %s", health.Status, strings.TrimSpace(health.Log[n-1].Output))
+	}
+	return fmt.Errorf("docker health check is %s", health.Status)
+}
+
+// probeAddress returns the address of the container on the Traefik network, which
+// is the path Traefik uses as well. The published host port is the fallback when
+// the service runs outside that network, e.g. in local development.
+func (e *Executor) probeAddress(inspect types.ContainerJSON, port, hostPort int32) string {
+	if inspect.NetworkSettings != nil {
+		if nw, ok := inspect.NetworkSettings.Networks[e.traefikNetwork]; ok && nw.IPAddress != "" {
+			return net.JoinHostPort(nw.IPAddress, fmt.Sprintf("%d", port))
+		}
+	}
+	return net.JoinHostPort("127.0.0.1", fmt.Sprintf("%d", hostPort))
+}
+
+// probe runs a single health check against addr
+func probe(ctx context.Context, hc HealthCheck, addr string) error {
+	ctx, cancel := context.WithTimeout(ctx, hc.Timeout)
+	defer cancel()
+
+	if hc.Type != HealthCheckHTTP {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+hc.Path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if hc.ExpectedStatus != 0 {
+		if resp.StatusCode != hc.ExpectedStatus {
+			return fmt.Errorf("GET %s returned %d, expected %d", hc.Path, resp.StatusCode, hc.ExpectedStatus)
+		}
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("GET %s returned %d", hc.Path, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/nexusdeploy/backend/services/deployment-service/events"
+	"github.com/nexusdeploy/backend/services/deployment-service/models"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+)
+
+// healthMonitorInterval is how often the monitor looks at the running
+// deployments. Each deployment is probed at its own health check interval.
+const healthMonitorInterval = 5 * time.Second
+
+// healthState is what the monitor remembers of a deployment between rounds
+type healthState struct {
+	lastProbe time.Time
+	failures  int // Failed probes in a row
+	lastErr   error
+}
+
+// MonitorHealth keeps the health and restart count of the running deployments up
+// to date until ctx is cancelled, and publishes health transitions and restarts.
+// Containers whose image defines a HEALTHCHECK report the health Docker
+// determined; the others are probed with the health check of their deployment.
+// Running deployments are also kept at their number of replicas.
+func (e *Executor) MonitorHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthMonitorInterval)
+	defer ticker.Stop()
+
+	states := make(map[string]*healthState)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.checkHealth(ctx, states)
+		}
+	}
+}
+
+// checkHealth runs one round of the health monitor
+func (e *Executor) checkHealth(ctx context.Context, states map[string]*healthState) {
+	active, err := e.store.Active(ctx)
+	if err != nil {
+		e.log.Warn().Err(err).Msg("Failed to list deployments for health check")
+		return
+	}
+
+	seen := make(map[string]bool, len(active))
+	for i := range active {
+		d := deploymentFromModel(&active[i])
+		// Pending deployments are checked by the cutover
+		if d.ContainerID == "" || d.Status == deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_PENDING {
+			continue
+		}
+		seen[d.ID] = true
+
+		// Replace containers that died and apply the desired count
+		if d.Status == deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING {
+			reconciled, err := e.reconcileReplicas(ctx, d.ID)
+			if err != nil {
+				e.log.Warn().Err(err).Str("deployment_id", d.ID).Msg("Failed to reconcile replicas")
+			} else {
+				d = reconciled
+			}
+		}
+
+		state, ok := states[d.ID]
+		if !ok {
+			state = &healthState{}
+			states[d.ID] = state
+		}
+		e.checkDeploymentHealth(ctx, d, state)
+	}
+
+	for id := range states {
+		if !seen[id] {
+			delete(states, id)
+		}
+	}
+}
+
+// checkDeploymentHealth updates the health and restart count of a deployment
+func (e *Executor) checkDeploymentHealth(ctx context.Context, d *Deployment, state *healthState) {
+	inspect, err := e.client.ContainerInspect(ctx, d.ContainerID)
+	if err != nil {
+		// Removed containers are picked up by GetStatus and on startup
+		return
+	}
+
+	health := d.Health
+	reason := ""
+	switch {
+	case !inspect.State.Running:
+		health = models.HealthUnhealthy
+		reason = fmt.Sprintf("container is %s", inspect.State.Status)
+	case inspect.State.Health != nil && inspect.State.Health.Status != types.NoHealthcheck:
+		health = inspect.State.Health.Status
+		if logs := inspect.State.Health.Log; len(logs) > 0 && health == models.HealthUnhealthy {
+			reason = logs[len(logs)-1].Output
+		}
+	case d.Port == 0:
+		// Deployed before the container port was stored, nothing to probe
+	case time.Since(state.lastProbe) >= d.HealthCheck.Interval:
+		state.lastProbe = time.Now()
+		if err := probe(ctx, d.HealthCheck, e.probeAddress(inspect, d.Port, d.HostPort)); err != nil {
+			state.failures++
+			state.lastErr = err
+		} else {
+			state.failures = 0
+			state.lastErr = nil
+			health = models.HealthHealthy
+		}
+		if state.failures >= d.HealthCheck.Retries {
+			health = models.HealthUnhealthy
+			reason = fmt.Sprintf("%d failed health checks: %v", state.failures, state.lastErr)
+		}
+	}
+
+	restartCount := int32(inspect.RestartCount)
+	if health == d.Health && restartCount == d.RestartCount {
+		return
+	}
+
+	if err := e.store.UpdateHealth(ctx, d.ID, health, int(restartCount)); err != nil {
+		e.log.Error().Err(err).Str("deployment_id", d.ID).Msg("Failed to store deployment health")
+		return
+	}
+
+	if restartCount > d.RestartCount {
+		e.log.Warn().
+			Str("deployment_id", d.ID).
+			Int32("restart_count", restartCount).
+			Msg("Deployment container restarted")
+
+		e.publish(ctx, d, events.DeploymentEvent{
+			Event:   events.EventRestarted,
+			Status:  health,
+			Message: fmt.Sprintf("Container restarted (%d restarts)", restartCount),
+			Level:   "warn",
+		})
+	}
+	d.RestartCount = restartCount
+
+	if health != d.Health {
+		e.log.Info().
+			Str("deployment_id", d.ID).
+			Str("old_health", d.Health).
+			Str("new_health", health).
+			Msg("Deployment health changed")
+
+		d.Health = health
+		e.publishHealth(ctx, d, reason)
+	}
+}
+
+// publishHealth publishes the current health of a deployment, with reason in the
+// message when there is one
+func (e *Executor) publishHealth(ctx context.Context, d *Deployment, reason string) {
+	var message, level string
+	switch d.Health {
+	case models.HealthHealthy:
+		message, level = "Deployment is healthy", "info"
+	case models.HealthUnhealthy:
+		message, level = "Deployment is unhealthy", "error"
+	default:
+		message, level = "Deployment health check is starting", "info"
+	}
+	if reason != "" {
+		message += ": " + reason
+	}
+
+	e.publish(ctx, d, events.DeploymentEvent{
+		Event:   events.EventHealthChanged,
+		Status:  d.Health,
+		Message: message,
+		Level:   level,
+	})
+}
+
+// publish sends a deployment event. Events are best effort, failures are logged.
+func (e *Executor) publish(ctx context.Context, d *Deployment, event events.DeploymentEvent) {
+	if e.publisher == nil {
+		return
+	}
+	event.DeploymentID = d.ID
+	if err := e.publisher.Publish(ctx, d.ProjectID, event); err != nil {
+		e.log.Warn().Err(err).
+			Str("deployment_id", d.ID).
+			Str("event", event.Event).
+			Msg("Failed to publish deployment event")
+	}
+}
@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StopPreview removes the preview of a pull request once the pull request is
+// closed. It returns the number of deployments stopped, 0 when the pull request
+// had no preview.
+func (e *Executor) StopPreview(ctx context.Context, projectID string, pullRequest int32) (int, error) {
+	if pullRequest <= 0 {
+		return 0, fmt.Errorf("invalid pull request %d", pullRequest)
+	}
+
+	previews, err := e.serving(ctx, projectID, "", pullRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	stopped := 0
+	message := fmt.Sprintf("Pull request #%d closed", pullRequest)
+	for _, d := range previews {
+		e.log.Info().
+			Str("deployment_id", d.ID).
+			Str("project_id", projectID).
+			Int32("pull_request", pullRequest).
+			Msg("Stopping preview deployment")
+
+		if err := e.removeContainer(ctx, d, 10*time.Second, message); err != nil {
+			return stopped, fmt.Errorf("stop preview %s: %w", d.ID, err)
+		}
+		stopped++
+	}
+	return stopped, nil
+}
@@ -0,0 +1,357 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/nexusdeploy/backend/services/deployment-service/events"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+)
+
+// replicaLabel is the index of a container among the replicas of its deployment.
+// Containers created before replicas were supported have no label and are index 0.
+const replicaLabel = "nexus.replica"
+
+// replica is one of the containers of a deployment. All replicas carry the same
+// Traefik labels, so Traefik load-balances the router of the deployment across
+// them.
+type replica struct {
+	containerID string
+	hostPort    int32
+	index       int
+	live        bool // Running, or being restarted by Docker
+}
+
+// Scale changes the number of containers of a running deployment. The count is
+// stored, so the health monitor keeps the deployment at it.
+func (e *Executor) Scale(ctx context.Context, deploymentID, projectID string, replicas int32) (*Deployment, error) {
+	if replicas < 1 {
+		return nil, errors.New("replicas must be at least 1")
+	}
+
+	deployment, err := e.findDeployment(ctx, deploymentID, projectID, "")
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Status != deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING || deployment.ContainerID == "" {
+		return nil, fmt.Errorf("deployment %s is not running", deployment.ID)
+	}
+
+	if err := e.store.UpdateReplicas(ctx, deployment.ID, int(replicas)); err != nil {
+		return nil, err
+	}
+
+	e.log.Info().
+		Str("deployment_id", deployment.ID).
+		Int32("from", deployment.Replicas).
+		Int32("to", replicas).
+		Msg("Scaling deployment")
+
+	return e.reconcileReplicas(ctx, deployment.ID)
+}
+
+// reconcileReplicas brings a running deployment to its desired number of
+// containers. Stopped containers are replaced and surplus ones removed; new
+// containers copy the configuration of a live one. A deployment without a live
+// container is left to the status checks, there is nothing to copy.
+func (e *Executor) reconcileReplicas(ctx context.Context, deploymentID string) (*Deployment, error) {
+	defer e.lockReplicas(deploymentID)()
+
+	// Read under the lock, a deployment being removed is no longer running
+	stored, err := e.store.Get(ctx, deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	deployment := deploymentFromModel(stored)
+	if deployment.Status != deploymentpb.DeploymentStatus_DEPLOYMENT_STATUS_RUNNING || deployment.ContainerID == "" {
+		return deployment, nil
+	}
+
+	replicas, err := e.listReplicas(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	var live, dead []replica
+	for _, r := range replicas {
+		if r.live {
+			live = append(live, r)
+		} else {
+			dead = append(dead, r)
+		}
+	}
+	if len(live) == 0 {
+		deployment.RunningReplicas = 0
+		return deployment, nil
+	}
+
+	// Docker restarts crashed containers itself, the others are not coming back
+	for _, r := range dead {
+		if err := e.removeReplica(ctx, r, 0); err != nil {
+			e.log.Warn().Err(err).Str("container_id", r.containerID).Msg("Failed to remove stopped replica")
+		}
+	}
+
+	// Status checks and logs use the primary container, which must be live
+	if !containsReplica(live, deployment.ContainerID) {
+		deployment.ContainerID = live[0].containerID
+		deployment.HostPort = live[0].hostPort
+		e.storeDeployment(ctx, deployment, "")
+	}
+
+	switch desired := int(deployment.Replicas); {
+	case len(live) < desired:
+		template, err := e.client.ContainerInspect(ctx, deployment.ContainerID)
+		if err != nil {
+			return nil, fmt.Errorf("inspect container: %w", err)
+		}
+		started := 0
+		for len(live) < desired {
+			r, err := e.startReplica(ctx, deployment, template, freeReplicaIndex(live))
+			if err != nil {
+				e.log.Error().Err(err).Str("deployment_id", deployment.ID).Msg("Failed to start replica")
+				break
+			}
+			live = append(live, r)
+			started++
+		}
+		if started > 0 && len(dead) > 0 {
+			e.publish(ctx, deployment, events.DeploymentEvent{
+				Event:   events.EventReplicasReplaced,
+				Status:  deployment.Health,
+				Message: fmt.Sprintf("Replaced %d stopped container(s)", len(dead)),
+				Level:   "warn",
+			})
+		}
+
+	case len(live) > desired:
+		// Remove the highest indexes first, the primary container stays
+		sort.Slice(live, func(i, j int) bool { return live[i].index > live[j].index })
+		surplus := len(live) - desired
+		kept := make([]replica, 0, desired)
+		for _, r := range live {
+			if surplus > 0 && r.containerID != deployment.ContainerID {
+				err := e.removeReplica(ctx, r, e.drainTimeout)
+				if err == nil {
+					surplus--
+					continue
+				}
+				e.log.Warn().Err(err).Str("container_id", r.containerID).Msg("Failed to remove replica")
+			}
+			kept = append(kept, r)
+		}
+		live = kept
+	}
+
+	deployment.RunningReplicas = int32(len(live))
+	return deployment, nil
+}
+
+// startReplicas starts the containers of a new deployment after the first one,
+// with the configuration of the first one
+func (e *Executor) startReplicas(ctx context.Context, deployment *Deployment) error {
+	template, err := e.client.ContainerInspect(ctx, deployment.ContainerID)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+	for i := 1; i < int(deployment.Replicas); i++ {
+		if _, err := e.startReplica(ctx, deployment, template, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startReplica creates and starts a container with the configuration of template,
+// a container of the same deployment, on a host port of its own
+func (e *Executor) startReplica(ctx context.Context, deployment *Deployment, template types.ContainerJSON, index int) (replica, error) {
+	if template.Config == nil || template.HostConfig == nil {
+		return replica{}, errors.New("template container has no configuration")
+	}
+
+	hostPort, err := e.allocatePort()
+	if err != nil {
+		return replica{}, fmt.Errorf("allocate port: %w", err)
+	}
+
+	config := *template.Config
+	config.Hostname = ""
+	config.Labels = make(map[string]string, len(template.Config.Labels)+1)
+	for k, v := range template.Config.Labels {
+		config.Labels[k] = v
+	}
+	config.Labels[replicaLabel] = strconv.Itoa(index)
+
+	hostConfig := *template.HostConfig
+	hostConfig.PortBindings = nat.PortMap{}
+	for port := range template.HostConfig.PortBindings {
+		hostConfig.PortBindings[port] = []nat.PortBinding{{
+			HostIP:   "0.0.0.0",
+			HostPort: strconv.Itoa(int(hostPort)),
+		}}
+	}
+
+	name := replicaName(e.getContainerName(deployment.ProjectID, deployment.Environment, deployment.ID), index)
+	resp, err := e.client.ContainerCreate(ctx, &config, &hostConfig, &network.NetworkingConfig{}, nil, name)
+	if err != nil {
+		e.releasePort(hostPort)
+		return replica{}, fmt.Errorf("create replica %d: %w", index, err)
+	}
+	if err := e.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		e.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
+		e.releasePort(hostPort)
+		return replica{}, fmt.Errorf("start replica %d: %w", index, err)
+	}
+
+	e.log.Info().
+		Str("deployment_id", deployment.ID).
+		Str("container_id", resp.ID).
+		Int("replica", index).
+		Int32("host_port", hostPort).
+		Msg("Started replica")
+
+	return replica{containerID: resp.ID, hostPort: hostPort, index: index, live: true}, nil
+}
+
+// listReplicas returns the containers of a deployment
+func (e *Executor) listReplicas(ctx context.Context, deployment *Deployment) ([]replica, error) {
+	containers, err := e.client.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", fmt.Sprintf("nexus.deployment_id=%s", deployment.ID)),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	replicas := make([]replica, 0, len(containers))
+	for _, c := range containers {
+		r := replica{
+			containerID: c.ID,
+			live:        c.State == "running" || c.State == "restarting",
+		}
+		r.index, _ = strconv.Atoi(c.Labels[replicaLabel])
+		for _, p := range c.Ports {
+			if p.PublicPort > 0 {
+				r.hostPort = int32(p.PublicPort)
+				break
+			}
+		}
+		replicas = append(replicas, r)
+	}
+	return replicas, nil
+}
+
+// removeReplicas removes all containers of a deployment, giving each timeout to
+// shut down gracefully
+func (e *Executor) removeReplicas(ctx context.Context, deployment *Deployment, timeout time.Duration) error {
+	replicas, err := e.listReplicas(ctx, deployment)
+	if err != nil {
+		e.log.Warn().Err(err).Str("deployment_id", deployment.ID).Msg("Failed to list replicas")
+	}
+	// Recovered deployments may have a container without the deployment label
+	if deployment.ContainerID != "" && !containsReplica(replicas, deployment.ContainerID) {
+		replicas = append(replicas, replica{containerID: deployment.ContainerID, hostPort: deployment.HostPort})
+	}
+
+	// Containers drain in parallel
+	errs := make([]error, len(replicas))
+	var wg sync.WaitGroup
+	for i, r := range replicas {
+		wg.Add(1)
+		go func(i int, r replica) {
+			defer wg.Done()
+			errs[i] = e.removeReplica(ctx, r, timeout)
+		}(i, r)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// removeReplica stops a container, removes it and releases its host port
+func (e *Executor) removeReplica(ctx context.Context, r replica, timeout time.Duration) error {
+	seconds := int(timeout.Seconds())
+	if err := e.client.ContainerStop(ctx, r.containerID, container.StopOptions{Timeout: &seconds}); err != nil && !isContainerNotFound(err) {
+		e.log.Warn().Err(err).Str("container_id", r.containerID).Msg("Failed to stop container gracefully")
+	}
+	if err := e.client.ContainerRemove(ctx, r.containerID, container.RemoveOptions{Force: true}); err != nil && !isContainerNotFound(err) {
+		return fmt.Errorf("remove container: %w", err)
+	}
+	e.releasePort(r.hostPort)
+	return nil
+}
+
+// runningReplicas returns the number of live containers of a deployment
+func (e *Executor) runningReplicas(ctx context.Context, deployment *Deployment) int32 {
+	replicas, err := e.listReplicas(ctx, deployment)
+	if err != nil {
+		return 0
+	}
+	var n int32
+	for _, r := range replicas {
+		if r.live {
+			n++
+		}
+	}
+	return n
+}
+
+// lockReplicas serializes changes to the containers of a deployment and returns
+// the unlock function. The locks are small and kept for the life of the process.
+func (e *Executor) lockReplicas(deploymentID string) func() {
+	v, _ := e.replicaLocks.LoadOrStore(deploymentID, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// lockDeploy serializes the deployments of a project environment, or of a pull
+// request, and returns the unlock function
+func (e *Executor) lockDeploy(projectID, environment string, pullRequest int32) func() {
+	key := fmt.Sprintf("%s/%s/%d", projectID, environment, pullRequest)
+	v, _ := e.deployLocks.LoadOrStore(key, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// replicaName returns the container name of a replica. The first replica keeps
+// the name containers had before replicas were supported.
+func replicaName(base string, index int) string {
+	if index == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, index)
+}
+
+// freeReplicaIndex returns the lowest index not used by a container
+func freeReplicaIndex(replicas []replica) int {
+	used := make(map[int]bool, len(replicas))
+	for _, r := range replicas {
+		used[r.index] = true
+	}
+	i := 0
+	for used[i] {
+		i++
+	}
+	return i
+}
+
+func containsReplica(replicas []replica, containerID string) bool {
+	for _, r := range replicas {
+		if r.containerID == containerID {
+			return true
+		}
+	}
+	return false
+}
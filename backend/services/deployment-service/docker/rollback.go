@@ -0,0 +1,135 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nexusdeploy/backend/pkg/crypto"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// RollbackPrevious selects the build deployed before the serving one
+const RollbackPrevious = "previous"
+
+// Rollback redeploys a build with the spec, secrets included, that it was last
+// deployed with. buildID is a build of the project or RollbackPrevious. The
+// rollback goes through the same health-checked cutover as a regular deployment.
+// Custom domains belong to the project rather than the build, customDomains
+// replaces those of the spec.
+func (e *Executor) Rollback(ctx context.Context, projectID, buildID, userID string, customDomains []string) (*Deployment, error) {
+	target, err := e.rollbackTarget(ctx, projectID, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := e.specFromSnapshot(target.SpecSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("restore spec of deployment %s: %w", target.ID, err)
+	}
+	if userID != "" {
+		spec.UserId = userID
+	}
+	spec.CustomDomains = customDomains
+
+	if err := e.checkImage(ctx, spec.ImageTag); err != nil {
+		return nil, fmt.Errorf("image of build %s is no longer available: %w", target.BuildID, err)
+	}
+
+	e.log.Info().
+		Str("project_id", projectID).
+		Str("build_id", target.BuildID).
+		Str("rollback_of", target.ID).
+		Msg("Rolling back deployment")
+
+	return e.deploy(ctx, spec, target, nil)
+}
+
+// rollbackTarget returns the latest successful deployment of the build to roll
+// back to
+func (e *Executor) rollbackTarget(ctx context.Context, projectID, buildID string) (*Deployment, error) {
+	stored, err := e.store.Successful(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if buildID == RollbackPrevious {
+		// The build serving now, or else the last one that did
+		currentBuild := ""
+		serving, err := e.serving(ctx, projectID, "", 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(serving) > 0 {
+			currentBuild = serving[0].BuildID
+		} else if len(stored) > 0 {
+			currentBuild = stored[0].BuildID
+		}
+
+		for i := range stored {
+			if stored[i].BuildID != "" && stored[i].BuildID != currentBuild {
+				return deploymentFromModel(&stored[i]), nil
+			}
+		}
+		return nil, errors.New("no previous build to roll back to")
+	}
+
+	for i := range stored {
+		if stored[i].BuildID == buildID {
+			return deploymentFromModel(&stored[i]), nil
+		}
+	}
+	return nil, fmt.Errorf("build %s has no successful deployment to roll back to", buildID)
+}
+
+// checkImage verifies that an image is available locally or in its registry,
+// without pulling it
+func (e *Executor) checkImage(ctx context.Context, tag string) error {
+	if _, _, err := e.client.ImageInspectWithRaw(ctx, tag); err == nil {
+		return nil
+	}
+	if _, _, err := e.client.ImageInspectWithRaw(ctx, localImageTag(tag)); err == nil {
+		return nil
+	}
+	if _, err := e.client.DistributionInspect(ctx, tag, ""); err != nil {
+		return fmt.Errorf("image %s not found locally or in the registry: %w", tag, err)
+	}
+	return nil
+}
+
+// snapshotSpec encrypts a spec for the deployment history. Without an encryption
+// key no snapshot is kept, as it contains the secrets, and the deployment cannot
+// be rolled back to.
+func (e *Executor) snapshotSpec(spec *deploymentpb.DeploymentSpec) string {
+	if e.encryptionKey == "" {
+		return ""
+	}
+
+	data, err := proto.Marshal(spec)
+	if err == nil {
+		var snapshot string
+		if snapshot, err = crypto.EncryptString(e.encryptionKey, string(data)); err == nil {
+			return snapshot
+		}
+	}
+	e.log.Warn().Err(err).Str("project_id", spec.ProjectId).Msg("Failed to snapshot deployment spec")
+	return ""
+}
+
+// specFromSnapshot decrypts a spec snapshot
+func (e *Executor) specFromSnapshot(snapshot string) (*deploymentpb.DeploymentSpec, error) {
+	if e.encryptionKey == "" {
+		return nil, errors.New("encryption key not configured")
+	}
+
+	data, err := crypto.DecryptString(e.encryptionKey, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	spec := &deploymentpb.DeploymentSpec{}
+	if err := proto.Unmarshal([]byte(data), spec); err != nil {
+		return nil, fmt.Errorf("unmarshal spec: %w", err)
+	}
+	return spec, nil
+}
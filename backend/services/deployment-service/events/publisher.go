@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// EventChannelPrefix is the prefix of the project event channels relayed to
+	// WebSocket clients by Notification Service (events:<project_id>)
+	EventChannelPrefix = "events:"
+
+	// LogChannelPrefix is the prefix of the deployment log channels
+	// (deployment_logs:<project_id>)
+	LogChannelPrefix = "deployment_logs:"
+)
+
+// Deployment events
+const (
+	EventHealthChanged    = "health_changed"
+	EventRestarted        = "restarted"
+	EventReplicasReplaced = "replicas_replaced"
+)
+
+// DeploymentEvent is an event of a deployment, e.g. a health transition
+type DeploymentEvent struct {
+	DeploymentID string    `json:"deployment_id"`
+	Event        string    `json:"event"`
+	Status       string    `json:"status,omitempty"`
+	Message      string    `json:"message,omitempty"`
+	Level        string    `json:"level,omitempty"` // "info", "warn" or "error"
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Publisher publishes deployment events to Redis Pub/Sub
+type Publisher struct {
+	redis *redis.Client
+}
+
+// NewPublisher creates a publisher for the Redis server at redisAddr
+func NewPublisher(redisAddr string) *Publisher {
+	return &Publisher{
+		redis: redis.NewClient(&redis.Options{Addr: redisAddr}),
+	}
+}
+
+// Close closes the Redis connection
+func (p *Publisher) Close() error {
+	return p.redis.Close()
+}
+
+// Publish sends an event on the event channel of the project, and its message on
+// the deployment log channel so it shows up in the runtime logs
+func (p *Publisher) Publish(ctx context.Context, projectID string, event DeploymentEvent) error {
+	event.Timestamp = time.Now()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	if err := p.redis.Publish(ctx, EventChannelPrefix+projectID, data).Err(); err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+
+	if event.Message == "" {
+		return nil
+	}
+	line, err := json.Marshal(map[string]interface{}{
+		"deployment_id": event.DeploymentID,
+		"message":       event.Message,
+		"level":         event.Level,
+		"timestamp":     event.Timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal log line: %w", err)
+	}
+	if err := p.redis.Publish(ctx, LogChannelPrefix+projectID, line).Err(); err != nil {
+		return fmt.Errorf("publish log line: %w", err)
+	}
+	return nil
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/nexusdeploy/backend/pkg/logger"
 	"github.com/nexusdeploy/backend/services/deployment-service/docker"
+	"github.com/nexusdeploy/backend/services/deployment-service/models"
 	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	"github.com/nexusdeploy/backend/services/deployment-service/store"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -15,13 +17,15 @@ import (
 type DeploymentHandler struct {
 	deploymentpb.UnimplementedDeploymentServiceServer
 	executor *docker.Executor
+	store    *store.Store
 	log      zerolog.Logger
 }
 
 // NewDeploymentHandler creates a new deployment handler
-func NewDeploymentHandler(executor *docker.Executor, log zerolog.Logger) *DeploymentHandler {
+func NewDeploymentHandler(executor *docker.Executor, store *store.Store, log zerolog.Logger) *DeploymentHandler {
 	return &DeploymentHandler{
 		executor: executor,
+		store:    store,
 		log:      log,
 	}
 }
@@ -78,9 +82,10 @@ func (h *DeploymentHandler) StopDeployment(ctx context.Context, req *deploymentp
 		Str("correlation_id", correlationID).
 		Str("deployment_id", req.DeploymentId).
 		Str("project_id", req.ProjectId).
+		Str("environment", req.Environment).
 		Msg("Stop deployment request received")
 
-	err := h.executor.Stop(ctx, req.DeploymentId, req.ProjectId)
+	err := h.executor.Stop(ctx, req.DeploymentId, req.ProjectId, req.Environment)
 	if err != nil {
 		h.log.Error().
 			Err(err).
@@ -111,6 +116,7 @@ func (h *DeploymentHandler) GetDeploymentStatus(ctx context.Context, req *deploy
 		Str("correlation_id", correlationID).
 		Str("deployment_id", req.DeploymentId).
 		Str("project_id", req.ProjectId).
+		Str("environment", req.Environment).
 		Msg("Get deployment status request")
 
 	// Nếu không có deployment_id và project_id, trả về luôn, tránh spam log
@@ -121,7 +127,7 @@ func (h *DeploymentHandler) GetDeploymentStatus(ctx context.Context, req *deploy
 		}, nil
 	}
 
-	deployment, err := h.executor.GetStatus(ctx, req.DeploymentId, req.ProjectId)
+	deployment, err := h.executor.GetStatus(ctx, req.DeploymentId, req.ProjectId, req.Environment)
 	if err != nil {
 		// Không spam cảnh báo nếu đơn giản là chưa có deployment
 		if strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -151,11 +157,15 @@ func (h *DeploymentHandler) GetDeploymentStatus(ctx context.Context, req *deploy
 	}
 
 	return &deploymentpb.GetDeploymentStatusResponse{
-		DeploymentId: deployment.ID,
-		ContainerId:  deployment.ContainerID,
-		Status:       deployment.Status,
-		PublicUrl:    deployment.PublicURL,
-		StartedAt:    timestamppb.New(deployment.StartedAt),
+		DeploymentId:    deployment.ID,
+		ContainerId:     deployment.ContainerID,
+		Status:          deployment.Status,
+		PublicUrl:       deployment.PublicURL,
+		StartedAt:       timestamppb.New(deployment.StartedAt),
+		Health:          deployment.Health,
+		RestartCount:    deployment.RestartCount,
+		Replicas:        deployment.Replicas,
+		RunningReplicas: deployment.RunningReplicas,
 	}, nil
 }
 
@@ -221,6 +231,306 @@ func (h *DeploymentHandler) GetRuntimeLogs(ctx context.Context, req *deploymentp
 	}, nil
 }
 
+// RollbackDeployment redeploys an earlier build of a project
+func (h *DeploymentHandler) RollbackDeployment(ctx context.Context, req *deploymentpb.RollbackDeploymentRequest) (*deploymentpb.RollbackDeploymentResponse, error) {
+	correlationID := logger.GetCorrelationID(ctx)
+	h.log.Info().
+		Str("correlation_id", correlationID).
+		Str("project_id", req.ProjectId).
+		Str("build_id", req.BuildId).
+		Msg("Rollback request received")
+
+	buildID := req.BuildId
+	if buildID == "" {
+		buildID = docker.RollbackPrevious
+	}
+
+	deployment, err := h.executor.Rollback(ctx, req.ProjectId, buildID, req.UserId, req.CustomDomains)
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("correlation_id", correlationID).
+			Str("project_id", req.ProjectId).
+			Str("build_id", buildID).
+			Msg("Rollback failed")
+
+		resp := &deploymentpb.RollbackDeploymentResponse{
+			Status: "failed",
+			Error:  err.Error(),
+		}
+		if deployment != nil {
+			resp.DeploymentId = deployment.ID
+			resp.BuildId = deployment.BuildID
+			resp.RollbackOf = deployment.RollbackOf
+		}
+		return resp, nil
+	}
+
+	h.log.Info().
+		Str("correlation_id", correlationID).
+		Str("deployment_id", deployment.ID).
+		Str("build_id", deployment.BuildID).
+		Str("rollback_of", deployment.RollbackOf).
+		Msg("Rollback successful")
+
+	return &deploymentpb.RollbackDeploymentResponse{
+		DeploymentId: deployment.ID,
+		ContainerId:  deployment.ContainerID,
+		Status:       statusToString(deployment.Status),
+		PublicUrl:    deployment.PublicURL,
+		BuildId:      deployment.BuildID,
+		RollbackOf:   deployment.RollbackOf,
+	}, nil
+}
+
+// PromoteDeployment deploys the image serving one environment of a project to
+// another, without a build
+func (h *DeploymentHandler) PromoteDeployment(ctx context.Context, req *deploymentpb.PromoteDeploymentRequest) (*deploymentpb.PromoteDeploymentResponse, error) {
+	correlationID := logger.GetCorrelationID(ctx)
+
+	if req.Spec == nil {
+		return &deploymentpb.PromoteDeploymentResponse{
+			Status: "failed",
+			Error:  "deployment spec is required",
+		}, nil
+	}
+
+	h.log.Info().
+		Str("correlation_id", correlationID).
+		Str("project_id", req.Spec.ProjectId).
+		Str("from_environment", req.FromEnvironment).
+		Str("environment", req.Spec.Environment).
+		Msg("Promote request received")
+
+	deployment, err := h.executor.Promote(ctx, req.FromEnvironment, req.Spec)
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("correlation_id", correlationID).
+			Str("project_id", req.Spec.ProjectId).
+			Str("environment", req.Spec.Environment).
+			Msg("Promotion failed")
+
+		resp := &deploymentpb.PromoteDeploymentResponse{
+			Status: "failed",
+			Error:  err.Error(),
+		}
+		if deployment != nil {
+			resp.DeploymentId = deployment.ID
+			resp.BuildId = deployment.BuildID
+			resp.ImageTag = deployment.ImageTag
+			resp.PromotedFrom = deployment.PromotedFrom
+		}
+		return resp, nil
+	}
+
+	h.log.Info().
+		Str("correlation_id", correlationID).
+		Str("deployment_id", deployment.ID).
+		Str("build_id", deployment.BuildID).
+		Str("promoted_from", deployment.PromotedFrom).
+		Msg("Promotion successful")
+
+	return &deploymentpb.PromoteDeploymentResponse{
+		DeploymentId: deployment.ID,
+		ContainerId:  deployment.ContainerID,
+		Status:       statusToString(deployment.Status),
+		PublicUrl:    deployment.PublicURL,
+		BuildId:      deployment.BuildID,
+		ImageTag:     deployment.ImageTag,
+		PromotedFrom: deployment.PromotedFrom,
+	}, nil
+}
+
+// ScaleDeployment changes the number of containers of a running deployment
+func (h *DeploymentHandler) ScaleDeployment(ctx context.Context, req *deploymentpb.ScaleDeploymentRequest) (*deploymentpb.ScaleDeploymentResponse, error) {
+	correlationID := logger.GetCorrelationID(ctx)
+	h.log.Info().
+		Str("correlation_id", correlationID).
+		Str("deployment_id", req.DeploymentId).
+		Str("project_id", req.ProjectId).
+		Int32("replicas", req.Replicas).
+		Msg("Scale request received")
+
+	deployment, err := h.executor.Scale(ctx, req.DeploymentId, req.ProjectId, req.Replicas)
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("correlation_id", correlationID).
+			Str("project_id", req.ProjectId).
+			Msg("Scale failed")
+
+		return &deploymentpb.ScaleDeploymentResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &deploymentpb.ScaleDeploymentResponse{
+		DeploymentId:    deployment.ID,
+		Replicas:        deployment.Replicas,
+		RunningReplicas: deployment.RunningReplicas,
+	}, nil
+}
+
+// ListPreviews returns the running previews of a project, the newest
+// deployment of each pull request
+func (h *DeploymentHandler) ListPreviews(ctx context.Context, req *deploymentpb.ListPreviewsRequest) (*deploymentpb.ListPreviewsResponse, error) {
+	correlationID := logger.GetCorrelationID(ctx)
+	h.log.Debug().
+		Str("correlation_id", correlationID).
+		Str("project_id", req.ProjectId).
+		Msg("List previews request")
+
+	deployments, err := h.store.Previews(ctx, req.ProjectId)
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("correlation_id", correlationID).
+			Str("project_id", req.ProjectId).
+			Msg("List previews failed")
+
+		return &deploymentpb.ListPreviewsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	// A pull request has two deployments during the cutover of a new commit
+	seen := make(map[int]bool)
+	previews := make([]*deploymentpb.Preview, 0, len(deployments))
+	for _, d := range deployments {
+		if seen[d.PullRequest] {
+			continue
+		}
+		seen[d.PullRequest] = true
+
+		previews = append(previews, &deploymentpb.Preview{
+			PullRequest:  int32(d.PullRequest),
+			DeploymentId: d.ID.String(),
+			BuildId:      d.BuildID,
+			Status:       docker.StatusFromModel(d.Status),
+			PublicUrl:    d.PublicURL,
+			CreatedAt:    timestamppb.New(d.CreatedAt),
+		})
+	}
+
+	return &deploymentpb.ListPreviewsResponse{
+		Previews: previews,
+	}, nil
+}
+
+// StopPreview stops the preview of a closed pull request
+func (h *DeploymentHandler) StopPreview(ctx context.Context, req *deploymentpb.StopPreviewRequest) (*deploymentpb.StopPreviewResponse, error) {
+	correlationID := logger.GetCorrelationID(ctx)
+	h.log.Info().
+		Str("correlation_id", correlationID).
+		Str("project_id", req.ProjectId).
+		Int32("pull_request", req.PullRequest).
+		Msg("Stop preview request received")
+
+	stopped, err := h.executor.StopPreview(ctx, req.ProjectId, req.PullRequest)
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("correlation_id", correlationID).
+			Str("project_id", req.ProjectId).
+			Int32("pull_request", req.PullRequest).
+			Msg("Stop preview failed")
+
+		return &deploymentpb.StopPreviewResponse{
+			Stopped: int32(stopped),
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &deploymentpb.StopPreviewResponse{
+		Success: true,
+		Stopped: int32(stopped),
+	}, nil
+}
+
+// ListDeployments returns the deployment history of a project, newest first
+func (h *DeploymentHandler) ListDeployments(ctx context.Context, req *deploymentpb.ListDeploymentsRequest) (*deploymentpb.ListDeploymentsResponse, error) {
+	correlationID := logger.GetCorrelationID(ctx)
+	h.log.Debug().
+		Str("correlation_id", correlationID).
+		Str("project_id", req.ProjectId).
+		Msg("List deployments request")
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	deployments, total, err := h.store.List(ctx, req.ProjectId, page, pageSize)
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("correlation_id", correlationID).
+			Str("project_id", req.ProjectId).
+			Msg("List deployments failed")
+
+		return &deploymentpb.ListDeploymentsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	records := make([]*deploymentpb.DeploymentRecord, 0, len(deployments))
+	for i := range deployments {
+		records = append(records, deploymentToRecord(&deployments[i]))
+	}
+
+	return &deploymentpb.ListDeploymentsResponse{
+		Deployments: records,
+		Total:       int32(total),
+	}, nil
+}
+
+// deploymentToRecord converts a stored deployment and its history to proto
+func deploymentToRecord(d *models.Deployment) *deploymentpb.DeploymentRecord {
+	record := &deploymentpb.DeploymentRecord{
+		DeploymentId: d.ID.String(),
+		ProjectId:    d.ProjectID.String(),
+		BuildId:      d.BuildID,
+		ImageTag:     d.ImageTag,
+		Status:       docker.StatusFromModel(d.Status),
+		ContainerId:  d.ContainerID,
+		HostPort:     int32(d.HostPort),
+		Domain:       d.Domain,
+		PublicUrl:    d.PublicURL,
+		Error:        d.Error,
+		StartedAt:    timestamppb.New(d.StartedAt),
+		CreatedAt:    timestamppb.New(d.CreatedAt),
+		UpdatedAt:    timestamppb.New(d.UpdatedAt),
+		Health:       d.Health,
+		RestartCount: int32(d.RestartCount),
+		Replicas:     int32(d.Replicas),
+		PullRequest:  int32(d.PullRequest),
+		Environment:  d.Environment,
+	}
+	if d.StoppedAt != nil {
+		record.StoppedAt = timestamppb.New(*d.StoppedAt)
+	}
+	if d.RollbackOf != nil {
+		record.RollbackOf = d.RollbackOf.String()
+	}
+	if d.PromotedFrom != nil {
+		record.PromotedFrom = d.PromotedFrom.String()
+	}
+
+	for _, event := range d.Events {
+		record.History = append(record.History, &deploymentpb.DeploymentEvent{
+			Status:    docker.StatusFromModel(event.Status),
+			Message:   event.Message,
+			CreatedAt: timestamppb.New(event.CreatedAt),
+		})
+	}
+	return record
+}
+
 // Helper function to convert status enum to string
 func statusToString(status deploymentpb.DeploymentStatus) string {
 	switch status {
@@ -13,13 +13,17 @@ import (
 	cfgpkg "github.com/nexusdeploy/backend/pkg/config"
 	"github.com/nexusdeploy/backend/pkg/logger"
 	"github.com/nexusdeploy/backend/services/deployment-service/docker"
+	"github.com/nexusdeploy/backend/services/deployment-service/events"
 	"github.com/nexusdeploy/backend/services/deployment-service/handlers"
 	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	"github.com/nexusdeploy/backend/services/deployment-service/store"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 const (
@@ -49,9 +53,26 @@ func main() {
 
 	log.Info().Msg("Starting Deployment Service...")
 
+	// Connect to database
+	db, err := connectDB()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+	log.Info().Msg("Connected to PostgreSQL")
+
+	deploymentStore := store.New(db)
+	if err := deploymentStore.Migrate(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to auto-migrate models")
+	}
+	log.Info().Msg("Database migration completed")
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Health transitions are published to Redis for Notification Service
+	publisher := events.NewPublisher(cfg.GetRedisAddr())
+	defer publisher.Close()
+
 	// Initialize Docker executor
 	portStart := getEnvAsInt("DEPLOY_PORT_RANGE_START", 12000)
 	portEnd := getEnvAsInt("DEPLOY_PORT_RANGE_END", 12999)
@@ -61,16 +82,20 @@ func main() {
 		TraefikDomainSuffix: getEnv("TRAEFIK_DOMAIN_SUFFIX", "localhost"),
 		PortRangeStart:      int32(portStart),
 		PortRangeEnd:        int32(portEnd),
-	}, log)
+		HealthCheckTimeout:  time.Duration(getEnvAsInt("DEPLOY_HEALTH_CHECK_TIMEOUT", 60)) * time.Second,
+		DrainTimeout:        time.Duration(getEnvAsInt("DEPLOY_DRAIN_TIMEOUT", 30)) * time.Second,
+		EncryptionKey:       cfg.EncryptionKey,
+	}, deploymentStore, publisher, log)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize Docker executor")
 	}
 	defer dockerExec.Close()
 
 	// Create deployment handler
-	deploymentHandler := handlers.NewDeploymentHandler(dockerExec, log)
+	deploymentHandler := handlers.NewDeploymentHandler(dockerExec, deploymentStore, log)
 
-	// Start servers
+	// Start servers and the health monitor
+	go dockerExec.MonitorHealth(ctx)
 	go startGRPCServer(ctx, deploymentHandler)
 	go startHTTPServer(ctx, dockerExec)
 
@@ -84,6 +109,11 @@ func main() {
 	time.Sleep(2 * time.Second)
 }
 
+func connectDB() (*gorm.DB, error) {
+	dsn := cfg.GetDSN()
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+}
+
 func startGRPCServer(ctx context.Context, handler *handlers.DeploymentHandler) {
 	lis, err := net.Listen("tcp", grpcPort)
 	if err != nil {
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeploymentStatus represents the state of a deployment, matching the names of
+// the DeploymentStatus proto enum
+type DeploymentStatus string
+
+const (
+	DeploymentStatusPending    DeploymentStatus = "pending"
+	DeploymentStatusRunning    DeploymentStatus = "running"
+	DeploymentStatusStopped    DeploymentStatus = "stopped"
+	DeploymentStatusFailed     DeploymentStatus = "failed"
+	DeploymentStatusRestarting DeploymentStatus = "restarting"
+)
+
+// Health of a deployment container, named like Docker's health states
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// IsActive reports whether a deployment in this state owns a container
+func (s DeploymentStatus) IsActive() bool {
+	switch s {
+	case DeploymentStatusPending, DeploymentStatusRunning, DeploymentStatusRestarting:
+		return true
+	default:
+		return false
+	}
+}
+
+// Deployment is a container deployed from a build image. Rows are kept after the
+// container is stopped or removed, as the deployment history of the project.
+type Deployment struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID        `gorm:"type:uuid;not null;index:idx_deployments_project_created,priority:1"`
+	BuildID     string           `gorm:"type:varchar(64);index"` // Empty for deployments recovered from containers
+	ImageTag    string           `gorm:"type:varchar(255);not null"`
+	Status      DeploymentStatus `gorm:"type:varchar(50);not null;default:pending;index"`
+	ContainerID string           `gorm:"type:varchar(128)"` // Cleared once the container is removed
+	HostPort    int              `gorm:"not null;default:0"`
+	Domain      string           `gorm:"type:varchar(255)"`
+	PublicURL   string           `gorm:"type:varchar(255)"`
+	Error       string           `gorm:"type:text"`
+	RollbackOf  *uuid.UUID       `gorm:"type:uuid"` // Deployment whose spec this rollback redeployed
+	StartedAt   time.Time        `gorm:"type:timestamptz;not null"`
+	StoppedAt   *time.Time       `gorm:"type:timestamptz"`
+	CreatedAt   time.Time        `gorm:"not null;default:now();index:idx_deployments_project_created,priority:2,sort:desc"`
+	UpdatedAt   time.Time        `gorm:"not null;default:now()"`
+
+	// Encrypted DeploymentSpec, secrets included, so the build can be redeployed
+	// as it was
+	SpecSnapshot string `gorm:"type:text"`
+
+	// Container port and health, kept up to date by the health monitor
+	Port         int         `gorm:"not null;default:0"`
+	HealthCheck  HealthCheck `gorm:"embedded;embeddedPrefix:health_check_"`
+	Health       string      `gorm:"type:varchar(20);not null;default:''"`
+	RestartCount int         `gorm:"not null;default:0"`
+
+	Replicas int `gorm:"not null;default:1"` // Desired number of containers
+
+	PullRequest int `gorm:"not null;default:0;index"` // Pull request of a preview, 0 for production
+
+	// Environment of the project, "" for production, and the deployment of
+	// another environment whose image a promotion deployed
+	Environment  string     `gorm:"type:varchar(32);not null;default:'';index"`
+	PromotedFrom *uuid.UUID `gorm:"type:uuid"`
+
+	// Associations
+	Events []DeploymentEvent `gorm:"foreignKey:DeploymentID;constraint:OnDelete:CASCADE"`
+}
+
+// HealthCheck is the health check of a deployment, 0 values use the defaults
+type HealthCheck struct {
+	Type            string `gorm:"type:varchar(10);not null;default:''"`
+	Path            string `gorm:"type:varchar(255);not null;default:''"`
+	ExpectedStatus  int    `gorm:"not null;default:0"`
+	IntervalSeconds int    `gorm:"not null;default:0"`
+	TimeoutSeconds  int    `gorm:"not null;default:0"`
+	Retries         int    `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for Deployment
+func (Deployment) TableName() string {
+	return "deployments"
+}
+
+// DeploymentEvent records a status change of a deployment
+type DeploymentEvent struct {
+	ID           int64            `gorm:"primaryKey;autoIncrement"`
+	DeploymentID uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Status       DeploymentStatus `gorm:"type:varchar(50);not null"`
+	Message      string           `gorm:"type:text"`
+	CreatedAt    time.Time        `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for DeploymentEvent
+func (DeploymentEvent) TableName() string {
+	return "deployment_events"
+}
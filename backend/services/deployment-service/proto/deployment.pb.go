@@ -91,6 +91,11 @@ type DeploymentSpec struct {
 	Secrets       map[string]string      `protobuf:"bytes,7,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                // Decrypted secrets
 	Resources     *ResourceLimits        `protobuf:"bytes,8,opt,name=resources,proto3" json:"resources,omitempty"`
 	UserId        string                 `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	HealthCheck   *HealthCheck           `protobuf:"bytes,10,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`       // Probe the new container must pass before the cutover
+	Replicas      int32                  `protobuf:"varint,11,opt,name=replicas,proto3" json:"replicas,omitempty"`                               // Containers behind the Traefik service, 0 = 1
+	CustomDomains []string               `protobuf:"bytes,12,rep,name=custom_domains,json=customDomains,proto3" json:"custom_domains,omitempty"` // Verified custom domains, routed next to domain with a certificate each
+	PullRequest   int32                  `protobuf:"varint,13,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`      // Pull request of a preview deployment, 0 for production
+	Environment   string                 `protobuf:"bytes,14,opt,name=environment,proto3" json:"environment,omitempty"`                          // Environment of the project, empty for production
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -188,6 +193,125 @@ func (x *DeploymentSpec) GetUserId() string {
 	return ""
 }
 
+func (x *DeploymentSpec) GetHealthCheck() *HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+func (x *DeploymentSpec) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *DeploymentSpec) GetCustomDomains() []string {
+	if x != nil {
+		return x.CustomDomains
+	}
+	return nil
+}
+
+func (x *DeploymentSpec) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
+	}
+	return 0
+}
+
+func (x *DeploymentSpec) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type HealthCheck struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                               // "tcp" (default) or "http"
+	Path            string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                               // HTTP path, default "/"
+	TimeoutSeconds  int32                  `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`    // Timeout of one probe, 0 = 3
+	ExpectedStatus  int32                  `protobuf:"varint,4,opt,name=expected_status,json=expectedStatus,proto3" json:"expected_status,omitempty"`    // HTTP status, 0 = any 2xx or 3xx
+	IntervalSeconds int32                  `protobuf:"varint,5,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // Time between probes, 0 = 10
+	Retries         int32                  `protobuf:"varint,6,opt,name=retries,proto3" json:"retries,omitempty"`                                        // Failed probes in a row before unhealthy, 0 = 3
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	mi := &file_deployment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HealthCheck) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetExpectedStatus() int32 {
+	if x != nil {
+		return x.ExpectedStatus
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
 type ResourceLimits struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MemoryMb      int64                  `protobuf:"varint,1,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"` // Memory limit in MB
@@ -198,7 +322,7 @@ type ResourceLimits struct {
 
 func (x *ResourceLimits) Reset() {
 	*x = ResourceLimits{}
-	mi := &file_deployment_proto_msgTypes[1]
+	mi := &file_deployment_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +334,7 @@ func (x *ResourceLimits) String() string {
 func (*ResourceLimits) ProtoMessage() {}
 
 func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[1]
+	mi := &file_deployment_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,7 +347,7 @@ func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
 func (*ResourceLimits) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{1}
+	return file_deployment_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ResourceLimits) GetMemoryMb() int64 {
@@ -249,7 +373,7 @@ type DeployRequest struct {
 
 func (x *DeployRequest) Reset() {
 	*x = DeployRequest{}
-	mi := &file_deployment_proto_msgTypes[2]
+	mi := &file_deployment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -261,7 +385,7 @@ func (x *DeployRequest) String() string {
 func (*DeployRequest) ProtoMessage() {}
 
 func (x *DeployRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[2]
+	mi := &file_deployment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -274,7 +398,7 @@ func (x *DeployRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeployRequest.ProtoReflect.Descriptor instead.
 func (*DeployRequest) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{2}
+	return file_deployment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DeployRequest) GetSpec() *DeploymentSpec {
@@ -297,7 +421,7 @@ type DeployResponse struct {
 
 func (x *DeployResponse) Reset() {
 	*x = DeployResponse{}
-	mi := &file_deployment_proto_msgTypes[3]
+	mi := &file_deployment_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -309,7 +433,7 @@ func (x *DeployResponse) String() string {
 func (*DeployResponse) ProtoMessage() {}
 
 func (x *DeployResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[3]
+	mi := &file_deployment_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,7 +446,7 @@ func (x *DeployResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeployResponse.ProtoReflect.Descriptor instead.
 func (*DeployResponse) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{3}
+	return file_deployment_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DeployResponse) GetDeploymentId() string {
@@ -364,13 +488,14 @@ type StopDeploymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
 	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Environment   string                 `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"` // Without deployment_id: stop the deployment of this environment
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StopDeploymentRequest) Reset() {
 	*x = StopDeploymentRequest{}
-	mi := &file_deployment_proto_msgTypes[4]
+	mi := &file_deployment_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -382,7 +507,7 @@ func (x *StopDeploymentRequest) String() string {
 func (*StopDeploymentRequest) ProtoMessage() {}
 
 func (x *StopDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[4]
+	mi := &file_deployment_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,7 +520,7 @@ func (x *StopDeploymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopDeploymentRequest.ProtoReflect.Descriptor instead.
 func (*StopDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{4}
+	return file_deployment_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *StopDeploymentRequest) GetDeploymentId() string {
@@ -412,6 +537,13 @@ func (x *StopDeploymentRequest) GetProjectId() string {
 	return ""
 }
 
+func (x *StopDeploymentRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
 type StopDeploymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -422,7 +554,7 @@ type StopDeploymentResponse struct {
 
 func (x *StopDeploymentResponse) Reset() {
 	*x = StopDeploymentResponse{}
-	mi := &file_deployment_proto_msgTypes[5]
+	mi := &file_deployment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -434,7 +566,7 @@ func (x *StopDeploymentResponse) String() string {
 func (*StopDeploymentResponse) ProtoMessage() {}
 
 func (x *StopDeploymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[5]
+	mi := &file_deployment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,7 +579,7 @@ func (x *StopDeploymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopDeploymentResponse.ProtoReflect.Descriptor instead.
 func (*StopDeploymentResponse) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{5}
+	return file_deployment_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *StopDeploymentResponse) GetSuccess() bool {
@@ -468,13 +600,14 @@ type GetDeploymentStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
 	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Environment   string                 `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"` // Without deployment_id: status of the deployment of this environment
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetDeploymentStatusRequest) Reset() {
 	*x = GetDeploymentStatusRequest{}
-	mi := &file_deployment_proto_msgTypes[6]
+	mi := &file_deployment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +619,7 @@ func (x *GetDeploymentStatusRequest) String() string {
 func (*GetDeploymentStatusRequest) ProtoMessage() {}
 
 func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[6]
+	mi := &file_deployment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +632,7 @@ func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{6}
+	return file_deployment_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetDeploymentStatusRequest) GetDeploymentId() string {
@@ -516,21 +649,32 @@ func (x *GetDeploymentStatusRequest) GetProjectId() string {
 	return ""
 }
 
+func (x *GetDeploymentStatusRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
 type GetDeploymentStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	Status        DeploymentStatus       `protobuf:"varint,3,opt,name=status,proto3,enum=deployment.DeploymentStatus" json:"status,omitempty"`
-	PublicUrl     string                 `protobuf:"bytes,4,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId    string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ContainerId     string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Status          DeploymentStatus       `protobuf:"varint,3,opt,name=status,proto3,enum=deployment.DeploymentStatus" json:"status,omitempty"`
+	PublicUrl       string                 `protobuf:"bytes,4,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Health          string                 `protobuf:"bytes,7,opt,name=health,proto3" json:"health,omitempty"`                                  // "starting", "healthy" or "unhealthy"
+	RestartCount    int32                  `protobuf:"varint,8,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"` // Restarts of the container by Docker
+	Replicas        int32                  `protobuf:"varint,9,opt,name=replicas,proto3" json:"replicas,omitempty"`                             // Desired number of containers
+	RunningReplicas int32                  `protobuf:"varint,10,opt,name=running_replicas,json=runningReplicas,proto3" json:"running_replicas,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetDeploymentStatusResponse) Reset() {
 	*x = GetDeploymentStatusResponse{}
-	mi := &file_deployment_proto_msgTypes[7]
+	mi := &file_deployment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -542,7 +686,7 @@ func (x *GetDeploymentStatusResponse) String() string {
 func (*GetDeploymentStatusResponse) ProtoMessage() {}
 
 func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[7]
+	mi := &file_deployment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -555,7 +699,7 @@ func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{7}
+	return file_deployment_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetDeploymentStatusResponse) GetDeploymentId() string {
@@ -600,6 +744,34 @@ func (x *GetDeploymentStatusResponse) GetError() string {
 	return ""
 }
 
+func (x *GetDeploymentStatusResponse) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *GetDeploymentStatusResponse) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *GetDeploymentStatusResponse) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *GetDeploymentStatusResponse) GetRunningReplicas() int32 {
+	if x != nil {
+		return x.RunningReplicas
+	}
+	return 0
+}
+
 type RestartDeploymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
@@ -610,7 +782,7 @@ type RestartDeploymentRequest struct {
 
 func (x *RestartDeploymentRequest) Reset() {
 	*x = RestartDeploymentRequest{}
-	mi := &file_deployment_proto_msgTypes[8]
+	mi := &file_deployment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -622,7 +794,7 @@ func (x *RestartDeploymentRequest) String() string {
 func (*RestartDeploymentRequest) ProtoMessage() {}
 
 func (x *RestartDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[8]
+	mi := &file_deployment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,7 +807,7 @@ func (x *RestartDeploymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestartDeploymentRequest.ProtoReflect.Descriptor instead.
 func (*RestartDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{8}
+	return file_deployment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RestartDeploymentRequest) GetDeploymentId() string {
@@ -663,7 +835,7 @@ type RestartDeploymentResponse struct {
 
 func (x *RestartDeploymentResponse) Reset() {
 	*x = RestartDeploymentResponse{}
-	mi := &file_deployment_proto_msgTypes[9]
+	mi := &file_deployment_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -675,7 +847,7 @@ func (x *RestartDeploymentResponse) String() string {
 func (*RestartDeploymentResponse) ProtoMessage() {}
 
 func (x *RestartDeploymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[9]
+	mi := &file_deployment_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +860,7 @@ func (x *RestartDeploymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestartDeploymentResponse.ProtoReflect.Descriptor instead.
 func (*RestartDeploymentResponse) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{9}
+	return file_deployment_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RestartDeploymentResponse) GetSuccess() bool {
@@ -724,7 +896,7 @@ type GetRuntimeLogsRequest struct {
 
 func (x *GetRuntimeLogsRequest) Reset() {
 	*x = GetRuntimeLogsRequest{}
-	mi := &file_deployment_proto_msgTypes[10]
+	mi := &file_deployment_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -736,7 +908,7 @@ func (x *GetRuntimeLogsRequest) String() string {
 func (*GetRuntimeLogsRequest) ProtoMessage() {}
 
 func (x *GetRuntimeLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[10]
+	mi := &file_deployment_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -749,7 +921,7 @@ func (x *GetRuntimeLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRuntimeLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetRuntimeLogsRequest) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{10}
+	return file_deployment_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetRuntimeLogsRequest) GetDeploymentId() string {
@@ -790,7 +962,7 @@ type GetRuntimeLogsResponse struct {
 
 func (x *GetRuntimeLogsResponse) Reset() {
 	*x = GetRuntimeLogsResponse{}
-	mi := &file_deployment_proto_msgTypes[11]
+	mi := &file_deployment_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -802,7 +974,7 @@ func (x *GetRuntimeLogsResponse) String() string {
 func (*GetRuntimeLogsResponse) ProtoMessage() {}
 
 func (x *GetRuntimeLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_deployment_proto_msgTypes[11]
+	mi := &file_deployment_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +987,7 @@ func (x *GetRuntimeLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRuntimeLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetRuntimeLogsResponse) Descriptor() ([]byte, []int) {
-	return file_deployment_proto_rawDescGZIP(), []int{11}
+	return file_deployment_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetRuntimeLogsResponse) GetLogLines() []string {
@@ -832,92 +1004,1352 @@ func (x *GetRuntimeLogsResponse) GetError() string {
 	return ""
 }
 
-var File_deployment_proto protoreflect.FileDescriptor
+type DeploymentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        DeploymentStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=deployment.DeploymentStatus" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_deployment_proto_rawDesc = "" +
-	"\n" +
-	"\x10deployment.proto\x12\n" +
-	"deployment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe5\x03\n" +
-	"\x0eDeploymentSpec\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x19\n" +
-	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12\x1b\n" +
-	"\timage_tag\x18\x03 \x01(\tR\bimageTag\x12\x12\n" +
-	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x16\n" +
-	"\x06domain\x18\x05 \x01(\tR\x06domain\x12B\n" +
-	"\benv_vars\x18\x06 \x03(\v2'.deployment.DeploymentSpec.EnvVarsEntryR\aenvVars\x12A\n" +
-	"\asecrets\x18\a \x03(\v2'.deployment.DeploymentSpec.SecretsEntryR\asecrets\x128\n" +
-	"\tresources\x18\b \x01(\v2\x1a.deployment.ResourceLimitsR\tresources\x12\x17\n" +
-	"\auser_id\x18\t \x01(\tR\x06userId\x1a:\n" +
-	"\fEnvVarsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
-	"\fSecretsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"J\n" +
-	"\x0eResourceLimits\x12\x1b\n" +
-	"\tmemory_mb\x18\x01 \x01(\x03R\bmemoryMb\x12\x1b\n" +
-	"\tcpu_cores\x18\x02 \x01(\x05R\bcpuCores\"?\n" +
-	"\rDeployRequest\x12.\n" +
-	"\x04spec\x18\x01 \x01(\v2\x1a.deployment.DeploymentSpecR\x04spec\"\xa5\x01\n" +
-	"\x0eDeployResponse\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
-	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"public_url\x18\x04 \x01(\tR\tpublicUrl\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"[\n" +
-	"\x15StopDeploymentRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\tR\tprojectId\"H\n" +
-	"\x16StopDeploymentResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"`\n" +
-	"\x1aGetDeploymentStatusRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\tR\tprojectId\"\x8b\x02\n" +
-	"\x1bGetDeploymentStatusResponse\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
-	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x124\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x1c.deployment.DeploymentStatusR\x06status\x12\x1d\n" +
-	"\n" +
-	"public_url\x18\x04 \x01(\tR\tpublicUrl\x129\n" +
-	"\n" +
-	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x14\n" +
-	"\x05error\x18\x06 \x01(\tR\x05error\"^\n" +
-	"\x18RestartDeploymentRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\tR\tprojectId\"n\n" +
-	"\x19RestartDeploymentResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
-	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\x92\x01\n" +
-	"\x15GetRuntimeLogsRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x1d\n" +
-	"\n" +
-	"tail_lines\x18\x03 \x01(\x05R\ttailLines\x12\x16\n" +
-	"\x06follow\x18\x04 \x01(\bR\x06follow\"K\n" +
-	"\x16GetRuntimeLogsResponse\x12\x1b\n" +
-	"\tlog_lines\x18\x01 \x03(\tR\blogLines\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error*\xd2\x01\n" +
+func (x *DeploymentEvent) Reset() {
+	*x = DeploymentEvent{}
+	mi := &file_deployment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentEvent) ProtoMessage() {}
+
+func (x *DeploymentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentEvent.ProtoReflect.Descriptor instead.
+func (*DeploymentEvent) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeploymentEvent) GetStatus() DeploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeploymentStatus_DEPLOYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *DeploymentEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeploymentEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type DeploymentRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	BuildId       string                 `protobuf:"bytes,3,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	ImageTag      string                 `protobuf:"bytes,4,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"`
+	Status        DeploymentStatus       `protobuf:"varint,5,opt,name=status,proto3,enum=deployment.DeploymentStatus" json:"status,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,6,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	HostPort      int32                  `protobuf:"varint,7,opt,name=host_port,json=hostPort,proto3" json:"host_port,omitempty"`
+	Domain        string                 `protobuf:"bytes,8,opt,name=domain,proto3" json:"domain,omitempty"`
+	PublicUrl     string                 `protobuf:"bytes,9,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`
+	Error         string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	StoppedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=stopped_at,json=stoppedAt,proto3" json:"stopped_at,omitempty"` // Unset while the container exists
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	History       []*DeploymentEvent     `protobuf:"bytes,15,rep,name=history,proto3" json:"history,omitempty"`                         // Status changes, oldest first
+	RollbackOf    string                 `protobuf:"bytes,16,opt,name=rollback_of,json=rollbackOf,proto3" json:"rollback_of,omitempty"` // Set for rollbacks: deployment that was redeployed
+	Health        string                 `protobuf:"bytes,17,opt,name=health,proto3" json:"health,omitempty"`
+	RestartCount  int32                  `protobuf:"varint,18,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	Replicas      int32                  `protobuf:"varint,19,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	PullRequest   int32                  `protobuf:"varint,20,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`   // Set for previews
+	Environment   string                 `protobuf:"bytes,21,opt,name=environment,proto3" json:"environment,omitempty"`                       // Empty for production
+	PromotedFrom  string                 `protobuf:"bytes,22,opt,name=promoted_from,json=promotedFrom,proto3" json:"promoted_from,omitempty"` // Set for promotions: deployment whose image was deployed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentRecord) Reset() {
+	*x = DeploymentRecord{}
+	mi := &file_deployment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentRecord) ProtoMessage() {}
+
+func (x *DeploymentRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentRecord.ProtoReflect.Descriptor instead.
+func (*DeploymentRecord) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeploymentRecord) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetImageTag() string {
+	if x != nil {
+		return x.ImageTag
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetStatus() DeploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeploymentStatus_DEPLOYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *DeploymentRecord) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetHostPort() int32 {
+	if x != nil {
+		return x.HostPort
+	}
+	return 0
+}
+
+func (x *DeploymentRecord) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetPublicUrl() string {
+	if x != nil {
+		return x.PublicUrl
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *DeploymentRecord) GetStoppedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return nil
+}
+
+func (x *DeploymentRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeploymentRecord) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *DeploymentRecord) GetHistory() []*DeploymentEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *DeploymentRecord) GetRollbackOf() string {
+	if x != nil {
+		return x.RollbackOf
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *DeploymentRecord) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *DeploymentRecord) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
+	}
+	return 0
+}
+
+func (x *DeploymentRecord) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *DeploymentRecord) GetPromotedFrom() string {
+	if x != nil {
+		return x.PromotedFrom
+	}
+	return ""
+}
+
+type ListDeploymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeploymentsRequest) Reset() {
+	*x = ListDeploymentsRequest{}
+	mi := &file_deployment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeploymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentsRequest) ProtoMessage() {}
+
+func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeploymentsRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListDeploymentsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListDeploymentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeploymentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListDeploymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployments   []*DeploymentRecord    `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"` // Newest first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeploymentsResponse) Reset() {
+	*x = ListDeploymentsResponse{}
+	mi := &file_deployment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeploymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentsResponse) ProtoMessage() {}
+
+func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeploymentsResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListDeploymentsResponse) GetDeployments() []*DeploymentRecord {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+func (x *ListDeploymentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDeploymentsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RollbackDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	BuildId       string                 `protobuf:"bytes,2,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"` // Build to redeploy, or "previous" for the build before the serving one
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CustomDomains []string               `protobuf:"bytes,4,rep,name=custom_domains,json=customDomains,proto3" json:"custom_domains,omitempty"` // Current custom domains of the project, replacing those of the build
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackDeploymentRequest) Reset() {
+	*x = RollbackDeploymentRequest{}
+	mi := &file_deployment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackDeploymentRequest) ProtoMessage() {}
+
+func (x *RollbackDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*RollbackDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RollbackDeploymentRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentRequest) GetCustomDomains() []string {
+	if x != nil {
+		return x.CustomDomains
+	}
+	return nil
+}
+
+type RollbackDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	PublicUrl     string                 `protobuf:"bytes,4,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`
+	BuildId       string                 `protobuf:"bytes,5,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	RollbackOf    string                 `protobuf:"bytes,6,opt,name=rollback_of,json=rollbackOf,proto3" json:"rollback_of,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackDeploymentResponse) Reset() {
+	*x = RollbackDeploymentResponse{}
+	mi := &file_deployment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackDeploymentResponse) ProtoMessage() {}
+
+func (x *RollbackDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*RollbackDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RollbackDeploymentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentResponse) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentResponse) GetPublicUrl() string {
+	if x != nil {
+		return x.PublicUrl
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentResponse) GetRollbackOf() string {
+	if x != nil {
+		return x.RollbackOf
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ScaleDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // Empty for the serving deployment of the project
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Replicas      int32                  `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"` // Already capped by the plan of the project owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScaleDeploymentRequest) Reset() {
+	*x = ScaleDeploymentRequest{}
+	mi := &file_deployment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScaleDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleDeploymentRequest) ProtoMessage() {}
+
+func (x *ScaleDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*ScaleDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ScaleDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ScaleDeploymentRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ScaleDeploymentRequest) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+type ScaleDeploymentResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId    string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Replicas        int32                  `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	RunningReplicas int32                  `protobuf:"varint,3,opt,name=running_replicas,json=runningReplicas,proto3" json:"running_replicas,omitempty"`
+	Error           string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScaleDeploymentResponse) Reset() {
+	*x = ScaleDeploymentResponse{}
+	mi := &file_deployment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScaleDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleDeploymentResponse) ProtoMessage() {}
+
+func (x *ScaleDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*ScaleDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScaleDeploymentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ScaleDeploymentResponse) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *ScaleDeploymentResponse) GetRunningReplicas() int32 {
+	if x != nil {
+		return x.RunningReplicas
+	}
+	return 0
+}
+
+func (x *ScaleDeploymentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Preview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   int32                  `protobuf:"varint,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	DeploymentId  string                 `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	BuildId       string                 `protobuf:"bytes,3,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Status        DeploymentStatus       `protobuf:"varint,4,opt,name=status,proto3,enum=deployment.DeploymentStatus" json:"status,omitempty"`
+	PublicUrl     string                 `protobuf:"bytes,5,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preview) Reset() {
+	*x = Preview{}
+	mi := &file_deployment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preview) ProtoMessage() {}
+
+func (x *Preview) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preview.ProtoReflect.Descriptor instead.
+func (*Preview) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Preview) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
+	}
+	return 0
+}
+
+func (x *Preview) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *Preview) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *Preview) GetStatus() DeploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeploymentStatus_DEPLOYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Preview) GetPublicUrl() string {
+	if x != nil {
+		return x.PublicUrl
+	}
+	return ""
+}
+
+func (x *Preview) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListPreviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPreviewsRequest) Reset() {
+	*x = ListPreviewsRequest{}
+	mi := &file_deployment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPreviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPreviewsRequest) ProtoMessage() {}
+
+func (x *ListPreviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPreviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListPreviewsRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPreviewsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListPreviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Previews      []*Preview             `protobuf:"bytes,1,rep,name=previews,proto3" json:"previews,omitempty"` // One per pull request, newest first
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPreviewsResponse) Reset() {
+	*x = ListPreviewsResponse{}
+	mi := &file_deployment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPreviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPreviewsResponse) ProtoMessage() {}
+
+func (x *ListPreviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPreviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListPreviewsResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListPreviewsResponse) GetPreviews() []*Preview {
+	if x != nil {
+		return x.Previews
+	}
+	return nil
+}
+
+func (x *ListPreviewsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StopPreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	PullRequest   int32                  `protobuf:"varint,2,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopPreviewRequest) Reset() {
+	*x = StopPreviewRequest{}
+	mi := &file_deployment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopPreviewRequest) ProtoMessage() {}
+
+func (x *StopPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopPreviewRequest.ProtoReflect.Descriptor instead.
+func (*StopPreviewRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StopPreviewRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *StopPreviewRequest) GetPullRequest() int32 {
+	if x != nil {
+		return x.PullRequest
+	}
+	return 0
+}
+
+type StopPreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Stopped       int32                  `protobuf:"varint,2,opt,name=stopped,proto3" json:"stopped,omitempty"` // Deployments stopped, 0 when the pull request had no preview
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopPreviewResponse) Reset() {
+	*x = StopPreviewResponse{}
+	mi := &file_deployment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopPreviewResponse) ProtoMessage() {}
+
+func (x *StopPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopPreviewResponse.ProtoReflect.Descriptor instead.
+func (*StopPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StopPreviewResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StopPreviewResponse) GetStopped() int32 {
+	if x != nil {
+		return x.Stopped
+	}
+	return 0
+}
+
+func (x *StopPreviewResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// PromoteDeploymentRequest deploys the image of the deployment serving
+// from_environment with spec, the configuration of the target environment.
+// The image tag and build of spec are replaced.
+type PromoteDeploymentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromEnvironment string                 `protobuf:"bytes,1,opt,name=from_environment,json=fromEnvironment,proto3" json:"from_environment,omitempty"` // Empty for production
+	Spec            *DeploymentSpec        `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PromoteDeploymentRequest) Reset() {
+	*x = PromoteDeploymentRequest{}
+	mi := &file_deployment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteDeploymentRequest) ProtoMessage() {}
+
+func (x *PromoteDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*PromoteDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PromoteDeploymentRequest) GetFromEnvironment() string {
+	if x != nil {
+		return x.FromEnvironment
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentRequest) GetSpec() *DeploymentSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type PromoteDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	PublicUrl     string                 `protobuf:"bytes,4,opt,name=public_url,json=publicUrl,proto3" json:"public_url,omitempty"`
+	BuildId       string                 `protobuf:"bytes,5,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	ImageTag      string                 `protobuf:"bytes,6,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"`
+	PromotedFrom  string                 `protobuf:"bytes,7,opt,name=promoted_from,json=promotedFrom,proto3" json:"promoted_from,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteDeploymentResponse) Reset() {
+	*x = PromoteDeploymentResponse{}
+	mi := &file_deployment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteDeploymentResponse) ProtoMessage() {}
+
+func (x *PromoteDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_deployment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*PromoteDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_deployment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PromoteDeploymentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetPublicUrl() string {
+	if x != nil {
+		return x.PublicUrl
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetImageTag() string {
+	if x != nil {
+		return x.ImageTag
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetPromotedFrom() string {
+	if x != nil {
+		return x.PromotedFrom
+	}
+	return ""
+}
+
+func (x *PromoteDeploymentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_deployment_proto protoreflect.FileDescriptor
+
+const file_deployment_proto_rawDesc = "" +
+	"\n" +
+	"\x10deployment.proto\x12\n" +
+	"deployment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x05\n" +
+	"\x0eDeploymentSpec\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x19\n" +
+	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12\x1b\n" +
+	"\timage_tag\x18\x03 \x01(\tR\bimageTag\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\x12B\n" +
+	"\benv_vars\x18\x06 \x03(\v2'.deployment.DeploymentSpec.EnvVarsEntryR\aenvVars\x12A\n" +
+	"\asecrets\x18\a \x03(\v2'.deployment.DeploymentSpec.SecretsEntryR\asecrets\x128\n" +
+	"\tresources\x18\b \x01(\v2\x1a.deployment.ResourceLimitsR\tresources\x12\x17\n" +
+	"\auser_id\x18\t \x01(\tR\x06userId\x12:\n" +
+	"\fhealth_check\x18\n" +
+	" \x01(\v2\x17.deployment.HealthCheckR\vhealthCheck\x12\x1a\n" +
+	"\breplicas\x18\v \x01(\x05R\breplicas\x12%\n" +
+	"\x0ecustom_domains\x18\f \x03(\tR\rcustomDomains\x12!\n" +
+	"\fpull_request\x18\r \x01(\x05R\vpullRequest\x12 \n" +
+	"\venvironment\x18\x0e \x01(\tR\venvironment\x1a:\n" +
+	"\fEnvVarsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcc\x01\n" +
+	"\vHealthCheck\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12'\n" +
+	"\x0ftimeout_seconds\x18\x03 \x01(\x05R\x0etimeoutSeconds\x12'\n" +
+	"\x0fexpected_status\x18\x04 \x01(\x05R\x0eexpectedStatus\x12)\n" +
+	"\x10interval_seconds\x18\x05 \x01(\x05R\x0fintervalSeconds\x12\x18\n" +
+	"\aretries\x18\x06 \x01(\x05R\aretries\"J\n" +
+	"\x0eResourceLimits\x12\x1b\n" +
+	"\tmemory_mb\x18\x01 \x01(\x03R\bmemoryMb\x12\x1b\n" +
+	"\tcpu_cores\x18\x02 \x01(\x05R\bcpuCores\"?\n" +
+	"\rDeployRequest\x12.\n" +
+	"\x04spec\x18\x01 \x01(\v2\x1a.deployment.DeploymentSpecR\x04spec\"\xa5\x01\n" +
+	"\x0eDeployResponse\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\x04 \x01(\tR\tpublicUrl\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"}\n" +
+	"\x15StopDeploymentRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironment\"H\n" +
+	"\x16StopDeploymentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x82\x01\n" +
+	"\x1aGetDeploymentStatusRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironment\"\x8f\x03\n" +
+	"\x1bGetDeploymentStatusResponse\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x124\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1c.deployment.DeploymentStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\x04 \x01(\tR\tpublicUrl\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x16\n" +
+	"\x06health\x18\a \x01(\tR\x06health\x12#\n" +
+	"\rrestart_count\x18\b \x01(\x05R\frestartCount\x12\x1a\n" +
+	"\breplicas\x18\t \x01(\x05R\breplicas\x12)\n" +
+	"\x10running_replicas\x18\n" +
+	" \x01(\x05R\x0frunningReplicas\"^\n" +
+	"\x18RestartDeploymentRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\"n\n" +
+	"\x19RestartDeploymentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x92\x01\n" +
+	"\x15GetRuntimeLogsRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x1d\n" +
+	"\n" +
+	"tail_lines\x18\x03 \x01(\x05R\ttailLines\x12\x16\n" +
+	"\x06follow\x18\x04 \x01(\bR\x06follow\"K\n" +
+	"\x16GetRuntimeLogsResponse\x12\x1b\n" +
+	"\tlog_lines\x18\x01 \x03(\tR\blogLines\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x9c\x01\n" +
+	"\x0fDeploymentEvent\x124\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1c.deployment.DeploymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd8\x06\n" +
+	"\x10DeploymentRecord\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x19\n" +
+	"\bbuild_id\x18\x03 \x01(\tR\abuildId\x12\x1b\n" +
+	"\timage_tag\x18\x04 \x01(\tR\bimageTag\x124\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1c.deployment.DeploymentStatusR\x06status\x12!\n" +
+	"\fcontainer_id\x18\x06 \x01(\tR\vcontainerId\x12\x1b\n" +
+	"\thost_port\x18\a \x01(\x05R\bhostPort\x12\x16\n" +
+	"\x06domain\x18\b \x01(\tR\x06domain\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\t \x01(\tR\tpublicUrl\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x129\n" +
+	"\n" +
+	"stopped_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tstoppedAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x125\n" +
+	"\ahistory\x18\x0f \x03(\v2\x1b.deployment.DeploymentEventR\ahistory\x12\x1f\n" +
+	"\vrollback_of\x18\x10 \x01(\tR\n" +
+	"rollbackOf\x12\x16\n" +
+	"\x06health\x18\x11 \x01(\tR\x06health\x12#\n" +
+	"\rrestart_count\x18\x12 \x01(\x05R\frestartCount\x12\x1a\n" +
+	"\breplicas\x18\x13 \x01(\x05R\breplicas\x12!\n" +
+	"\fpull_request\x18\x14 \x01(\x05R\vpullRequest\x12 \n" +
+	"\venvironment\x18\x15 \x01(\tR\venvironment\x12#\n" +
+	"\rpromoted_from\x18\x16 \x01(\tR\fpromotedFrom\"h\n" +
+	"\x16ListDeploymentsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x85\x01\n" +
+	"\x17ListDeploymentsResponse\x12>\n" +
+	"\vdeployments\x18\x01 \x03(\v2\x1c.deployment.DeploymentRecordR\vdeployments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x95\x01\n" +
+	"\x19RollbackDeploymentRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x19\n" +
+	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12%\n" +
+	"\x0ecustom_domains\x18\x04 \x03(\tR\rcustomDomains\"\xed\x01\n" +
+	"\x1aRollbackDeploymentResponse\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\x04 \x01(\tR\tpublicUrl\x12\x19\n" +
+	"\bbuild_id\x18\x05 \x01(\tR\abuildId\x12\x1f\n" +
+	"\vrollback_of\x18\x06 \x01(\tR\n" +
+	"rollbackOf\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"x\n" +
+	"\x16ScaleDeploymentRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x1a\n" +
+	"\breplicas\x18\x03 \x01(\x05R\breplicas\"\x9b\x01\n" +
+	"\x17ScaleDeploymentResponse\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1a\n" +
+	"\breplicas\x18\x02 \x01(\x05R\breplicas\x12)\n" +
+	"\x10running_replicas\x18\x03 \x01(\x05R\x0frunningReplicas\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xfc\x01\n" +
+	"\aPreview\x12!\n" +
+	"\fpull_request\x18\x01 \x01(\x05R\vpullRequest\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\x12\x19\n" +
+	"\bbuild_id\x18\x03 \x01(\tR\abuildId\x124\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1c.deployment.DeploymentStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\x05 \x01(\tR\tpublicUrl\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"4\n" +
+	"\x13ListPreviewsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"]\n" +
+	"\x14ListPreviewsResponse\x12/\n" +
+	"\bpreviews\x18\x01 \x03(\v2\x13.deployment.PreviewR\bpreviews\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"V\n" +
+	"\x12StopPreviewRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12!\n" +
+	"\fpull_request\x18\x02 \x01(\x05R\vpullRequest\"_\n" +
+	"\x13StopPreviewResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\astopped\x18\x02 \x01(\x05R\astopped\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"u\n" +
+	"\x18PromoteDeploymentRequest\x12)\n" +
+	"\x10from_environment\x18\x01 \x01(\tR\x0ffromEnvironment\x12.\n" +
+	"\x04spec\x18\x02 \x01(\v2\x1a.deployment.DeploymentSpecR\x04spec\"\x8d\x02\n" +
+	"\x19PromoteDeploymentResponse\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"public_url\x18\x04 \x01(\tR\tpublicUrl\x12\x19\n" +
+	"\bbuild_id\x18\x05 \x01(\tR\abuildId\x12\x1b\n" +
+	"\timage_tag\x18\x06 \x01(\tR\bimageTag\x12#\n" +
+	"\rpromoted_from\x18\a \x01(\tR\fpromotedFrom\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error*\xd2\x01\n" +
 	"\x10DeploymentStatus\x12!\n" +
 	"\x1dDEPLOYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19DEPLOYMENT_STATUS_PENDING\x10\x01\x12\x1d\n" +
 	"\x19DEPLOYMENT_STATUS_RUNNING\x10\x02\x12\x1d\n" +
 	"\x19DEPLOYMENT_STATUS_STOPPED\x10\x03\x12\x1c\n" +
 	"\x18DEPLOYMENT_STATUS_FAILED\x10\x04\x12 \n" +
-	"\x1cDEPLOYMENT_STATUS_RESTARTING\x10\x052\xd0\x03\n" +
+	"\x1cDEPLOYMENT_STATUS_RESTARTING\x10\x052\xf2\a\n" +
 	"\x11DeploymentService\x12?\n" +
 	"\x06Deploy\x12\x19.deployment.DeployRequest\x1a\x1a.deployment.DeployResponse\x12W\n" +
 	"\x0eStopDeployment\x12!.deployment.StopDeploymentRequest\x1a\".deployment.StopDeploymentResponse\x12f\n" +
 	"\x13GetDeploymentStatus\x12&.deployment.GetDeploymentStatusRequest\x1a'.deployment.GetDeploymentStatusResponse\x12`\n" +
 	"\x11RestartDeployment\x12$.deployment.RestartDeploymentRequest\x1a%.deployment.RestartDeploymentResponse\x12W\n" +
-	"\x0eGetRuntimeLogs\x12!.deployment.GetRuntimeLogsRequest\x1a\".deployment.GetRuntimeLogsResponseBBZ@github.com/nexusdeploy/backend/services/deployment-service/protob\x06proto3"
+	"\x0eGetRuntimeLogs\x12!.deployment.GetRuntimeLogsRequest\x1a\".deployment.GetRuntimeLogsResponse\x12Z\n" +
+	"\x0fListDeployments\x12\".deployment.ListDeploymentsRequest\x1a#.deployment.ListDeploymentsResponse\x12c\n" +
+	"\x12RollbackDeployment\x12%.deployment.RollbackDeploymentRequest\x1a&.deployment.RollbackDeploymentResponse\x12Z\n" +
+	"\x0fScaleDeployment\x12\".deployment.ScaleDeploymentRequest\x1a#.deployment.ScaleDeploymentResponse\x12Q\n" +
+	"\fListPreviews\x12\x1f.deployment.ListPreviewsRequest\x1a .deployment.ListPreviewsResponse\x12N\n" +
+	"\vStopPreview\x12\x1e.deployment.StopPreviewRequest\x1a\x1f.deployment.StopPreviewResponse\x12`\n" +
+	"\x11PromoteDeployment\x12$.deployment.PromoteDeploymentRequest\x1a%.deployment.PromoteDeploymentResponseBBZ@github.com/nexusdeploy/backend/services/deployment-service/protob\x06proto3"
 
 var (
 	file_deployment_proto_rawDescOnce sync.Once
@@ -932,47 +2364,89 @@ func file_deployment_proto_rawDescGZIP() []byte {
 }
 
 var file_deployment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_deployment_proto_goTypes = []any{
 	(DeploymentStatus)(0),               // 0: deployment.DeploymentStatus
 	(*DeploymentSpec)(nil),              // 1: deployment.DeploymentSpec
-	(*ResourceLimits)(nil),              // 2: deployment.ResourceLimits
-	(*DeployRequest)(nil),               // 3: deployment.DeployRequest
-	(*DeployResponse)(nil),              // 4: deployment.DeployResponse
-	(*StopDeploymentRequest)(nil),       // 5: deployment.StopDeploymentRequest
-	(*StopDeploymentResponse)(nil),      // 6: deployment.StopDeploymentResponse
-	(*GetDeploymentStatusRequest)(nil),  // 7: deployment.GetDeploymentStatusRequest
-	(*GetDeploymentStatusResponse)(nil), // 8: deployment.GetDeploymentStatusResponse
-	(*RestartDeploymentRequest)(nil),    // 9: deployment.RestartDeploymentRequest
-	(*RestartDeploymentResponse)(nil),   // 10: deployment.RestartDeploymentResponse
-	(*GetRuntimeLogsRequest)(nil),       // 11: deployment.GetRuntimeLogsRequest
-	(*GetRuntimeLogsResponse)(nil),      // 12: deployment.GetRuntimeLogsResponse
-	nil,                                 // 13: deployment.DeploymentSpec.EnvVarsEntry
-	nil,                                 // 14: deployment.DeploymentSpec.SecretsEntry
-	(*timestamppb.Timestamp)(nil),       // 15: google.protobuf.Timestamp
+	(*HealthCheck)(nil),                 // 2: deployment.HealthCheck
+	(*ResourceLimits)(nil),              // 3: deployment.ResourceLimits
+	(*DeployRequest)(nil),               // 4: deployment.DeployRequest
+	(*DeployResponse)(nil),              // 5: deployment.DeployResponse
+	(*StopDeploymentRequest)(nil),       // 6: deployment.StopDeploymentRequest
+	(*StopDeploymentResponse)(nil),      // 7: deployment.StopDeploymentResponse
+	(*GetDeploymentStatusRequest)(nil),  // 8: deployment.GetDeploymentStatusRequest
+	(*GetDeploymentStatusResponse)(nil), // 9: deployment.GetDeploymentStatusResponse
+	(*RestartDeploymentRequest)(nil),    // 10: deployment.RestartDeploymentRequest
+	(*RestartDeploymentResponse)(nil),   // 11: deployment.RestartDeploymentResponse
+	(*GetRuntimeLogsRequest)(nil),       // 12: deployment.GetRuntimeLogsRequest
+	(*GetRuntimeLogsResponse)(nil),      // 13: deployment.GetRuntimeLogsResponse
+	(*DeploymentEvent)(nil),             // 14: deployment.DeploymentEvent
+	(*DeploymentRecord)(nil),            // 15: deployment.DeploymentRecord
+	(*ListDeploymentsRequest)(nil),      // 16: deployment.ListDeploymentsRequest
+	(*ListDeploymentsResponse)(nil),     // 17: deployment.ListDeploymentsResponse
+	(*RollbackDeploymentRequest)(nil),   // 18: deployment.RollbackDeploymentRequest
+	(*RollbackDeploymentResponse)(nil),  // 19: deployment.RollbackDeploymentResponse
+	(*ScaleDeploymentRequest)(nil),      // 20: deployment.ScaleDeploymentRequest
+	(*ScaleDeploymentResponse)(nil),     // 21: deployment.ScaleDeploymentResponse
+	(*Preview)(nil),                     // 22: deployment.Preview
+	(*ListPreviewsRequest)(nil),         // 23: deployment.ListPreviewsRequest
+	(*ListPreviewsResponse)(nil),        // 24: deployment.ListPreviewsResponse
+	(*StopPreviewRequest)(nil),          // 25: deployment.StopPreviewRequest
+	(*StopPreviewResponse)(nil),         // 26: deployment.StopPreviewResponse
+	(*PromoteDeploymentRequest)(nil),    // 27: deployment.PromoteDeploymentRequest
+	(*PromoteDeploymentResponse)(nil),   // 28: deployment.PromoteDeploymentResponse
+	nil,                                 // 29: deployment.DeploymentSpec.EnvVarsEntry
+	nil,                                 // 30: deployment.DeploymentSpec.SecretsEntry
+	(*timestamppb.Timestamp)(nil),       // 31: google.protobuf.Timestamp
 }
 var file_deployment_proto_depIdxs = []int32{
-	13, // 0: deployment.DeploymentSpec.env_vars:type_name -> deployment.DeploymentSpec.EnvVarsEntry
-	14, // 1: deployment.DeploymentSpec.secrets:type_name -> deployment.DeploymentSpec.SecretsEntry
-	2,  // 2: deployment.DeploymentSpec.resources:type_name -> deployment.ResourceLimits
-	1,  // 3: deployment.DeployRequest.spec:type_name -> deployment.DeploymentSpec
-	0,  // 4: deployment.GetDeploymentStatusResponse.status:type_name -> deployment.DeploymentStatus
-	15, // 5: deployment.GetDeploymentStatusResponse.started_at:type_name -> google.protobuf.Timestamp
-	3,  // 6: deployment.DeploymentService.Deploy:input_type -> deployment.DeployRequest
-	5,  // 7: deployment.DeploymentService.StopDeployment:input_type -> deployment.StopDeploymentRequest
-	7,  // 8: deployment.DeploymentService.GetDeploymentStatus:input_type -> deployment.GetDeploymentStatusRequest
-	9,  // 9: deployment.DeploymentService.RestartDeployment:input_type -> deployment.RestartDeploymentRequest
-	11, // 10: deployment.DeploymentService.GetRuntimeLogs:input_type -> deployment.GetRuntimeLogsRequest
-	4,  // 11: deployment.DeploymentService.Deploy:output_type -> deployment.DeployResponse
-	6,  // 12: deployment.DeploymentService.StopDeployment:output_type -> deployment.StopDeploymentResponse
-	8,  // 13: deployment.DeploymentService.GetDeploymentStatus:output_type -> deployment.GetDeploymentStatusResponse
-	10, // 14: deployment.DeploymentService.RestartDeployment:output_type -> deployment.RestartDeploymentResponse
-	12, // 15: deployment.DeploymentService.GetRuntimeLogs:output_type -> deployment.GetRuntimeLogsResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	29, // 0: deployment.DeploymentSpec.env_vars:type_name -> deployment.DeploymentSpec.EnvVarsEntry
+	30, // 1: deployment.DeploymentSpec.secrets:type_name -> deployment.DeploymentSpec.SecretsEntry
+	3,  // 2: deployment.DeploymentSpec.resources:type_name -> deployment.ResourceLimits
+	2,  // 3: deployment.DeploymentSpec.health_check:type_name -> deployment.HealthCheck
+	1,  // 4: deployment.DeployRequest.spec:type_name -> deployment.DeploymentSpec
+	0,  // 5: deployment.GetDeploymentStatusResponse.status:type_name -> deployment.DeploymentStatus
+	31, // 6: deployment.GetDeploymentStatusResponse.started_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: deployment.DeploymentEvent.status:type_name -> deployment.DeploymentStatus
+	31, // 8: deployment.DeploymentEvent.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: deployment.DeploymentRecord.status:type_name -> deployment.DeploymentStatus
+	31, // 10: deployment.DeploymentRecord.started_at:type_name -> google.protobuf.Timestamp
+	31, // 11: deployment.DeploymentRecord.stopped_at:type_name -> google.protobuf.Timestamp
+	31, // 12: deployment.DeploymentRecord.created_at:type_name -> google.protobuf.Timestamp
+	31, // 13: deployment.DeploymentRecord.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 14: deployment.DeploymentRecord.history:type_name -> deployment.DeploymentEvent
+	15, // 15: deployment.ListDeploymentsResponse.deployments:type_name -> deployment.DeploymentRecord
+	0,  // 16: deployment.Preview.status:type_name -> deployment.DeploymentStatus
+	31, // 17: deployment.Preview.created_at:type_name -> google.protobuf.Timestamp
+	22, // 18: deployment.ListPreviewsResponse.previews:type_name -> deployment.Preview
+	1,  // 19: deployment.PromoteDeploymentRequest.spec:type_name -> deployment.DeploymentSpec
+	4,  // 20: deployment.DeploymentService.Deploy:input_type -> deployment.DeployRequest
+	6,  // 21: deployment.DeploymentService.StopDeployment:input_type -> deployment.StopDeploymentRequest
+	8,  // 22: deployment.DeploymentService.GetDeploymentStatus:input_type -> deployment.GetDeploymentStatusRequest
+	10, // 23: deployment.DeploymentService.RestartDeployment:input_type -> deployment.RestartDeploymentRequest
+	12, // 24: deployment.DeploymentService.GetRuntimeLogs:input_type -> deployment.GetRuntimeLogsRequest
+	16, // 25: deployment.DeploymentService.ListDeployments:input_type -> deployment.ListDeploymentsRequest
+	18, // 26: deployment.DeploymentService.RollbackDeployment:input_type -> deployment.RollbackDeploymentRequest
+	20, // 27: deployment.DeploymentService.ScaleDeployment:input_type -> deployment.ScaleDeploymentRequest
+	23, // 28: deployment.DeploymentService.ListPreviews:input_type -> deployment.ListPreviewsRequest
+	25, // 29: deployment.DeploymentService.StopPreview:input_type -> deployment.StopPreviewRequest
+	27, // 30: deployment.DeploymentService.PromoteDeployment:input_type -> deployment.PromoteDeploymentRequest
+	5,  // 31: deployment.DeploymentService.Deploy:output_type -> deployment.DeployResponse
+	7,  // 32: deployment.DeploymentService.StopDeployment:output_type -> deployment.StopDeploymentResponse
+	9,  // 33: deployment.DeploymentService.GetDeploymentStatus:output_type -> deployment.GetDeploymentStatusResponse
+	11, // 34: deployment.DeploymentService.RestartDeployment:output_type -> deployment.RestartDeploymentResponse
+	13, // 35: deployment.DeploymentService.GetRuntimeLogs:output_type -> deployment.GetRuntimeLogsResponse
+	17, // 36: deployment.DeploymentService.ListDeployments:output_type -> deployment.ListDeploymentsResponse
+	19, // 37: deployment.DeploymentService.RollbackDeployment:output_type -> deployment.RollbackDeploymentResponse
+	21, // 38: deployment.DeploymentService.ScaleDeployment:output_type -> deployment.ScaleDeploymentResponse
+	24, // 39: deployment.DeploymentService.ListPreviews:output_type -> deployment.ListPreviewsResponse
+	26, // 40: deployment.DeploymentService.StopPreview:output_type -> deployment.StopPreviewResponse
+	28, // 41: deployment.DeploymentService.PromoteDeployment:output_type -> deployment.PromoteDeploymentResponse
+	31, // [31:42] is the sub-list for method output_type
+	20, // [20:31] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_deployment_proto_init() }
@@ -986,7 +2460,7 @@ func file_deployment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_deployment_proto_rawDesc), len(file_deployment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   14,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
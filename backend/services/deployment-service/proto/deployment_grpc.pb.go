@@ -24,6 +24,12 @@ const (
 	DeploymentService_GetDeploymentStatus_FullMethodName = "/deployment.DeploymentService/GetDeploymentStatus"
 	DeploymentService_RestartDeployment_FullMethodName   = "/deployment.DeploymentService/RestartDeployment"
 	DeploymentService_GetRuntimeLogs_FullMethodName      = "/deployment.DeploymentService/GetRuntimeLogs"
+	DeploymentService_ListDeployments_FullMethodName     = "/deployment.DeploymentService/ListDeployments"
+	DeploymentService_RollbackDeployment_FullMethodName  = "/deployment.DeploymentService/RollbackDeployment"
+	DeploymentService_ScaleDeployment_FullMethodName     = "/deployment.DeploymentService/ScaleDeployment"
+	DeploymentService_ListPreviews_FullMethodName        = "/deployment.DeploymentService/ListPreviews"
+	DeploymentService_StopPreview_FullMethodName         = "/deployment.DeploymentService/StopPreview"
+	DeploymentService_PromoteDeployment_FullMethodName   = "/deployment.DeploymentService/PromoteDeployment"
 )
 
 // DeploymentServiceClient is the client API for DeploymentService service.
@@ -42,6 +48,18 @@ type DeploymentServiceClient interface {
 	RestartDeployment(ctx context.Context, in *RestartDeploymentRequest, opts ...grpc.CallOption) (*RestartDeploymentResponse, error)
 	// Get runtime logs from a container
 	GetRuntimeLogs(ctx context.Context, in *GetRuntimeLogsRequest, opts ...grpc.CallOption) (*GetRuntimeLogsResponse, error)
+	// List the deployment history of a project
+	ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error)
+	// Redeploy an earlier build with the spec and secrets it was deployed with
+	RollbackDeployment(ctx context.Context, in *RollbackDeploymentRequest, opts ...grpc.CallOption) (*RollbackDeploymentResponse, error)
+	// Change the number of containers of the serving deployment
+	ScaleDeployment(ctx context.Context, in *ScaleDeploymentRequest, opts ...grpc.CallOption) (*ScaleDeploymentResponse, error)
+	// List the pull request previews of a project that are running
+	ListPreviews(ctx context.Context, in *ListPreviewsRequest, opts ...grpc.CallOption) (*ListPreviewsResponse, error)
+	// Stop the preview of a pull request
+	StopPreview(ctx context.Context, in *StopPreviewRequest, opts ...grpc.CallOption) (*StopPreviewResponse, error)
+	// Deploy the image serving one environment of a project to another, without a build
+	PromoteDeployment(ctx context.Context, in *PromoteDeploymentRequest, opts ...grpc.CallOption) (*PromoteDeploymentResponse, error)
 }
 
 type deploymentServiceClient struct {
@@ -102,6 +120,66 @@ func (c *deploymentServiceClient) GetRuntimeLogs(ctx context.Context, in *GetRun
 	return out, nil
 }
 
+func (c *deploymentServiceClient) ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeploymentsResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ListDeployments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) RollbackDeployment(ctx context.Context, in *RollbackDeploymentRequest, opts ...grpc.CallOption) (*RollbackDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackDeploymentResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_RollbackDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ScaleDeployment(ctx context.Context, in *ScaleDeploymentRequest, opts ...grpc.CallOption) (*ScaleDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScaleDeploymentResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ScaleDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ListPreviews(ctx context.Context, in *ListPreviewsRequest, opts ...grpc.CallOption) (*ListPreviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPreviewsResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ListPreviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) StopPreview(ctx context.Context, in *StopPreviewRequest, opts ...grpc.CallOption) (*StopPreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopPreviewResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_StopPreview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) PromoteDeployment(ctx context.Context, in *PromoteDeploymentRequest, opts ...grpc.CallOption) (*PromoteDeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteDeploymentResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_PromoteDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeploymentServiceServer is the server API for DeploymentService service.
 // All implementations must embed UnimplementedDeploymentServiceServer
 // for forward compatibility.
@@ -118,6 +196,18 @@ type DeploymentServiceServer interface {
 	RestartDeployment(context.Context, *RestartDeploymentRequest) (*RestartDeploymentResponse, error)
 	// Get runtime logs from a container
 	GetRuntimeLogs(context.Context, *GetRuntimeLogsRequest) (*GetRuntimeLogsResponse, error)
+	// List the deployment history of a project
+	ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error)
+	// Redeploy an earlier build with the spec and secrets it was deployed with
+	RollbackDeployment(context.Context, *RollbackDeploymentRequest) (*RollbackDeploymentResponse, error)
+	// Change the number of containers of the serving deployment
+	ScaleDeployment(context.Context, *ScaleDeploymentRequest) (*ScaleDeploymentResponse, error)
+	// List the pull request previews of a project that are running
+	ListPreviews(context.Context, *ListPreviewsRequest) (*ListPreviewsResponse, error)
+	// Stop the preview of a pull request
+	StopPreview(context.Context, *StopPreviewRequest) (*StopPreviewResponse, error)
+	// Deploy the image serving one environment of a project to another, without a build
+	PromoteDeployment(context.Context, *PromoteDeploymentRequest) (*PromoteDeploymentResponse, error)
 	mustEmbedUnimplementedDeploymentServiceServer()
 }
 
@@ -143,6 +233,24 @@ func (UnimplementedDeploymentServiceServer) RestartDeployment(context.Context, *
 func (UnimplementedDeploymentServiceServer) GetRuntimeLogs(context.Context, *GetRuntimeLogsRequest) (*GetRuntimeLogsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetRuntimeLogs not implemented")
 }
+func (UnimplementedDeploymentServiceServer) ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeployments not implemented")
+}
+func (UnimplementedDeploymentServiceServer) RollbackDeployment(context.Context, *RollbackDeploymentRequest) (*RollbackDeploymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackDeployment not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ScaleDeployment(context.Context, *ScaleDeploymentRequest) (*ScaleDeploymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScaleDeployment not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ListPreviews(context.Context, *ListPreviewsRequest) (*ListPreviewsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPreviews not implemented")
+}
+func (UnimplementedDeploymentServiceServer) StopPreview(context.Context, *StopPreviewRequest) (*StopPreviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopPreview not implemented")
+}
+func (UnimplementedDeploymentServiceServer) PromoteDeployment(context.Context, *PromoteDeploymentRequest) (*PromoteDeploymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PromoteDeployment not implemented")
+}
 func (UnimplementedDeploymentServiceServer) mustEmbedUnimplementedDeploymentServiceServer() {}
 func (UnimplementedDeploymentServiceServer) testEmbeddedByValue()                           {}
 
@@ -254,6 +362,114 @@ func _DeploymentService_GetRuntimeLogs_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeploymentService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ListDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ListDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ListDeployments(ctx, req.(*ListDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_RollbackDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).RollbackDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_RollbackDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).RollbackDeployment(ctx, req.(*RollbackDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ScaleDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ScaleDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ScaleDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ScaleDeployment(ctx, req.(*ScaleDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ListPreviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPreviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ListPreviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ListPreviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ListPreviews(ctx, req.(*ListPreviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_StopPreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopPreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).StopPreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_StopPreview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).StopPreview(ctx, req.(*StopPreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_PromoteDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).PromoteDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_PromoteDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).PromoteDeployment(ctx, req.(*PromoteDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeploymentService_ServiceDesc is the grpc.ServiceDesc for DeploymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +497,30 @@ var DeploymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRuntimeLogs",
 			Handler:    _DeploymentService_GetRuntimeLogs_Handler,
 		},
+		{
+			MethodName: "ListDeployments",
+			Handler:    _DeploymentService_ListDeployments_Handler,
+		},
+		{
+			MethodName: "RollbackDeployment",
+			Handler:    _DeploymentService_RollbackDeployment_Handler,
+		},
+		{
+			MethodName: "ScaleDeployment",
+			Handler:    _DeploymentService_ScaleDeployment_Handler,
+		},
+		{
+			MethodName: "ListPreviews",
+			Handler:    _DeploymentService_ListPreviews_Handler,
+		},
+		{
+			MethodName: "StopPreview",
+			Handler:    _DeploymentService_StopPreview_Handler,
+		},
+		{
+			MethodName: "PromoteDeployment",
+			Handler:    _DeploymentService_PromoteDeployment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "deployment.proto",
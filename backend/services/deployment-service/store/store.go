@@ -0,0 +1,246 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nexusdeploy/backend/services/deployment-service/models"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when no deployment matches
+var ErrNotFound = errors.New("deployment not found")
+
+// Store persists deployments and their status history in deployment_db
+type Store struct {
+	db *gorm.DB
+}
+
+// New creates a deployment store
+func New(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Migrate creates or updates the deployment tables
+func (s *Store) Migrate() error {
+	return s.db.AutoMigrate(&models.Deployment{}, &models.DeploymentEvent{})
+}
+
+// Save creates or updates a deployment. A status change, including the first save,
+// is appended to the status history with message.
+func (s *Store) Save(ctx context.Context, d *models.Deployment, message string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Deployment
+		err := tx.Select("status").First(&current, "id = ?", d.ID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("get deployment: %w", err)
+		}
+		statusChanged := errors.Is(err, gorm.ErrRecordNotFound) || current.Status != d.Status
+
+		if err := tx.Omit("Events").Save(d).Error; err != nil {
+			return fmt.Errorf("save deployment: %w", err)
+		}
+
+		if statusChanged {
+			event := &models.DeploymentEvent{
+				DeploymentID: d.ID,
+				Status:       d.Status,
+				Message:      message,
+			}
+			if err := tx.Create(event).Error; err != nil {
+				return fmt.Errorf("record deployment event: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateHealth stores the health and restart count of a deployment. Only these
+// columns are written, so a concurrent status change is not overwritten.
+func (s *Store) UpdateHealth(ctx context.Context, id string, health string, restartCount int) error {
+	deploymentID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	err = s.db.WithContext(ctx).
+		Model(&models.Deployment{}).
+		Where("id = ?", deploymentID).
+		Updates(map[string]interface{}{
+			"health":        health,
+			"restart_count": restartCount,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("update deployment health: %w", err)
+	}
+	return nil
+}
+
+// UpdateReplicas stores the desired number of containers of a deployment
+func (s *Store) UpdateReplicas(ctx context.Context, id string, replicas int) error {
+	deploymentID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	err = s.db.WithContext(ctx).
+		Model(&models.Deployment{}).
+		Where("id = ?", deploymentID).
+		Update("replicas", replicas).Error
+	if err != nil {
+		return fmt.Errorf("update deployment replicas: %w", err)
+	}
+	return nil
+}
+
+// Get returns a deployment by ID
+func (s *Store) Get(ctx context.Context, id string) (*models.Deployment, error) {
+	deploymentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var d models.Deployment
+	if err := s.db.WithContext(ctx).First(&d, "id = ?", deploymentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get deployment: %w", err)
+	}
+	return &d, nil
+}
+
+// Latest returns the most recent deployment of an environment of a project, ""
+// for production
+func (s *Store) Latest(ctx context.Context, projectID, environment string) (*models.Deployment, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var d models.Deployment
+	err = s.db.WithContext(ctx).
+		Where("project_id = ? AND environment = ? AND pull_request = 0", pid, environment).
+		Order("created_at DESC").
+		First(&d).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get latest deployment: %w", err)
+	}
+	return &d, nil
+}
+
+// Serving returns the deployments of a project that own a container, newest
+// first. pullRequest selects the preview of a pull request, 0 the environment,
+// "" for production.
+func (s *Store) Serving(ctx context.Context, projectID, environment string, pullRequest int) ([]models.Deployment, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var deployments []models.Deployment
+	err = s.db.WithContext(ctx).
+		Where("project_id = ? AND environment = ? AND pull_request = ? AND container_id <> '' AND status IN ?", pid, environment, pullRequest, []models.DeploymentStatus{
+			models.DeploymentStatusRunning,
+			models.DeploymentStatusRestarting,
+		}).
+		Order("created_at DESC").
+		Find(&deployments).Error
+	if err != nil {
+		return nil, fmt.Errorf("list serving deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// Previews returns the preview deployments of a project that own a container,
+// newest first
+func (s *Store) Previews(ctx context.Context, projectID string) ([]models.Deployment, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var deployments []models.Deployment
+	err = s.db.WithContext(ctx).
+		Where("project_id = ? AND pull_request > 0 AND container_id <> '' AND status IN ?", pid, []models.DeploymentStatus{
+			models.DeploymentStatusRunning,
+			models.DeploymentStatusRestarting,
+		}).
+		Order("created_at DESC").
+		Find(&deployments).Error
+	if err != nil {
+		return nil, fmt.Errorf("list preview deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// List returns a page of the deployments of a project, newest first, with their
+// status history
+func (s *Store) List(ctx context.Context, projectID string, page, pageSize int) ([]models.Deployment, int64, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, 0, nil
+	}
+
+	var total int64
+	if err := s.db.WithContext(ctx).Model(&models.Deployment{}).Where("project_id = ?", pid).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count deployments: %w", err)
+	}
+
+	var deployments []models.Deployment
+	err = s.db.WithContext(ctx).
+		Where("project_id = ?", pid).
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&deployments).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("list deployments: %w", err)
+	}
+	return deployments, total, nil
+}
+
+// Active returns the deployments that own a container
+func (s *Store) Active(ctx context.Context) ([]models.Deployment, error) {
+	var deployments []models.Deployment
+	err := s.db.WithContext(ctx).
+		Where("status IN ?", []models.DeploymentStatus{
+			models.DeploymentStatusPending,
+			models.DeploymentStatusRunning,
+			models.DeploymentStatusRestarting,
+		}).
+		Find(&deployments).Error
+	if err != nil {
+		return nil, fmt.Errorf("list active deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// Successful returns the production deployments of a project that reached the
+// running state and can be redeployed, newest first
+func (s *Store) Successful(ctx context.Context, projectID string) ([]models.Deployment, error) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var deployments []models.Deployment
+	err = s.db.WithContext(ctx).
+		Where("project_id = ? AND environment = '' AND pull_request = 0 AND spec_snapshot <> ''", pid).
+		Where("EXISTS (SELECT 1 FROM deployment_events e WHERE e.deployment_id = deployments.id AND e.status = ?)", models.DeploymentStatusRunning).
+		Order("created_at DESC").
+		Limit(100).
+		Find(&deployments).Error
+	if err != nil {
+		return nil, fmt.Errorf("list successful deployments: %w", err)
+	}
+	return deployments, nil
+}
@@ -24,12 +24,15 @@ type Consumer struct {
 
 // LogMessage represents a log entry from build/deployment
 type LogMessage struct {
-	Type      string `json:"type"` // "build_log", "deployment_log", "event"
-	ProjectID string `json:"project_id"`
-	BuildID   string `json:"build_id,omitempty"`
-	Timestamp string `json:"timestamp"`
-	Message   string `json:"message"`
-	Level     string `json:"level,omitempty"` // "info", "error", "warn"
+	Type         string `json:"type"` // "build_log", "deployment_log", "event"
+	ProjectID    string `json:"project_id"`
+	BuildID      string `json:"build_id,omitempty"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+	Timestamp    string `json:"timestamp"`
+	Message      string `json:"message"`
+	Level        string `json:"level,omitempty"` // "info", "error", "warn"
+	Event        string `json:"event,omitempty"` // Set for events, e.g. "cancelled" or "health_changed"
+	Status       string `json:"status,omitempty"`
 }
 
 // NewConsumer creates a new Redis Pub/Sub consumer
@@ -114,6 +117,9 @@ func (c *Consumer) handleMessage(msg *redis.Message) {
 		if buildID, ok := rawPayload["build_id"].(string); ok {
 			logMsg.BuildID = buildID
 		}
+		if deploymentID, ok := rawPayload["deployment_id"].(string); ok {
+			logMsg.DeploymentID = deploymentID
+		}
 		if timestamp, ok := rawPayload["timestamp"].(string); ok {
 			logMsg.Timestamp = timestamp
 		} else if ts, ok := rawPayload["timestamp"]; ok {
@@ -125,6 +131,12 @@ func (c *Consumer) handleMessage(msg *redis.Message) {
 		if level, ok := rawPayload["level"].(string); ok {
 			logMsg.Level = level
 		}
+		if event, ok := rawPayload["event"].(string); ok {
+			logMsg.Event = event
+		}
+		if status, ok := rawPayload["status"].(string); ok {
+			logMsg.Status = status
+		}
 	}
 
 	// Extract IDs from channel if not in payload
@@ -0,0 +1,82 @@
+// Package deploywindows evaluates the deploy windows of a project: recurring
+// periods, opened by a cron expression and lasting a fixed duration, in which
+// production deploys are allowed.
+package deploywindows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	// Deploy windows are read in the time zone of the team, which the runtime
+	// image may not ship
+	_ "time/tzdata"
+)
+
+// MaxDuration bounds how long a window stays open. A weekly schedule open for a
+// week allows everything, which is better expressed with no windows at all.
+const MaxDuration = 7 * 24 * time.Hour
+
+// parser reads standard 5-field cron expressions and descriptors like @daily
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Window is a recurring period in which deploys are allowed
+type Window struct {
+	Schedule string        // Cron expression opening the window, e.g. "0 9 * * 1-4"
+	Duration time.Duration // How long the window stays open
+	Timezone string        // IANA name the schedule is read in, "" = UTC
+}
+
+// Validate checks the schedule, duration and time zone of a window
+func Validate(w Window) error {
+	if strings.TrimSpace(w.Schedule) == "" {
+		return errors.New("schedule is required")
+	}
+	if strings.HasPrefix(w.Schedule, "TZ=") || strings.HasPrefix(w.Schedule, "CRON_TZ=") {
+		return errors.New("set the time zone of a window with timezone, not in its schedule")
+	}
+	if _, err := parser.Parse(w.Schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", w.Schedule, err)
+	}
+	if w.Duration < time.Minute || w.Duration > MaxDuration {
+		return fmt.Errorf("duration must be between 1 minute and %d days", int(MaxDuration/(24*time.Hour)))
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", w.Timezone)
+	}
+	return nil
+}
+
+// Open reports whether now falls inside one of windows. Without windows deploys
+// are always allowed. When closed, next is the time the earliest window opens.
+func Open(windows []Window, now time.Time) (open bool, next time.Time, err error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	for _, w := range windows {
+		schedule, err := parser.Parse(w.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid schedule %q: %w", w.Schedule, err)
+		}
+		loc, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("unknown timezone %q", w.Timezone)
+		}
+
+		local := now.In(loc)
+		// The window is open if it opened within the last Duration. Next is
+		// exclusive, so a window opening exactly now is found too. A schedule
+		// that never matches, e.g. on February 30, yields the zero time.
+		if opened := schedule.Next(local.Add(-w.Duration)); !opened.IsZero() && !opened.After(local) {
+			return true, time.Time{}, nil
+		}
+		if n := schedule.Next(local); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return false, next, nil
+}
@@ -0,0 +1,220 @@
+package deploywindows
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func utc(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestOpen(t *testing.T) {
+	berlinOfficeHours := Window{Schedule: "0 9 * * 1-5", Duration: 8 * time.Hour, Timezone: "Europe/Berlin"}
+	newYorkMorning := Window{Schedule: "0 9 * * *", Duration: time.Hour, Timezone: "America/New_York"}
+
+	tests := []struct {
+		name     string
+		windows  []Window
+		now      string
+		wantOpen bool
+		wantNext string // "" for the zero time
+		wantErr  string
+	}{
+		{
+			name:     "no windows",
+			now:      "2026-01-15T03:00:00Z",
+			wantOpen: true,
+		},
+		{
+			name:     "winter, inside",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-01-15T08:30:00Z", // 09:30 CET
+			wantOpen: true,
+		},
+		{
+			name:     "winter, before opening",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-01-15T07:30:00Z", // 08:30 CET
+			wantNext: "2026-01-15T08:00:00Z",
+		},
+		{
+			name:     "summer, inside",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-07-15T07:30:00Z", // 09:30 CEST
+			wantOpen: true,
+		},
+		{
+			name:     "summer, after closing",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-07-15T15:30:00Z", // 17:30 CEST
+			wantNext: "2026-07-16T07:00:00Z",
+		},
+		{
+			name:     "opening instant is inside",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-01-15T08:00:00Z",
+			wantOpen: true,
+		},
+		{
+			name:     "closing instant is outside",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-01-15T16:00:00Z",
+			wantNext: "2026-01-16T08:00:00Z",
+		},
+		{
+			name:     "weekend waits for Monday",
+			windows:  []Window{berlinOfficeHours},
+			now:      "2026-01-16T17:30:00Z", // Friday 18:30 CET
+			wantNext: "2026-01-19T08:00:00Z",
+		},
+		{
+			name:     "no timezone is UTC",
+			windows:  []Window{{Schedule: "0 9 * * 1-5", Duration: 8 * time.Hour}},
+			now:      "2026-07-15T07:30:00Z",
+			wantNext: "2026-07-15T09:00:00Z",
+		},
+		{
+			name:     "same schedule in Tokyo is open",
+			windows:  []Window{{Schedule: "0 9 * * *", Duration: 8 * time.Hour, Timezone: "Asia/Tokyo"}},
+			now:      "2026-01-15T01:00:00Z", // 10:00 JST
+			wantOpen: true,
+		},
+		{
+			name:     "same schedule in Los Angeles is closed",
+			windows:  []Window{{Schedule: "0 9 * * *", Duration: 8 * time.Hour, Timezone: "America/Los_Angeles"}},
+			now:      "2026-01-15T01:00:00Z", // 17:00 PST the day before
+			wantNext: "2026-01-15T17:00:00Z",
+		},
+		{
+			name:     "window across midnight",
+			windows:  []Window{{Schedule: "0 22 * * *", Duration: 4 * time.Hour}},
+			now:      "2026-01-16T01:00:00Z",
+			wantOpen: true,
+		},
+		{
+			name:     "day before DST starts",
+			windows:  []Window{newYorkMorning},
+			now:      "2026-03-07T14:30:00Z", // 09:30 EST
+			wantOpen: true,
+		},
+		{
+			name:     "day DST starts",
+			windows:  []Window{newYorkMorning},
+			now:      "2026-03-08T13:30:00Z", // 09:30 EDT
+			wantOpen: true,
+		},
+		{
+			name:     "day DST starts, after closing",
+			windows:  []Window{newYorkMorning},
+			now:      "2026-03-08T14:30:00Z", // 10:30 EDT
+			wantNext: "2026-03-09T13:00:00Z",
+		},
+		{
+			name:     "day DST ends",
+			windows:  []Window{newYorkMorning},
+			now:      "2026-11-01T13:30:00Z", // 08:30 EST
+			wantNext: "2026-11-01T14:00:00Z",
+		},
+		{
+			name:     "duration is elapsed time across the DST change",
+			windows:  []Window{{Schedule: "0 1 * * *", Duration: 2 * time.Hour, Timezone: "America/New_York"}},
+			now:      "2026-03-08T07:30:00Z", // 03:30 EDT, 1.5 hours after 01:00 EST
+			wantOpen: true,
+		},
+		{
+			name:     "duration has elapsed across the DST change",
+			windows:  []Window{{Schedule: "0 1 * * *", Duration: 2 * time.Hour, Timezone: "America/New_York"}},
+			now:      "2026-03-08T08:30:00Z", // 04:30 EDT, 2.5 hours after 01:00 EST
+			wantNext: "2026-03-09T05:00:00Z",
+		},
+		{
+			name: "earliest next of several windows",
+			windows: []Window{
+				{Schedule: "0 14 * * *", Duration: time.Hour, Timezone: "Europe/Berlin"},
+				{Schedule: "0 9 * * *", Duration: time.Hour, Timezone: "America/New_York"},
+			},
+			now:      "2026-01-15T12:00:00Z",
+			wantNext: "2026-01-15T13:00:00Z",
+		},
+		{
+			name: "any open window",
+			windows: []Window{
+				{Schedule: "0 14 * * *", Duration: time.Hour, Timezone: "Europe/Berlin"},
+				{Schedule: "0 9 * * *", Duration: time.Hour, Timezone: "America/New_York"},
+			},
+			now:      "2026-01-15T14:15:00Z",
+			wantOpen: true,
+		},
+		{
+			name:    "schedule never matches",
+			windows: []Window{{Schedule: "0 0 30 2 *", Duration: time.Hour}},
+			now:     "2026-01-15T12:00:00Z",
+		},
+		{
+			name:    "unknown timezone",
+			windows: []Window{{Schedule: "0 9 * * *", Duration: time.Hour, Timezone: "Mars/Olympus_Mons"}},
+			now:     "2026-01-15T12:00:00Z",
+			wantErr: "unknown timezone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next, err := Open(tt.windows, utc(tt.now))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			if open != tt.wantOpen {
+				t.Errorf("Open() open = %v, want %v", open, tt.wantOpen)
+			}
+			var wantNext time.Time
+			if tt.wantNext != "" {
+				wantNext = utc(tt.wantNext)
+			}
+			if !next.Equal(wantNext) {
+				t.Errorf("Open() next = %v, want %v", next.UTC(), wantNext)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  Window
+		wantErr string
+	}{
+		{"valid", Window{Schedule: "0 9 * * 1-5", Duration: 8 * time.Hour, Timezone: "Europe/Berlin"}, ""},
+		{"descriptor", Window{Schedule: "@daily", Duration: time.Hour}, ""},
+		{"empty schedule", Window{Schedule: " ", Duration: time.Hour}, "schedule is required"},
+		{"timezone in schedule", Window{Schedule: "CRON_TZ=Europe/Berlin 0 9 * * *", Duration: time.Hour}, "with timezone"},
+		{"seconds field", Window{Schedule: "0 0 9 * * *", Duration: time.Hour}, "invalid schedule"},
+		{"too short", Window{Schedule: "0 9 * * *", Duration: 30 * time.Second}, "duration must be"},
+		{"too long", Window{Schedule: "0 9 * * *", Duration: MaxDuration + time.Minute}, "duration must be"},
+		{"unknown timezone", Window{Schedule: "0 9 * * *", Duration: time.Hour, Timezone: "Europe/Atlantis"}, "unknown timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.window)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Validate() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
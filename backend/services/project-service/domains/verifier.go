@@ -0,0 +1,124 @@
+package domains
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// recordPrefix is the label under the custom domain holding the TXT record
+	recordPrefix = "_nexusdeploy"
+	// valuePrefix starts the content of the TXT record, followed by the token
+	valuePrefix = "nexusdeploy-verification="
+)
+
+// ErrRecordNotFound is returned when the domain has no matching TXT record
+var ErrRecordNotFound = errors.New("verification record not found")
+
+// RecordName returns the name of the TXT record proving ownership of domain
+func RecordName(domain string) string {
+	return recordPrefix + "." + domain
+}
+
+// RecordValue returns the content of the TXT record for a verification token
+func RecordValue(token string) string {
+	return valuePrefix + token
+}
+
+// NewToken returns a random verification token
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Normalize validates a custom domain and returns it in lower case, without a
+// trailing dot. Wildcards, IP addresses and single labels are rejected.
+func Normalize(domain string) (string, error) {
+	d := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if d == "" {
+		return "", errors.New("domain is required")
+	}
+	if len(d) > 253 {
+		return "", errors.New("domain is longer than 253 characters")
+	}
+	if net.ParseIP(d) != nil {
+		return "", errors.New("domain must be a host name, not an IP address")
+	}
+
+	labels := strings.Split(d, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%q is not a fully qualified domain", d)
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return "", fmt.Errorf("%q is not a valid domain", d)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "", fmt.Errorf("%q is not a valid domain", d)
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return "", fmt.Errorf("%q is not a valid domain", d)
+			}
+		}
+	}
+	return d, nil
+}
+
+// Verifier checks domain ownership through DNS TXT records
+type Verifier struct {
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+// NewVerifier creates a verifier querying the DNS server at addr (host:port). An
+// empty addr uses the system resolver.
+func NewVerifier(addr string, timeout time.Duration) *Verifier {
+	resolver := net.DefaultResolver
+	if addr != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		}
+	}
+	return &Verifier{resolver: resolver, timeout: timeout}
+}
+
+// Verify checks that the TXT record of domain contains token. It returns
+// ErrRecordNotFound when the record is missing or holds another token.
+func (v *Verifier) Verify(ctx context.Context, domain, token string) error {
+	if v.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v.timeout)
+		defer cancel()
+	}
+
+	records, err := v.resolver.LookupTXT(ctx, RecordName(domain))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return ErrRecordNotFound
+		}
+		return fmt.Errorf("lookup %s: %w", RecordName(domain), err)
+	}
+
+	want := RecordValue(token)
+	for _, r := range records {
+		if strings.TrimSpace(r) == want {
+			return nil
+		}
+	}
+	return ErrRecordNotFound
+}
@@ -53,6 +53,22 @@ type WebhookResponse struct {
 	Error  string `json:"message,omitempty"`
 }
 
+// Commit status states
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+	StatusError   = "error"
+)
+
+// CommitStatus is a status shown next to a commit and on its pull requests
+type CommitStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"` // Statuses with the same context replace each other
+}
+
 // Client is a GitHub API client
 type Client struct {
 	httpClient *http.Client
@@ -199,6 +215,42 @@ func (c *Client) DeleteWebhook(ctx context.Context, accessToken, owner, repo str
 	return nil
 }
 
+// CreateCommitStatus sets a status on a commit
+func (c *Client) CreateCommitStatus(ctx context.Context, accessToken, owner, repo, sha string, status CommitStatus) error {
+	// GitHub rejects descriptions longer than 140 characters
+	if len(status.Description) > 140 {
+		status.Description = status.Description[:137] + "..."
+	}
+
+	body, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/statuses/%s", githubAPIURL, owner, repo, sha)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
 // ParseRepoFullName parses "owner/repo" format
 func ParseRepoFullName(fullName string) (owner, repo string, err error) {
 	parts := strings.SplitN(fullName, "/", 2)
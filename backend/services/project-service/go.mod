@@ -10,6 +10,7 @@ require (
 	github.com/nexusdeploy/backend/pkg/logger v0.0.0
 	github.com/nexusdeploy/backend/services/auth-service/proto v0.0.0
 	github.com/nexusdeploy/backend/services/project-service/proto v0.0.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/rs/zerolog v1.34.0
 	google.golang.org/grpc v1.67.0
 	google.golang.org/protobuf v1.36.10
@@ -2,9 +2,14 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +17,8 @@ import (
 	cfgpkg "github.com/nexusdeploy/backend/pkg/config"
 	"github.com/nexusdeploy/backend/pkg/crypto"
 	authpb "github.com/nexusdeploy/backend/services/auth-service/proto"
+	"github.com/nexusdeploy/backend/services/project-service/deploywindows"
+	"github.com/nexusdeploy/backend/services/project-service/domains"
 	"github.com/nexusdeploy/backend/services/project-service/github"
 	"github.com/nexusdeploy/backend/services/project-service/models"
 	pb "github.com/nexusdeploy/backend/services/project-service/proto"
@@ -19,6 +26,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var log zerolog.Logger
@@ -38,6 +46,7 @@ type ProjectServiceServer struct {
 	githubClient *github.Client
 	authClient   authpb.AuthServiceClient
 	authConn     *grpc.ClientConn
+	verifier     *domains.Verifier
 }
 
 // NewProjectServiceServer creates a new ProjectService server
@@ -48,6 +57,7 @@ func NewProjectServiceServer(db *gorm.DB, cfg *cfgpkg.Config, authClient authpb.
 		githubClient: github.NewClient(),
 		authClient:   authClient,
 		authConn:     authConn,
+		verifier:     domains.NewVerifier(cfg.DomainVerifyResolver, cfg.DomainVerifyTimeout),
 	}
 }
 
@@ -74,8 +84,9 @@ func (s *ProjectServiceServer) CreateProject(ctx context.Context, req *pb.Create
 	}
 
 	// Check permission: enforce max_projects limit (FR7.4)
+	var planResp *authpb.GetUserPlanResponse
 	if s.authClient != nil {
-		planResp, err := s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{
+		planResp, err = s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{
 			UserId: req.UserId,
 		})
 		if err != nil {
@@ -109,6 +120,40 @@ func (s *ProjectServiceServer) CreateProject(ctx context.Context, req *pb.Create
 		}
 	}
 
+	if msg := validateBuildSettings(planResp, req.BuildMemoryMb, req.BuildCpus, req.BuildTimeoutMinutes, req.BuildDiskMb); msg != "" {
+		return &pb.CreateProjectResponse{Error: msg}, nil
+	}
+	replicas := req.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	if msg := validateReplicas(planResp, replicas); msg != "" {
+		return &pb.CreateProjectResponse{Error: msg}, nil
+	}
+
+	rootDir, err := normalizeRootDirectory(req.RootDirectory)
+	if err != nil {
+		return &pb.CreateProjectResponse{Error: err.Error()}, nil
+	}
+	watchPaths, err := normalizeWatchPaths(req.WatchPaths)
+	if err != nil {
+		return &pb.CreateProjectResponse{Error: err.Error()}, nil
+	}
+	buildMatrix, err := normalizeBuildMatrix(req.BuildMatrix)
+	if err != nil {
+		return &pb.CreateProjectResponse{Error: err.Error()}, nil
+	}
+	if req.CloneDepth < 0 {
+		return &pb.CreateProjectResponse{Error: "clone_depth must not be negative"}, nil
+	}
+	healthCheckType, err := normalizeHealthCheckType(req.HealthCheckType)
+	if err != nil {
+		return &pb.CreateProjectResponse{Error: err.Error()}, nil
+	}
+	if msg := validateHealthCheck(req.HealthCheckExpectedStatus, req.HealthCheckIntervalSeconds, req.HealthCheckTimeoutSeconds, req.HealthCheckRetries); msg != "" {
+		return &pb.CreateProjectResponse{Error: msg}, nil
+	}
+
 	// Set defaults
 	branch := req.Branch
 	if branch == "" {
@@ -130,9 +175,40 @@ func (s *ProjectServiceServer) CreateProject(ctx context.Context, req *pb.Create
 		Preset:       req.Preset,
 		BuildCommand: req.BuildCommand,
 		StartCommand: req.StartCommand,
+		TestCommand:  req.TestCommand,
 		Port:         int(port),
 		GithubRepoID: req.GithubRepoId,
 		IsPrivate:    req.IsPrivate,
+
+		BuildMemoryMB:       int(req.BuildMemoryMb),
+		BuildCPUs:           req.BuildCpus,
+		BuildTimeoutMinutes: int(req.BuildTimeoutMinutes),
+		BuildDiskMB:         int(req.BuildDiskMb),
+
+		RootDirectory: rootDir,
+		WatchPaths:    watchPaths,
+
+		CloneSubmodules:  req.CloneSubmodules,
+		CloneLFS:         req.CloneLfs,
+		CloneDepth:       int(req.CloneDepth),
+		CloneFullHistory: req.CloneFullHistory,
+		CloneFetchTags:   req.CloneFetchTags,
+
+		AutoDeploy: req.AutoDeploy,
+
+		AutoCancelPending: req.AutoCancelPending,
+		AutoCancelRunning: req.AutoCancelRunning,
+
+		BuildMatrix: buildMatrix,
+
+		HealthCheckType:           healthCheckType,
+		HealthCheckPath:           strings.TrimSpace(req.HealthCheckPath),
+		HealthCheckExpectedStatus: int(req.HealthCheckExpectedStatus),
+		HealthCheckInterval:       int(req.HealthCheckIntervalSeconds),
+		HealthCheckTimeout:        int(req.HealthCheckTimeoutSeconds),
+		HealthCheckRetries:        int(req.HealthCheckRetries),
+
+		Replicas: int(replicas),
 	}
 
 	if err := s.db.Create(project).Error; err != nil {
@@ -190,7 +266,10 @@ func (s *ProjectServiceServer) GetProject(ctx context.Context, req *pb.GetProjec
 	}
 
 	var project models.Project
-	if err := s.db.First(&project, "id = ?", projectID).Error; err != nil {
+	err = s.db.Preload("Domains", "status = ?", models.DomainStatusVerified).
+		Preload("Environments", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		First(&project, "id = ?", projectID).Error
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &pb.GetProjectResponse{Error: "project not found"}, nil
 		}
@@ -263,6 +342,44 @@ func (s *ProjectServiceServer) GetProjectByRepo(ctx context.Context, req *pb.Get
 	return &pb.GetProjectResponse{Error: "project not found"}, nil
 }
 
+// ListProjectsByRepo returns every project built from a repository, which is more
+// than one when services of a monorepo are separate projects
+func (s *ProjectServiceServer) ListProjectsByRepo(ctx context.Context, req *pb.GetProjectByRepoRequest) (*pb.ListProjectsResponse, error) {
+	log.Info().
+		Str("repo_url", req.RepoUrl).
+		Int64("github_repo_id", req.GithubRepoId).
+		Msg("ListProjectsByRepo called")
+
+	if req.GithubRepoId == 0 && req.RepoUrl == "" {
+		return &pb.ListProjectsResponse{Error: "repo_url or github_repo_id is required"}, nil
+	}
+
+	var projects []models.Project
+	err := s.db.Preload("Domains", "status = ?", models.DomainStatusVerified).Preload("Environments").
+		Order("created_at ASC").Find(&projects).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query projects")
+		return &pb.ListProjectsResponse{Error: "failed to query projects"}, nil
+	}
+
+	// Same matching as GetProjectByRepo: the GitHub repo ID, or the normalized URL
+	normalizedURL := strings.ToLower(strings.TrimSuffix(req.RepoUrl, ".git"))
+	result := make([]*pb.Project, 0)
+	for i := range projects {
+		p := &projects[i]
+		byID := req.GithubRepoId != 0 && p.GithubRepoID == req.GithubRepoId
+		byURL := req.RepoUrl != "" && strings.ToLower(strings.TrimSuffix(p.RepoURL, ".git")) == normalizedURL
+		if byID || byURL {
+			result = append(result, projectToProto(p))
+		}
+	}
+
+	return &pb.ListProjectsResponse{
+		Projects: result,
+		Total:    int32(len(result)),
+	}, nil
+}
+
 // ListProjects lists all projects for a user
 func (s *ProjectServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
 	log.Info().
@@ -364,10 +481,135 @@ func (s *ProjectServiceServer) UpdateProject(ctx context.Context, req *pb.Update
 	if req.StartCommand != "" {
 		updates["start_command"] = req.StartCommand
 	}
+	if req.TestCommand != nil {
+		updates["test_command"] = strings.TrimSpace(*req.TestCommand)
+	}
 	if req.Port > 0 {
 		updates["port"] = req.Port
 	}
 
+	// Build settings and replicas are checked against the current plan whenever one
+	// is changed
+	if req.BuildMemoryMb != 0 || req.BuildCpus != 0 || req.BuildTimeoutMinutes != 0 || req.BuildDiskMb != 0 || req.Replicas != nil {
+		var planResp *authpb.GetUserPlanResponse
+		if s.authClient != nil {
+			planResp, err = s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{
+				UserId: req.UserId,
+			})
+			if err != nil {
+				log.Error().Err(err).Str("user_id", req.UserId).Msg("Failed to get user plan for build settings")
+				return &pb.UpdateProjectResponse{Error: "failed to check user plan"}, nil
+			}
+			if planResp.Error != "" {
+				return &pb.UpdateProjectResponse{Error: "failed to check user plan: " + planResp.Error}, nil
+			}
+		}
+		if msg := validateBuildSettings(planResp, req.BuildMemoryMb, req.BuildCpus, req.BuildTimeoutMinutes, req.BuildDiskMb); msg != "" {
+			return &pb.UpdateProjectResponse{Error: msg}, nil
+		}
+		if req.Replicas != nil {
+			if msg := validateReplicas(planResp, *req.Replicas); msg != "" {
+				return &pb.UpdateProjectResponse{Error: msg}, nil
+			}
+			updates["replicas"] = *req.Replicas
+		}
+	}
+	if req.BuildMemoryMb > 0 {
+		updates["build_memory_mb"] = req.BuildMemoryMb
+	}
+	if req.BuildCpus > 0 {
+		updates["build_cpus"] = req.BuildCpus
+	}
+	if req.BuildTimeoutMinutes > 0 {
+		updates["build_timeout_minutes"] = req.BuildTimeoutMinutes
+	}
+	if req.BuildDiskMb > 0 {
+		updates["build_disk_mb"] = req.BuildDiskMb
+	}
+
+	if req.RootDirectory != nil {
+		rootDir, err := normalizeRootDirectory(*req.RootDirectory)
+		if err != nil {
+			return &pb.UpdateProjectResponse{Error: err.Error()}, nil
+		}
+		updates["root_directory"] = rootDir
+	}
+	if req.ClearWatchPaths || len(req.WatchPaths) > 0 {
+		watchPaths, err := normalizeWatchPaths(req.WatchPaths)
+		if err != nil {
+			return &pb.UpdateProjectResponse{Error: err.Error()}, nil
+		}
+		if req.ClearWatchPaths {
+			watchPaths = nil
+		}
+		data, _ := json.Marshal(watchPaths)
+		updates["watch_paths"] = string(data) // Map updates bypass the json serializer
+	}
+	if req.ClearBuildMatrix || len(req.BuildMatrix) > 0 {
+		buildMatrix, err := normalizeBuildMatrix(req.BuildMatrix)
+		if err != nil {
+			return &pb.UpdateProjectResponse{Error: err.Error()}, nil
+		}
+		if req.ClearBuildMatrix {
+			buildMatrix = nil
+		}
+		data, _ := json.Marshal(buildMatrix)
+		updates["build_matrix"] = string(data)
+	}
+
+	if req.CloneSubmodules != nil {
+		updates["clone_submodules"] = *req.CloneSubmodules
+	}
+	if req.CloneLfs != nil {
+		updates["clone_lfs"] = *req.CloneLfs
+	}
+	if req.CloneDepth != nil {
+		if *req.CloneDepth < 0 {
+			return &pb.UpdateProjectResponse{Error: "clone_depth must not be negative"}, nil
+		}
+		updates["clone_depth"] = *req.CloneDepth
+	}
+	if req.CloneFullHistory != nil {
+		updates["clone_full_history"] = *req.CloneFullHistory
+	}
+	if req.CloneFetchTags != nil {
+		updates["clone_fetch_tags"] = *req.CloneFetchTags
+	}
+	if req.AutoDeploy != nil {
+		updates["auto_deploy"] = *req.AutoDeploy
+	}
+	if req.AutoCancelPending != nil {
+		updates["auto_cancel_pending"] = *req.AutoCancelPending
+	}
+	if req.AutoCancelRunning != nil {
+		updates["auto_cancel_running"] = *req.AutoCancelRunning
+	}
+	if req.HealthCheckType != nil {
+		healthCheckType, err := normalizeHealthCheckType(*req.HealthCheckType)
+		if err != nil {
+			return &pb.UpdateProjectResponse{Error: err.Error()}, nil
+		}
+		updates["health_check_type"] = healthCheckType
+	}
+	if req.HealthCheckPath != nil {
+		updates["health_check_path"] = strings.TrimSpace(*req.HealthCheckPath)
+	}
+	if msg := validateHealthCheck(req.GetHealthCheckExpectedStatus(), req.GetHealthCheckIntervalSeconds(), req.GetHealthCheckTimeoutSeconds(), req.GetHealthCheckRetries()); msg != "" {
+		return &pb.UpdateProjectResponse{Error: msg}, nil
+	}
+	if req.HealthCheckExpectedStatus != nil {
+		updates["health_check_expected_status"] = *req.HealthCheckExpectedStatus
+	}
+	if req.HealthCheckIntervalSeconds != nil {
+		updates["health_check_interval"] = *req.HealthCheckIntervalSeconds
+	}
+	if req.HealthCheckTimeoutSeconds != nil {
+		updates["health_check_timeout"] = *req.HealthCheckTimeoutSeconds
+	}
+	if req.HealthCheckRetries != nil {
+		updates["health_check_retries"] = *req.HealthCheckRetries
+	}
+
 	if len(updates) > 0 {
 		if err := s.db.Model(&project).Updates(updates).Error; err != nil {
 			log.Error().Err(err).Msg("Failed to update project")
@@ -587,6 +829,108 @@ func (s *ProjectServiceServer) DeleteWebhook(ctx context.Context, req *pb.Delete
 	return &pb.DeleteWebhookResponse{Success: true}, nil
 }
 
+// defaultStatusContext names the commit statuses posted without a context
+const defaultStatusContext = "nexusdeploy"
+
+// SetCommitStatus sets a status on a commit of the repository of a project, with
+// the GitHub token of the project owner. Internal: called for pull request
+// previews, which are not triggered by a user.
+func (s *ProjectServiceServer) SetCommitStatus(ctx context.Context, req *pb.SetCommitStatusRequest) (*pb.SetCommitStatusResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Str("commit_sha", req.CommitSha).
+		Str("state", req.State).
+		Msg("SetCommitStatus called (internal)")
+
+	if req.ProjectId == "" || req.CommitSha == "" {
+		return &pb.SetCommitStatusResponse{Error: "project_id and commit_sha are required"}, nil
+	}
+	switch req.State {
+	case github.StatusPending, github.StatusSuccess, github.StatusFailure, github.StatusError:
+	default:
+		return &pb.SetCommitStatusResponse{Error: fmt.Sprintf("invalid state %q", req.State)}, nil
+	}
+
+	projectID, err := uuid.Parse(req.ProjectId)
+	if err != nil {
+		return &pb.SetCommitStatusResponse{Error: "invalid project_id format"}, nil
+	}
+
+	var project models.Project
+	if err := s.db.First(&project, "id = ?", projectID).Error; err != nil {
+		return &pb.SetCommitStatusResponse{Error: "project not found"}, nil
+	}
+
+	owner, repo, err := github.ParseRepoURL(project.RepoURL)
+	if err != nil {
+		return &pb.SetCommitStatusResponse{Error: "invalid repo URL"}, nil
+	}
+
+	if s.authClient == nil {
+		return &pb.SetCommitStatusResponse{Error: "auth service unavailable"}, nil
+	}
+	tokenResp, err := s.authClient.GetGitHubToken(ctx, &authpb.GetGitHubTokenRequest{UserId: project.UserID.String()})
+	if err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to get GitHub token of project owner")
+		return &pb.SetCommitStatusResponse{Error: "failed to get GitHub token"}, nil
+	}
+	if tokenResp.Error != "" || tokenResp.GithubToken == "" {
+		return &pb.SetCommitStatusResponse{Error: "project owner has no GitHub token"}, nil
+	}
+
+	statusContext := req.Context
+	if statusContext == "" {
+		statusContext = defaultStatusContext
+	}
+	err = s.githubClient.CreateCommitStatus(ctx, tokenResp.GithubToken, owner, repo, req.CommitSha, github.CommitStatus{
+		State:       req.State,
+		TargetURL:   req.TargetUrl,
+		Description: req.Description,
+		Context:     statusContext,
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("project_id", req.ProjectId).Str("commit_sha", req.CommitSha).Msg("Failed to set commit status")
+		return &pb.SetCommitStatusResponse{Error: "failed to set commit status: " + err.Error()}, nil
+	}
+
+	return &pb.SetCommitStatusResponse{Success: true}, nil
+}
+
+// GetRepoToken returns the GitHub token of the project owner, for the Runner to
+// clone private repositories. Internal: the Runner asks for it at the start of
+// every job, so the token is never stored in the queue.
+func (s *ProjectServiceServer) GetRepoToken(ctx context.Context, req *pb.GetRepoTokenRequest) (*pb.GetRepoTokenResponse, error) {
+	log.Info().Str("project_id", req.ProjectId).Msg("GetRepoToken called (internal)")
+
+	if req.ProjectId == "" {
+		return &pb.GetRepoTokenResponse{Error: "project_id is required"}, nil
+	}
+	projectID, err := uuid.Parse(req.ProjectId)
+	if err != nil {
+		return &pb.GetRepoTokenResponse{Error: "invalid project_id format"}, nil
+	}
+
+	var project models.Project
+	if err := s.db.First(&project, "id = ?", projectID).Error; err != nil {
+		return &pb.GetRepoTokenResponse{Error: "project not found"}, nil
+	}
+
+	if s.authClient == nil {
+		return &pb.GetRepoTokenResponse{Error: "auth service unavailable"}, nil
+	}
+	tokenResp, err := s.authClient.GetGitHubToken(ctx, &authpb.GetGitHubTokenRequest{UserId: project.UserID.String()})
+	if err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to get GitHub token of project owner")
+		return &pb.GetRepoTokenResponse{Error: "failed to get GitHub token"}, nil
+	}
+	// Owners without a linked GitHub account can still build public repositories
+	if tokenResp.Error != "" {
+		return &pb.GetRepoTokenResponse{}, nil
+	}
+
+	return &pb.GetRepoTokenResponse{Token: tokenResp.GithubToken}, nil
+}
+
 // ==================== Secrets Management ====================
 
 // AddSecret adds an encrypted secret to a project
@@ -594,6 +938,7 @@ func (s *ProjectServiceServer) AddSecret(ctx context.Context, req *pb.AddSecretR
 	log.Info().
 		Str("project_id", req.ProjectId).
 		Str("name", req.Name).
+		Str("environment", req.Environment).
 		Msg("AddSecret called")
 
 	if req.ProjectId == "" || req.Name == "" || req.Value == "" {
@@ -618,6 +963,11 @@ func (s *ProjectServiceServer) AddSecret(ctx context.Context, req *pb.AddSecretR
 		}
 	}
 
+	env, msg := s.projectEnvironment(ctx, projectID, req.Environment)
+	if msg != "" {
+		return &pb.AddSecretResponse{Error: msg}, nil
+	}
+
 	// Encrypt value
 	encryptedValue, err := crypto.EncryptString(req.Value, s.cfg.EncryptionKey)
 	if err != nil {
@@ -631,6 +981,9 @@ func (s *ProjectServiceServer) AddSecret(ctx context.Context, req *pb.AddSecretR
 		Name:           req.Name,
 		EncryptedValue: []byte(encryptedValue),
 	}
+	if env != nil {
+		secret.EnvironmentID = &env.ID
+	}
 
 	if err := s.db.Create(secret).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to create secret")
@@ -638,7 +991,7 @@ func (s *ProjectServiceServer) AddSecret(ctx context.Context, req *pb.AddSecretR
 	}
 
 	return &pb.AddSecretResponse{
-		Secret: secretToProto(secret),
+		Secret: secretToProto(secret, s.environmentNames(ctx, projectID)),
 	}, nil
 }
 
@@ -686,7 +1039,7 @@ func (s *ProjectServiceServer) UpdateSecret(ctx context.Context, req *pb.UpdateS
 	}
 
 	return &pb.UpdateSecretResponse{
-		Secret: secretToProto(&secret),
+		Secret: secretToProto(&secret, s.environmentNames(ctx, secret.ProjectID)),
 	}, nil
 }
 
@@ -757,17 +1110,19 @@ func (s *ProjectServiceServer) ListSecrets(ctx context.Context, req *pb.ListSecr
 		return &pb.ListSecretsResponse{Error: "failed to list secrets"}, nil
 	}
 
+	environments := s.environmentNames(ctx, projectID)
 	protoSecrets := make([]*pb.Secret, len(secrets))
-	for i, s := range secrets {
-		protoSecrets[i] = secretToProto(&s)
+	for i := range secrets {
+		protoSecrets[i] = secretToProto(&secrets[i], environments)
 	}
 
 	return &pb.ListSecretsResponse{Secrets: protoSecrets}, nil
 }
 
-// GetSecrets returns decrypted secrets for Runner Service (internal use)
+// GetSecrets returns decrypted secrets for Runner Service (internal use). The
+// secrets of an environment override the project secrets of the same name.
 func (s *ProjectServiceServer) GetSecrets(ctx context.Context, req *pb.GetSecretsRequest) (*pb.GetSecretsResponse, error) {
-	log.Info().Str("project_id", req.ProjectId).Msg("GetSecrets called (internal)")
+	log.Info().Str("project_id", req.ProjectId).Str("environment", req.Environment).Msg("GetSecrets called (internal)")
 
 	if req.ProjectId == "" {
 		return &pb.GetSecretsResponse{Error: "project_id is required"}, nil
@@ -778,8 +1133,18 @@ func (s *ProjectServiceServer) GetSecrets(ctx context.Context, req *pb.GetSecret
 		return &pb.GetSecretsResponse{Error: "invalid project_id format"}, nil
 	}
 
+	env, msg := s.projectEnvironment(ctx, projectID, req.Environment)
+	if msg != "" {
+		return &pb.GetSecretsResponse{Error: msg}, nil
+	}
+
+	query := s.db.Where("project_id = ? AND environment_id IS NULL", projectID)
+	if env != nil {
+		query = s.db.Where("project_id = ? AND (environment_id IS NULL OR environment_id = ?)", projectID, env.ID).
+			Order("environment_id NULLS FIRST")
+	}
 	var secrets []models.Secret
-	if err := s.db.Where("project_id = ?", projectID).Find(&secrets).Error; err != nil {
+	if err := query.Find(&secrets).Error; err != nil {
 		return &pb.GetSecretsResponse{Error: "failed to get secrets"}, nil
 	}
 
@@ -796,32 +1161,1260 @@ func (s *ProjectServiceServer) GetSecrets(ctx context.Context, req *pb.GetSecret
 	return &pb.GetSecretsResponse{Secrets: result}, nil
 }
 
-// ==================== Helper Functions ====================
+// ==================== Custom Domains ====================
 
-func projectToProto(p *models.Project) *pb.Project {
-	return &pb.Project{
-		Id:           p.ID.String(),
-		UserId:       p.UserID.String(),
-		Name:         p.Name,
-		RepoUrl:      p.RepoURL,
-		Branch:       p.Branch,
-		Preset:       p.Preset,
-		BuildCommand: p.BuildCommand,
-		StartCommand: p.StartCommand,
-		Port:         int32(p.Port),
-		GithubRepoId: p.GithubRepoID,
-		IsPrivate:    p.IsPrivate,
-		CreatedAt:    timestamppb.New(p.CreatedAt),
-		UpdatedAt:    timestamppb.New(p.UpdatedAt),
+// maxDomainsPerProject limits the custom domains of a project
+const maxDomainsPerProject = 20
+
+// AddDomain adds a custom domain to a project. It is routed once VerifyDomain
+// finds the TXT record returned with the domain.
+func (s *ProjectServiceServer) AddDomain(ctx context.Context, req *pb.AddDomainRequest) (*pb.AddDomainResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Str("domain", req.Name).
+		Msg("AddDomain called")
+
+	if req.ProjectId == "" || req.UserId == "" || req.Name == "" {
+		return &pb.AddDomainResponse{Error: "project_id, user_id and name are required"}, nil
 	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.AddDomainResponse{Error: msg}, nil
+	}
+	if msg := s.checkCustomDomainPermission(ctx, req.UserId); msg != "" {
+		return &pb.AddDomainResponse{Error: msg}, nil
+	}
+
+	name, err := domains.Normalize(req.Name)
+	if err != nil {
+		return &pb.AddDomainResponse{Error: err.Error()}, nil
+	}
+	if suffix := strings.ToLower(s.cfg.TraefikDomainSuffix); suffix != "" && (name == suffix || strings.HasSuffix(name, "."+suffix)) {
+		return &pb.AddDomainResponse{Error: fmt.Sprintf("domains under %s are assigned by NexusDeploy", suffix)}, nil
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Domain{}).Where("project_id = ?", project.ID).Count(&count).Error; err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to count domains")
+		return &pb.AddDomainResponse{Error: "failed to add domain"}, nil
+	}
+	if count >= maxDomainsPerProject {
+		return &pb.AddDomainResponse{Error: fmt.Sprintf("a project can have at most %d custom domains", maxDomainsPerProject)}, nil
+	}
+
+	var existing []models.Domain
+	if err := s.db.WithContext(ctx).Where("name = ?", name).Find(&existing).Error; err != nil {
+		log.Error().Err(err).Str("domain", name).Msg("Failed to look up domain")
+		return &pb.AddDomainResponse{Error: "failed to add domain"}, nil
+	}
+	for _, d := range existing {
+		if d.ProjectID == project.ID {
+			return &pb.AddDomainResponse{Error: "domain already added to this project"}, nil
+		}
+		if d.Status == models.DomainStatusVerified {
+			return &pb.AddDomainResponse{Error: "domain is already used by another project"}, nil
+		}
+	}
+
+	token, err := domains.NewToken()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate verification token")
+		return &pb.AddDomainResponse{Error: "failed to add domain"}, nil
+	}
+
+	domain := &models.Domain{
+		ProjectID:         project.ID,
+		Name:              name,
+		VerificationToken: token,
+		Status:            models.DomainStatusPending,
+	}
+	if err := s.db.WithContext(ctx).Create(domain).Error; err != nil {
+		log.Error().Err(err).Str("domain", name).Msg("Failed to create domain")
+		return &pb.AddDomainResponse{Error: "failed to add domain"}, nil
+	}
+
+	return &pb.AddDomainResponse{Domain: domainToProto(domain)}, nil
 }
 
-func secretToProto(s *models.Secret) *pb.Secret {
-	return &pb.Secret{
-		Id:        s.ID.String(),
-		ProjectId: s.ProjectID.String(),
-		Name:      s.Name,
-		CreatedAt: timestamppb.New(s.CreatedAt),
-		UpdatedAt: timestamppb.New(s.UpdatedAt),
+// VerifyDomain looks up the TXT record of a domain and stores the outcome. A
+// verified domain whose record is gone is no longer routed from the next
+// deployment on; failed lookups leave the status unchanged.
+func (s *ProjectServiceServer) VerifyDomain(ctx context.Context, req *pb.VerifyDomainRequest) (*pb.VerifyDomainResponse, error) {
+	log.Info().
+		Str("domain_id", req.DomainId).
+		Str("project_id", req.ProjectId).
+		Msg("VerifyDomain called")
+
+	if req.DomainId == "" || req.ProjectId == "" || req.UserId == "" {
+		return &pb.VerifyDomainResponse{Error: "domain_id, project_id and user_id are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.VerifyDomainResponse{Error: msg}, nil
+	}
+	domain, msg := s.projectDomain(ctx, project, req.DomainId)
+	if msg != "" {
+		return &pb.VerifyDomainResponse{Error: msg}, nil
+	}
+	if msg := s.checkCustomDomainPermission(ctx, req.UserId); msg != "" {
+		return &pb.VerifyDomainResponse{Error: msg}, nil
+	}
+
+	// The DNS lookup runs before the transaction, so it holds no row locks
+	verifyErr := s.verifier.Verify(ctx, domain.Name, domain.VerificationToken)
+	if verifyErr != nil && !errors.Is(verifyErr, domains.ErrRecordNotFound) {
+		log.Warn().Err(verifyErr).Str("domain", domain.Name).Msg("Domain verification lookup failed")
+		return &pb.VerifyDomainResponse{Error: "DNS lookup failed, try again later"}, nil
+	}
+
+	const takenError = "domain is already used by another project"
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking every claim of the name makes concurrent verifications of it wait
+		// for each other, so the second one sees the first one verified
+		var claims []models.Domain
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("name = ?", domain.Name).
+			Find(&claims).Error; err != nil {
+			return err
+		}
+		taken := false
+		for _, claim := range claims {
+			if claim.ProjectID != project.ID && claim.Status == models.DomainStatusVerified {
+				taken = true
+			}
+		}
+
+		switch {
+		case taken:
+			domain.Status = models.DomainStatusFailed
+			domain.Error = takenError
+		case verifyErr == nil:
+			now := time.Now()
+			domain.Status = models.DomainStatusVerified
+			domain.Error = ""
+			domain.VerifiedAt = &now
+		default:
+			domain.Status = models.DomainStatusFailed
+			domain.Error = fmt.Sprintf("no TXT record %s with value %s", domains.RecordName(domain.Name), domains.RecordValue(domain.VerificationToken))
+		}
+		return tx.Save(domain).Error
+	})
+	// The unique index on verified names catches what the locks cannot, e.g. a
+	// claim added after they were taken
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		domain.Status = models.DomainStatusFailed
+		domain.Error = takenError
+		domain.VerifiedAt = nil
+		err = s.db.WithContext(ctx).Save(domain).Error
+	}
+	if err != nil {
+		log.Error().Err(err).Str("domain", domain.Name).Msg("Failed to store domain verification")
+		return &pb.VerifyDomainResponse{Error: "failed to verify domain"}, nil
+	}
+
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Str("domain", domain.Name).
+		Str("status", domain.Status).
+		Msg("Domain verification checked")
+
+	return &pb.VerifyDomainResponse{Domain: domainToProto(domain)}, nil
+}
+
+// RemoveDomain removes a custom domain from a project. The running deployment
+// keeps serving it until the next deployment.
+func (s *ProjectServiceServer) RemoveDomain(ctx context.Context, req *pb.RemoveDomainRequest) (*pb.RemoveDomainResponse, error) {
+	log.Info().
+		Str("domain_id", req.DomainId).
+		Str("project_id", req.ProjectId).
+		Msg("RemoveDomain called")
+
+	if req.DomainId == "" || req.ProjectId == "" || req.UserId == "" {
+		return &pb.RemoveDomainResponse{Success: false, Error: "domain_id, project_id and user_id are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.RemoveDomainResponse{Success: false, Error: msg}, nil
+	}
+	domain, msg := s.projectDomain(ctx, project, req.DomainId)
+	if msg != "" {
+		return &pb.RemoveDomainResponse{Success: false, Error: msg}, nil
+	}
+
+	if err := s.db.WithContext(ctx).Delete(domain).Error; err != nil {
+		log.Error().Err(err).Str("domain", domain.Name).Msg("Failed to delete domain")
+		return &pb.RemoveDomainResponse{Success: false, Error: "failed to remove domain"}, nil
+	}
+
+	return &pb.RemoveDomainResponse{Success: true}, nil
+}
+
+// ListDomains lists the custom domains of a project with their verification status
+func (s *ProjectServiceServer) ListDomains(ctx context.Context, req *pb.ListDomainsRequest) (*pb.ListDomainsResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Msg("ListDomains called")
+
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.ListDomainsResponse{Error: "project_id and user_id are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.ListDomainsResponse{Error: msg}, nil
+	}
+
+	var list []models.Domain
+	if err := s.db.WithContext(ctx).Where("project_id = ?", project.ID).Order("created_at ASC").Find(&list).Error; err != nil {
+		return &pb.ListDomainsResponse{Error: "failed to list domains"}, nil
+	}
+
+	protoDomains := make([]*pb.Domain, len(list))
+	for i := range list {
+		protoDomains[i] = domainToProto(&list[i])
+	}
+
+	return &pb.ListDomainsResponse{Domains: protoDomains}, nil
+}
+
+// ownedProject returns a project of a user, or the error to report
+func (s *ProjectServiceServer) ownedProject(ctx context.Context, projectID, userID string) (*models.Project, string) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, "invalid project_id format"
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, "invalid user_id format"
+	}
+
+	var project models.Project
+	if err := s.db.WithContext(ctx).First(&project, "id = ? AND user_id = ?", pid, uid).Error; err != nil {
+		return nil, "project not found or permission denied"
+	}
+	return &project, ""
+}
+
+// projectByID loads a project regardless of its owner, or returns the error to report
+func (s *ProjectServiceServer) projectByID(ctx context.Context, projectID string) (*models.Project, string) {
+	pid, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, "invalid project_id format"
+	}
+
+	var project models.Project
+	if err := s.db.WithContext(ctx).First(&project, "id = ?", pid).Error; err != nil {
+		return nil, "project not found"
+	}
+	return &project, ""
+}
+
+// userPlan returns the plan limits of a user, or the error to report. Without
+// Auth Service the plan is nil and only the fixed limits apply.
+func (s *ProjectServiceServer) userPlan(ctx context.Context, userID string) (*authpb.GetUserPlanResponse, string) {
+	if s.authClient == nil {
+		return nil, ""
+	}
+	plan, err := s.authClient.GetUserPlan(ctx, &authpb.GetUserPlanRequest{UserId: userID})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to get user plan")
+		return nil, "failed to check user plan"
+	}
+	if plan.Error != "" {
+		return nil, "failed to check user plan: " + plan.Error
+	}
+	return plan, ""
+}
+
+// projectDomain returns a custom domain of a project, or the error to report
+func (s *ProjectServiceServer) projectDomain(ctx context.Context, project *models.Project, domainID string) (*models.Domain, string) {
+	id, err := uuid.Parse(domainID)
+	if err != nil {
+		return nil, "invalid domain_id format"
+	}
+
+	var domain models.Domain
+	if err := s.db.WithContext(ctx).First(&domain, "id = ? AND project_id = ?", id, project.ID).Error; err != nil {
+		return nil, "domain not found"
+	}
+	return &domain, ""
+}
+
+// checkCustomDomainPermission asks Auth Service whether the plan of a user allows
+// custom domains. It returns the reason to report, or "" if allowed.
+func (s *ProjectServiceServer) checkCustomDomainPermission(ctx context.Context, userID string) string {
+	if s.authClient == nil {
+		return ""
+	}
+	resp, err := s.authClient.CheckPermission(ctx, &authpb.CheckPermissionRequest{
+		UserId:       userID,
+		ResourceType: "custom_domain",
+		Action:       "write",
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to check custom domain permission")
+		return "failed to check permission"
+	}
+	if !resp.Allowed {
+		return resp.Reason
+	}
+	return ""
+}
+
+// ==================== Environments ====================
+
+// Limits of the environments of a project, the plan of the owner may set lower
+// ones. Containers of an environment default to 512 MB and 1 CPU, like production.
+const (
+	maxEnvironmentsPerProject = 5
+	maxEnvironmentMemoryMB    = 8192
+	maxEnvironmentCPUCores    = 4
+)
+
+// environmentNamePattern matches environment names, which are used as DNS labels
+// in the domain of the environment and in container names
+var environmentNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,30}[a-z0-9]$`)
+
+// CreateEnvironment adds an environment to a project. Pushes to its branch are
+// built for it from then on.
+func (s *ProjectServiceServer) CreateEnvironment(ctx context.Context, req *pb.CreateEnvironmentRequest) (*pb.CreateEnvironmentResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Str("environment", req.Name).
+		Msg("CreateEnvironment called")
+
+	if req.ProjectId == "" || req.UserId == "" || req.Name == "" || req.Branch == "" {
+		return &pb.CreateEnvironmentResponse{Error: "project_id, user_id, name and branch are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.CreateEnvironmentResponse{Error: msg}, nil
+	}
+
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	switch {
+	case name == models.ProductionEnvironment:
+		return &pb.CreateEnvironmentResponse{Error: "production is the project itself"}, nil
+	case !environmentNamePattern.MatchString(name):
+		return &pb.CreateEnvironmentResponse{Error: "name must be 2 to 32 lowercase letters, digits or hyphens, starting with a letter"}, nil
+	case strings.HasPrefix(name, "pr-"):
+		return &pb.CreateEnvironmentResponse{Error: "names starting with pr- are used by pull request previews"}, nil
+	}
+
+	plan, msg := s.userPlan(ctx, req.UserId)
+	if msg != "" {
+		return &pb.CreateEnvironmentResponse{Error: msg}, nil
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Environment{}).Where("project_id = ?", project.ID).Count(&count).Error; err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to count environments")
+		return &pb.CreateEnvironmentResponse{Error: "failed to create environment"}, nil
+	}
+	if count >= maxEnvironmentsPerProject {
+		return &pb.CreateEnvironmentResponse{Error: fmt.Sprintf("a project can have at most %d environments besides production", maxEnvironmentsPerProject)}, nil
+	}
+	if plan != nil && plan.MaxEnvironments > 0 && count >= int64(plan.MaxEnvironments) {
+		return &pb.CreateEnvironmentResponse{Error: fmt.Sprintf("You have reached the environment limit for the %s plan (%d environments besides production). Please upgrade your plan to create more environments.", plan.Plan, plan.MaxEnvironments)}, nil
+	}
+
+	var existing int64
+	if err := s.db.WithContext(ctx).Model(&models.Environment{}).Where("project_id = ? AND name = ?", project.ID, name).Count(&existing).Error; err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to look up environment")
+		return &pb.CreateEnvironmentResponse{Error: "failed to create environment"}, nil
+	}
+	if existing > 0 {
+		return &pb.CreateEnvironmentResponse{Error: "environment already exists"}, nil
+	}
+
+	env := &models.Environment{ProjectID: project.ID, Name: name}
+	if msg := s.applyEnvironmentSettings(ctx, plan, env, req.Branch, req.Domain, req.MemoryMb, req.CpuCores, req.AutoDeploy); msg != "" {
+		return &pb.CreateEnvironmentResponse{Error: msg}, nil
+	}
+
+	if err := s.db.WithContext(ctx).Create(env).Error; err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to create environment")
+		return &pb.CreateEnvironmentResponse{Error: "failed to create environment"}, nil
+	}
+
+	return &pb.CreateEnvironmentResponse{Environment: environmentToProto(env)}, nil
+}
+
+// UpdateEnvironment replaces the settings of an environment. They apply from the
+// next build and deployment on.
+func (s *ProjectServiceServer) UpdateEnvironment(ctx context.Context, req *pb.UpdateEnvironmentRequest) (*pb.UpdateEnvironmentResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Str("environment", req.Name).
+		Msg("UpdateEnvironment called")
+
+	if req.ProjectId == "" || req.UserId == "" || req.Name == "" || req.Branch == "" {
+		return &pb.UpdateEnvironmentResponse{Error: "project_id, user_id, name and branch are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.UpdateEnvironmentResponse{Error: msg}, nil
+	}
+	env, msg := s.projectEnvironment(ctx, project.ID, req.Name)
+	if msg != "" {
+		return &pb.UpdateEnvironmentResponse{Error: msg}, nil
+	}
+	if env == nil {
+		return &pb.UpdateEnvironmentResponse{Error: "production is configured in the project settings"}, nil
+	}
+
+	plan, msg := s.userPlan(ctx, req.UserId)
+	if msg != "" {
+		return &pb.UpdateEnvironmentResponse{Error: msg}, nil
+	}
+	if msg := s.applyEnvironmentSettings(ctx, plan, env, req.Branch, req.Domain, req.MemoryMb, req.CpuCores, req.AutoDeploy); msg != "" {
+		return &pb.UpdateEnvironmentResponse{Error: msg}, nil
+	}
+
+	if err := s.db.WithContext(ctx).Save(env).Error; err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Str("environment", env.Name).Msg("Failed to update environment")
+		return &pb.UpdateEnvironmentResponse{Error: "failed to update environment"}, nil
+	}
+
+	return &pb.UpdateEnvironmentResponse{Environment: environmentToProto(env)}, nil
+}
+
+// DeleteEnvironment deletes an environment with its secrets
+func (s *ProjectServiceServer) DeleteEnvironment(ctx context.Context, req *pb.DeleteEnvironmentRequest) (*pb.DeleteEnvironmentResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Str("environment", req.Name).
+		Msg("DeleteEnvironment called")
+
+	if req.ProjectId == "" || req.UserId == "" || req.Name == "" {
+		return &pb.DeleteEnvironmentResponse{Success: false, Error: "project_id, user_id and name are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.DeleteEnvironmentResponse{Success: false, Error: msg}, nil
+	}
+	env, msg := s.projectEnvironment(ctx, project.ID, req.Name)
+	if msg != "" {
+		return &pb.DeleteEnvironmentResponse{Success: false, Error: msg}, nil
+	}
+	if env == nil {
+		return &pb.DeleteEnvironmentResponse{Success: false, Error: "production cannot be deleted"}, nil
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("environment_id = ?", env.ID).Delete(&models.Secret{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(env).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Str("environment", env.Name).Msg("Failed to delete environment")
+		return &pb.DeleteEnvironmentResponse{Success: false, Error: "failed to delete environment"}, nil
+	}
+
+	return &pb.DeleteEnvironmentResponse{Success: true}, nil
+}
+
+// ListEnvironments lists the environments of a project besides production
+func (s *ProjectServiceServer) ListEnvironments(ctx context.Context, req *pb.ListEnvironmentsRequest) (*pb.ListEnvironmentsResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Msg("ListEnvironments called")
+
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.ListEnvironmentsResponse{Error: "project_id and user_id are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.ListEnvironmentsResponse{Error: msg}, nil
+	}
+
+	var list []models.Environment
+	if err := s.db.WithContext(ctx).Where("project_id = ?", project.ID).Order("name ASC").Find(&list).Error; err != nil {
+		return &pb.ListEnvironmentsResponse{Error: "failed to list environments"}, nil
+	}
+
+	environments := make([]*pb.Environment, len(list))
+	for i := range list {
+		environments[i] = environmentToProto(&list[i])
+	}
+
+	return &pb.ListEnvironmentsResponse{Environments: environments}, nil
+}
+
+// applyEnvironmentSettings validates the settings of an environment against the
+// plan of the owner and sets them, it returns the error to report. The domain must
+// be a verified custom domain of the project not assigned to another environment.
+func (s *ProjectServiceServer) applyEnvironmentSettings(ctx context.Context, plan *authpb.GetUserPlanResponse, env *models.Environment, branch, domain string, memoryMB, cpuCores int32, autoDeploy bool) string {
+	if memoryMB < 0 || memoryMB > maxEnvironmentMemoryMB {
+		return fmt.Sprintf("memory_mb must be between 0 and %d", maxEnvironmentMemoryMB)
+	}
+	if cpuCores < 0 || cpuCores > maxEnvironmentCPUCores {
+		return fmt.Sprintf("cpu_cores must be between 0 and %d", maxEnvironmentCPUCores)
+	}
+	if plan != nil {
+		switch {
+		case plan.MaxEnvironmentMemoryMb > 0 && memoryMB > plan.MaxEnvironmentMemoryMb:
+			return fmt.Sprintf("Environment memory of %d MB exceeds the limit for the %s plan (%d MB).", memoryMB, plan.Plan, plan.MaxEnvironmentMemoryMb)
+		case plan.MaxEnvironmentCpuCores > 0 && cpuCores > plan.MaxEnvironmentCpuCores:
+			return fmt.Sprintf("Environment CPU cores of %d exceed the limit for the %s plan (%d cores).", cpuCores, plan.Plan, plan.MaxEnvironmentCpuCores)
+		}
+	}
+
+	if domain != "" {
+		name, err := domains.Normalize(domain)
+		if err != nil {
+			return err.Error()
+		}
+		var verified int64
+		err = s.db.WithContext(ctx).Model(&models.Domain{}).
+			Where("project_id = ? AND name = ? AND status = ?", env.ProjectID, name, models.DomainStatusVerified).
+			Count(&verified).Error
+		if err != nil {
+			log.Error().Err(err).Str("domain", name).Msg("Failed to look up domain")
+			return "failed to check domain"
+		}
+		if verified == 0 {
+			return "domain must be a verified custom domain of the project"
+		}
+		var taken int64
+		err = s.db.WithContext(ctx).Model(&models.Environment{}).
+			Where("project_id = ? AND domain = ? AND id <> ?", env.ProjectID, name, env.ID).
+			Count(&taken).Error
+		if err != nil {
+			log.Error().Err(err).Str("domain", name).Msg("Failed to look up domain")
+			return "failed to check domain"
+		}
+		if taken > 0 {
+			return "domain is already assigned to another environment"
+		}
+		domain = name
+	}
+
+	env.Branch = strings.TrimSpace(branch)
+	env.Domain = domain
+	env.MemoryMB = int(memoryMB)
+	env.CPUCores = int(cpuCores)
+	env.AutoDeploy = autoDeploy
+	return ""
+}
+
+// projectEnvironment returns an environment of a project by name, or the error to
+// report. It returns nil for production, named "" or "production".
+func (s *ProjectServiceServer) projectEnvironment(ctx context.Context, projectID uuid.UUID, name string) (*models.Environment, string) {
+	if name == "" || name == models.ProductionEnvironment {
+		return nil, ""
+	}
+
+	var env models.Environment
+	if err := s.db.WithContext(ctx).First(&env, "project_id = ? AND name = ?", projectID, name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Sprintf("environment %s not found", name)
+		}
+		log.Error().Err(err).Str("environment", name).Msg("Failed to get environment")
+		return nil, "failed to get environment"
+	}
+	return &env, ""
+}
+
+// environmentNames maps the environment IDs of a project to their names
+func (s *ProjectServiceServer) environmentNames(ctx context.Context, projectID uuid.UUID) map[uuid.UUID]string {
+	var list []models.Environment
+	if err := s.db.WithContext(ctx).Select("id", "name").Where("project_id = ?", projectID).Find(&list).Error; err != nil {
+		log.Warn().Err(err).Str("project_id", projectID.String()).Msg("Failed to list environments")
+	}
+
+	names := make(map[uuid.UUID]string, len(list))
+	for _, env := range list {
+		names[env.ID] = env.Name
+	}
+	return names
+}
+
+// ==================== Deploy Policy ====================
+
+// Codes of CheckDeploy when a deploy is not allowed
+const (
+	deployCodeFrozen                 = "deploy_frozen"
+	deployCodeOutsideWindow          = "outside_deploy_window"
+	deployCodeOverrideReasonRequired = "override_reason_required"
+	deployCodeOverrideNotPermitted   = "override_not_permitted"
+)
+
+const (
+	maxDeployWindowsPerProject = 10
+	deployAuditEntriesShown    = 20
+)
+
+// GetDeployPolicy returns the deploy windows and freeze of a project, whether
+// production can be deployed now, and the latest audit entries
+func (s *ProjectServiceServer) GetDeployPolicy(ctx context.Context, req *pb.GetDeployPolicyRequest) (*pb.DeployPolicyResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Msg("GetDeployPolicy called")
+
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.DeployPolicyResponse{Error: "project_id and user_id are required"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.DeployPolicyResponse{Error: msg}, nil
+	}
+
+	policy, msg := s.deployPolicy(ctx, project)
+	if msg != "" {
+		return &pb.DeployPolicyResponse{Error: msg}, nil
+	}
+	return &pb.DeployPolicyResponse{Policy: policy}, nil
+}
+
+// SetDeployWindows replaces the deploy windows of a project. Without windows
+// production can be deployed at any time.
+func (s *ProjectServiceServer) SetDeployWindows(ctx context.Context, req *pb.SetDeployWindowsRequest) (*pb.DeployPolicyResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Int("windows", len(req.Windows)).
+		Msg("SetDeployWindows called")
+
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.DeployPolicyResponse{Error: "project_id and user_id are required"}, nil
+	}
+	if len(req.Windows) > maxDeployWindowsPerProject {
+		return &pb.DeployPolicyResponse{Error: fmt.Sprintf("a project can have at most %d deploy windows", maxDeployWindowsPerProject)}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.DeployPolicyResponse{Error: msg}, nil
+	}
+
+	windows := make([]models.DeployWindow, len(req.Windows))
+	descriptions := make([]string, len(req.Windows))
+	for i, w := range req.Windows {
+		windows[i] = models.DeployWindow{
+			ProjectID:       project.ID,
+			Schedule:        strings.TrimSpace(w.Schedule),
+			DurationMinutes: int(w.DurationMinutes),
+			Timezone:        strings.TrimSpace(w.Timezone),
+		}
+		if err := deploywindows.Validate(toDeployWindow(windows[i])); err != nil {
+			return &pb.DeployPolicyResponse{Error: fmt.Sprintf("window %d: %v", i+1, err)}, nil
+		}
+		descriptions[i] = describeDeployWindow(windows[i])
+	}
+
+	summary := "deploys allowed at any time"
+	if len(descriptions) > 0 {
+		summary = strings.Join(descriptions, "; ")
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", project.ID).Delete(&models.DeployWindow{}).Error; err != nil {
+			return err
+		}
+		if len(windows) > 0 {
+			if err := tx.Create(&windows).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&models.DeployAuditEntry{
+			ProjectID: project.ID,
+			UserID:    project.UserID,
+			Action:    models.DeployAuditWindows,
+			Reason:    summary,
+		}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to set deploy windows")
+		return &pb.DeployPolicyResponse{Error: "failed to set deploy windows"}, nil
+	}
+
+	policy, msg := s.deployPolicy(ctx, project)
+	if msg != "" {
+		return &pb.DeployPolicyResponse{Error: msg}, nil
+	}
+	return &pb.DeployPolicyResponse{Policy: policy}, nil
+}
+
+// SetDeployFreeze freezes production deploys of a project with a reason, or
+// lifts the freeze. Both are recorded in the audit log.
+func (s *ProjectServiceServer) SetDeployFreeze(ctx context.Context, req *pb.SetDeployFreezeRequest) (*pb.DeployPolicyResponse, error) {
+	log.Info().
+		Str("project_id", req.ProjectId).
+		Bool("frozen", req.Frozen).
+		Msg("SetDeployFreeze called")
+
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.DeployPolicyResponse{Error: "project_id and user_id are required"}, nil
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if req.Frozen && reason == "" {
+		return &pb.DeployPolicyResponse{Error: "a reason is required to freeze deploys"}, nil
+	}
+
+	project, msg := s.ownedProject(ctx, req.ProjectId, req.UserId)
+	if msg != "" {
+		return &pb.DeployPolicyResponse{Error: msg}, nil
+	}
+
+	// Lifting a freeze that is not there changes nothing
+	if req.Frozen || project.DeployFrozen {
+		entry := &models.DeployAuditEntry{
+			ProjectID: project.ID,
+			UserID:    project.UserID,
+			Action:    models.DeployAuditUnfreeze,
+			Reason:    reason,
+		}
+		project.DeployFrozen = req.Frozen
+		project.DeployFreezeReason = ""
+		project.DeployFrozenBy = nil
+		project.DeployFrozenAt = nil
+		if req.Frozen {
+			now := time.Now()
+			entry.Action = models.DeployAuditFreeze
+			project.DeployFreezeReason = reason
+			project.DeployFrozenBy = &project.UserID
+			project.DeployFrozenAt = &now
+		}
+
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Model(project).
+				Select("DeployFrozen", "DeployFreezeReason", "DeployFrozenBy", "DeployFrozenAt").
+				Updates(project).Error
+			if err != nil {
+				return err
+			}
+			return tx.Create(entry).Error
+		})
+		if err != nil {
+			log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to set deploy freeze")
+			return &pb.DeployPolicyResponse{Error: "failed to set deploy freeze"}, nil
+		}
+	}
+
+	policy, msg := s.deployPolicy(ctx, project)
+	if msg != "" {
+		return &pb.DeployPolicyResponse{Error: msg}, nil
+	}
+	return &pb.DeployPolicyResponse{Policy: policy}, nil
+}
+
+// CheckDeploy tells whether a deploy of a project is allowed now. Only
+// production is restricted. A blocked deploy goes through with an override by a
+// user holding the deploy_policy override permission, which is audited.
+func (s *ProjectServiceServer) CheckDeploy(ctx context.Context, req *pb.CheckDeployRequest) (*pb.CheckDeployResponse, error) {
+	if req.ProjectId == "" || req.UserId == "" {
+		return &pb.CheckDeployResponse{Error: "project_id and user_id are required"}, nil
+	}
+
+	environment := strings.ToLower(strings.TrimSpace(req.Environment))
+	if environment != "" && environment != models.ProductionEnvironment {
+		return &pb.CheckDeployResponse{Allowed: true}, nil
+	}
+
+	// Users permitted to override the deploy policy may do so for projects they do
+	// not own, so the permission is checked before the ownership
+	permissionMsg := ""
+	if req.Override {
+		permissionMsg = s.checkDeployOverridePermission(ctx, req.UserId)
+	}
+	var (
+		project *models.Project
+		msg     string
+	)
+	if req.Override && permissionMsg == "" {
+		project, msg = s.projectByID(ctx, req.ProjectId)
+	} else {
+		project, msg = s.ownedProject(ctx, req.ProjectId, req.UserId)
+	}
+	if msg != "" {
+		return &pb.CheckDeployResponse{Error: msg}, nil
+	}
+
+	code, reason, next, msg := s.deployBlock(ctx, project, time.Now())
+	if msg != "" {
+		return &pb.CheckDeployResponse{Error: msg}, nil
+	}
+	if code == "" {
+		return &pb.CheckDeployResponse{Allowed: true}, nil
+	}
+
+	blocked := &pb.CheckDeployResponse{Code: code, Reason: reason}
+	if !next.IsZero() {
+		blocked.NextWindow = timestamppb.New(next)
+	}
+	if !req.Override {
+		return blocked, nil
+	}
+
+	overrideReason := strings.TrimSpace(req.OverrideReason)
+	if overrideReason == "" {
+		return &pb.CheckDeployResponse{
+			Code:   deployCodeOverrideReasonRequired,
+			Reason: "a reason is required to override the deploy policy",
+		}, nil
+	}
+	if permissionMsg != "" {
+		return &pb.CheckDeployResponse{Code: deployCodeOverrideNotPermitted, Reason: permissionMsg}, nil
+	}
+
+	// No override without its audit entry
+	userID, _ := uuid.Parse(req.UserId)
+	err := s.db.WithContext(ctx).Create(&models.DeployAuditEntry{
+		ProjectID: project.ID,
+		UserID:    userID,
+		Action:    models.DeployAuditOverride,
+		Reason:    fmt.Sprintf("%s (%s)", overrideReason, code),
+	}).Error
+	if err != nil {
+		log.Error().Err(err).Str("project_id", req.ProjectId).Msg("Failed to record deploy override")
+		return &pb.CheckDeployResponse{Error: "failed to record deploy override"}, nil
+	}
+
+	log.Warn().
+		Str("project_id", req.ProjectId).
+		Str("user_id", req.UserId).
+		Str("code", code).
+		Str("reason", overrideReason).
+		Msg("Deploy policy overridden")
+
+	return &pb.CheckDeployResponse{Allowed: true}, nil
+}
+
+// deployBlock returns the code and message of why production of a project
+// cannot be deployed at now, or "" if it can. next is the next opening of a
+// deploy window when outside all of them. msg is the error to report.
+func (s *ProjectServiceServer) deployBlock(ctx context.Context, project *models.Project, now time.Time) (code, reason string, next time.Time, msg string) {
+	if project.DeployFrozen {
+		return deployCodeFrozen, fmt.Sprintf("production deploys are frozen: %s", project.DeployFreezeReason), time.Time{}, ""
+	}
+
+	var list []models.DeployWindow
+	if err := s.db.WithContext(ctx).Where("project_id = ?", project.ID).Find(&list).Error; err != nil {
+		log.Error().Err(err).Str("project_id", project.ID.String()).Msg("Failed to list deploy windows")
+		return "", "", time.Time{}, "failed to check deploy windows"
+	}
+
+	windows := make([]deploywindows.Window, len(list))
+	for i := range list {
+		windows[i] = toDeployWindow(list[i])
+	}
+	open, next, err := deploywindows.Open(windows, now)
+	if err != nil {
+		log.Error().Err(err).Str("project_id", project.ID.String()).Msg("Failed to evaluate deploy windows")
+		return "", "", time.Time{}, "failed to check deploy windows"
+	}
+	if open {
+		return "", "", time.Time{}, ""
+	}
+	if next.IsZero() {
+		return deployCodeOutsideWindow, "production deploys are outside the deploy windows of the project, none of which opens again", next, ""
+	}
+	return deployCodeOutsideWindow, fmt.Sprintf("production deploys are outside the deploy windows of the project, the next one opens at %s", next.Format(time.RFC3339)), next, ""
+}
+
+// deployPolicy returns the deploy policy of a project with its latest audit
+// entries, or the error to report
+func (s *ProjectServiceServer) deployPolicy(ctx context.Context, project *models.Project) (*pb.DeployPolicy, string) {
+	var windows []models.DeployWindow
+	if err := s.db.WithContext(ctx).Where("project_id = ?", project.ID).Order("created_at ASC").Find(&windows).Error; err != nil {
+		log.Error().Err(err).Str("project_id", project.ID.String()).Msg("Failed to list deploy windows")
+		return nil, "failed to get deploy policy"
+	}
+	var audit []models.DeployAuditEntry
+	err := s.db.WithContext(ctx).Where("project_id = ?", project.ID).
+		Order("created_at DESC").Limit(deployAuditEntriesShown).Find(&audit).Error
+	if err != nil {
+		log.Error().Err(err).Str("project_id", project.ID.String()).Msg("Failed to list deploy audit entries")
+		return nil, "failed to get deploy policy"
+	}
+
+	code, _, next, msg := s.deployBlock(ctx, project, time.Now())
+	if msg != "" {
+		return nil, msg
+	}
+
+	policy := &pb.DeployPolicy{
+		ProjectId:    project.ID.String(),
+		Windows:      make([]*pb.DeployWindow, len(windows)),
+		Frozen:       project.DeployFrozen,
+		FreezeReason: project.DeployFreezeReason,
+		Open:         code == "",
+		Audit:        make([]*pb.DeployAuditEntry, len(audit)),
+	}
+	for i, w := range windows {
+		policy.Windows[i] = &pb.DeployWindow{
+			Schedule:        w.Schedule,
+			DurationMinutes: int32(w.DurationMinutes),
+			Timezone:        w.Timezone,
+		}
+	}
+	for i, e := range audit {
+		policy.Audit[i] = &pb.DeployAuditEntry{
+			Id:          e.ID.String(),
+			UserId:      e.UserID.String(),
+			Action:      e.Action,
+			Environment: e.Environment,
+			Reason:      e.Reason,
+			CreatedAt:   timestamppb.New(e.CreatedAt),
+		}
+	}
+	if project.DeployFrozenBy != nil {
+		policy.FrozenBy = project.DeployFrozenBy.String()
+	}
+	if project.DeployFrozenAt != nil {
+		policy.FrozenAt = timestamppb.New(*project.DeployFrozenAt)
+	}
+	if !next.IsZero() {
+		policy.NextWindow = timestamppb.New(next)
+	}
+	return policy, ""
+}
+
+// checkDeployOverridePermission asks Auth Service whether a user may override
+// deploy policies. It returns the reason to report, or "" if allowed.
+func (s *ProjectServiceServer) checkDeployOverridePermission(ctx context.Context, userID string) string {
+	if s.authClient == nil {
+		return "deploy overrides are not available"
+	}
+	resp, err := s.authClient.CheckPermission(ctx, &authpb.CheckPermissionRequest{
+		UserId:       userID,
+		ResourceType: "deploy_policy",
+		Action:       "override",
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to check deploy override permission")
+		return "failed to check permission"
+	}
+	if !resp.Allowed {
+		return resp.Reason
+	}
+	return ""
+}
+
+// ==================== Helper Functions ====================
+
+func projectToProto(p *models.Project) *pb.Project {
+	// A verified domain is routed to the environment it is assigned to, the
+	// others to production
+	customDomains := verifiedDomains(p.Domains)
+	environments := make([]*pb.Environment, len(p.Environments))
+	for i := range p.Environments {
+		env := environmentToProto(&p.Environments[i])
+		if env.Domain != "" {
+			if !slices.Contains(customDomains, env.Domain) {
+				env.Domain = "" // Removed or no longer verified
+			}
+			customDomains = slices.DeleteFunc(customDomains, func(name string) bool { return name == env.Domain })
+		}
+		environments[i] = env
+	}
+
+	return &pb.Project{
+		Id:           p.ID.String(),
+		UserId:       p.UserID.String(),
+		Name:         p.Name,
+		RepoUrl:      p.RepoURL,
+		Branch:       p.Branch,
+		Preset:       p.Preset,
+		BuildCommand: p.BuildCommand,
+		StartCommand: p.StartCommand,
+		TestCommand:  p.TestCommand,
+		Port:         int32(p.Port),
+		GithubRepoId: p.GithubRepoID,
+		IsPrivate:    p.IsPrivate,
+		CreatedAt:    timestamppb.New(p.CreatedAt),
+		UpdatedAt:    timestamppb.New(p.UpdatedAt),
+
+		BuildMemoryMb:       int32(p.BuildMemoryMB),
+		BuildCpus:           p.BuildCPUs,
+		BuildTimeoutMinutes: int32(p.BuildTimeoutMinutes),
+		BuildDiskMb:         int32(p.BuildDiskMB),
+
+		RootDirectory: p.RootDirectory,
+		WatchPaths:    p.WatchPaths,
+
+		CloneSubmodules:  p.CloneSubmodules,
+		CloneLfs:         p.CloneLFS,
+		CloneDepth:       int32(p.CloneDepth),
+		CloneFullHistory: p.CloneFullHistory,
+		CloneFetchTags:   p.CloneFetchTags,
+
+		AutoDeploy: p.AutoDeploy,
+
+		AutoCancelPending: p.AutoCancelPending,
+		AutoCancelRunning: p.AutoCancelRunning,
+
+		BuildMatrix: matrixToProto(p.BuildMatrix),
+
+		HealthCheckType:            p.HealthCheckType,
+		HealthCheckPath:            p.HealthCheckPath,
+		HealthCheckExpectedStatus:  int32(p.HealthCheckExpectedStatus),
+		HealthCheckIntervalSeconds: int32(p.HealthCheckInterval),
+		HealthCheckTimeoutSeconds:  int32(p.HealthCheckTimeout),
+		HealthCheckRetries:         int32(p.HealthCheckRetries),
+
+		Replicas: int32(p.Replicas),
+
+		CustomDomains: customDomains,
+		Environments:  environments,
+	}
+}
+
+// normalizeRootDirectory cleans a build root directory. It must stay inside the
+// repository; "" and "." both mean the repository root.
+func normalizeRootDirectory(dir string) (string, error) {
+	dir = strings.TrimSpace(strings.ReplaceAll(dir, "\\", "/"))
+	if dir == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(dir, "/") {
+		return "", errors.New("root_directory must be relative to the repository root")
+	}
+	dir = path.Clean(dir)
+	if dir == ".." || strings.HasPrefix(dir, "../") {
+		return "", errors.New("root_directory must be inside the repository")
+	}
+	if dir == "." {
+		return "", nil
+	}
+	return dir, nil
+}
+
+// normalizeHealthCheckType lower-cases a health check type; "" keeps the default
+func normalizeHealthCheckType(t string) (string, error) {
+	t = strings.ToLower(strings.TrimSpace(t))
+	switch t {
+	case "", "tcp", "http":
+		return t, nil
+	default:
+		return "", errors.New("health_check_type must be tcp or http")
+	}
+}
+
+// validateHealthCheck checks the health check numbers, 0 means the default. It
+// returns an error message, or "" when they are valid.
+func validateHealthCheck(expectedStatus, interval, timeout, retries int32) string {
+	if expectedStatus != 0 && (expectedStatus < 100 || expectedStatus > 599) {
+		return "health_check_expected_status must be an HTTP status code"
+	}
+	if interval < 0 || timeout < 0 || retries < 0 {
+		return "health check interval, timeout and retries must not be negative"
+	}
+	if interval > 3600 || timeout > 3600 {
+		return "health check interval and timeout must be at most 3600 seconds"
+	}
+	return ""
+}
+
+// normalizeWatchPaths trims and checks the syntax of watch path globs
+func normalizeWatchPaths(patterns []string) ([]string, error) {
+	var result []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "./")
+		if pattern == "" {
+			continue
+		}
+		if strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("watch path %q must be relative to the repository root", pattern)
+		}
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid watch path %q", pattern)
+			}
+		}
+		result = append(result, pattern)
+	}
+	return result, nil
+}
+
+// maxMatrixVariants bounds the builds a single trigger fans out into
+const maxMatrixVariants = 12
+
+var (
+	// matrixVariantNamePattern matches variant names, which are shown next to the
+	// build and logged
+	matrixVariantNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,39}$`)
+	envNamePattern           = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// normalizeBuildMatrix trims and checks the variants of a build matrix. At least one
+// variant must be required, or the build could never fail.
+func normalizeBuildMatrix(variants []*pb.MatrixVariant) ([]models.MatrixVariant, error) {
+	if len(variants) > maxMatrixVariants {
+		return nil, fmt.Errorf("a build matrix has at most %d variants", maxMatrixVariants)
+	}
+
+	var result []models.MatrixVariant
+	names := make(map[string]bool, len(variants))
+	required := false
+	for _, v := range variants {
+		name := strings.ToLower(strings.TrimSpace(v.GetName()))
+		if !matrixVariantNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid matrix variant name %q, use up to 40 lowercase letters, digits, '.', '-' or '_'", v.GetName())
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate matrix variant %q", name)
+		}
+		names[name] = true
+
+		baseImage := strings.TrimSpace(v.BaseImage)
+		if strings.ContainsAny(baseImage, " \t\n") {
+			return nil, fmt.Errorf("matrix variant %q: invalid base image %q", name, baseImage)
+		}
+		for key := range v.Env {
+			if !envNamePattern.MatchString(key) {
+				return nil, fmt.Errorf("matrix variant %q: invalid env name %q", name, key)
+			}
+		}
+
+		result = append(result, models.MatrixVariant{
+			Name:      name,
+			BaseImage: baseImage,
+			Env:       v.Env,
+			Optional:  v.Optional,
+		})
+		required = required || !v.Optional
+	}
+	if len(result) > 0 && !required {
+		return nil, errors.New("at least one matrix variant must be required")
+	}
+	return result, nil
+}
+
+func matrixToProto(variants []models.MatrixVariant) []*pb.MatrixVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	result := make([]*pb.MatrixVariant, len(variants))
+	for i, v := range variants {
+		result[i] = &pb.MatrixVariant{
+			Name:      v.Name,
+			BaseImage: v.BaseImage,
+			Env:       v.Env,
+			Optional:  v.Optional,
+		}
+	}
+	return result
+}
+
+// validateBuildSettings checks project build settings against the plan limits and
+// returns the error to report, or "" if they are allowed. A nil plan skips the limits.
+func validateBuildSettings(plan *authpb.GetUserPlanResponse, memoryMB int32, cpus float64, timeoutMinutes, diskMB int32) string {
+	if memoryMB < 0 || cpus < 0 || timeoutMinutes < 0 || diskMB < 0 {
+		return "build settings must not be negative"
+	}
+	if plan == nil {
+		return ""
+	}
+
+	switch {
+	case plan.MaxBuildMemoryMb > 0 && memoryMB > plan.MaxBuildMemoryMb:
+		return fmt.Sprintf("Build memory of %d MB exceeds the limit for the %s plan (%d MB).", memoryMB, plan.Plan, plan.MaxBuildMemoryMb)
+	case plan.MaxBuildCpus > 0 && cpus > plan.MaxBuildCpus:
+		return fmt.Sprintf("Build CPUs of %g exceed the limit for the %s plan (%g CPUs).", cpus, plan.Plan, plan.MaxBuildCpus)
+	case plan.MaxBuildTimeoutMinutes > 0 && timeoutMinutes > plan.MaxBuildTimeoutMinutes:
+		return fmt.Sprintf("Build timeout of %d minutes exceeds the limit for the %s plan (%d minutes).", timeoutMinutes, plan.Plan, plan.MaxBuildTimeoutMinutes)
+	case plan.MaxBuildDiskMb > 0 && diskMB > plan.MaxBuildDiskMb:
+		return fmt.Sprintf("Build disk quota of %d MB exceeds the limit for the %s plan (%d MB).", diskMB, plan.Plan, plan.MaxBuildDiskMb)
+	}
+	return ""
+}
+
+// validateReplicas checks the replicas of a project against the plan limit and
+// returns the error to report, or "" if the count is allowed. A nil plan skips the
+// limit.
+func validateReplicas(plan *authpb.GetUserPlanResponse, replicas int32) string {
+	if replicas < 1 {
+		return "replicas must be at least 1"
+	}
+	if plan != nil && plan.MaxReplicas > 0 && replicas > plan.MaxReplicas {
+		return fmt.Sprintf("%d replicas exceed the limit for the %s plan (%d replicas).", replicas, plan.Plan, plan.MaxReplicas)
+	}
+	return ""
+}
+
+// secretToProto converts a secret, environments maps environment IDs to names
+func secretToProto(s *models.Secret, environments map[uuid.UUID]string) *pb.Secret {
+	secret := &pb.Secret{
+		Id:        s.ID.String(),
+		ProjectId: s.ProjectID.String(),
+		Name:      s.Name,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+	if s.EnvironmentID != nil {
+		secret.Environment = environments[*s.EnvironmentID]
+	}
+	return secret
+}
+
+func environmentToProto(e *models.Environment) *pb.Environment {
+	return &pb.Environment{
+		Id:         e.ID.String(),
+		ProjectId:  e.ProjectID.String(),
+		Name:       e.Name,
+		Branch:     e.Branch,
+		Domain:     e.Domain,
+		MemoryMb:   int32(e.MemoryMB),
+		CpuCores:   int32(e.CPUCores),
+		AutoDeploy: e.AutoDeploy,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+		UpdatedAt:  timestamppb.New(e.UpdatedAt),
+	}
+}
+
+func domainToProto(d *models.Domain) *pb.Domain {
+	domain := &pb.Domain{
+		Id:             d.ID.String(),
+		ProjectId:      d.ProjectID.String(),
+		Name:           d.Name,
+		Status:         d.Status,
+		TxtRecordName:  domains.RecordName(d.Name),
+		TxtRecordValue: domains.RecordValue(d.VerificationToken),
+		Error:          d.Error,
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+	if d.VerifiedAt != nil {
+		domain.VerifiedAt = timestamppb.New(*d.VerifiedAt)
+	}
+	return domain
+}
+
+// verifiedDomains returns the names of the verified domains among list
+func verifiedDomains(list []models.Domain) []string {
+	var names []string
+	for _, d := range list {
+		if d.Status == models.DomainStatusVerified {
+			names = append(names, d.Name)
+		}
+	}
+	return names
+}
+
+func toDeployWindow(w models.DeployWindow) deploywindows.Window {
+	return deploywindows.Window{
+		Schedule: w.Schedule,
+		Duration: time.Duration(w.DurationMinutes) * time.Minute,
+		Timezone: w.Timezone,
+	}
+}
+
+// describeDeployWindow returns a window as recorded in the audit log, e.g.
+// "0 9 * * 1-4 for 8h0m0s (Europe/Berlin)"
+func describeDeployWindow(w models.DeployWindow) string {
+	timezone := w.Timezone
+	if timezone == "" {
+		timezone = "UTC"
 	}
+	return fmt.Sprintf("%s for %s (%s)", w.Schedule, time.Duration(w.DurationMinutes)*time.Minute, timezone)
 }
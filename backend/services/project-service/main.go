@@ -57,7 +57,7 @@ func main() {
 	log.Info().Msg("Connected to PostgreSQL")
 
 	// Auto-migrate models
-	if err := db.AutoMigrate(&models.Project{}, &models.Secret{}, &models.Webhook{}); err != nil {
+	if err := db.AutoMigrate(&models.Project{}, &models.Secret{}, &models.Webhook{}, &models.Domain{}, &models.Environment{}, &models.DeployWindow{}, &models.DeployAuditEntry{}); err != nil {
 		log.Fatal().Err(err).Msg("Failed to auto-migrate models")
 	}
 	log.Info().Msg("Database migration completed")
@@ -98,7 +98,8 @@ func main() {
 
 func connectDB() (*gorm.DB, error) {
 	dsn := cfg.GetDSN()
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError maps unique violations to gorm.ErrDuplicatedKey
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 }
 
 func startGRPCServer(ctx context.Context, authClient authpb.AuthServiceClient, authConn *grpc.ClientConn) {
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Actions recorded in the deploy audit log
+const (
+	DeployAuditFreeze   = "freeze"
+	DeployAuditUnfreeze = "unfreeze"
+	DeployAuditWindows  = "windows"  // Deploy windows replaced
+	DeployAuditOverride = "override" // Production deploy outside the policy by an admin
+)
+
+// DeployWindow is a recurring period in which production deploys of a project
+// are allowed. A project without windows may deploy at any time.
+type DeployWindow struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Schedule        string    `gorm:"type:varchar(100);not null"` // Cron expression opening the window
+	DurationMinutes int       `gorm:"not null"`
+	Timezone        string    `gorm:"type:varchar(64);not null;default:''"` // IANA name, "" = UTC
+	CreatedAt       time.Time `gorm:"not null;default:now()"`
+}
+
+// BeforeCreate generates UUID if not set
+func (w *DeployWindow) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name
+func (DeployWindow) TableName() string {
+	return "project_deploy_windows"
+}
+
+// DeployAuditEntry records a change of the deploy policy of a project, or a
+// deploy that an admin pushed through it
+type DeployAuditEntry struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID   uuid.UUID `gorm:"type:uuid;not null;index:idx_project_deploy_audit_project_created,priority:1"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null"`
+	Action      string    `gorm:"type:varchar(20);not null"`
+	Environment string    `gorm:"type:varchar(32);not null;default:''"`
+	Reason      string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"not null;default:now();index:idx_project_deploy_audit_project_created,priority:2"`
+}
+
+// BeforeCreate generates UUID if not set
+func (e *DeployAuditEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name
+func (DeployAuditEntry) TableName() string {
+	return "project_deploy_audit"
+}
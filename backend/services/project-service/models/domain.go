@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Domain verification states
+const (
+	DomainStatusPending  = "pending"
+	DomainStatusVerified = "verified"
+	DomainStatusFailed   = "failed"
+)
+
+// Domain is a custom domain of a project. It is routed to the deployments of the
+// project once a DNS TXT record with the verification token proves ownership.
+// Several projects may claim a name, only one can verify it; a partial unique
+// index enforces that.
+type Domain struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_project_domains_project_name,priority:1"`
+	Name              string     `gorm:"type:varchar(253);not null;index;uniqueIndex:idx_project_domains_project_name,priority:2;uniqueIndex:idx_project_domains_verified_name,where:status = 'verified'"`
+	VerificationToken string     `gorm:"type:varchar(64);not null"`
+	Status            string     `gorm:"type:varchar(20);not null;default:pending"`
+	Error             string     `gorm:"type:text"` // Why the last verification failed
+	VerifiedAt        *time.Time `gorm:"type:timestamptz"`
+	CreatedAt         time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt         time.Time  `gorm:"not null;default:now()"`
+}
+
+// BeforeCreate generates UUID if not set
+func (d *Domain) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name
+func (Domain) TableName() string {
+	return "project_domains"
+}
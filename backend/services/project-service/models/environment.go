@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductionEnvironment names the project itself among its environments. It
+// builds the branch of the project and uses the project secrets only.
+const ProductionEnvironment = "production"
+
+// Environment is a named deployment of a project next to production, e.g.
+// staging. It builds its own branch, and its secrets override the project
+// secrets of the same name.
+type Environment struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_project_environments_project_name,priority:1"`
+	Name       string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_project_environments_project_name,priority:2"`
+	Branch     string    `gorm:"type:varchar(255);not null"`
+	Domain     string    `gorm:"type:varchar(253);not null;default:''"` // Verified custom domain of the project routed here instead of production
+	AutoDeploy bool      `gorm:"not null;default:false"`
+	CreatedAt  time.Time `gorm:"not null;default:now()"`
+	UpdatedAt  time.Time `gorm:"not null;default:now()"`
+
+	// Container resources, 0 uses the deployment default
+	MemoryMB int `gorm:"not null;default:0"`
+	CPUCores int `gorm:"not null;default:0"`
+
+	// Relations
+	Secrets []Secret `gorm:"foreignKey:EnvironmentID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate generates UUID if not set
+func (e *Environment) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName returns the table name
+func (Environment) TableName() string {
+	return "project_environments"
+}
@@ -17,15 +17,72 @@ type Project struct {
 	Preset       string    `gorm:"type:varchar(50);not null"` // nodejs, go, python, docker, static
 	BuildCommand string    `gorm:"type:text"`
 	StartCommand string    `gorm:"type:text"`
+	TestCommand  string    `gorm:"type:text"` // Runs between the build command and the image build
 	Port         int       `gorm:"not null;default:8080"`
 	GithubRepoID int64     `gorm:"not null"`
 	IsPrivate    bool      `gorm:"default:false"`
 	CreatedAt    time.Time `gorm:"not null;default:now()"`
 	UpdatedAt    time.Time `gorm:"not null;default:now()"`
 
+	// Build settings, 0 uses the runner default. Capped by the owner's plan.
+	BuildMemoryMB       int     `gorm:"not null;default:0"`
+	BuildCPUs           float64 `gorm:"not null;default:0"`
+	BuildTimeoutMinutes int     `gorm:"not null;default:0"`
+	BuildDiskMB         int     `gorm:"not null;default:0"`
+
+	// Monorepo settings, see project.proto
+	RootDirectory string   `gorm:"type:varchar(255);not null;default:''"`
+	WatchPaths    []string `gorm:"type:text;serializer:json"`
+
+	// Clone options, see project.proto
+	CloneSubmodules  bool `gorm:"not null;default:false"`
+	CloneLFS         bool `gorm:"not null;default:false"`
+	CloneDepth       int  `gorm:"not null;default:0"`
+	CloneFullHistory bool `gorm:"not null;default:false"`
+	CloneFetchTags   bool `gorm:"not null;default:false"`
+
+	AutoDeploy bool `gorm:"not null;default:false"` // Deploy every successful build
+
+	// Auto-cancel policy, a new build of a branch supersedes older builds of it
+	AutoCancelPending bool `gorm:"not null;default:false"` // Supersede queued builds
+	AutoCancelRunning bool `gorm:"not null;default:false"` // Also stop running builds
+
+	BuildMatrix []MatrixVariant `gorm:"type:text;serializer:json"` // Variants built for every trigger, see project.proto
+
+	// Health check of the deployment, see project.proto
+	HealthCheckType           string `gorm:"type:varchar(10);not null;default:''"`
+	HealthCheckPath           string `gorm:"type:varchar(255);not null;default:''"`
+	HealthCheckExpectedStatus int    `gorm:"not null;default:0"`
+	HealthCheckInterval       int    `gorm:"not null;default:0"` // Seconds
+	HealthCheckTimeout        int    `gorm:"not null;default:0"` // Seconds
+	HealthCheckRetries        int    `gorm:"not null;default:0"`
+
+	Replicas int `gorm:"not null;default:1"` // Containers per deployment
+
+	// Deploy freeze, production deploys are rejected while frozen
+	DeployFrozen       bool       `gorm:"not null;default:false"`
+	DeployFreezeReason string     `gorm:"type:text"`
+	DeployFrozenBy     *uuid.UUID `gorm:"type:uuid"`
+	DeployFrozenAt     *time.Time `gorm:"type:timestamptz"`
+
 	// Relations
 	Secrets  []Secret  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
 	Webhooks []Webhook `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+	Domains  []Domain  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	Environments []Environment `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+
+	DeployWindows []DeployWindow     `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+	DeployAudit   []DeployAuditEntry `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+}
+
+// MatrixVariant is one combination of a build matrix, e.g. a Node.js version or a
+// GOOS/GOARCH pair
+type MatrixVariant struct {
+	Name      string            `json:"name"`
+	BaseImage string            `json:"base_image,omitempty"` // "" uses the image of the preset
+	Env       map[string]string `json:"env,omitempty"`
+	Optional  bool              `json:"optional,omitempty"` // A failure does not fail the build
 }
 
 // BeforeCreate generates UUID if not set
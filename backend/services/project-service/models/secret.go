@@ -15,6 +15,8 @@ type Secret struct {
 	EncryptedValue []byte    `gorm:"type:bytea;not null"` // AES-256-GCM encrypted
 	CreatedAt      time.Time `gorm:"not null;default:now()"`
 	UpdatedAt      time.Time `gorm:"not null;default:now()"`
+
+	EnvironmentID *uuid.UUID `gorm:"type:uuid;index"` // nil for project secrets, shared by all environments
 }
 
 // BeforeCreate generates UUID if not set
@@ -23,20 +23,56 @@ const (
 )
 
 type Project struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	RepoUrl       string                 `protobuf:"bytes,4,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
-	Branch        string                 `protobuf:"bytes,5,opt,name=branch,proto3" json:"branch,omitempty"`
-	Preset        string                 `protobuf:"bytes,6,opt,name=preset,proto3" json:"preset,omitempty"` // nodejs, go, python, docker, static
-	BuildCommand  string                 `protobuf:"bytes,7,opt,name=build_command,json=buildCommand,proto3" json:"build_command,omitempty"`
-	StartCommand  string                 `protobuf:"bytes,8,opt,name=start_command,json=startCommand,proto3" json:"start_command,omitempty"`
-	Port          int32                  `protobuf:"varint,9,opt,name=port,proto3" json:"port,omitempty"`
-	GithubRepoId  int64                  `protobuf:"varint,10,opt,name=github_repo_id,json=githubRepoId,proto3" json:"github_repo_id,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,11,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	RepoUrl      string                 `protobuf:"bytes,4,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	Branch       string                 `protobuf:"bytes,5,opt,name=branch,proto3" json:"branch,omitempty"`
+	Preset       string                 `protobuf:"bytes,6,opt,name=preset,proto3" json:"preset,omitempty"` // nodejs, go, python, docker, static
+	BuildCommand string                 `protobuf:"bytes,7,opt,name=build_command,json=buildCommand,proto3" json:"build_command,omitempty"`
+	StartCommand string                 `protobuf:"bytes,8,opt,name=start_command,json=startCommand,proto3" json:"start_command,omitempty"`
+	Port         int32                  `protobuf:"varint,9,opt,name=port,proto3" json:"port,omitempty"`
+	GithubRepoId int64                  `protobuf:"varint,10,opt,name=github_repo_id,json=githubRepoId,proto3" json:"github_repo_id,omitempty"`
+	IsPrivate    bool                   `protobuf:"varint,11,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	TestCommand  string                 `protobuf:"bytes,14,opt,name=test_command,json=testCommand,proto3" json:"test_command,omitempty"`
+	// Build settings, 0 uses the runner default. Capped by the owner's plan.
+	BuildMemoryMb       int32   `protobuf:"varint,15,opt,name=build_memory_mb,json=buildMemoryMb,proto3" json:"build_memory_mb,omitempty"`
+	BuildCpus           float64 `protobuf:"fixed64,16,opt,name=build_cpus,json=buildCpus,proto3" json:"build_cpus,omitempty"`
+	BuildTimeoutMinutes int32   `protobuf:"varint,17,opt,name=build_timeout_minutes,json=buildTimeoutMinutes,proto3" json:"build_timeout_minutes,omitempty"`
+	BuildDiskMb         int32   `protobuf:"varint,18,opt,name=build_disk_mb,json=buildDiskMb,proto3" json:"build_disk_mb,omitempty"`
+	// Monorepo settings. root_directory is built instead of the repository root.
+	// Pushes only trigger a build when a changed file matches watch_paths, which
+	// defaults to everything under root_directory.
+	RootDirectory string   `protobuf:"bytes,19,opt,name=root_directory,json=rootDirectory,proto3" json:"root_directory,omitempty"`
+	WatchPaths    []string `protobuf:"bytes,20,rep,name=watch_paths,json=watchPaths,proto3" json:"watch_paths,omitempty"` // Globs from the repository root, ** matches any depth
+	// Clone options
+	CloneSubmodules  bool  `protobuf:"varint,21,opt,name=clone_submodules,json=cloneSubmodules,proto3" json:"clone_submodules,omitempty"`      // Clone submodules recursively
+	CloneLfs         bool  `protobuf:"varint,22,opt,name=clone_lfs,json=cloneLfs,proto3" json:"clone_lfs,omitempty"`                           // Download Git LFS objects
+	CloneDepth       int32 `protobuf:"varint,23,opt,name=clone_depth,json=cloneDepth,proto3" json:"clone_depth,omitempty"`                     // Commits of history to fetch, 0 = 1
+	CloneFullHistory bool  `protobuf:"varint,24,opt,name=clone_full_history,json=cloneFullHistory,proto3" json:"clone_full_history,omitempty"` // Clone the whole history, clone_depth is ignored
+	CloneFetchTags   bool  `protobuf:"varint,25,opt,name=clone_fetch_tags,json=cloneFetchTags,proto3" json:"clone_fetch_tags,omitempty"`       // Fetch all tags, e.g. for git describe
+	AutoDeploy       bool  `protobuf:"varint,26,opt,name=auto_deploy,json=autoDeploy,proto3" json:"auto_deploy,omitempty"`                     // Deploy every successful build
+	// Health check of the deployed container, also gating the blue/green cutover
+	HealthCheckType            string         `protobuf:"bytes,27,opt,name=health_check_type,json=healthCheckType,proto3" json:"health_check_type,omitempty"`                                     // "tcp" (default) or "http"
+	HealthCheckPath            string         `protobuf:"bytes,28,opt,name=health_check_path,json=healthCheckPath,proto3" json:"health_check_path,omitempty"`                                     // HTTP path, default "/"
+	HealthCheckExpectedStatus  int32          `protobuf:"varint,29,opt,name=health_check_expected_status,json=healthCheckExpectedStatus,proto3" json:"health_check_expected_status,omitempty"`    // HTTP status, 0 = any 2xx or 3xx
+	HealthCheckIntervalSeconds int32          `protobuf:"varint,30,opt,name=health_check_interval_seconds,json=healthCheckIntervalSeconds,proto3" json:"health_check_interval_seconds,omitempty"` // 0 = 10
+	HealthCheckTimeoutSeconds  int32          `protobuf:"varint,31,opt,name=health_check_timeout_seconds,json=healthCheckTimeoutSeconds,proto3" json:"health_check_timeout_seconds,omitempty"`    // Timeout of one probe, 0 = 3
+	HealthCheckRetries         int32          `protobuf:"varint,32,opt,name=health_check_retries,json=healthCheckRetries,proto3" json:"health_check_retries,omitempty"`                           // Failed probes before unhealthy, 0 = 3
+	Replicas                   int32          `protobuf:"varint,33,opt,name=replicas,proto3" json:"replicas,omitempty"`                                                                           // Containers per deployment, capped by the plan
+	CustomDomains              []string       `protobuf:"bytes,34,rep,name=custom_domains,json=customDomains,proto3" json:"custom_domains,omitempty"`                                             // Verified custom domains, set by GetProject
+	Environments               []*Environment `protobuf:"bytes,35,rep,name=environments,proto3" json:"environments,omitempty"`                                                                    // Set by GetProject and ListProjectsByRepo
+	// Auto-cancel policy. A new build of a branch supersedes the older builds of the
+	// same branch that are still queued and, with auto_cancel_running, running.
+	AutoCancelPending bool `protobuf:"varint,36,opt,name=auto_cancel_pending,json=autoCancelPending,proto3" json:"auto_cancel_pending,omitempty"`
+	AutoCancelRunning bool `protobuf:"varint,37,opt,name=auto_cancel_running,json=autoCancelRunning,proto3" json:"auto_cancel_running,omitempty"`
+	// Build matrix. With variants, a trigger builds one child build per variant.
+	// Variants only build and test; once every required variant passes, the build
+	// builds the image and deploys it, or the preview of a pull request.
+	BuildMatrix   []*MatrixVariant `protobuf:"bytes,38,rep,name=build_matrix,json=buildMatrix,proto3" json:"build_matrix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -162,205 +198,208 @@ func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type CreateProjectRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	RepoUrl           string                 `protobuf:"bytes,3,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
-	Branch            string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
-	Preset            string                 `protobuf:"bytes,5,opt,name=preset,proto3" json:"preset,omitempty"`
-	BuildCommand      string                 `protobuf:"bytes,6,opt,name=build_command,json=buildCommand,proto3" json:"build_command,omitempty"`
-	StartCommand      string                 `protobuf:"bytes,7,opt,name=start_command,json=startCommand,proto3" json:"start_command,omitempty"`
-	Port              int32                  `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
-	GithubRepoId      int64                  `protobuf:"varint,9,opt,name=github_repo_id,json=githubRepoId,proto3" json:"github_repo_id,omitempty"`
-	IsPrivate         bool                   `protobuf:"varint,10,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	GithubAccessToken string                 `protobuf:"bytes,11,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"` // For webhook setup
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *Project) GetTestCommand() string {
+	if x != nil {
+		return x.TestCommand
+	}
+	return ""
 }
 
-func (x *CreateProjectRequest) Reset() {
-	*x = CreateProjectRequest{}
-	mi := &file_proto_project_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Project) GetBuildMemoryMb() int32 {
+	if x != nil {
+		return x.BuildMemoryMb
+	}
+	return 0
 }
 
-func (x *CreateProjectRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Project) GetBuildCpus() float64 {
+	if x != nil {
+		return x.BuildCpus
+	}
+	return 0
 }
 
-func (*CreateProjectRequest) ProtoMessage() {}
-
-func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[1]
+func (x *Project) GetBuildTimeoutMinutes() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.BuildTimeoutMinutes
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
-func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{1}
+func (x *Project) GetBuildDiskMb() int32 {
+	if x != nil {
+		return x.BuildDiskMb
+	}
+	return 0
 }
 
-func (x *CreateProjectRequest) GetUserId() string {
+func (x *Project) GetRootDirectory() string {
 	if x != nil {
-		return x.UserId
+		return x.RootDirectory
 	}
 	return ""
 }
 
-func (x *CreateProjectRequest) GetName() string {
+func (x *Project) GetWatchPaths() []string {
 	if x != nil {
-		return x.Name
+		return x.WatchPaths
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateProjectRequest) GetRepoUrl() string {
+func (x *Project) GetCloneSubmodules() bool {
 	if x != nil {
-		return x.RepoUrl
+		return x.CloneSubmodules
 	}
-	return ""
+	return false
 }
 
-func (x *CreateProjectRequest) GetBranch() string {
+func (x *Project) GetCloneLfs() bool {
 	if x != nil {
-		return x.Branch
+		return x.CloneLfs
 	}
-	return ""
+	return false
 }
 
-func (x *CreateProjectRequest) GetPreset() string {
+func (x *Project) GetCloneDepth() int32 {
 	if x != nil {
-		return x.Preset
+		return x.CloneDepth
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateProjectRequest) GetBuildCommand() string {
+func (x *Project) GetCloneFullHistory() bool {
 	if x != nil {
-		return x.BuildCommand
+		return x.CloneFullHistory
 	}
-	return ""
+	return false
 }
 
-func (x *CreateProjectRequest) GetStartCommand() string {
+func (x *Project) GetCloneFetchTags() bool {
 	if x != nil {
-		return x.StartCommand
+		return x.CloneFetchTags
+	}
+	return false
+}
+
+func (x *Project) GetAutoDeploy() bool {
+	if x != nil {
+		return x.AutoDeploy
+	}
+	return false
+}
+
+func (x *Project) GetHealthCheckType() string {
+	if x != nil {
+		return x.HealthCheckType
 	}
 	return ""
 }
 
-func (x *CreateProjectRequest) GetPort() int32 {
+func (x *Project) GetHealthCheckPath() string {
 	if x != nil {
-		return x.Port
+		return x.HealthCheckPath
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateProjectRequest) GetGithubRepoId() int64 {
+func (x *Project) GetHealthCheckExpectedStatus() int32 {
 	if x != nil {
-		return x.GithubRepoId
+		return x.HealthCheckExpectedStatus
 	}
 	return 0
 }
 
-func (x *CreateProjectRequest) GetIsPrivate() bool {
+func (x *Project) GetHealthCheckIntervalSeconds() int32 {
 	if x != nil {
-		return x.IsPrivate
+		return x.HealthCheckIntervalSeconds
 	}
-	return false
+	return 0
 }
 
-func (x *CreateProjectRequest) GetGithubAccessToken() string {
+func (x *Project) GetHealthCheckTimeoutSeconds() int32 {
 	if x != nil {
-		return x.GithubAccessToken
+		return x.HealthCheckTimeoutSeconds
 	}
-	return ""
+	return 0
 }
 
-type CreateProjectResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Project) GetHealthCheckRetries() int32 {
+	if x != nil {
+		return x.HealthCheckRetries
+	}
+	return 0
 }
 
-func (x *CreateProjectResponse) Reset() {
-	*x = CreateProjectResponse{}
-	mi := &file_proto_project_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Project) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
 }
 
-func (x *CreateProjectResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Project) GetCustomDomains() []string {
+	if x != nil {
+		return x.CustomDomains
+	}
+	return nil
 }
 
-func (*CreateProjectResponse) ProtoMessage() {}
-
-func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[2]
+func (x *Project) GetEnvironments() []*Environment {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Environments
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
-func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{2}
+func (x *Project) GetAutoCancelPending() bool {
+	if x != nil {
+		return x.AutoCancelPending
+	}
+	return false
 }
 
-func (x *CreateProjectResponse) GetProject() *Project {
+func (x *Project) GetAutoCancelRunning() bool {
 	if x != nil {
-		return x.Project
+		return x.AutoCancelRunning
 	}
-	return nil
+	return false
 }
 
-func (x *CreateProjectResponse) GetError() string {
+func (x *Project) GetBuildMatrix() []*MatrixVariant {
 	if x != nil {
-		return x.Error
+		return x.BuildMatrix
 	}
-	return ""
+	return nil
 }
 
-type GetProjectRequest struct {
+// MatrixVariant is one combination a project is built and tested with, e.g. a
+// Node.js version or a GOOS/GOARCH pair
+type MatrixVariant struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                         // Unique in the matrix, e.g. "node-20"
+	BaseImage     string                 `protobuf:"bytes,2,opt,name=base_image,json=baseImage,proto3" json:"base_image,omitempty"`                                              // Replaces the image of the preset, e.g. "node:20-alpine"
+	Env           map[string]string      `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Set in the build containers, e.g. GOOS and GOARCH
+	Optional      bool                   `protobuf:"varint,4,opt,name=optional,proto3" json:"optional,omitempty"`                                                                // A failure does not fail the build
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProjectRequest) Reset() {
-	*x = GetProjectRequest{}
-	mi := &file_proto_project_proto_msgTypes[3]
+func (x *MatrixVariant) Reset() {
+	*x = MatrixVariant{}
+	mi := &file_proto_project_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProjectRequest) String() string {
+func (x *MatrixVariant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProjectRequest) ProtoMessage() {}
+func (*MatrixVariant) ProtoMessage() {}
 
-func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[3]
+func (x *MatrixVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,48 +410,94 @@ func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
-func (*GetProjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use MatrixVariant.ProtoReflect.Descriptor instead.
+func (*MatrixVariant) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetProjectRequest) GetProjectId() string {
+func (x *MatrixVariant) GetName() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GetProjectRequest) GetUserId() string {
+func (x *MatrixVariant) GetBaseImage() string {
 	if x != nil {
-		return x.UserId
+		return x.BaseImage
 	}
 	return ""
 }
 
-type GetProjectResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MatrixVariant) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
 }
 
-func (x *GetProjectResponse) Reset() {
-	*x = GetProjectResponse{}
-	mi := &file_proto_project_proto_msgTypes[4]
+func (x *MatrixVariant) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+type CreateProjectRequest struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	UserId                     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name                       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RepoUrl                    string                 `protobuf:"bytes,3,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
+	Branch                     string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	Preset                     string                 `protobuf:"bytes,5,opt,name=preset,proto3" json:"preset,omitempty"`
+	BuildCommand               string                 `protobuf:"bytes,6,opt,name=build_command,json=buildCommand,proto3" json:"build_command,omitempty"`
+	StartCommand               string                 `protobuf:"bytes,7,opt,name=start_command,json=startCommand,proto3" json:"start_command,omitempty"`
+	Port                       int32                  `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
+	GithubRepoId               int64                  `protobuf:"varint,9,opt,name=github_repo_id,json=githubRepoId,proto3" json:"github_repo_id,omitempty"`
+	IsPrivate                  bool                   `protobuf:"varint,10,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	GithubAccessToken          string                 `protobuf:"bytes,11,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"` // For webhook setup
+	TestCommand                string                 `protobuf:"bytes,12,opt,name=test_command,json=testCommand,proto3" json:"test_command,omitempty"`
+	BuildMemoryMb              int32                  `protobuf:"varint,13,opt,name=build_memory_mb,json=buildMemoryMb,proto3" json:"build_memory_mb,omitempty"`
+	BuildCpus                  float64                `protobuf:"fixed64,14,opt,name=build_cpus,json=buildCpus,proto3" json:"build_cpus,omitempty"`
+	BuildTimeoutMinutes        int32                  `protobuf:"varint,15,opt,name=build_timeout_minutes,json=buildTimeoutMinutes,proto3" json:"build_timeout_minutes,omitempty"`
+	BuildDiskMb                int32                  `protobuf:"varint,16,opt,name=build_disk_mb,json=buildDiskMb,proto3" json:"build_disk_mb,omitempty"`
+	RootDirectory              string                 `protobuf:"bytes,17,opt,name=root_directory,json=rootDirectory,proto3" json:"root_directory,omitempty"`
+	WatchPaths                 []string               `protobuf:"bytes,18,rep,name=watch_paths,json=watchPaths,proto3" json:"watch_paths,omitempty"`
+	CloneSubmodules            bool                   `protobuf:"varint,19,opt,name=clone_submodules,json=cloneSubmodules,proto3" json:"clone_submodules,omitempty"`
+	CloneLfs                   bool                   `protobuf:"varint,20,opt,name=clone_lfs,json=cloneLfs,proto3" json:"clone_lfs,omitempty"`
+	CloneDepth                 int32                  `protobuf:"varint,21,opt,name=clone_depth,json=cloneDepth,proto3" json:"clone_depth,omitempty"`
+	CloneFullHistory           bool                   `protobuf:"varint,22,opt,name=clone_full_history,json=cloneFullHistory,proto3" json:"clone_full_history,omitempty"`
+	CloneFetchTags             bool                   `protobuf:"varint,23,opt,name=clone_fetch_tags,json=cloneFetchTags,proto3" json:"clone_fetch_tags,omitempty"`
+	AutoDeploy                 bool                   `protobuf:"varint,24,opt,name=auto_deploy,json=autoDeploy,proto3" json:"auto_deploy,omitempty"`
+	HealthCheckType            string                 `protobuf:"bytes,25,opt,name=health_check_type,json=healthCheckType,proto3" json:"health_check_type,omitempty"`
+	HealthCheckPath            string                 `protobuf:"bytes,26,opt,name=health_check_path,json=healthCheckPath,proto3" json:"health_check_path,omitempty"`
+	HealthCheckExpectedStatus  int32                  `protobuf:"varint,27,opt,name=health_check_expected_status,json=healthCheckExpectedStatus,proto3" json:"health_check_expected_status,omitempty"`
+	HealthCheckIntervalSeconds int32                  `protobuf:"varint,28,opt,name=health_check_interval_seconds,json=healthCheckIntervalSeconds,proto3" json:"health_check_interval_seconds,omitempty"`
+	HealthCheckTimeoutSeconds  int32                  `protobuf:"varint,29,opt,name=health_check_timeout_seconds,json=healthCheckTimeoutSeconds,proto3" json:"health_check_timeout_seconds,omitempty"`
+	HealthCheckRetries         int32                  `protobuf:"varint,30,opt,name=health_check_retries,json=healthCheckRetries,proto3" json:"health_check_retries,omitempty"`
+	Replicas                   int32                  `protobuf:"varint,31,opt,name=replicas,proto3" json:"replicas,omitempty"` // 0 = 1
+	AutoCancelPending          bool                   `protobuf:"varint,32,opt,name=auto_cancel_pending,json=autoCancelPending,proto3" json:"auto_cancel_pending,omitempty"`
+	AutoCancelRunning          bool                   `protobuf:"varint,33,opt,name=auto_cancel_running,json=autoCancelRunning,proto3" json:"auto_cancel_running,omitempty"`
+	BuildMatrix                []*MatrixVariant       `protobuf:"bytes,34,rep,name=build_matrix,json=buildMatrix,proto3" json:"build_matrix,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_proto_project_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProjectResponse) String() string {
+func (x *CreateProjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProjectResponse) ProtoMessage() {}
+func (*CreateProjectRequest) ProtoMessage() {}
 
-func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[4]
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,101 +508,2570 @@ func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
-func (*GetProjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetProjectResponse) GetProject() *Project {
+func (x *CreateProjectRequest) GetUserId() string {
 	if x != nil {
-		return x.Project
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetProjectResponse) GetError() string {
+func (x *CreateProjectRequest) GetName() string {
 	if x != nil {
-		return x.Error
+		return x.Name
 	}
 	return ""
 }
 
-type GetProjectByRepoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RepoUrl       string                 `protobuf:"bytes,1,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`                   // Repository URL (e.g., https://github.com/owner/repo)
-	GithubRepoId  int64                  `protobuf:"varint,2,opt,name=github_repo_id,json=githubRepoId,proto3" json:"github_repo_id,omitempty"` // GitHub repository ID (preferred for exact match)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetProjectByRepoRequest) Reset() {
-	*x = GetProjectByRepoRequest{}
-	mi := &file_proto_project_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetProjectByRepoRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetProjectByRepoRequest) ProtoMessage() {}
-
-func (x *GetProjectByRepoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[5]
+func (x *CreateProjectRequest) GetRepoUrl() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RepoUrl
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetProjectByRepoRequest.ProtoReflect.Descriptor instead.
-func (*GetProjectByRepoRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{5}
+func (x *CreateProjectRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
 }
 
-func (x *GetProjectByRepoRequest) GetRepoUrl() string {
+func (x *CreateProjectRequest) GetPreset() string {
 	if x != nil {
-		return x.RepoUrl
+		return x.Preset
 	}
 	return ""
 }
 
-func (x *GetProjectByRepoRequest) GetGithubRepoId() int64 {
+func (x *CreateProjectRequest) GetBuildCommand() string {
 	if x != nil {
-		return x.GithubRepoId
+		return x.BuildCommand
 	}
-	return 0
+	return ""
 }
 
-type ListProjectsRequest struct {
+func (x *CreateProjectRequest) GetStartCommand() string {
+	if x != nil {
+		return x.StartCommand
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetGithubRepoId() int64 {
+	if x != nil {
+		return x.GithubRepoId
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetGithubAccessToken() string {
+	if x != nil {
+		return x.GithubAccessToken
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetTestCommand() string {
+	if x != nil {
+		return x.TestCommand
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetBuildMemoryMb() int32 {
+	if x != nil {
+		return x.BuildMemoryMb
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetBuildCpus() float64 {
+	if x != nil {
+		return x.BuildCpus
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetBuildTimeoutMinutes() int32 {
+	if x != nil {
+		return x.BuildTimeoutMinutes
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetBuildDiskMb() int32 {
+	if x != nil {
+		return x.BuildDiskMb
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetRootDirectory() string {
+	if x != nil {
+		return x.RootDirectory
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetWatchPaths() []string {
+	if x != nil {
+		return x.WatchPaths
+	}
+	return nil
+}
+
+func (x *CreateProjectRequest) GetCloneSubmodules() bool {
+	if x != nil {
+		return x.CloneSubmodules
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetCloneLfs() bool {
+	if x != nil {
+		return x.CloneLfs
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetCloneDepth() int32 {
+	if x != nil {
+		return x.CloneDepth
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetCloneFullHistory() bool {
+	if x != nil {
+		return x.CloneFullHistory
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetCloneFetchTags() bool {
+	if x != nil {
+		return x.CloneFetchTags
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetAutoDeploy() bool {
+	if x != nil {
+		return x.AutoDeploy
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetHealthCheckType() string {
+	if x != nil {
+		return x.HealthCheckType
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetHealthCheckPath() string {
+	if x != nil {
+		return x.HealthCheckPath
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetHealthCheckExpectedStatus() int32 {
+	if x != nil {
+		return x.HealthCheckExpectedStatus
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetHealthCheckIntervalSeconds() int32 {
+	if x != nil {
+		return x.HealthCheckIntervalSeconds
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetHealthCheckTimeoutSeconds() int32 {
+	if x != nil {
+		return x.HealthCheckTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetHealthCheckRetries() int32 {
+	if x != nil {
+		return x.HealthCheckRetries
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetAutoCancelPending() bool {
+	if x != nil {
+		return x.AutoCancelPending
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetAutoCancelRunning() bool {
+	if x != nil {
+		return x.AutoCancelRunning
+	}
+	return false
+}
+
+func (x *CreateProjectRequest) GetBuildMatrix() []*MatrixVariant {
+	if x != nil {
+		return x.BuildMatrix
+	}
+	return nil
+}
+
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_proto_project_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *CreateProjectResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // For permission check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_proto_project_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetProjectRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_proto_project_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *GetProjectResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetProjectByRepoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RepoUrl       string                 `protobuf:"bytes,1,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`                   // Repository URL (e.g., https://github.com/owner/repo)
+	GithubRepoId  int64                  `protobuf:"varint,2,opt,name=github_repo_id,json=githubRepoId,proto3" json:"github_repo_id,omitempty"` // GitHub repository ID (preferred for exact match)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectByRepoRequest) Reset() {
+	*x = GetProjectByRepoRequest{}
+	mi := &file_proto_project_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectByRepoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectByRepoRequest) ProtoMessage() {}
+
+func (x *GetProjectByRepoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectByRepoRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectByRepoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProjectByRepoRequest) GetRepoUrl() string {
+	if x != nil {
+		return x.RepoUrl
+	}
+	return ""
+}
+
+func (x *GetProjectByRepoRequest) GetGithubRepoId() int64 {
+	if x != nil {
+		return x.GithubRepoId
+	}
+	return 0
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_proto_project_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListProjectsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListProjectsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProjectsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_proto_project_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *ListProjectsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListProjectsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateProjectRequest struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId                  string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId                     string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name                       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Branch                     string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	Preset                     string                 `protobuf:"bytes,5,opt,name=preset,proto3" json:"preset,omitempty"`
+	BuildCommand               string                 `protobuf:"bytes,6,opt,name=build_command,json=buildCommand,proto3" json:"build_command,omitempty"`
+	StartCommand               string                 `protobuf:"bytes,7,opt,name=start_command,json=startCommand,proto3" json:"start_command,omitempty"`
+	Port                       int32                  `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
+	TestCommand                *string                `protobuf:"bytes,9,opt,name=test_command,json=testCommand,proto3,oneof" json:"test_command,omitempty"` // Set to "" to stop running tests
+	BuildMemoryMb              int32                  `protobuf:"varint,10,opt,name=build_memory_mb,json=buildMemoryMb,proto3" json:"build_memory_mb,omitempty"`
+	BuildCpus                  float64                `protobuf:"fixed64,11,opt,name=build_cpus,json=buildCpus,proto3" json:"build_cpus,omitempty"`
+	BuildTimeoutMinutes        int32                  `protobuf:"varint,12,opt,name=build_timeout_minutes,json=buildTimeoutMinutes,proto3" json:"build_timeout_minutes,omitempty"`
+	BuildDiskMb                int32                  `protobuf:"varint,13,opt,name=build_disk_mb,json=buildDiskMb,proto3" json:"build_disk_mb,omitempty"`
+	RootDirectory              *string                `protobuf:"bytes,14,opt,name=root_directory,json=rootDirectory,proto3,oneof" json:"root_directory,omitempty"` // Set to "" to build from the repository root
+	WatchPaths                 []string               `protobuf:"bytes,15,rep,name=watch_paths,json=watchPaths,proto3" json:"watch_paths,omitempty"`
+	ClearWatchPaths            bool                   `protobuf:"varint,16,opt,name=clear_watch_paths,json=clearWatchPaths,proto3" json:"clear_watch_paths,omitempty"` // Remove all watch paths, watch_paths is ignored
+	CloneSubmodules            *bool                  `protobuf:"varint,17,opt,name=clone_submodules,json=cloneSubmodules,proto3,oneof" json:"clone_submodules,omitempty"`
+	CloneLfs                   *bool                  `protobuf:"varint,18,opt,name=clone_lfs,json=cloneLfs,proto3,oneof" json:"clone_lfs,omitempty"`
+	CloneDepth                 *int32                 `protobuf:"varint,19,opt,name=clone_depth,json=cloneDepth,proto3,oneof" json:"clone_depth,omitempty"`
+	CloneFullHistory           *bool                  `protobuf:"varint,20,opt,name=clone_full_history,json=cloneFullHistory,proto3,oneof" json:"clone_full_history,omitempty"`
+	CloneFetchTags             *bool                  `protobuf:"varint,21,opt,name=clone_fetch_tags,json=cloneFetchTags,proto3,oneof" json:"clone_fetch_tags,omitempty"`
+	AutoDeploy                 *bool                  `protobuf:"varint,22,opt,name=auto_deploy,json=autoDeploy,proto3,oneof" json:"auto_deploy,omitempty"`
+	HealthCheckType            *string                `protobuf:"bytes,23,opt,name=health_check_type,json=healthCheckType,proto3,oneof" json:"health_check_type,omitempty"`
+	HealthCheckPath            *string                `protobuf:"bytes,24,opt,name=health_check_path,json=healthCheckPath,proto3,oneof" json:"health_check_path,omitempty"`
+	HealthCheckExpectedStatus  *int32                 `protobuf:"varint,25,opt,name=health_check_expected_status,json=healthCheckExpectedStatus,proto3,oneof" json:"health_check_expected_status,omitempty"`
+	HealthCheckIntervalSeconds *int32                 `protobuf:"varint,26,opt,name=health_check_interval_seconds,json=healthCheckIntervalSeconds,proto3,oneof" json:"health_check_interval_seconds,omitempty"`
+	HealthCheckTimeoutSeconds  *int32                 `protobuf:"varint,27,opt,name=health_check_timeout_seconds,json=healthCheckTimeoutSeconds,proto3,oneof" json:"health_check_timeout_seconds,omitempty"`
+	HealthCheckRetries         *int32                 `protobuf:"varint,28,opt,name=health_check_retries,json=healthCheckRetries,proto3,oneof" json:"health_check_retries,omitempty"`
+	Replicas                   *int32                 `protobuf:"varint,29,opt,name=replicas,proto3,oneof" json:"replicas,omitempty"`
+	AutoCancelPending          *bool                  `protobuf:"varint,30,opt,name=auto_cancel_pending,json=autoCancelPending,proto3,oneof" json:"auto_cancel_pending,omitempty"`
+	AutoCancelRunning          *bool                  `protobuf:"varint,31,opt,name=auto_cancel_running,json=autoCancelRunning,proto3,oneof" json:"auto_cancel_running,omitempty"`
+	BuildMatrix                []*MatrixVariant       `protobuf:"bytes,32,rep,name=build_matrix,json=buildMatrix,proto3" json:"build_matrix,omitempty"`
+	ClearBuildMatrix           bool                   `protobuf:"varint,33,opt,name=clear_build_matrix,json=clearBuildMatrix,proto3" json:"clear_build_matrix,omitempty"` // Remove the matrix, build_matrix is ignored
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_proto_project_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetBuildCommand() string {
+	if x != nil {
+		return x.BuildCommand
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetStartCommand() string {
+	if x != nil {
+		return x.StartCommand
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetTestCommand() string {
+	if x != nil && x.TestCommand != nil {
+		return *x.TestCommand
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetBuildMemoryMb() int32 {
+	if x != nil {
+		return x.BuildMemoryMb
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetBuildCpus() float64 {
+	if x != nil {
+		return x.BuildCpus
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetBuildTimeoutMinutes() int32 {
+	if x != nil {
+		return x.BuildTimeoutMinutes
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetBuildDiskMb() int32 {
+	if x != nil {
+		return x.BuildDiskMb
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetRootDirectory() string {
+	if x != nil && x.RootDirectory != nil {
+		return *x.RootDirectory
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetWatchPaths() []string {
+	if x != nil {
+		return x.WatchPaths
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetClearWatchPaths() bool {
+	if x != nil {
+		return x.ClearWatchPaths
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetCloneSubmodules() bool {
+	if x != nil && x.CloneSubmodules != nil {
+		return *x.CloneSubmodules
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetCloneLfs() bool {
+	if x != nil && x.CloneLfs != nil {
+		return *x.CloneLfs
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetCloneDepth() int32 {
+	if x != nil && x.CloneDepth != nil {
+		return *x.CloneDepth
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetCloneFullHistory() bool {
+	if x != nil && x.CloneFullHistory != nil {
+		return *x.CloneFullHistory
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetCloneFetchTags() bool {
+	if x != nil && x.CloneFetchTags != nil {
+		return *x.CloneFetchTags
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetAutoDeploy() bool {
+	if x != nil && x.AutoDeploy != nil {
+		return *x.AutoDeploy
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetHealthCheckType() string {
+	if x != nil && x.HealthCheckType != nil {
+		return *x.HealthCheckType
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetHealthCheckPath() string {
+	if x != nil && x.HealthCheckPath != nil {
+		return *x.HealthCheckPath
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetHealthCheckExpectedStatus() int32 {
+	if x != nil && x.HealthCheckExpectedStatus != nil {
+		return *x.HealthCheckExpectedStatus
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetHealthCheckIntervalSeconds() int32 {
+	if x != nil && x.HealthCheckIntervalSeconds != nil {
+		return *x.HealthCheckIntervalSeconds
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetHealthCheckTimeoutSeconds() int32 {
+	if x != nil && x.HealthCheckTimeoutSeconds != nil {
+		return *x.HealthCheckTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetHealthCheckRetries() int32 {
+	if x != nil && x.HealthCheckRetries != nil {
+		return *x.HealthCheckRetries
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetReplicas() int32 {
+	if x != nil && x.Replicas != nil {
+		return *x.Replicas
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetAutoCancelPending() bool {
+	if x != nil && x.AutoCancelPending != nil {
+		return *x.AutoCancelPending
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetAutoCancelRunning() bool {
+	if x != nil && x.AutoCancelRunning != nil {
+		return *x.AutoCancelRunning
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetBuildMatrix() []*MatrixVariant {
+	if x != nil {
+		return x.BuildMatrix
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetClearBuildMatrix() bool {
+	if x != nil {
+		return x.ClearBuildMatrix
+	}
+	return false
+}
+
+type UpdateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectResponse) Reset() {
+	*x = UpdateProjectResponse{}
+	mi := &file_proto_project_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectResponse) ProtoMessage() {}
+
+func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *UpdateProjectResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DeleteProjectRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId         string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GithubAccessToken string                 `protobuf:"bytes,3,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"` // For webhook deletion
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_proto_project_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeleteProjectRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteProjectRequest) GetGithubAccessToken() string {
+	if x != nil {
+		return x.GithubAccessToken
+	}
+	return ""
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_proto_project_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteProjectResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteProjectResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Repository struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	CloneUrl      string                 `protobuf:"bytes,4,opt,name=clone_url,json=cloneUrl,proto3" json:"clone_url,omitempty"`
+	HtmlUrl       string                 `protobuf:"bytes,5,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	IsPrivate     bool                   `protobuf:"varint,6,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Language      string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	DefaultBranch string                 `protobuf:"bytes,9,opt,name=default_branch,json=defaultBranch,proto3" json:"default_branch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Repository) Reset() {
+	*x = Repository{}
+	mi := &file_proto_project_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Repository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repository) ProtoMessage() {}
+
+func (x *Repository) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repository.ProtoReflect.Descriptor instead.
+func (*Repository) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Repository) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Repository) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Repository) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *Repository) GetCloneUrl() string {
+	if x != nil {
+		return x.CloneUrl
+	}
+	return ""
+}
+
+func (x *Repository) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+func (x *Repository) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *Repository) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Repository) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Repository) GetDefaultBranch() string {
+	if x != nil {
+		return x.DefaultBranch
+	}
+	return ""
+}
+
+type ListRepositoriesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GithubAccessToken string                 `protobuf:"bytes,2,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListRepositoriesRequest) Reset() {
+	*x = ListRepositoriesRequest{}
+	mi := &file_proto_project_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRepositoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepositoriesRequest) ProtoMessage() {}
+
+func (x *ListRepositoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepositoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListRepositoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListRepositoriesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRepositoriesRequest) GetGithubAccessToken() string {
+	if x != nil {
+		return x.GithubAccessToken
+	}
+	return ""
+}
+
+type ListRepositoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repositories  []*Repository          `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRepositoriesResponse) Reset() {
+	*x = ListRepositoriesResponse{}
+	mi := &file_proto_project_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRepositoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepositoriesResponse) ProtoMessage() {}
+
+func (x *ListRepositoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepositoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListRepositoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRepositoriesResponse) GetRepositories() []*Repository {
+	if x != nil {
+		return x.Repositories
+	}
+	return nil
+}
+
+func (x *ListRepositoriesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SetupWebhookRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId         string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CallbackUrl       string                 `protobuf:"bytes,3,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	GithubAccessToken string                 `protobuf:"bytes,4,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetupWebhookRequest) Reset() {
+	*x = SetupWebhookRequest{}
+	mi := &file_proto_project_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetupWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetupWebhookRequest) ProtoMessage() {}
+
+func (x *SetupWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetupWebhookRequest.ProtoReflect.Descriptor instead.
+func (*SetupWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetupWebhookRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *SetupWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetupWebhookRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *SetupWebhookRequest) GetGithubAccessToken() string {
+	if x != nil {
+		return x.GithubAccessToken
+	}
+	return ""
+}
+
+type SetupWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetupWebhookResponse) Reset() {
+	*x = SetupWebhookResponse{}
+	mi := &file_proto_project_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetupWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetupWebhookResponse) ProtoMessage() {}
+
+func (x *SetupWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetupWebhookResponse.ProtoReflect.Descriptor instead.
+func (*SetupWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetupWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetupWebhookResponse) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *SetupWebhookResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DeleteWebhookRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId         string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GithubAccessToken string                 `protobuf:"bytes,3,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_proto_project_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteWebhookRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetGithubAccessToken() string {
+	if x != nil {
+		return x.GithubAccessToken
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_proto_project_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteWebhookResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SetCommitStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	CommitSha     string                 `protobuf:"bytes,2,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`                          // "pending", "success", "failure" or "error"
+	TargetUrl     string                 `protobuf:"bytes,4,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"` // E.g. the URL of a preview
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Context       string                 `protobuf:"bytes,6,opt,name=context,proto3" json:"context,omitempty"` // Default "nexusdeploy"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCommitStatusRequest) Reset() {
+	*x = SetCommitStatusRequest{}
+	mi := &file_proto_project_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCommitStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCommitStatusRequest) ProtoMessage() {}
+
+func (x *SetCommitStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCommitStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetCommitStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetCommitStatusRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *SetCommitStatusRequest) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+func (x *SetCommitStatusRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SetCommitStatusRequest) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *SetCommitStatusRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SetCommitStatusRequest) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+type SetCommitStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCommitStatusResponse) Reset() {
+	*x = SetCommitStatusResponse{}
+	mi := &file_proto_project_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCommitStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCommitStatusResponse) ProtoMessage() {}
+
+func (x *SetCommitStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCommitStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetCommitStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetCommitStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetCommitStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetRepoTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRepoTokenRequest) Reset() {
+	*x = GetRepoTokenRequest{}
+	mi := &file_proto_project_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRepoTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRepoTokenRequest) ProtoMessage() {}
+
+func (x *GetRepoTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRepoTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetRepoTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRepoTokenRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type GetRepoTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // GitHub token of the project owner, "" if the owner has none
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRepoTokenResponse) Reset() {
+	*x = GetRepoTokenResponse{}
+	mi := &file_proto_project_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRepoTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRepoTokenResponse) ProtoMessage() {}
+
+func (x *GetRepoTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRepoTokenResponse.ProtoReflect.Descriptor instead.
+func (*GetRepoTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetRepoTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetRepoTokenResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Secret struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Note: value is never returned in ListSecrets, only in GetSecrets (internal)
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Environment   string                 `protobuf:"bytes,6,opt,name=environment,proto3" json:"environment,omitempty"` // Empty for project secrets, shared by all environments
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Secret) Reset() {
+	*x = Secret{}
+	mi := &file_proto_project_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Secret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Secret) ProtoMessage() {}
+
+func (x *Secret) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
+func (*Secret) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Secret) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Secret) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Secret) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Secret) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Secret) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Secret) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type AddSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`             // Plain text, will be encrypted before storage
+	Environment   string                 `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"` // Environment name, empty for a project secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSecretRequest) Reset() {
+	*x = AddSecretRequest{}
+	mi := &file_proto_project_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSecretRequest) ProtoMessage() {}
+
+func (x *AddSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSecretRequest.ProtoReflect.Descriptor instead.
+func (*AddSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddSecretRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *AddSecretRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddSecretRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *AddSecretRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type AddSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSecretResponse) Reset() {
+	*x = AddSecretResponse{}
+	mi := &file_proto_project_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSecretResponse) ProtoMessage() {}
+
+func (x *AddSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSecretResponse.ProtoReflect.Descriptor instead.
+func (*AddSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AddSecretResponse) GetSecret() *Secret {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *AddSecretResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"` // New value to encrypt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSecretRequest) Reset() {
+	*x = UpdateSecretRequest{}
+	mi := &file_proto_project_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSecretRequest) ProtoMessage() {}
+
+func (x *UpdateSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSecretRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateSecretRequest) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *UpdateSecretRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *UpdateSecretRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateSecretRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type UpdateSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSecretResponse) Reset() {
+	*x = UpdateSecretResponse{}
+	mi := &file_proto_project_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSecretResponse) ProtoMessage() {}
+
+func (x *UpdateSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSecretResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateSecretResponse) GetSecret() *Secret {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *UpdateSecretResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DeleteSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretRequest) Reset() {
+	*x = DeleteSecretRequest{}
+	mi := &file_proto_project_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretRequest) ProtoMessage() {}
+
+func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteSecretRequest) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *DeleteSecretRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeleteSecretRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretResponse) Reset() {
+	*x = DeleteSecretResponse{}
+	mi := &file_proto_project_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretResponse) ProtoMessage() {}
+
+func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteSecretResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteSecretResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretsRequest) Reset() {
+	*x = ListSecretsRequest{}
+	mi := &file_proto_project_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretsRequest) ProtoMessage() {}
+
+func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListSecretsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListSecretsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*Secret              `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"` // Values are masked
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecretsResponse) Reset() {
+	*x = ListSecretsResponse{}
+	mi := &file_proto_project_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecretsResponse) ProtoMessage() {}
+
+func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListSecretsResponse) GetSecrets() []*Secret {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *ListSecretsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetSecrets is for internal use by Runner Service only
+type GetSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Environment   string                 `protobuf:"bytes,2,opt,name=environment,proto3" json:"environment,omitempty"` // Its secrets override project secrets of the same name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretsRequest) Reset() {
+	*x = GetSecretsRequest{}
+	mi := &file_proto_project_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretsRequest) ProtoMessage() {}
+
+func (x *GetSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretsRequest.ProtoReflect.Descriptor instead.
+func (*GetSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetSecretsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetSecretsRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type GetSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       map[string]string      `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // name -> decrypted value
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSecretsResponse) Reset() {
+	*x = GetSecretsResponse{}
+	mi := &file_proto_project_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSecretsResponse) ProtoMessage() {}
+
+func (x *GetSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSecretsResponse.ProtoReflect.Descriptor instead.
+func (*GetSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetSecretsResponse) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *GetSecretsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Domain struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId      string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                      // "pending", "verified" or "failed"
+	TxtRecordName  string                 `protobuf:"bytes,5,opt,name=txt_record_name,json=txtRecordName,proto3" json:"txt_record_name,omitempty"` // TXT record proving ownership of the domain
+	TxtRecordValue string                 `protobuf:"bytes,6,opt,name=txt_record_value,json=txtRecordValue,proto3" json:"txt_record_value,omitempty"`
+	Error          string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // Why the last verification failed
+	VerifiedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Domain) Reset() {
+	*x = Domain{}
+	mi := &file_proto_project_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Domain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Domain) ProtoMessage() {}
+
+func (x *Domain) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Domain.ProtoReflect.Descriptor instead.
+func (*Domain) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Domain) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Domain) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Domain) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Domain) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Domain) GetTxtRecordName() string {
+	if x != nil {
+		return x.TxtRecordName
+	}
+	return ""
+}
+
+func (x *Domain) GetTxtRecordValue() string {
+	if x != nil {
+		return x.TxtRecordValue
+	}
+	return ""
+}
+
+func (x *Domain) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Domain) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *Domain) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainRequest) Reset() {
+	*x = AddDomainRequest{}
+	mi := &file_proto_project_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainRequest) ProtoMessage() {}
+
+func (x *AddDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainRequest.ProtoReflect.Descriptor instead.
+func (*AddDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddDomainRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *AddDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddDomainRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AddDomainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Domain        *Domain                `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProjectsRequest) Reset() {
-	*x = ListProjectsRequest{}
-	mi := &file_proto_project_proto_msgTypes[6]
+func (x *AddDomainResponse) Reset() {
+	*x = AddDomainResponse{}
+	mi := &file_proto_project_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProjectsRequest) String() string {
+func (x *AddDomainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProjectsRequest) ProtoMessage() {}
+func (*AddDomainResponse) ProtoMessage() {}
 
-func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[6]
+func (x *AddDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,56 +3082,110 @@ func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
-func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use AddDomainResponse.ProtoReflect.Descriptor instead.
+func (*AddDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ListProjectsRequest) GetUserId() string {
+func (x *AddDomainResponse) GetDomain() *Domain {
 	if x != nil {
-		return x.UserId
+		return x.Domain
+	}
+	return nil
+}
+
+func (x *AddDomainResponse) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *ListProjectsRequest) GetPage() int32 {
+// VerifyDomain looks up the TXT record. A missing record is not an error, the
+// domain is returned with status "failed".
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DomainId      string                 `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_proto_project_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[38]
 	if x != nil {
-		return x.Page
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ListProjectsRequest) GetPageSize() int32 {
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *VerifyDomainRequest) GetDomainId() string {
 	if x != nil {
-		return x.PageSize
+		return x.DomainId
 	}
-	return 0
+	return ""
 }
 
-type ListProjectsResponse struct {
+func (x *VerifyDomainRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *VerifyDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type VerifyDomainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Domain        *Domain                `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProjectsResponse) Reset() {
-	*x = ListProjectsResponse{}
-	mi := &file_proto_project_proto_msgTypes[7]
+func (x *VerifyDomainResponse) Reset() {
+	*x = VerifyDomainResponse{}
+	mi := &file_proto_project_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProjectsResponse) String() string {
+func (x *VerifyDomainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProjectsResponse) ProtoMessage() {}
+func (*VerifyDomainResponse) ProtoMessage() {}
 
-func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[7]
+func (x *VerifyDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,61 +3196,275 @@ func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
-func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use VerifyDomainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ListProjectsResponse) GetProjects() []*Project {
+func (x *VerifyDomainResponse) GetDomain() *Domain {
 	if x != nil {
-		return x.Projects
+		return x.Domain
 	}
 	return nil
 }
 
-func (x *ListProjectsResponse) GetTotal() int32 {
+func (x *VerifyDomainResponse) GetError() string {
 	if x != nil {
-		return x.Total
+		return x.Error
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProjectsResponse) GetError() string {
+type RemoveDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DomainId      string                 `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDomainRequest) Reset() {
+	*x = RemoveDomainRequest{}
+	mi := &file_proto_project_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDomainRequest) ProtoMessage() {}
+
+func (x *RemoveDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDomainRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RemoveDomainRequest) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *RemoveDomainRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *RemoveDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDomainResponse) Reset() {
+	*x = RemoveDomainResponse{}
+	mi := &file_proto_project_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDomainResponse) ProtoMessage() {}
+
+func (x *RemoveDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDomainResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RemoveDomainResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveDomainResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type UpdateProjectRequest struct {
+type ListDomainsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsRequest) Reset() {
+	*x = ListDomainsRequest{}
+	mi := &file_proto_project_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsRequest) ProtoMessage() {}
+
+func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsRequest.ProtoReflect.Descriptor instead.
+func (*ListDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListDomainsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListDomainsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []*Domain              `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsResponse) Reset() {
+	*x = ListDomainsResponse{}
+	mi := &file_proto_project_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsResponse) ProtoMessage() {}
+
+func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsResponse.ProtoReflect.Descriptor instead.
+func (*ListDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListDomainsResponse) GetDomains() []*Domain {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *ListDomainsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Environment is a named deployment of a project next to production, e.g.
+// staging. Pushes to its branch are built for it, and its secrets override the
+// project secrets of the same name.
+type Environment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // DNS label; "production" is the project itself
 	Branch        string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
-	Preset        string                 `protobuf:"bytes,5,opt,name=preset,proto3" json:"preset,omitempty"`
-	BuildCommand  string                 `protobuf:"bytes,6,opt,name=build_command,json=buildCommand,proto3" json:"build_command,omitempty"`
-	StartCommand  string                 `protobuf:"bytes,7,opt,name=start_command,json=startCommand,proto3" json:"start_command,omitempty"`
-	Port          int32                  `protobuf:"varint,8,opt,name=port,proto3" json:"port,omitempty"`
+	Domain        string                 `protobuf:"bytes,5,opt,name=domain,proto3" json:"domain,omitempty"`                            // Verified custom domain of the project routed here instead of production
+	MemoryMb      int32                  `protobuf:"varint,6,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`       // Container memory, 0 = 512
+	CpuCores      int32                  `protobuf:"varint,7,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`       // 0 = 1
+	AutoDeploy    bool                   `protobuf:"varint,8,opt,name=auto_deploy,json=autoDeploy,proto3" json:"auto_deploy,omitempty"` // Deploy every successful build of the environment
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProjectRequest) Reset() {
-	*x = UpdateProjectRequest{}
-	mi := &file_proto_project_proto_msgTypes[8]
+func (x *Environment) Reset() {
+	*x = Environment{}
+	mi := &file_proto_project_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProjectRequest) String() string {
+func (x *Environment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProjectRequest) ProtoMessage() {}
+func (*Environment) ProtoMessage() {}
 
-func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[8]
+func (x *Environment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,90 +3475,110 @@ func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Environment.ProtoReflect.Descriptor instead.
+func (*Environment) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *UpdateProjectRequest) GetProjectId() string {
+func (x *Environment) GetId() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateProjectRequest) GetUserId() string {
+func (x *Environment) GetProjectId() string {
 	if x != nil {
-		return x.UserId
+		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *UpdateProjectRequest) GetName() string {
+func (x *Environment) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateProjectRequest) GetBranch() string {
+func (x *Environment) GetBranch() string {
 	if x != nil {
 		return x.Branch
 	}
 	return ""
 }
 
-func (x *UpdateProjectRequest) GetPreset() string {
+func (x *Environment) GetDomain() string {
 	if x != nil {
-		return x.Preset
+		return x.Domain
 	}
 	return ""
 }
 
-func (x *UpdateProjectRequest) GetBuildCommand() string {
+func (x *Environment) GetMemoryMb() int32 {
 	if x != nil {
-		return x.BuildCommand
+		return x.MemoryMb
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProjectRequest) GetStartCommand() string {
+func (x *Environment) GetCpuCores() int32 {
 	if x != nil {
-		return x.StartCommand
+		return x.CpuCores
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProjectRequest) GetPort() int32 {
+func (x *Environment) GetAutoDeploy() bool {
 	if x != nil {
-		return x.Port
+		return x.AutoDeploy
 	}
-	return 0
+	return false
 }
 
-type UpdateProjectResponse struct {
+func (x *Environment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Environment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateEnvironmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Branch        string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	Domain        string                 `protobuf:"bytes,5,opt,name=domain,proto3" json:"domain,omitempty"`
+	MemoryMb      int32                  `protobuf:"varint,6,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	CpuCores      int32                  `protobuf:"varint,7,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`
+	AutoDeploy    bool                   `protobuf:"varint,8,opt,name=auto_deploy,json=autoDeploy,proto3" json:"auto_deploy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProjectResponse) Reset() {
-	*x = UpdateProjectResponse{}
-	mi := &file_proto_project_proto_msgTypes[9]
+func (x *CreateEnvironmentRequest) Reset() {
+	*x = CreateEnvironmentRequest{}
+	mi := &file_proto_project_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProjectResponse) String() string {
+func (x *CreateEnvironmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProjectResponse) ProtoMessage() {}
+func (*CreateEnvironmentRequest) ProtoMessage() {}
 
-func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[9]
+func (x *CreateEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -747,108 +3589,90 @@ func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CreateEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *UpdateProjectResponse) GetProject() *Project {
+func (x *CreateEnvironmentRequest) GetProjectId() string {
 	if x != nil {
-		return x.Project
+		return x.ProjectId
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateProjectResponse) GetError() string {
+func (x *CreateEnvironmentRequest) GetUserId() string {
 	if x != nil {
-		return x.Error
+		return x.UserId
 	}
 	return ""
 }
 
-type DeleteProjectRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId         string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GithubAccessToken string                 `protobuf:"bytes,3,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"` // For webhook deletion
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
-}
-
-func (x *DeleteProjectRequest) Reset() {
-	*x = DeleteProjectRequest{}
-	mi := &file_proto_project_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteProjectRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreateEnvironmentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (*DeleteProjectRequest) ProtoMessage() {}
-
-func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[10]
+func (x *CreateEnvironmentRequest) GetBranch() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Branch
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{10}
+func (x *CreateEnvironmentRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
 }
 
-func (x *DeleteProjectRequest) GetProjectId() string {
+func (x *CreateEnvironmentRequest) GetMemoryMb() int32 {
 	if x != nil {
-		return x.ProjectId
+		return x.MemoryMb
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteProjectRequest) GetUserId() string {
+func (x *CreateEnvironmentRequest) GetCpuCores() int32 {
 	if x != nil {
-		return x.UserId
+		return x.CpuCores
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteProjectRequest) GetGithubAccessToken() string {
+func (x *CreateEnvironmentRequest) GetAutoDeploy() bool {
 	if x != nil {
-		return x.GithubAccessToken
+		return x.AutoDeploy
 	}
-	return ""
+	return false
 }
 
-type DeleteProjectResponse struct {
+type CreateEnvironmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Environment   *Environment           `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProjectResponse) Reset() {
-	*x = DeleteProjectResponse{}
-	mi := &file_proto_project_proto_msgTypes[11]
+func (x *CreateEnvironmentResponse) Reset() {
+	*x = CreateEnvironmentResponse{}
+	mi := &file_proto_project_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProjectResponse) String() string {
+func (x *CreateEnvironmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProjectResponse) ProtoMessage() {}
+func (*CreateEnvironmentResponse) ProtoMessage() {}
 
-func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[11]
+func (x *CreateEnvironmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,55 +3683,55 @@ func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CreateEnvironmentResponse.ProtoReflect.Descriptor instead.
+func (*CreateEnvironmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *DeleteProjectResponse) GetSuccess() bool {
+func (x *CreateEnvironmentResponse) GetEnvironment() *Environment {
 	if x != nil {
-		return x.Success
+		return x.Environment
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteProjectResponse) GetError() string {
+func (x *CreateEnvironmentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type Repository struct {
+// UpdateEnvironment replaces the settings of an environment, its name is fixed
+type UpdateEnvironmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	CloneUrl      string                 `protobuf:"bytes,4,opt,name=clone_url,json=cloneUrl,proto3" json:"clone_url,omitempty"`
-	HtmlUrl       string                 `protobuf:"bytes,5,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,6,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
-	Language      string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
-	DefaultBranch string                 `protobuf:"bytes,9,opt,name=default_branch,json=defaultBranch,proto3" json:"default_branch,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Branch        string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	Domain        string                 `protobuf:"bytes,5,opt,name=domain,proto3" json:"domain,omitempty"`
+	MemoryMb      int32                  `protobuf:"varint,6,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	CpuCores      int32                  `protobuf:"varint,7,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`
+	AutoDeploy    bool                   `protobuf:"varint,8,opt,name=auto_deploy,json=autoDeploy,proto3" json:"auto_deploy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Repository) Reset() {
-	*x = Repository{}
-	mi := &file_proto_project_proto_msgTypes[12]
+func (x *UpdateEnvironmentRequest) Reset() {
+	*x = UpdateEnvironmentRequest{}
+	mi := &file_proto_project_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Repository) String() string {
+func (x *UpdateEnvironmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Repository) ProtoMessage() {}
+func (*UpdateEnvironmentRequest) ProtoMessage() {}
 
-func (x *Repository) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[12]
+func (x *UpdateEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -918,149 +3742,90 @@ func (x *Repository) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Repository.ProtoReflect.Descriptor instead.
-func (*Repository) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *Repository) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Repository) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Repository) GetFullName() string {
-	if x != nil {
-		return x.FullName
-	}
-	return ""
+// Deprecated: Use UpdateEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *Repository) GetCloneUrl() string {
+func (x *UpdateEnvironmentRequest) GetProjectId() string {
 	if x != nil {
-		return x.CloneUrl
+		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *Repository) GetHtmlUrl() string {
+func (x *UpdateEnvironmentRequest) GetUserId() string {
 	if x != nil {
-		return x.HtmlUrl
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Repository) GetIsPrivate() bool {
-	if x != nil {
-		return x.IsPrivate
-	}
-	return false
-}
-
-func (x *Repository) GetDescription() string {
+func (x *UpdateEnvironmentRequest) GetName() string {
 	if x != nil {
-		return x.Description
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Repository) GetLanguage() string {
+func (x *UpdateEnvironmentRequest) GetBranch() string {
 	if x != nil {
-		return x.Language
+		return x.Branch
 	}
 	return ""
 }
 
-func (x *Repository) GetDefaultBranch() string {
+func (x *UpdateEnvironmentRequest) GetDomain() string {
 	if x != nil {
-		return x.DefaultBranch
+		return x.Domain
 	}
 	return ""
 }
 
-type ListRepositoriesRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GithubAccessToken string                 `protobuf:"bytes,2,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
-}
-
-func (x *ListRepositoriesRequest) Reset() {
-	*x = ListRepositoriesRequest{}
-	mi := &file_proto_project_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListRepositoriesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListRepositoriesRequest) ProtoMessage() {}
-
-func (x *ListRepositoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[13]
+func (x *UpdateEnvironmentRequest) GetMemoryMb() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MemoryMb
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListRepositoriesRequest.ProtoReflect.Descriptor instead.
-func (*ListRepositoriesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{13}
+	return 0
 }
 
-func (x *ListRepositoriesRequest) GetUserId() string {
+func (x *UpdateEnvironmentRequest) GetCpuCores() int32 {
 	if x != nil {
-		return x.UserId
+		return x.CpuCores
 	}
-	return ""
+	return 0
 }
 
-func (x *ListRepositoriesRequest) GetGithubAccessToken() string {
+func (x *UpdateEnvironmentRequest) GetAutoDeploy() bool {
 	if x != nil {
-		return x.GithubAccessToken
+		return x.AutoDeploy
 	}
-	return ""
+	return false
 }
 
-type ListRepositoriesResponse struct {
+type UpdateEnvironmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Repositories  []*Repository          `protobuf:"bytes,1,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	Environment   *Environment           `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRepositoriesResponse) Reset() {
-	*x = ListRepositoriesResponse{}
-	mi := &file_proto_project_proto_msgTypes[14]
+func (x *UpdateEnvironmentResponse) Reset() {
+	*x = UpdateEnvironmentResponse{}
+	mi := &file_proto_project_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRepositoriesResponse) String() string {
+func (x *UpdateEnvironmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRepositoriesResponse) ProtoMessage() {}
+func (*UpdateEnvironmentResponse) ProtoMessage() {}
 
-func (x *ListRepositoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[14]
+func (x *UpdateEnvironmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,50 +3836,51 @@ func (x *ListRepositoriesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRepositoriesResponse.ProtoReflect.Descriptor instead.
-func (*ListRepositoriesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use UpdateEnvironmentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEnvironmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ListRepositoriesResponse) GetRepositories() []*Repository {
+func (x *UpdateEnvironmentResponse) GetEnvironment() *Environment {
 	if x != nil {
-		return x.Repositories
+		return x.Environment
 	}
 	return nil
 }
 
-func (x *ListRepositoriesResponse) GetError() string {
+func (x *UpdateEnvironmentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type SetupWebhookRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId         string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CallbackUrl       string                 `protobuf:"bytes,3,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
-	GithubAccessToken string                 `protobuf:"bytes,4,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// DeleteEnvironment deletes an environment and its secrets. Its deployment is
+// stopped by the caller.
+type DeleteEnvironmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetupWebhookRequest) Reset() {
-	*x = SetupWebhookRequest{}
-	mi := &file_proto_project_proto_msgTypes[15]
+func (x *DeleteEnvironmentRequest) Reset() {
+	*x = DeleteEnvironmentRequest{}
+	mi := &file_proto_project_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetupWebhookRequest) String() string {
+func (x *DeleteEnvironmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetupWebhookRequest) ProtoMessage() {}
+func (*DeleteEnvironmentRequest) ProtoMessage() {}
 
-func (x *SetupWebhookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[15]
+func (x *DeleteEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,63 +3891,55 @@ func (x *SetupWebhookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetupWebhookRequest.ProtoReflect.Descriptor instead.
-func (*SetupWebhookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use DeleteEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *SetupWebhookRequest) GetProjectId() string {
+func (x *DeleteEnvironmentRequest) GetProjectId() string {
 	if x != nil {
 		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *SetupWebhookRequest) GetUserId() string {
+func (x *DeleteEnvironmentRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *SetupWebhookRequest) GetCallbackUrl() string {
-	if x != nil {
-		return x.CallbackUrl
-	}
-	return ""
-}
-
-func (x *SetupWebhookRequest) GetGithubAccessToken() string {
+func (x *DeleteEnvironmentRequest) GetName() string {
 	if x != nil {
-		return x.GithubAccessToken
+		return x.Name
 	}
 	return ""
 }
 
-type SetupWebhookResponse struct {
+type DeleteEnvironmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	WebhookId     int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetupWebhookResponse) Reset() {
-	*x = SetupWebhookResponse{}
-	mi := &file_proto_project_proto_msgTypes[16]
+func (x *DeleteEnvironmentResponse) Reset() {
+	*x = DeleteEnvironmentResponse{}
+	mi := &file_proto_project_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetupWebhookResponse) String() string {
+func (x *DeleteEnvironmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetupWebhookResponse) ProtoMessage() {}
+func (*DeleteEnvironmentResponse) ProtoMessage() {}
 
-func (x *SetupWebhookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[16]
+func (x *DeleteEnvironmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1192,56 +3950,48 @@ func (x *SetupWebhookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetupWebhookResponse.ProtoReflect.Descriptor instead.
-func (*SetupWebhookResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use DeleteEnvironmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEnvironmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *SetupWebhookResponse) GetSuccess() bool {
+func (x *DeleteEnvironmentResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *SetupWebhookResponse) GetWebhookId() int64 {
-	if x != nil {
-		return x.WebhookId
-	}
-	return 0
-}
-
-func (x *SetupWebhookResponse) GetError() string {
+func (x *DeleteEnvironmentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type DeleteWebhookRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId         string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GithubAccessToken string                 `protobuf:"bytes,3,opt,name=github_access_token,json=githubAccessToken,proto3" json:"github_access_token,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type ListEnvironmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteWebhookRequest) Reset() {
-	*x = DeleteWebhookRequest{}
-	mi := &file_proto_project_proto_msgTypes[17]
+func (x *ListEnvironmentsRequest) Reset() {
+	*x = ListEnvironmentsRequest{}
+	mi := &file_proto_project_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteWebhookRequest) String() string {
+func (x *ListEnvironmentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteWebhookRequest) ProtoMessage() {}
+func (*ListEnvironmentsRequest) ProtoMessage() {}
 
-func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[17]
+func (x *ListEnvironmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1252,55 +4002,48 @@ func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
-func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListEnvironmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListEnvironmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *DeleteWebhookRequest) GetProjectId() string {
+func (x *ListEnvironmentsRequest) GetProjectId() string {
 	if x != nil {
 		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *DeleteWebhookRequest) GetUserId() string {
+func (x *ListEnvironmentsRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *DeleteWebhookRequest) GetGithubAccessToken() string {
-	if x != nil {
-		return x.GithubAccessToken
-	}
-	return ""
-}
-
-type DeleteWebhookResponse struct {
+type ListEnvironmentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Environments  []*Environment         `protobuf:"bytes,1,rep,name=environments,proto3" json:"environments,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteWebhookResponse) Reset() {
-	*x = DeleteWebhookResponse{}
-	mi := &file_proto_project_proto_msgTypes[18]
+func (x *ListEnvironmentsResponse) Reset() {
+	*x = ListEnvironmentsResponse{}
+	mi := &file_proto_project_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteWebhookResponse) String() string {
+func (x *ListEnvironmentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteWebhookResponse) ProtoMessage() {}
+func (*ListEnvironmentsResponse) ProtoMessage() {}
 
-func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[18]
+func (x *ListEnvironmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1311,52 +4054,51 @@ func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
-func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ListEnvironmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListEnvironmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *DeleteWebhookResponse) GetSuccess() bool {
+func (x *ListEnvironmentsResponse) GetEnvironments() []*Environment {
 	if x != nil {
-		return x.Success
+		return x.Environments
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteWebhookResponse) GetError() string {
+func (x *ListEnvironmentsResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type Secret struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProjectId string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	// Note: value is never returned in ListSecrets, only in GetSecrets (internal)
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// DeployWindow is a recurring period in which production deploys are allowed.
+// It opens at every time matched by schedule and stays open for duration_minutes.
+type DeployWindow struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Schedule        string                 `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`                                       // 5-field cron expression, e.g. "0 9 * * 1-4" for 9:00 Monday to Thursday
+	DurationMinutes int32                  `protobuf:"varint,2,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"` // At most 7 days
+	Timezone        string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`                                       // IANA name the schedule is read in, "" = UTC
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *Secret) Reset() {
-	*x = Secret{}
-	mi := &file_proto_project_proto_msgTypes[19]
+func (x *DeployWindow) Reset() {
+	*x = DeployWindow{}
+	mi := &file_proto_project_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Secret) String() string {
+func (x *DeployWindow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Secret) ProtoMessage() {}
+func (*DeployWindow) ProtoMessage() {}
 
-func (x *Secret) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[19]
+func (x *DeployWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1367,71 +4109,59 @@ func (x *Secret) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
-func (*Secret) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use DeployWindow.ProtoReflect.Descriptor instead.
+func (*DeployWindow) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *Secret) GetId() string {
+func (x *DeployWindow) GetSchedule() string {
 	if x != nil {
-		return x.Id
+		return x.Schedule
 	}
 	return ""
 }
 
-func (x *Secret) GetProjectId() string {
+func (x *DeployWindow) GetDurationMinutes() int32 {
 	if x != nil {
-		return x.ProjectId
+		return x.DurationMinutes
 	}
-	return ""
+	return 0
 }
 
-func (x *Secret) GetName() string {
+func (x *DeployWindow) GetTimezone() string {
 	if x != nil {
-		return x.Name
+		return x.Timezone
 	}
 	return ""
 }
 
-func (x *Secret) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *Secret) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-type AddSecretRequest struct {
+type DeployAuditEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"` // Plain text, will be encrypted before storage
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`           // "freeze", "unfreeze", "windows" or "override"
+	Environment   string                 `protobuf:"bytes,4,opt,name=environment,proto3" json:"environment,omitempty"` // Environment deployed by an override, "" = production
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddSecretRequest) Reset() {
-	*x = AddSecretRequest{}
-	mi := &file_proto_project_proto_msgTypes[20]
+func (x *DeployAuditEntry) Reset() {
+	*x = DeployAuditEntry{}
+	mi := &file_proto_project_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddSecretRequest) String() string {
+func (x *DeployAuditEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddSecretRequest) ProtoMessage() {}
+func (*DeployAuditEntry) ProtoMessage() {}
 
-func (x *AddSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[20]
+func (x *DeployAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1442,62 +4172,85 @@ func (x *AddSecretRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddSecretRequest.ProtoReflect.Descriptor instead.
-func (*AddSecretRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use DeployAuditEntry.ProtoReflect.Descriptor instead.
+func (*DeployAuditEntry) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *AddSecretRequest) GetProjectId() string {
+func (x *DeployAuditEntry) GetId() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AddSecretRequest) GetUserId() string {
+func (x *DeployAuditEntry) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *AddSecretRequest) GetName() string {
+func (x *DeployAuditEntry) GetAction() string {
 	if x != nil {
-		return x.Name
+		return x.Action
 	}
 	return ""
 }
 
-func (x *AddSecretRequest) GetValue() string {
+func (x *DeployAuditEntry) GetEnvironment() string {
 	if x != nil {
-		return x.Value
+		return x.Environment
 	}
 	return ""
 }
 
-type AddSecretResponse struct {
+func (x *DeployAuditEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeployAuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// DeployPolicy decides when production of a project may be deployed. Other
+// environments and previews are not restricted.
+type DeployPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Windows       []*DeployWindow        `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"` // Empty = deploys allowed at any time
+	Frozen        bool                   `protobuf:"varint,3,opt,name=frozen,proto3" json:"frozen,omitempty"`  // Manual freeze, overrides the windows
+	FreezeReason  string                 `protobuf:"bytes,4,opt,name=freeze_reason,json=freezeReason,proto3" json:"freeze_reason,omitempty"`
+	FrozenBy      string                 `protobuf:"bytes,5,opt,name=frozen_by,json=frozenBy,proto3" json:"frozen_by,omitempty"`
+	FrozenAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=frozen_at,json=frozenAt,proto3" json:"frozen_at,omitempty"`
+	Open          bool                   `protobuf:"varint,7,opt,name=open,proto3" json:"open,omitempty"`                              // Whether production can be deployed now
+	NextWindow    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=next_window,json=nextWindow,proto3" json:"next_window,omitempty"` // Next opening of a window, when outside all windows
+	Audit         []*DeployAuditEntry    `protobuf:"bytes,9,rep,name=audit,proto3" json:"audit,omitempty"`                             // Latest entries, newest first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddSecretResponse) Reset() {
-	*x = AddSecretResponse{}
-	mi := &file_proto_project_proto_msgTypes[21]
+func (x *DeployPolicy) Reset() {
+	*x = DeployPolicy{}
+	mi := &file_proto_project_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddSecretResponse) String() string {
+func (x *DeployPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddSecretResponse) ProtoMessage() {}
+func (*DeployPolicy) ProtoMessage() {}
 
-func (x *AddSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[21]
+func (x *DeployPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1508,116 +4261,97 @@ func (x *AddSecretResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddSecretResponse.ProtoReflect.Descriptor instead.
-func (*AddSecretResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use DeployPolicy.ProtoReflect.Descriptor instead.
+func (*DeployPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *AddSecretResponse) GetSecret() *Secret {
+func (x *DeployPolicy) GetProjectId() string {
 	if x != nil {
-		return x.Secret
+		return x.ProjectId
 	}
-	return nil
+	return ""
 }
 
-func (x *AddSecretResponse) GetError() string {
+func (x *DeployPolicy) GetWindows() []*DeployWindow {
 	if x != nil {
-		return x.Error
+		return x.Windows
 	}
-	return ""
-}
-
-type UpdateSecretRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
-	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"` // New value to encrypt
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateSecretRequest) Reset() {
-	*x = UpdateSecretRequest{}
-	mi := &file_proto_project_proto_msgTypes[22]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return nil
 }
 
-func (x *UpdateSecretRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DeployPolicy) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
 }
 
-func (*UpdateSecretRequest) ProtoMessage() {}
-
-func (x *UpdateSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[22]
+func (x *DeployPolicy) GetFreezeReason() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.FreezeReason
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateSecretRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSecretRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{22}
+func (x *DeployPolicy) GetFrozenBy() string {
+	if x != nil {
+		return x.FrozenBy
+	}
+	return ""
 }
 
-func (x *UpdateSecretRequest) GetSecretId() string {
+func (x *DeployPolicy) GetFrozenAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.SecretId
+		return x.FrozenAt
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateSecretRequest) GetProjectId() string {
+func (x *DeployPolicy) GetOpen() bool {
 	if x != nil {
-		return x.ProjectId
+		return x.Open
 	}
-	return ""
+	return false
 }
 
-func (x *UpdateSecretRequest) GetUserId() string {
+func (x *DeployPolicy) GetNextWindow() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UserId
+		return x.NextWindow
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateSecretRequest) GetValue() string {
+func (x *DeployPolicy) GetAudit() []*DeployAuditEntry {
 	if x != nil {
-		return x.Value
+		return x.Audit
 	}
-	return ""
+	return nil
 }
 
-type UpdateSecretResponse struct {
+type DeployPolicyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secret        *Secret                `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Policy        *DeployPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSecretResponse) Reset() {
-	*x = UpdateSecretResponse{}
-	mi := &file_proto_project_proto_msgTypes[23]
+func (x *DeployPolicyResponse) Reset() {
+	*x = DeployPolicyResponse{}
+	mi := &file_proto_project_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSecretResponse) String() string {
+func (x *DeployPolicyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSecretResponse) ProtoMessage() {}
+func (*DeployPolicyResponse) ProtoMessage() {}
 
-func (x *UpdateSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[23]
+func (x *DeployPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1628,49 +4362,48 @@ func (x *UpdateSecretResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSecretResponse.ProtoReflect.Descriptor instead.
-func (*UpdateSecretResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use DeployPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeployPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *UpdateSecretResponse) GetSecret() *Secret {
+func (x *DeployPolicyResponse) GetPolicy() *DeployPolicy {
 	if x != nil {
-		return x.Secret
+		return x.Policy
 	}
 	return nil
 }
 
-func (x *UpdateSecretResponse) GetError() string {
+func (x *DeployPolicyResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type DeleteSecretRequest struct {
+type GetDeployPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
-	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSecretRequest) Reset() {
-	*x = DeleteSecretRequest{}
-	mi := &file_proto_project_proto_msgTypes[24]
+func (x *GetDeployPolicyRequest) Reset() {
+	*x = GetDeployPolicyRequest{}
+	mi := &file_proto_project_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSecretRequest) String() string {
+func (x *GetDeployPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSecretRequest) ProtoMessage() {}
+func (*GetDeployPolicyRequest) ProtoMessage() {}
 
-func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[24]
+func (x *GetDeployPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1681,55 +4414,50 @@ func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *DeleteSecretRequest) GetSecretId() string {
-	if x != nil {
-		return x.SecretId
-	}
-	return ""
+// Deprecated: Use GetDeployPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetDeployPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *DeleteSecretRequest) GetProjectId() string {
+func (x *GetDeployPolicyRequest) GetProjectId() string {
 	if x != nil {
 		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *DeleteSecretRequest) GetUserId() string {
+func (x *GetDeployPolicyRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type DeleteSecretResponse struct {
+// SetDeployWindows replaces the deploy windows of a project
+type SetDeployWindowsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Windows       []*DeployWindow        `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSecretResponse) Reset() {
-	*x = DeleteSecretResponse{}
-	mi := &file_proto_project_proto_msgTypes[25]
+func (x *SetDeployWindowsRequest) Reset() {
+	*x = SetDeployWindowsRequest{}
+	mi := &file_proto_project_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSecretResponse) String() string {
+func (x *SetDeployWindowsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSecretResponse) ProtoMessage() {}
+func (*SetDeployWindowsRequest) ProtoMessage() {}
 
-func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[25]
+func (x *SetDeployWindowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1740,48 +4468,59 @@ func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
-func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use SetDeployWindowsRequest.ProtoReflect.Descriptor instead.
+func (*SetDeployWindowsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *DeleteSecretResponse) GetSuccess() bool {
+func (x *SetDeployWindowsRequest) GetProjectId() string {
 	if x != nil {
-		return x.Success
+		return x.ProjectId
 	}
-	return false
+	return ""
 }
 
-func (x *DeleteSecretResponse) GetError() string {
+func (x *SetDeployWindowsRequest) GetUserId() string {
 	if x != nil {
-		return x.Error
+		return x.UserId
 	}
 	return ""
 }
 
-type ListSecretsRequest struct {
+func (x *SetDeployWindowsRequest) GetWindows() []*DeployWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+// SetDeployFreeze freezes or unfreezes production deploys. A reason is required
+// to freeze.
+type SetDeployFreezeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Frozen        bool                   `protobuf:"varint,3,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSecretsRequest) Reset() {
-	*x = ListSecretsRequest{}
-	mi := &file_proto_project_proto_msgTypes[26]
+func (x *SetDeployFreezeRequest) Reset() {
+	*x = SetDeployFreezeRequest{}
+	mi := &file_proto_project_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSecretsRequest) String() string {
+func (x *SetDeployFreezeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSecretsRequest) ProtoMessage() {}
+func (*SetDeployFreezeRequest) ProtoMessage() {}
 
-func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[26]
+func (x *SetDeployFreezeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1792,48 +4531,68 @@ func (x *ListSecretsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSecretsRequest.ProtoReflect.Descriptor instead.
-func (*ListSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use SetDeployFreezeRequest.ProtoReflect.Descriptor instead.
+func (*SetDeployFreezeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ListSecretsRequest) GetProjectId() string {
+func (x *SetDeployFreezeRequest) GetProjectId() string {
 	if x != nil {
 		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *ListSecretsRequest) GetUserId() string {
+func (x *SetDeployFreezeRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type ListSecretsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secrets       []*Secret              `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"` // Values are masked
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SetDeployFreezeRequest) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
 }
 
-func (x *ListSecretsResponse) Reset() {
-	*x = ListSecretsResponse{}
-	mi := &file_proto_project_proto_msgTypes[27]
+func (x *SetDeployFreezeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CheckDeploy tells whether a deploy is allowed by the policy of the project. An
+// override is allowed to users with the deploy_policy override permission and
+// recorded in the audit log.
+type CheckDeployRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId      string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // User deploying, the owner for automatic deploys
+	Environment    string                 `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`     // "" or "production" for production
+	Override       bool                   `protobuf:"varint,4,opt,name=override,proto3" json:"override,omitempty"`
+	OverrideReason string                 `protobuf:"bytes,5,opt,name=override_reason,json=overrideReason,proto3" json:"override_reason,omitempty"` // Required with override
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckDeployRequest) Reset() {
+	*x = CheckDeployRequest{}
+	mi := &file_proto_project_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSecretsResponse) String() string {
+func (x *CheckDeployRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSecretsResponse) ProtoMessage() {}
+func (*CheckDeployRequest) ProtoMessage() {}
 
-func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[27]
+func (x *CheckDeployRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1844,93 +4603,72 @@ func (x *ListSecretsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSecretsResponse.ProtoReflect.Descriptor instead.
-func (*ListSecretsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use CheckDeployRequest.ProtoReflect.Descriptor instead.
+func (*CheckDeployRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ListSecretsResponse) GetSecrets() []*Secret {
+func (x *CheckDeployRequest) GetProjectId() string {
 	if x != nil {
-		return x.Secrets
+		return x.ProjectId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListSecretsResponse) GetError() string {
+func (x *CheckDeployRequest) GetUserId() string {
 	if x != nil {
-		return x.Error
+		return x.UserId
 	}
 	return ""
 }
 
-// GetSecrets is for internal use by Runner Service only
-type GetSecretsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Note: This should be authenticated via mTLS or service-to-service auth
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetSecretsRequest) Reset() {
-	*x = GetSecretsRequest{}
-	mi := &file_proto_project_proto_msgTypes[28]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetSecretsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetSecretsRequest) ProtoMessage() {}
-
-func (x *GetSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[28]
+func (x *CheckDeployRequest) GetEnvironment() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Environment
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetSecretsRequest.ProtoReflect.Descriptor instead.
-func (*GetSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{28}
+func (x *CheckDeployRequest) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
 }
 
-func (x *GetSecretsRequest) GetProjectId() string {
+func (x *CheckDeployRequest) GetOverrideReason() string {
 	if x != nil {
-		return x.ProjectId
+		return x.OverrideReason
 	}
 	return ""
 }
 
-type GetSecretsResponse struct {
+type CheckDeployResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secrets       map[string]string      `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // name -> decrypted value
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`     // Why not: "deploy_frozen", "outside_deploy_window", "override_reason_required" or "override_not_permitted"
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Message for the user
+	NextWindow    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_window,json=nextWindow,proto3" json:"next_window,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSecretsResponse) Reset() {
-	*x = GetSecretsResponse{}
-	mi := &file_proto_project_proto_msgTypes[29]
+func (x *CheckDeployResponse) Reset() {
+	*x = CheckDeployResponse{}
+	mi := &file_proto_project_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSecretsResponse) String() string {
+func (x *CheckDeployResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSecretsResponse) ProtoMessage() {}
+func (*CheckDeployResponse) ProtoMessage() {}
 
-func (x *GetSecretsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_proto_msgTypes[29]
+func (x *CheckDeployResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1941,19 +4679,40 @@ func (x *GetSecretsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSecretsResponse.ProtoReflect.Descriptor instead.
-func (*GetSecretsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use CheckDeployResponse.ProtoReflect.Descriptor instead.
+func (*CheckDeployResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *GetSecretsResponse) GetSecrets() map[string]string {
+func (x *CheckDeployResponse) GetAllowed() bool {
 	if x != nil {
-		return x.Secrets
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *CheckDeployResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CheckDeployResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CheckDeployResponse) GetNextWindow() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextWindow
 	}
 	return nil
 }
 
-func (x *GetSecretsResponse) GetError() string {
+func (x *CheckDeployResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
@@ -1964,7 +4723,7 @@ var File_proto_project_proto protoreflect.FileDescriptor
 
 const file_proto_project_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/project.proto\x12\aproject\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaa\x03\n" +
+	"\x13proto/project.proto\x12\aproject\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\v\n" +
 	"\aProject\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
@@ -1982,7 +4741,46 @@ const file_proto_project_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe1\x02\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\ftest_command\x18\x0e \x01(\tR\vtestCommand\x12&\n" +
+	"\x0fbuild_memory_mb\x18\x0f \x01(\x05R\rbuildMemoryMb\x12\x1d\n" +
+	"\n" +
+	"build_cpus\x18\x10 \x01(\x01R\tbuildCpus\x122\n" +
+	"\x15build_timeout_minutes\x18\x11 \x01(\x05R\x13buildTimeoutMinutes\x12\"\n" +
+	"\rbuild_disk_mb\x18\x12 \x01(\x05R\vbuildDiskMb\x12%\n" +
+	"\x0eroot_directory\x18\x13 \x01(\tR\rrootDirectory\x12\x1f\n" +
+	"\vwatch_paths\x18\x14 \x03(\tR\n" +
+	"watchPaths\x12)\n" +
+	"\x10clone_submodules\x18\x15 \x01(\bR\x0fcloneSubmodules\x12\x1b\n" +
+	"\tclone_lfs\x18\x16 \x01(\bR\bcloneLfs\x12\x1f\n" +
+	"\vclone_depth\x18\x17 \x01(\x05R\n" +
+	"cloneDepth\x12,\n" +
+	"\x12clone_full_history\x18\x18 \x01(\bR\x10cloneFullHistory\x12(\n" +
+	"\x10clone_fetch_tags\x18\x19 \x01(\bR\x0ecloneFetchTags\x12\x1f\n" +
+	"\vauto_deploy\x18\x1a \x01(\bR\n" +
+	"autoDeploy\x12*\n" +
+	"\x11health_check_type\x18\x1b \x01(\tR\x0fhealthCheckType\x12*\n" +
+	"\x11health_check_path\x18\x1c \x01(\tR\x0fhealthCheckPath\x12?\n" +
+	"\x1chealth_check_expected_status\x18\x1d \x01(\x05R\x19healthCheckExpectedStatus\x12A\n" +
+	"\x1dhealth_check_interval_seconds\x18\x1e \x01(\x05R\x1ahealthCheckIntervalSeconds\x12?\n" +
+	"\x1chealth_check_timeout_seconds\x18\x1f \x01(\x05R\x19healthCheckTimeoutSeconds\x120\n" +
+	"\x14health_check_retries\x18  \x01(\x05R\x12healthCheckRetries\x12\x1a\n" +
+	"\breplicas\x18! \x01(\x05R\breplicas\x12%\n" +
+	"\x0ecustom_domains\x18\" \x03(\tR\rcustomDomains\x128\n" +
+	"\fenvironments\x18# \x03(\v2\x14.project.EnvironmentR\fenvironments\x12.\n" +
+	"\x13auto_cancel_pending\x18$ \x01(\bR\x11autoCancelPending\x12.\n" +
+	"\x13auto_cancel_running\x18% \x01(\bR\x11autoCancelRunning\x129\n" +
+	"\fbuild_matrix\x18& \x03(\v2\x16.project.MatrixVariantR\vbuildMatrix\"\xc9\x01\n" +
+	"\rMatrixVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"base_image\x18\x02 \x01(\tR\tbaseImage\x121\n" +
+	"\x03env\x18\x03 \x03(\v2\x1f.project.MatrixVariant.EnvEntryR\x03env\x12\x1a\n" +
+	"\boptional\x18\x04 \x01(\bR\boptional\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\n" +
+	"\n" +
 	"\x14CreateProjectRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
@@ -1996,7 +4794,34 @@ const file_proto_project_proto_rawDesc = "" +
 	"\n" +
 	"is_private\x18\n" +
 	" \x01(\bR\tisPrivate\x12.\n" +
-	"\x13github_access_token\x18\v \x01(\tR\x11githubAccessToken\"Y\n" +
+	"\x13github_access_token\x18\v \x01(\tR\x11githubAccessToken\x12!\n" +
+	"\ftest_command\x18\f \x01(\tR\vtestCommand\x12&\n" +
+	"\x0fbuild_memory_mb\x18\r \x01(\x05R\rbuildMemoryMb\x12\x1d\n" +
+	"\n" +
+	"build_cpus\x18\x0e \x01(\x01R\tbuildCpus\x122\n" +
+	"\x15build_timeout_minutes\x18\x0f \x01(\x05R\x13buildTimeoutMinutes\x12\"\n" +
+	"\rbuild_disk_mb\x18\x10 \x01(\x05R\vbuildDiskMb\x12%\n" +
+	"\x0eroot_directory\x18\x11 \x01(\tR\rrootDirectory\x12\x1f\n" +
+	"\vwatch_paths\x18\x12 \x03(\tR\n" +
+	"watchPaths\x12)\n" +
+	"\x10clone_submodules\x18\x13 \x01(\bR\x0fcloneSubmodules\x12\x1b\n" +
+	"\tclone_lfs\x18\x14 \x01(\bR\bcloneLfs\x12\x1f\n" +
+	"\vclone_depth\x18\x15 \x01(\x05R\n" +
+	"cloneDepth\x12,\n" +
+	"\x12clone_full_history\x18\x16 \x01(\bR\x10cloneFullHistory\x12(\n" +
+	"\x10clone_fetch_tags\x18\x17 \x01(\bR\x0ecloneFetchTags\x12\x1f\n" +
+	"\vauto_deploy\x18\x18 \x01(\bR\n" +
+	"autoDeploy\x12*\n" +
+	"\x11health_check_type\x18\x19 \x01(\tR\x0fhealthCheckType\x12*\n" +
+	"\x11health_check_path\x18\x1a \x01(\tR\x0fhealthCheckPath\x12?\n" +
+	"\x1chealth_check_expected_status\x18\x1b \x01(\x05R\x19healthCheckExpectedStatus\x12A\n" +
+	"\x1dhealth_check_interval_seconds\x18\x1c \x01(\x05R\x1ahealthCheckIntervalSeconds\x12?\n" +
+	"\x1chealth_check_timeout_seconds\x18\x1d \x01(\x05R\x19healthCheckTimeoutSeconds\x120\n" +
+	"\x14health_check_retries\x18\x1e \x01(\x05R\x12healthCheckRetries\x12\x1a\n" +
+	"\breplicas\x18\x1f \x01(\x05R\breplicas\x12.\n" +
+	"\x13auto_cancel_pending\x18  \x01(\bR\x11autoCancelPending\x12.\n" +
+	"\x13auto_cancel_running\x18! \x01(\bR\x11autoCancelRunning\x129\n" +
+	"\fbuild_matrix\x18\" \x03(\v2\x16.project.MatrixVariantR\vbuildMatrix\"Y\n" +
 	"\x15CreateProjectResponse\x12*\n" +
 	"\aproject\x18\x01 \x01(\v2\x10.project.ProjectR\aproject\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"K\n" +
@@ -2017,7 +4842,7 @@ const file_proto_project_proto_rawDesc = "" +
 	"\x14ListProjectsResponse\x12,\n" +
 	"\bprojects\x18\x01 \x03(\v2\x10.project.ProjectR\bprojects\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\xf0\x01\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x8a\x0e\n" +
 	"\x14UpdateProjectRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
@@ -2027,7 +4852,56 @@ const file_proto_project_proto_rawDesc = "" +
 	"\x06preset\x18\x05 \x01(\tR\x06preset\x12#\n" +
 	"\rbuild_command\x18\x06 \x01(\tR\fbuildCommand\x12#\n" +
 	"\rstart_command\x18\a \x01(\tR\fstartCommand\x12\x12\n" +
-	"\x04port\x18\b \x01(\x05R\x04port\"Y\n" +
+	"\x04port\x18\b \x01(\x05R\x04port\x12&\n" +
+	"\ftest_command\x18\t \x01(\tH\x00R\vtestCommand\x88\x01\x01\x12&\n" +
+	"\x0fbuild_memory_mb\x18\n" +
+	" \x01(\x05R\rbuildMemoryMb\x12\x1d\n" +
+	"\n" +
+	"build_cpus\x18\v \x01(\x01R\tbuildCpus\x122\n" +
+	"\x15build_timeout_minutes\x18\f \x01(\x05R\x13buildTimeoutMinutes\x12\"\n" +
+	"\rbuild_disk_mb\x18\r \x01(\x05R\vbuildDiskMb\x12*\n" +
+	"\x0eroot_directory\x18\x0e \x01(\tH\x01R\rrootDirectory\x88\x01\x01\x12\x1f\n" +
+	"\vwatch_paths\x18\x0f \x03(\tR\n" +
+	"watchPaths\x12*\n" +
+	"\x11clear_watch_paths\x18\x10 \x01(\bR\x0fclearWatchPaths\x12.\n" +
+	"\x10clone_submodules\x18\x11 \x01(\bH\x02R\x0fcloneSubmodules\x88\x01\x01\x12 \n" +
+	"\tclone_lfs\x18\x12 \x01(\bH\x03R\bcloneLfs\x88\x01\x01\x12$\n" +
+	"\vclone_depth\x18\x13 \x01(\x05H\x04R\n" +
+	"cloneDepth\x88\x01\x01\x121\n" +
+	"\x12clone_full_history\x18\x14 \x01(\bH\x05R\x10cloneFullHistory\x88\x01\x01\x12-\n" +
+	"\x10clone_fetch_tags\x18\x15 \x01(\bH\x06R\x0ecloneFetchTags\x88\x01\x01\x12$\n" +
+	"\vauto_deploy\x18\x16 \x01(\bH\aR\n" +
+	"autoDeploy\x88\x01\x01\x12/\n" +
+	"\x11health_check_type\x18\x17 \x01(\tH\bR\x0fhealthCheckType\x88\x01\x01\x12/\n" +
+	"\x11health_check_path\x18\x18 \x01(\tH\tR\x0fhealthCheckPath\x88\x01\x01\x12D\n" +
+	"\x1chealth_check_expected_status\x18\x19 \x01(\x05H\n" +
+	"R\x19healthCheckExpectedStatus\x88\x01\x01\x12F\n" +
+	"\x1dhealth_check_interval_seconds\x18\x1a \x01(\x05H\vR\x1ahealthCheckIntervalSeconds\x88\x01\x01\x12D\n" +
+	"\x1chealth_check_timeout_seconds\x18\x1b \x01(\x05H\fR\x19healthCheckTimeoutSeconds\x88\x01\x01\x125\n" +
+	"\x14health_check_retries\x18\x1c \x01(\x05H\rR\x12healthCheckRetries\x88\x01\x01\x12\x1f\n" +
+	"\breplicas\x18\x1d \x01(\x05H\x0eR\breplicas\x88\x01\x01\x123\n" +
+	"\x13auto_cancel_pending\x18\x1e \x01(\bH\x0fR\x11autoCancelPending\x88\x01\x01\x123\n" +
+	"\x13auto_cancel_running\x18\x1f \x01(\bH\x10R\x11autoCancelRunning\x88\x01\x01\x129\n" +
+	"\fbuild_matrix\x18  \x03(\v2\x16.project.MatrixVariantR\vbuildMatrix\x12,\n" +
+	"\x12clear_build_matrix\x18! \x01(\bR\x10clearBuildMatrixB\x0f\n" +
+	"\r_test_commandB\x11\n" +
+	"\x0f_root_directoryB\x13\n" +
+	"\x11_clone_submodulesB\f\n" +
+	"\n" +
+	"_clone_lfsB\x0e\n" +
+	"\f_clone_depthB\x15\n" +
+	"\x13_clone_full_historyB\x13\n" +
+	"\x11_clone_fetch_tagsB\x0e\n" +
+	"\f_auto_deployB\x14\n" +
+	"\x12_health_check_typeB\x14\n" +
+	"\x12_health_check_pathB\x1f\n" +
+	"\x1d_health_check_expected_statusB \n" +
+	"\x1e_health_check_interval_secondsB\x1f\n" +
+	"\x1d_health_check_timeout_secondsB\x17\n" +
+	"\x15_health_check_retriesB\v\n" +
+	"\t_replicasB\x16\n" +
+	"\x14_auto_cancel_pendingB\x16\n" +
+	"\x14_auto_cancel_running\"Y\n" +
 	"\x15UpdateProjectResponse\x12*\n" +
 	"\aproject\x18\x01 \x01(\v2\x10.project.ProjectR\aproject\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"~\n" +
@@ -2075,7 +4949,26 @@ const file_proto_project_proto_rawDesc = "" +
 	"\x13github_access_token\x18\x03 \x01(\tR\x11githubAccessToken\"G\n" +
 	"\x15DeleteWebhookResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xc1\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xc7\x01\n" +
+	"\x16SetCommitStatusRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x02 \x01(\tR\tcommitSha\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x04 \x01(\tR\ttargetUrl\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontext\x18\x06 \x01(\tR\acontext\"I\n" +
+	"\x17SetCommitStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"4\n" +
+	"\x13GetRepoTokenRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"B\n" +
+	"\x14GetRepoTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xe3\x01\n" +
 	"\x06Secret\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -2084,13 +4977,15 @@ const file_proto_project_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"t\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
+	"\venvironment\x18\x06 \x01(\tR\venvironment\"\x96\x01\n" +
 	"\x10AddSecretRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
-	"\x05value\x18\x04 \x01(\tR\x05value\"R\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12 \n" +
+	"\venvironment\x18\x05 \x01(\tR\venvironment\"R\n" +
 	"\x11AddSecretResponse\x12'\n" +
 	"\x06secret\x18\x01 \x01(\v2\x0f.project.SecretR\x06secret\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"\x80\x01\n" +
@@ -2117,33 +5012,208 @@ const file_proto_project_proto_rawDesc = "" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\"V\n" +
 	"\x13ListSecretsResponse\x12)\n" +
 	"\asecrets\x18\x01 \x03(\v2\x0f.project.SecretR\asecrets\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"2\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"T\n" +
 	"\x11GetSecretsRequest\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x01 \x01(\tR\tprojectId\"\xaa\x01\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12 \n" +
+	"\venvironment\x18\x02 \x01(\tR\venvironment\"\xaa\x01\n" +
 	"\x12GetSecretsResponse\x12B\n" +
 	"\asecrets\x18\x01 \x03(\v2(.project.GetSecretsResponse.SecretsEntryR\asecrets\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x1a:\n" +
 	"\fSecretsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xcc\b\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x02\n" +
+	"\x06Domain\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12&\n" +
+	"\x0ftxt_record_name\x18\x05 \x01(\tR\rtxtRecordName\x12(\n" +
+	"\x10txt_record_value\x18\x06 \x01(\tR\x0etxtRecordValue\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12;\n" +
+	"\vverified_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"^\n" +
+	"\x10AddDomainRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"R\n" +
+	"\x11AddDomainResponse\x12'\n" +
+	"\x06domain\x18\x01 \x01(\v2\x0f.project.DomainR\x06domain\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"j\n" +
+	"\x13VerifyDomainRequest\x12\x1b\n" +
+	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"U\n" +
+	"\x14VerifyDomainResponse\x12'\n" +
+	"\x06domain\x18\x01 \x01(\v2\x0f.project.DomainR\x06domain\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"j\n" +
+	"\x13RemoveDomainRequest\x12\x1b\n" +
+	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"F\n" +
+	"\x14RemoveDomainResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"L\n" +
+	"\x12ListDomainsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"V\n" +
+	"\x13ListDomainsResponse\x12)\n" +
+	"\adomains\x18\x01 \x03(\v2\x0f.project.DomainR\adomains\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xd1\x02\n" +
+	"\vEnvironment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06branch\x18\x04 \x01(\tR\x06branch\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\x12\x1b\n" +
+	"\tmemory_mb\x18\x06 \x01(\x05R\bmemoryMb\x12\x1b\n" +
+	"\tcpu_cores\x18\a \x01(\x05R\bcpuCores\x12\x1f\n" +
+	"\vauto_deploy\x18\b \x01(\bR\n" +
+	"autoDeploy\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf1\x01\n" +
+	"\x18CreateEnvironmentRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06branch\x18\x04 \x01(\tR\x06branch\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\x12\x1b\n" +
+	"\tmemory_mb\x18\x06 \x01(\x05R\bmemoryMb\x12\x1b\n" +
+	"\tcpu_cores\x18\a \x01(\x05R\bcpuCores\x12\x1f\n" +
+	"\vauto_deploy\x18\b \x01(\bR\n" +
+	"autoDeploy\"i\n" +
+	"\x19CreateEnvironmentResponse\x126\n" +
+	"\venvironment\x18\x01 \x01(\v2\x14.project.EnvironmentR\venvironment\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xf1\x01\n" +
+	"\x18UpdateEnvironmentRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06branch\x18\x04 \x01(\tR\x06branch\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\x12\x1b\n" +
+	"\tmemory_mb\x18\x06 \x01(\x05R\bmemoryMb\x12\x1b\n" +
+	"\tcpu_cores\x18\a \x01(\x05R\bcpuCores\x12\x1f\n" +
+	"\vauto_deploy\x18\b \x01(\bR\n" +
+	"autoDeploy\"i\n" +
+	"\x19UpdateEnvironmentResponse\x126\n" +
+	"\venvironment\x18\x01 \x01(\v2\x14.project.EnvironmentR\venvironment\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"f\n" +
+	"\x18DeleteEnvironmentRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"K\n" +
+	"\x19DeleteEnvironmentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"Q\n" +
+	"\x17ListEnvironmentsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"j\n" +
+	"\x18ListEnvironmentsResponse\x128\n" +
+	"\fenvironments\x18\x01 \x03(\v2\x14.project.EnvironmentR\fenvironments\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"q\n" +
+	"\fDeployWindow\x12\x1a\n" +
+	"\bschedule\x18\x01 \x01(\tR\bschedule\x12)\n" +
+	"\x10duration_minutes\x18\x02 \x01(\x05R\x0fdurationMinutes\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\"\xc8\x01\n" +
+	"\x10DeployAuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12 \n" +
+	"\venvironment\x18\x04 \x01(\tR\venvironment\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf3\x02\n" +
+	"\fDeployPolicy\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12/\n" +
+	"\awindows\x18\x02 \x03(\v2\x15.project.DeployWindowR\awindows\x12\x16\n" +
+	"\x06frozen\x18\x03 \x01(\bR\x06frozen\x12#\n" +
+	"\rfreeze_reason\x18\x04 \x01(\tR\ffreezeReason\x12\x1b\n" +
+	"\tfrozen_by\x18\x05 \x01(\tR\bfrozenBy\x127\n" +
+	"\tfrozen_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bfrozenAt\x12\x12\n" +
+	"\x04open\x18\a \x01(\bR\x04open\x12;\n" +
+	"\vnext_window\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"nextWindow\x12/\n" +
+	"\x05audit\x18\t \x03(\v2\x19.project.DeployAuditEntryR\x05audit\"[\n" +
+	"\x14DeployPolicyResponse\x12-\n" +
+	"\x06policy\x18\x01 \x01(\v2\x15.project.DeployPolicyR\x06policy\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"P\n" +
+	"\x16GetDeployPolicyRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x82\x01\n" +
+	"\x17SetDeployWindowsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12/\n" +
+	"\awindows\x18\x03 \x03(\v2\x15.project.DeployWindowR\awindows\"\x80\x01\n" +
+	"\x16SetDeployFreezeRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06frozen\x18\x03 \x01(\bR\x06frozen\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xb3\x01\n" +
+	"\x12CheckDeployRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12 \n" +
+	"\venvironment\x18\x03 \x01(\tR\venvironment\x12\x1a\n" +
+	"\boverride\x18\x04 \x01(\bR\boverride\x12'\n" +
+	"\x0foverride_reason\x18\x05 \x01(\tR\x0eoverrideReason\"\xae\x01\n" +
+	"\x13CheckDeployResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12;\n" +
+	"\vnext_window\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"nextWindow\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error2\xa0\x12\n" +
 	"\x0eProjectService\x12N\n" +
 	"\rCreateProject\x12\x1d.project.CreateProjectRequest\x1a\x1e.project.CreateProjectResponse\x12E\n" +
 	"\n" +
 	"GetProject\x12\x1a.project.GetProjectRequest\x1a\x1b.project.GetProjectResponse\x12Q\n" +
-	"\x10GetProjectByRepo\x12 .project.GetProjectByRepoRequest\x1a\x1b.project.GetProjectResponse\x12K\n" +
+	"\x10GetProjectByRepo\x12 .project.GetProjectByRepoRequest\x1a\x1b.project.GetProjectResponse\x12U\n" +
+	"\x12ListProjectsByRepo\x12 .project.GetProjectByRepoRequest\x1a\x1d.project.ListProjectsResponse\x12K\n" +
 	"\fListProjects\x12\x1c.project.ListProjectsRequest\x1a\x1d.project.ListProjectsResponse\x12N\n" +
 	"\rUpdateProject\x12\x1d.project.UpdateProjectRequest\x1a\x1e.project.UpdateProjectResponse\x12N\n" +
 	"\rDeleteProject\x12\x1d.project.DeleteProjectRequest\x1a\x1e.project.DeleteProjectResponse\x12W\n" +
 	"\x10ListRepositories\x12 .project.ListRepositoriesRequest\x1a!.project.ListRepositoriesResponse\x12K\n" +
 	"\fSetupWebhook\x12\x1c.project.SetupWebhookRequest\x1a\x1d.project.SetupWebhookResponse\x12N\n" +
-	"\rDeleteWebhook\x12\x1d.project.DeleteWebhookRequest\x1a\x1e.project.DeleteWebhookResponse\x12B\n" +
+	"\rDeleteWebhook\x12\x1d.project.DeleteWebhookRequest\x1a\x1e.project.DeleteWebhookResponse\x12T\n" +
+	"\x0fSetCommitStatus\x12\x1f.project.SetCommitStatusRequest\x1a .project.SetCommitStatusResponse\x12K\n" +
+	"\fGetRepoToken\x12\x1c.project.GetRepoTokenRequest\x1a\x1d.project.GetRepoTokenResponse\x12B\n" +
 	"\tAddSecret\x12\x19.project.AddSecretRequest\x1a\x1a.project.AddSecretResponse\x12K\n" +
 	"\fUpdateSecret\x12\x1c.project.UpdateSecretRequest\x1a\x1d.project.UpdateSecretResponse\x12K\n" +
 	"\fDeleteSecret\x12\x1c.project.DeleteSecretRequest\x1a\x1d.project.DeleteSecretResponse\x12H\n" +
 	"\vListSecrets\x12\x1b.project.ListSecretsRequest\x1a\x1c.project.ListSecretsResponse\x12E\n" +
 	"\n" +
-	"GetSecrets\x12\x1a.project.GetSecretsRequest\x1a\x1b.project.GetSecretsResponseB?Z=github.com/nexusdeploy/backend/services/project-service/protob\x06proto3"
+	"GetSecrets\x12\x1a.project.GetSecretsRequest\x1a\x1b.project.GetSecretsResponse\x12B\n" +
+	"\tAddDomain\x12\x19.project.AddDomainRequest\x1a\x1a.project.AddDomainResponse\x12K\n" +
+	"\fVerifyDomain\x12\x1c.project.VerifyDomainRequest\x1a\x1d.project.VerifyDomainResponse\x12K\n" +
+	"\fRemoveDomain\x12\x1c.project.RemoveDomainRequest\x1a\x1d.project.RemoveDomainResponse\x12H\n" +
+	"\vListDomains\x12\x1b.project.ListDomainsRequest\x1a\x1c.project.ListDomainsResponse\x12Z\n" +
+	"\x11CreateEnvironment\x12!.project.CreateEnvironmentRequest\x1a\".project.CreateEnvironmentResponse\x12Z\n" +
+	"\x11UpdateEnvironment\x12!.project.UpdateEnvironmentRequest\x1a\".project.UpdateEnvironmentResponse\x12Z\n" +
+	"\x11DeleteEnvironment\x12!.project.DeleteEnvironmentRequest\x1a\".project.DeleteEnvironmentResponse\x12W\n" +
+	"\x10ListEnvironments\x12 .project.ListEnvironmentsRequest\x1a!.project.ListEnvironmentsResponse\x12Q\n" +
+	"\x0fGetDeployPolicy\x12\x1f.project.GetDeployPolicyRequest\x1a\x1d.project.DeployPolicyResponse\x12S\n" +
+	"\x10SetDeployWindows\x12 .project.SetDeployWindowsRequest\x1a\x1d.project.DeployPolicyResponse\x12Q\n" +
+	"\x0fSetDeployFreeze\x12\x1f.project.SetDeployFreezeRequest\x1a\x1d.project.DeployPolicyResponse\x12H\n" +
+	"\vCheckDeploy\x12\x1b.project.CheckDeployRequest\x1a\x1c.project.CheckDeployResponseB?Z=github.com/nexusdeploy/backend/services/project-service/protob\x06proto3"
 
 var (
 	file_proto_project_proto_rawDescOnce sync.Once
@@ -2157,88 +5227,174 @@ func file_proto_project_proto_rawDescGZIP() []byte {
 	return file_proto_project_proto_rawDescData
 }
 
-var file_proto_project_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_proto_project_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_proto_project_proto_goTypes = []any{
-	(*Project)(nil),                  // 0: project.Project
-	(*CreateProjectRequest)(nil),     // 1: project.CreateProjectRequest
-	(*CreateProjectResponse)(nil),    // 2: project.CreateProjectResponse
-	(*GetProjectRequest)(nil),        // 3: project.GetProjectRequest
-	(*GetProjectResponse)(nil),       // 4: project.GetProjectResponse
-	(*GetProjectByRepoRequest)(nil),  // 5: project.GetProjectByRepoRequest
-	(*ListProjectsRequest)(nil),      // 6: project.ListProjectsRequest
-	(*ListProjectsResponse)(nil),     // 7: project.ListProjectsResponse
-	(*UpdateProjectRequest)(nil),     // 8: project.UpdateProjectRequest
-	(*UpdateProjectResponse)(nil),    // 9: project.UpdateProjectResponse
-	(*DeleteProjectRequest)(nil),     // 10: project.DeleteProjectRequest
-	(*DeleteProjectResponse)(nil),    // 11: project.DeleteProjectResponse
-	(*Repository)(nil),               // 12: project.Repository
-	(*ListRepositoriesRequest)(nil),  // 13: project.ListRepositoriesRequest
-	(*ListRepositoriesResponse)(nil), // 14: project.ListRepositoriesResponse
-	(*SetupWebhookRequest)(nil),      // 15: project.SetupWebhookRequest
-	(*SetupWebhookResponse)(nil),     // 16: project.SetupWebhookResponse
-	(*DeleteWebhookRequest)(nil),     // 17: project.DeleteWebhookRequest
-	(*DeleteWebhookResponse)(nil),    // 18: project.DeleteWebhookResponse
-	(*Secret)(nil),                   // 19: project.Secret
-	(*AddSecretRequest)(nil),         // 20: project.AddSecretRequest
-	(*AddSecretResponse)(nil),        // 21: project.AddSecretResponse
-	(*UpdateSecretRequest)(nil),      // 22: project.UpdateSecretRequest
-	(*UpdateSecretResponse)(nil),     // 23: project.UpdateSecretResponse
-	(*DeleteSecretRequest)(nil),      // 24: project.DeleteSecretRequest
-	(*DeleteSecretResponse)(nil),     // 25: project.DeleteSecretResponse
-	(*ListSecretsRequest)(nil),       // 26: project.ListSecretsRequest
-	(*ListSecretsResponse)(nil),      // 27: project.ListSecretsResponse
-	(*GetSecretsRequest)(nil),        // 28: project.GetSecretsRequest
-	(*GetSecretsResponse)(nil),       // 29: project.GetSecretsResponse
-	nil,                              // 30: project.GetSecretsResponse.SecretsEntry
-	(*timestamppb.Timestamp)(nil),    // 31: google.protobuf.Timestamp
+	(*Project)(nil),                   // 0: project.Project
+	(*MatrixVariant)(nil),             // 1: project.MatrixVariant
+	(*CreateProjectRequest)(nil),      // 2: project.CreateProjectRequest
+	(*CreateProjectResponse)(nil),     // 3: project.CreateProjectResponse
+	(*GetProjectRequest)(nil),         // 4: project.GetProjectRequest
+	(*GetProjectResponse)(nil),        // 5: project.GetProjectResponse
+	(*GetProjectByRepoRequest)(nil),   // 6: project.GetProjectByRepoRequest
+	(*ListProjectsRequest)(nil),       // 7: project.ListProjectsRequest
+	(*ListProjectsResponse)(nil),      // 8: project.ListProjectsResponse
+	(*UpdateProjectRequest)(nil),      // 9: project.UpdateProjectRequest
+	(*UpdateProjectResponse)(nil),     // 10: project.UpdateProjectResponse
+	(*DeleteProjectRequest)(nil),      // 11: project.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),     // 12: project.DeleteProjectResponse
+	(*Repository)(nil),                // 13: project.Repository
+	(*ListRepositoriesRequest)(nil),   // 14: project.ListRepositoriesRequest
+	(*ListRepositoriesResponse)(nil),  // 15: project.ListRepositoriesResponse
+	(*SetupWebhookRequest)(nil),       // 16: project.SetupWebhookRequest
+	(*SetupWebhookResponse)(nil),      // 17: project.SetupWebhookResponse
+	(*DeleteWebhookRequest)(nil),      // 18: project.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),     // 19: project.DeleteWebhookResponse
+	(*SetCommitStatusRequest)(nil),    // 20: project.SetCommitStatusRequest
+	(*SetCommitStatusResponse)(nil),   // 21: project.SetCommitStatusResponse
+	(*GetRepoTokenRequest)(nil),       // 22: project.GetRepoTokenRequest
+	(*GetRepoTokenResponse)(nil),      // 23: project.GetRepoTokenResponse
+	(*Secret)(nil),                    // 24: project.Secret
+	(*AddSecretRequest)(nil),          // 25: project.AddSecretRequest
+	(*AddSecretResponse)(nil),         // 26: project.AddSecretResponse
+	(*UpdateSecretRequest)(nil),       // 27: project.UpdateSecretRequest
+	(*UpdateSecretResponse)(nil),      // 28: project.UpdateSecretResponse
+	(*DeleteSecretRequest)(nil),       // 29: project.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),      // 30: project.DeleteSecretResponse
+	(*ListSecretsRequest)(nil),        // 31: project.ListSecretsRequest
+	(*ListSecretsResponse)(nil),       // 32: project.ListSecretsResponse
+	(*GetSecretsRequest)(nil),         // 33: project.GetSecretsRequest
+	(*GetSecretsResponse)(nil),        // 34: project.GetSecretsResponse
+	(*Domain)(nil),                    // 35: project.Domain
+	(*AddDomainRequest)(nil),          // 36: project.AddDomainRequest
+	(*AddDomainResponse)(nil),         // 37: project.AddDomainResponse
+	(*VerifyDomainRequest)(nil),       // 38: project.VerifyDomainRequest
+	(*VerifyDomainResponse)(nil),      // 39: project.VerifyDomainResponse
+	(*RemoveDomainRequest)(nil),       // 40: project.RemoveDomainRequest
+	(*RemoveDomainResponse)(nil),      // 41: project.RemoveDomainResponse
+	(*ListDomainsRequest)(nil),        // 42: project.ListDomainsRequest
+	(*ListDomainsResponse)(nil),       // 43: project.ListDomainsResponse
+	(*Environment)(nil),               // 44: project.Environment
+	(*CreateEnvironmentRequest)(nil),  // 45: project.CreateEnvironmentRequest
+	(*CreateEnvironmentResponse)(nil), // 46: project.CreateEnvironmentResponse
+	(*UpdateEnvironmentRequest)(nil),  // 47: project.UpdateEnvironmentRequest
+	(*UpdateEnvironmentResponse)(nil), // 48: project.UpdateEnvironmentResponse
+	(*DeleteEnvironmentRequest)(nil),  // 49: project.DeleteEnvironmentRequest
+	(*DeleteEnvironmentResponse)(nil), // 50: project.DeleteEnvironmentResponse
+	(*ListEnvironmentsRequest)(nil),   // 51: project.ListEnvironmentsRequest
+	(*ListEnvironmentsResponse)(nil),  // 52: project.ListEnvironmentsResponse
+	(*DeployWindow)(nil),              // 53: project.DeployWindow
+	(*DeployAuditEntry)(nil),          // 54: project.DeployAuditEntry
+	(*DeployPolicy)(nil),              // 55: project.DeployPolicy
+	(*DeployPolicyResponse)(nil),      // 56: project.DeployPolicyResponse
+	(*GetDeployPolicyRequest)(nil),    // 57: project.GetDeployPolicyRequest
+	(*SetDeployWindowsRequest)(nil),   // 58: project.SetDeployWindowsRequest
+	(*SetDeployFreezeRequest)(nil),    // 59: project.SetDeployFreezeRequest
+	(*CheckDeployRequest)(nil),        // 60: project.CheckDeployRequest
+	(*CheckDeployResponse)(nil),       // 61: project.CheckDeployResponse
+	nil,                               // 62: project.MatrixVariant.EnvEntry
+	nil,                               // 63: project.GetSecretsResponse.SecretsEntry
+	(*timestamppb.Timestamp)(nil),     // 64: google.protobuf.Timestamp
 }
 var file_proto_project_proto_depIdxs = []int32{
-	31, // 0: project.Project.created_at:type_name -> google.protobuf.Timestamp
-	31, // 1: project.Project.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: project.CreateProjectResponse.project:type_name -> project.Project
-	0,  // 3: project.GetProjectResponse.project:type_name -> project.Project
-	0,  // 4: project.ListProjectsResponse.projects:type_name -> project.Project
-	0,  // 5: project.UpdateProjectResponse.project:type_name -> project.Project
-	12, // 6: project.ListRepositoriesResponse.repositories:type_name -> project.Repository
-	31, // 7: project.Secret.created_at:type_name -> google.protobuf.Timestamp
-	31, // 8: project.Secret.updated_at:type_name -> google.protobuf.Timestamp
-	19, // 9: project.AddSecretResponse.secret:type_name -> project.Secret
-	19, // 10: project.UpdateSecretResponse.secret:type_name -> project.Secret
-	19, // 11: project.ListSecretsResponse.secrets:type_name -> project.Secret
-	30, // 12: project.GetSecretsResponse.secrets:type_name -> project.GetSecretsResponse.SecretsEntry
-	1,  // 13: project.ProjectService.CreateProject:input_type -> project.CreateProjectRequest
-	3,  // 14: project.ProjectService.GetProject:input_type -> project.GetProjectRequest
-	5,  // 15: project.ProjectService.GetProjectByRepo:input_type -> project.GetProjectByRepoRequest
-	6,  // 16: project.ProjectService.ListProjects:input_type -> project.ListProjectsRequest
-	8,  // 17: project.ProjectService.UpdateProject:input_type -> project.UpdateProjectRequest
-	10, // 18: project.ProjectService.DeleteProject:input_type -> project.DeleteProjectRequest
-	13, // 19: project.ProjectService.ListRepositories:input_type -> project.ListRepositoriesRequest
-	15, // 20: project.ProjectService.SetupWebhook:input_type -> project.SetupWebhookRequest
-	17, // 21: project.ProjectService.DeleteWebhook:input_type -> project.DeleteWebhookRequest
-	20, // 22: project.ProjectService.AddSecret:input_type -> project.AddSecretRequest
-	22, // 23: project.ProjectService.UpdateSecret:input_type -> project.UpdateSecretRequest
-	24, // 24: project.ProjectService.DeleteSecret:input_type -> project.DeleteSecretRequest
-	26, // 25: project.ProjectService.ListSecrets:input_type -> project.ListSecretsRequest
-	28, // 26: project.ProjectService.GetSecrets:input_type -> project.GetSecretsRequest
-	2,  // 27: project.ProjectService.CreateProject:output_type -> project.CreateProjectResponse
-	4,  // 28: project.ProjectService.GetProject:output_type -> project.GetProjectResponse
-	4,  // 29: project.ProjectService.GetProjectByRepo:output_type -> project.GetProjectResponse
-	7,  // 30: project.ProjectService.ListProjects:output_type -> project.ListProjectsResponse
-	9,  // 31: project.ProjectService.UpdateProject:output_type -> project.UpdateProjectResponse
-	11, // 32: project.ProjectService.DeleteProject:output_type -> project.DeleteProjectResponse
-	14, // 33: project.ProjectService.ListRepositories:output_type -> project.ListRepositoriesResponse
-	16, // 34: project.ProjectService.SetupWebhook:output_type -> project.SetupWebhookResponse
-	18, // 35: project.ProjectService.DeleteWebhook:output_type -> project.DeleteWebhookResponse
-	21, // 36: project.ProjectService.AddSecret:output_type -> project.AddSecretResponse
-	23, // 37: project.ProjectService.UpdateSecret:output_type -> project.UpdateSecretResponse
-	25, // 38: project.ProjectService.DeleteSecret:output_type -> project.DeleteSecretResponse
-	27, // 39: project.ProjectService.ListSecrets:output_type -> project.ListSecretsResponse
-	29, // 40: project.ProjectService.GetSecrets:output_type -> project.GetSecretsResponse
-	27, // [27:41] is the sub-list for method output_type
-	13, // [13:27] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	64, // 0: project.Project.created_at:type_name -> google.protobuf.Timestamp
+	64, // 1: project.Project.updated_at:type_name -> google.protobuf.Timestamp
+	44, // 2: project.Project.environments:type_name -> project.Environment
+	1,  // 3: project.Project.build_matrix:type_name -> project.MatrixVariant
+	62, // 4: project.MatrixVariant.env:type_name -> project.MatrixVariant.EnvEntry
+	1,  // 5: project.CreateProjectRequest.build_matrix:type_name -> project.MatrixVariant
+	0,  // 6: project.CreateProjectResponse.project:type_name -> project.Project
+	0,  // 7: project.GetProjectResponse.project:type_name -> project.Project
+	0,  // 8: project.ListProjectsResponse.projects:type_name -> project.Project
+	1,  // 9: project.UpdateProjectRequest.build_matrix:type_name -> project.MatrixVariant
+	0,  // 10: project.UpdateProjectResponse.project:type_name -> project.Project
+	13, // 11: project.ListRepositoriesResponse.repositories:type_name -> project.Repository
+	64, // 12: project.Secret.created_at:type_name -> google.protobuf.Timestamp
+	64, // 13: project.Secret.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 14: project.AddSecretResponse.secret:type_name -> project.Secret
+	24, // 15: project.UpdateSecretResponse.secret:type_name -> project.Secret
+	24, // 16: project.ListSecretsResponse.secrets:type_name -> project.Secret
+	63, // 17: project.GetSecretsResponse.secrets:type_name -> project.GetSecretsResponse.SecretsEntry
+	64, // 18: project.Domain.verified_at:type_name -> google.protobuf.Timestamp
+	64, // 19: project.Domain.created_at:type_name -> google.protobuf.Timestamp
+	35, // 20: project.AddDomainResponse.domain:type_name -> project.Domain
+	35, // 21: project.VerifyDomainResponse.domain:type_name -> project.Domain
+	35, // 22: project.ListDomainsResponse.domains:type_name -> project.Domain
+	64, // 23: project.Environment.created_at:type_name -> google.protobuf.Timestamp
+	64, // 24: project.Environment.updated_at:type_name -> google.protobuf.Timestamp
+	44, // 25: project.CreateEnvironmentResponse.environment:type_name -> project.Environment
+	44, // 26: project.UpdateEnvironmentResponse.environment:type_name -> project.Environment
+	44, // 27: project.ListEnvironmentsResponse.environments:type_name -> project.Environment
+	64, // 28: project.DeployAuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	53, // 29: project.DeployPolicy.windows:type_name -> project.DeployWindow
+	64, // 30: project.DeployPolicy.frozen_at:type_name -> google.protobuf.Timestamp
+	64, // 31: project.DeployPolicy.next_window:type_name -> google.protobuf.Timestamp
+	54, // 32: project.DeployPolicy.audit:type_name -> project.DeployAuditEntry
+	55, // 33: project.DeployPolicyResponse.policy:type_name -> project.DeployPolicy
+	53, // 34: project.SetDeployWindowsRequest.windows:type_name -> project.DeployWindow
+	64, // 35: project.CheckDeployResponse.next_window:type_name -> google.protobuf.Timestamp
+	2,  // 36: project.ProjectService.CreateProject:input_type -> project.CreateProjectRequest
+	4,  // 37: project.ProjectService.GetProject:input_type -> project.GetProjectRequest
+	6,  // 38: project.ProjectService.GetProjectByRepo:input_type -> project.GetProjectByRepoRequest
+	6,  // 39: project.ProjectService.ListProjectsByRepo:input_type -> project.GetProjectByRepoRequest
+	7,  // 40: project.ProjectService.ListProjects:input_type -> project.ListProjectsRequest
+	9,  // 41: project.ProjectService.UpdateProject:input_type -> project.UpdateProjectRequest
+	11, // 42: project.ProjectService.DeleteProject:input_type -> project.DeleteProjectRequest
+	14, // 43: project.ProjectService.ListRepositories:input_type -> project.ListRepositoriesRequest
+	16, // 44: project.ProjectService.SetupWebhook:input_type -> project.SetupWebhookRequest
+	18, // 45: project.ProjectService.DeleteWebhook:input_type -> project.DeleteWebhookRequest
+	20, // 46: project.ProjectService.SetCommitStatus:input_type -> project.SetCommitStatusRequest
+	22, // 47: project.ProjectService.GetRepoToken:input_type -> project.GetRepoTokenRequest
+	25, // 48: project.ProjectService.AddSecret:input_type -> project.AddSecretRequest
+	27, // 49: project.ProjectService.UpdateSecret:input_type -> project.UpdateSecretRequest
+	29, // 50: project.ProjectService.DeleteSecret:input_type -> project.DeleteSecretRequest
+	31, // 51: project.ProjectService.ListSecrets:input_type -> project.ListSecretsRequest
+	33, // 52: project.ProjectService.GetSecrets:input_type -> project.GetSecretsRequest
+	36, // 53: project.ProjectService.AddDomain:input_type -> project.AddDomainRequest
+	38, // 54: project.ProjectService.VerifyDomain:input_type -> project.VerifyDomainRequest
+	40, // 55: project.ProjectService.RemoveDomain:input_type -> project.RemoveDomainRequest
+	42, // 56: project.ProjectService.ListDomains:input_type -> project.ListDomainsRequest
+	45, // 57: project.ProjectService.CreateEnvironment:input_type -> project.CreateEnvironmentRequest
+	47, // 58: project.ProjectService.UpdateEnvironment:input_type -> project.UpdateEnvironmentRequest
+	49, // 59: project.ProjectService.DeleteEnvironment:input_type -> project.DeleteEnvironmentRequest
+	51, // 60: project.ProjectService.ListEnvironments:input_type -> project.ListEnvironmentsRequest
+	57, // 61: project.ProjectService.GetDeployPolicy:input_type -> project.GetDeployPolicyRequest
+	58, // 62: project.ProjectService.SetDeployWindows:input_type -> project.SetDeployWindowsRequest
+	59, // 63: project.ProjectService.SetDeployFreeze:input_type -> project.SetDeployFreezeRequest
+	60, // 64: project.ProjectService.CheckDeploy:input_type -> project.CheckDeployRequest
+	3,  // 65: project.ProjectService.CreateProject:output_type -> project.CreateProjectResponse
+	5,  // 66: project.ProjectService.GetProject:output_type -> project.GetProjectResponse
+	5,  // 67: project.ProjectService.GetProjectByRepo:output_type -> project.GetProjectResponse
+	8,  // 68: project.ProjectService.ListProjectsByRepo:output_type -> project.ListProjectsResponse
+	8,  // 69: project.ProjectService.ListProjects:output_type -> project.ListProjectsResponse
+	10, // 70: project.ProjectService.UpdateProject:output_type -> project.UpdateProjectResponse
+	12, // 71: project.ProjectService.DeleteProject:output_type -> project.DeleteProjectResponse
+	15, // 72: project.ProjectService.ListRepositories:output_type -> project.ListRepositoriesResponse
+	17, // 73: project.ProjectService.SetupWebhook:output_type -> project.SetupWebhookResponse
+	19, // 74: project.ProjectService.DeleteWebhook:output_type -> project.DeleteWebhookResponse
+	21, // 75: project.ProjectService.SetCommitStatus:output_type -> project.SetCommitStatusResponse
+	23, // 76: project.ProjectService.GetRepoToken:output_type -> project.GetRepoTokenResponse
+	26, // 77: project.ProjectService.AddSecret:output_type -> project.AddSecretResponse
+	28, // 78: project.ProjectService.UpdateSecret:output_type -> project.UpdateSecretResponse
+	30, // 79: project.ProjectService.DeleteSecret:output_type -> project.DeleteSecretResponse
+	32, // 80: project.ProjectService.ListSecrets:output_type -> project.ListSecretsResponse
+	34, // 81: project.ProjectService.GetSecrets:output_type -> project.GetSecretsResponse
+	37, // 82: project.ProjectService.AddDomain:output_type -> project.AddDomainResponse
+	39, // 83: project.ProjectService.VerifyDomain:output_type -> project.VerifyDomainResponse
+	41, // 84: project.ProjectService.RemoveDomain:output_type -> project.RemoveDomainResponse
+	43, // 85: project.ProjectService.ListDomains:output_type -> project.ListDomainsResponse
+	46, // 86: project.ProjectService.CreateEnvironment:output_type -> project.CreateEnvironmentResponse
+	48, // 87: project.ProjectService.UpdateEnvironment:output_type -> project.UpdateEnvironmentResponse
+	50, // 88: project.ProjectService.DeleteEnvironment:output_type -> project.DeleteEnvironmentResponse
+	52, // 89: project.ProjectService.ListEnvironments:output_type -> project.ListEnvironmentsResponse
+	56, // 90: project.ProjectService.GetDeployPolicy:output_type -> project.DeployPolicyResponse
+	56, // 91: project.ProjectService.SetDeployWindows:output_type -> project.DeployPolicyResponse
+	56, // 92: project.ProjectService.SetDeployFreeze:output_type -> project.DeployPolicyResponse
+	61, // 93: project.ProjectService.CheckDeploy:output_type -> project.CheckDeployResponse
+	65, // [65:94] is the sub-list for method output_type
+	36, // [36:65] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_proto_project_proto_init() }
@@ -2246,13 +5402,14 @@ func file_proto_project_proto_init() {
 	if File_proto_project_proto != nil {
 		return
 	}
+	file_proto_project_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_project_proto_rawDesc), len(file_proto_project_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
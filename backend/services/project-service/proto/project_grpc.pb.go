@@ -19,20 +19,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProjectService_CreateProject_FullMethodName    = "/project.ProjectService/CreateProject"
-	ProjectService_GetProject_FullMethodName       = "/project.ProjectService/GetProject"
-	ProjectService_GetProjectByRepo_FullMethodName = "/project.ProjectService/GetProjectByRepo"
-	ProjectService_ListProjects_FullMethodName     = "/project.ProjectService/ListProjects"
-	ProjectService_UpdateProject_FullMethodName    = "/project.ProjectService/UpdateProject"
-	ProjectService_DeleteProject_FullMethodName    = "/project.ProjectService/DeleteProject"
-	ProjectService_ListRepositories_FullMethodName = "/project.ProjectService/ListRepositories"
-	ProjectService_SetupWebhook_FullMethodName     = "/project.ProjectService/SetupWebhook"
-	ProjectService_DeleteWebhook_FullMethodName    = "/project.ProjectService/DeleteWebhook"
-	ProjectService_AddSecret_FullMethodName        = "/project.ProjectService/AddSecret"
-	ProjectService_UpdateSecret_FullMethodName     = "/project.ProjectService/UpdateSecret"
-	ProjectService_DeleteSecret_FullMethodName     = "/project.ProjectService/DeleteSecret"
-	ProjectService_ListSecrets_FullMethodName      = "/project.ProjectService/ListSecrets"
-	ProjectService_GetSecrets_FullMethodName       = "/project.ProjectService/GetSecrets"
+	ProjectService_CreateProject_FullMethodName      = "/project.ProjectService/CreateProject"
+	ProjectService_GetProject_FullMethodName         = "/project.ProjectService/GetProject"
+	ProjectService_GetProjectByRepo_FullMethodName   = "/project.ProjectService/GetProjectByRepo"
+	ProjectService_ListProjectsByRepo_FullMethodName = "/project.ProjectService/ListProjectsByRepo"
+	ProjectService_ListProjects_FullMethodName       = "/project.ProjectService/ListProjects"
+	ProjectService_UpdateProject_FullMethodName      = "/project.ProjectService/UpdateProject"
+	ProjectService_DeleteProject_FullMethodName      = "/project.ProjectService/DeleteProject"
+	ProjectService_ListRepositories_FullMethodName   = "/project.ProjectService/ListRepositories"
+	ProjectService_SetupWebhook_FullMethodName       = "/project.ProjectService/SetupWebhook"
+	ProjectService_DeleteWebhook_FullMethodName      = "/project.ProjectService/DeleteWebhook"
+	ProjectService_SetCommitStatus_FullMethodName    = "/project.ProjectService/SetCommitStatus"
+	ProjectService_GetRepoToken_FullMethodName       = "/project.ProjectService/GetRepoToken"
+	ProjectService_AddSecret_FullMethodName          = "/project.ProjectService/AddSecret"
+	ProjectService_UpdateSecret_FullMethodName       = "/project.ProjectService/UpdateSecret"
+	ProjectService_DeleteSecret_FullMethodName       = "/project.ProjectService/DeleteSecret"
+	ProjectService_ListSecrets_FullMethodName        = "/project.ProjectService/ListSecrets"
+	ProjectService_GetSecrets_FullMethodName         = "/project.ProjectService/GetSecrets"
+	ProjectService_AddDomain_FullMethodName          = "/project.ProjectService/AddDomain"
+	ProjectService_VerifyDomain_FullMethodName       = "/project.ProjectService/VerifyDomain"
+	ProjectService_RemoveDomain_FullMethodName       = "/project.ProjectService/RemoveDomain"
+	ProjectService_ListDomains_FullMethodName        = "/project.ProjectService/ListDomains"
+	ProjectService_CreateEnvironment_FullMethodName  = "/project.ProjectService/CreateEnvironment"
+	ProjectService_UpdateEnvironment_FullMethodName  = "/project.ProjectService/UpdateEnvironment"
+	ProjectService_DeleteEnvironment_FullMethodName  = "/project.ProjectService/DeleteEnvironment"
+	ProjectService_ListEnvironments_FullMethodName   = "/project.ProjectService/ListEnvironments"
+	ProjectService_GetDeployPolicy_FullMethodName    = "/project.ProjectService/GetDeployPolicy"
+	ProjectService_SetDeployWindows_FullMethodName   = "/project.ProjectService/SetDeployWindows"
+	ProjectService_SetDeployFreeze_FullMethodName    = "/project.ProjectService/SetDeployFreeze"
+	ProjectService_CheckDeploy_FullMethodName        = "/project.ProjectService/CheckDeploy"
 )
 
 // ProjectServiceClient is the client API for ProjectService service.
@@ -45,6 +60,7 @@ type ProjectServiceClient interface {
 	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
 	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
 	GetProjectByRepo(ctx context.Context, in *GetProjectByRepoRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
+	ListProjectsByRepo(ctx context.Context, in *GetProjectByRepoRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
 	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
@@ -52,12 +68,31 @@ type ProjectServiceClient interface {
 	ListRepositories(ctx context.Context, in *ListRepositoriesRequest, opts ...grpc.CallOption) (*ListRepositoriesResponse, error)
 	SetupWebhook(ctx context.Context, in *SetupWebhookRequest, opts ...grpc.CallOption) (*SetupWebhookResponse, error)
 	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	SetCommitStatus(ctx context.Context, in *SetCommitStatusRequest, opts ...grpc.CallOption) (*SetCommitStatusResponse, error)
+	// Internal: token for the Runner to clone the repository
+	GetRepoToken(ctx context.Context, in *GetRepoTokenRequest, opts ...grpc.CallOption) (*GetRepoTokenResponse, error)
 	// Secrets management
 	AddSecret(ctx context.Context, in *AddSecretRequest, opts ...grpc.CallOption) (*AddSecretResponse, error)
 	UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error)
 	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
 	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
 	GetSecrets(ctx context.Context, in *GetSecretsRequest, opts ...grpc.CallOption) (*GetSecretsResponse, error)
+	// Custom domains (Premium), routed to the deployments once verified
+	AddDomain(ctx context.Context, in *AddDomainRequest, opts ...grpc.CallOption) (*AddDomainResponse, error)
+	VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error)
+	RemoveDomain(ctx context.Context, in *RemoveDomainRequest, opts ...grpc.CallOption) (*RemoveDomainResponse, error)
+	ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error)
+	// Environments deployed next to production, the project itself
+	CreateEnvironment(ctx context.Context, in *CreateEnvironmentRequest, opts ...grpc.CallOption) (*CreateEnvironmentResponse, error)
+	UpdateEnvironment(ctx context.Context, in *UpdateEnvironmentRequest, opts ...grpc.CallOption) (*UpdateEnvironmentResponse, error)
+	DeleteEnvironment(ctx context.Context, in *DeleteEnvironmentRequest, opts ...grpc.CallOption) (*DeleteEnvironmentResponse, error)
+	ListEnvironments(ctx context.Context, in *ListEnvironmentsRequest, opts ...grpc.CallOption) (*ListEnvironmentsResponse, error)
+	// Deploy windows and freeze of production
+	GetDeployPolicy(ctx context.Context, in *GetDeployPolicyRequest, opts ...grpc.CallOption) (*DeployPolicyResponse, error)
+	SetDeployWindows(ctx context.Context, in *SetDeployWindowsRequest, opts ...grpc.CallOption) (*DeployPolicyResponse, error)
+	SetDeployFreeze(ctx context.Context, in *SetDeployFreezeRequest, opts ...grpc.CallOption) (*DeployPolicyResponse, error)
+	// Internal: called before every deploy
+	CheckDeploy(ctx context.Context, in *CheckDeployRequest, opts ...grpc.CallOption) (*CheckDeployResponse, error)
 }
 
 type projectServiceClient struct {
@@ -98,6 +133,16 @@ func (c *projectServiceClient) GetProjectByRepo(ctx context.Context, in *GetProj
 	return out, nil
 }
 
+func (c *projectServiceClient) ListProjectsByRepo(ctx context.Context, in *GetProjectByRepoRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListProjectsByRepo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListProjectsResponse)
@@ -158,6 +203,26 @@ func (c *projectServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebh
 	return out, nil
 }
 
+func (c *projectServiceClient) SetCommitStatus(ctx context.Context, in *SetCommitStatusRequest, opts ...grpc.CallOption) (*SetCommitStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCommitStatusResponse)
+	err := c.cc.Invoke(ctx, ProjectService_SetCommitStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetRepoToken(ctx context.Context, in *GetRepoTokenRequest, opts ...grpc.CallOption) (*GetRepoTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRepoTokenResponse)
+	err := c.cc.Invoke(ctx, ProjectService_GetRepoToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) AddSecret(ctx context.Context, in *AddSecretRequest, opts ...grpc.CallOption) (*AddSecretResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddSecretResponse)
@@ -208,6 +273,126 @@ func (c *projectServiceClient) GetSecrets(ctx context.Context, in *GetSecretsReq
 	return out, nil
 }
 
+func (c *projectServiceClient) AddDomain(ctx context.Context, in *AddDomainRequest, opts ...grpc.CallOption) (*AddDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDomainResponse)
+	err := c.cc.Invoke(ctx, ProjectService_AddDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyDomainResponse)
+	err := c.cc.Invoke(ctx, ProjectService_VerifyDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) RemoveDomain(ctx context.Context, in *RemoveDomainRequest, opts ...grpc.CallOption) (*RemoveDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDomainResponse)
+	err := c.cc.Invoke(ctx, ProjectService_RemoveDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDomainsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) CreateEnvironment(ctx context.Context, in *CreateEnvironmentRequest, opts ...grpc.CallOption) (*CreateEnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateEnvironmentResponse)
+	err := c.cc.Invoke(ctx, ProjectService_CreateEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) UpdateEnvironment(ctx context.Context, in *UpdateEnvironmentRequest, opts ...grpc.CallOption) (*UpdateEnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateEnvironmentResponse)
+	err := c.cc.Invoke(ctx, ProjectService_UpdateEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) DeleteEnvironment(ctx context.Context, in *DeleteEnvironmentRequest, opts ...grpc.CallOption) (*DeleteEnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteEnvironmentResponse)
+	err := c.cc.Invoke(ctx, ProjectService_DeleteEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListEnvironments(ctx context.Context, in *ListEnvironmentsRequest, opts ...grpc.CallOption) (*ListEnvironmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEnvironmentsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListEnvironments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetDeployPolicy(ctx context.Context, in *GetDeployPolicyRequest, opts ...grpc.CallOption) (*DeployPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployPolicyResponse)
+	err := c.cc.Invoke(ctx, ProjectService_GetDeployPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) SetDeployWindows(ctx context.Context, in *SetDeployWindowsRequest, opts ...grpc.CallOption) (*DeployPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployPolicyResponse)
+	err := c.cc.Invoke(ctx, ProjectService_SetDeployWindows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) SetDeployFreeze(ctx context.Context, in *SetDeployFreezeRequest, opts ...grpc.CallOption) (*DeployPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeployPolicyResponse)
+	err := c.cc.Invoke(ctx, ProjectService_SetDeployFreeze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) CheckDeploy(ctx context.Context, in *CheckDeployRequest, opts ...grpc.CallOption) (*CheckDeployResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDeployResponse)
+	err := c.cc.Invoke(ctx, ProjectService_CheckDeploy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProjectServiceServer is the server API for ProjectService service.
 // All implementations must embed UnimplementedProjectServiceServer
 // for forward compatibility.
@@ -218,6 +403,7 @@ type ProjectServiceServer interface {
 	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
 	GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error)
 	GetProjectByRepo(context.Context, *GetProjectByRepoRequest) (*GetProjectResponse, error)
+	ListProjectsByRepo(context.Context, *GetProjectByRepoRequest) (*ListProjectsResponse, error)
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
 	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
 	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
@@ -225,12 +411,31 @@ type ProjectServiceServer interface {
 	ListRepositories(context.Context, *ListRepositoriesRequest) (*ListRepositoriesResponse, error)
 	SetupWebhook(context.Context, *SetupWebhookRequest) (*SetupWebhookResponse, error)
 	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	SetCommitStatus(context.Context, *SetCommitStatusRequest) (*SetCommitStatusResponse, error)
+	// Internal: token for the Runner to clone the repository
+	GetRepoToken(context.Context, *GetRepoTokenRequest) (*GetRepoTokenResponse, error)
 	// Secrets management
 	AddSecret(context.Context, *AddSecretRequest) (*AddSecretResponse, error)
 	UpdateSecret(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error)
 	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
 	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
 	GetSecrets(context.Context, *GetSecretsRequest) (*GetSecretsResponse, error)
+	// Custom domains (Premium), routed to the deployments once verified
+	AddDomain(context.Context, *AddDomainRequest) (*AddDomainResponse, error)
+	VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error)
+	RemoveDomain(context.Context, *RemoveDomainRequest) (*RemoveDomainResponse, error)
+	ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error)
+	// Environments deployed next to production, the project itself
+	CreateEnvironment(context.Context, *CreateEnvironmentRequest) (*CreateEnvironmentResponse, error)
+	UpdateEnvironment(context.Context, *UpdateEnvironmentRequest) (*UpdateEnvironmentResponse, error)
+	DeleteEnvironment(context.Context, *DeleteEnvironmentRequest) (*DeleteEnvironmentResponse, error)
+	ListEnvironments(context.Context, *ListEnvironmentsRequest) (*ListEnvironmentsResponse, error)
+	// Deploy windows and freeze of production
+	GetDeployPolicy(context.Context, *GetDeployPolicyRequest) (*DeployPolicyResponse, error)
+	SetDeployWindows(context.Context, *SetDeployWindowsRequest) (*DeployPolicyResponse, error)
+	SetDeployFreeze(context.Context, *SetDeployFreezeRequest) (*DeployPolicyResponse, error)
+	// Internal: called before every deploy
+	CheckDeploy(context.Context, *CheckDeployRequest) (*CheckDeployResponse, error)
 	mustEmbedUnimplementedProjectServiceServer()
 }
 
@@ -250,6 +455,9 @@ func (UnimplementedProjectServiceServer) GetProject(context.Context, *GetProject
 func (UnimplementedProjectServiceServer) GetProjectByRepo(context.Context, *GetProjectByRepoRequest) (*GetProjectResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProjectByRepo not implemented")
 }
+func (UnimplementedProjectServiceServer) ListProjectsByRepo(context.Context, *GetProjectByRepoRequest) (*ListProjectsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListProjectsByRepo not implemented")
+}
 func (UnimplementedProjectServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListProjects not implemented")
 }
@@ -268,6 +476,12 @@ func (UnimplementedProjectServiceServer) SetupWebhook(context.Context, *SetupWeb
 func (UnimplementedProjectServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteWebhook not implemented")
 }
+func (UnimplementedProjectServiceServer) SetCommitStatus(context.Context, *SetCommitStatusRequest) (*SetCommitStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCommitStatus not implemented")
+}
+func (UnimplementedProjectServiceServer) GetRepoToken(context.Context, *GetRepoTokenRequest) (*GetRepoTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRepoToken not implemented")
+}
 func (UnimplementedProjectServiceServer) AddSecret(context.Context, *AddSecretRequest) (*AddSecretResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddSecret not implemented")
 }
@@ -283,6 +497,42 @@ func (UnimplementedProjectServiceServer) ListSecrets(context.Context, *ListSecre
 func (UnimplementedProjectServiceServer) GetSecrets(context.Context, *GetSecretsRequest) (*GetSecretsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetSecrets not implemented")
 }
+func (UnimplementedProjectServiceServer) AddDomain(context.Context, *AddDomainRequest) (*AddDomainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddDomain not implemented")
+}
+func (UnimplementedProjectServiceServer) VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyDomain not implemented")
+}
+func (UnimplementedProjectServiceServer) RemoveDomain(context.Context, *RemoveDomainRequest) (*RemoveDomainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveDomain not implemented")
+}
+func (UnimplementedProjectServiceServer) ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDomains not implemented")
+}
+func (UnimplementedProjectServiceServer) CreateEnvironment(context.Context, *CreateEnvironmentRequest) (*CreateEnvironmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateEnvironment not implemented")
+}
+func (UnimplementedProjectServiceServer) UpdateEnvironment(context.Context, *UpdateEnvironmentRequest) (*UpdateEnvironmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateEnvironment not implemented")
+}
+func (UnimplementedProjectServiceServer) DeleteEnvironment(context.Context, *DeleteEnvironmentRequest) (*DeleteEnvironmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteEnvironment not implemented")
+}
+func (UnimplementedProjectServiceServer) ListEnvironments(context.Context, *ListEnvironmentsRequest) (*ListEnvironmentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListEnvironments not implemented")
+}
+func (UnimplementedProjectServiceServer) GetDeployPolicy(context.Context, *GetDeployPolicyRequest) (*DeployPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeployPolicy not implemented")
+}
+func (UnimplementedProjectServiceServer) SetDeployWindows(context.Context, *SetDeployWindowsRequest) (*DeployPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDeployWindows not implemented")
+}
+func (UnimplementedProjectServiceServer) SetDeployFreeze(context.Context, *SetDeployFreezeRequest) (*DeployPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDeployFreeze not implemented")
+}
+func (UnimplementedProjectServiceServer) CheckDeploy(context.Context, *CheckDeployRequest) (*CheckDeployResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckDeploy not implemented")
+}
 func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
 func (UnimplementedProjectServiceServer) testEmbeddedByValue()                        {}
 
@@ -358,6 +608,24 @@ func _ProjectService_GetProjectByRepo_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_ListProjectsByRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectByRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListProjectsByRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListProjectsByRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListProjectsByRepo(ctx, req.(*GetProjectByRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListProjectsRequest)
 	if err := dec(in); err != nil {
@@ -466,6 +734,42 @@ func _ProjectService_DeleteWebhook_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_SetCommitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCommitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).SetCommitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_SetCommitStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).SetCommitStatus(ctx, req.(*SetCommitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetRepoToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRepoTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetRepoToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetRepoToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetRepoToken(ctx, req.(*GetRepoTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_AddSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddSecretRequest)
 	if err := dec(in); err != nil {
@@ -556,6 +860,222 @@ func _ProjectService_GetSecrets_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_AddDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).AddDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_AddDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).AddDomain(ctx, req.(*AddDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_VerifyDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).VerifyDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_VerifyDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).VerifyDomain(ctx, req.(*VerifyDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_RemoveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).RemoveDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_RemoveDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).RemoveDomain(ctx, req.(*RemoveDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListDomains(ctx, req.(*ListDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_CreateEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CreateEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CreateEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CreateEnvironment(ctx, req.(*CreateEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_UpdateEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).UpdateEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_UpdateEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).UpdateEnvironment(ctx, req.(*UpdateEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_DeleteEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).DeleteEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_DeleteEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).DeleteEnvironment(ctx, req.(*DeleteEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListEnvironments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnvironmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListEnvironments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListEnvironments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListEnvironments(ctx, req.(*ListEnvironmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetDeployPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeployPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetDeployPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetDeployPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetDeployPolicy(ctx, req.(*GetDeployPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_SetDeployWindows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeployWindowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).SetDeployWindows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_SetDeployWindows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).SetDeployWindows(ctx, req.(*SetDeployWindowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_SetDeployFreeze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeployFreezeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).SetDeployFreeze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_SetDeployFreeze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).SetDeployFreeze(ctx, req.(*SetDeployFreezeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_CheckDeploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CheckDeploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CheckDeploy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CheckDeploy(ctx, req.(*CheckDeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -575,6 +1095,10 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProjectByRepo",
 			Handler:    _ProjectService_GetProjectByRepo_Handler,
 		},
+		{
+			MethodName: "ListProjectsByRepo",
+			Handler:    _ProjectService_ListProjectsByRepo_Handler,
+		},
 		{
 			MethodName: "ListProjects",
 			Handler:    _ProjectService_ListProjects_Handler,
@@ -599,6 +1123,14 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteWebhook",
 			Handler:    _ProjectService_DeleteWebhook_Handler,
 		},
+		{
+			MethodName: "SetCommitStatus",
+			Handler:    _ProjectService_SetCommitStatus_Handler,
+		},
+		{
+			MethodName: "GetRepoToken",
+			Handler:    _ProjectService_GetRepoToken_Handler,
+		},
 		{
 			MethodName: "AddSecret",
 			Handler:    _ProjectService_AddSecret_Handler,
@@ -619,6 +1151,54 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSecrets",
 			Handler:    _ProjectService_GetSecrets_Handler,
 		},
+		{
+			MethodName: "AddDomain",
+			Handler:    _ProjectService_AddDomain_Handler,
+		},
+		{
+			MethodName: "VerifyDomain",
+			Handler:    _ProjectService_VerifyDomain_Handler,
+		},
+		{
+			MethodName: "RemoveDomain",
+			Handler:    _ProjectService_RemoveDomain_Handler,
+		},
+		{
+			MethodName: "ListDomains",
+			Handler:    _ProjectService_ListDomains_Handler,
+		},
+		{
+			MethodName: "CreateEnvironment",
+			Handler:    _ProjectService_CreateEnvironment_Handler,
+		},
+		{
+			MethodName: "UpdateEnvironment",
+			Handler:    _ProjectService_UpdateEnvironment_Handler,
+		},
+		{
+			MethodName: "DeleteEnvironment",
+			Handler:    _ProjectService_DeleteEnvironment_Handler,
+		},
+		{
+			MethodName: "ListEnvironments",
+			Handler:    _ProjectService_ListEnvironments_Handler,
+		},
+		{
+			MethodName: "GetDeployPolicy",
+			Handler:    _ProjectService_GetDeployPolicy_Handler,
+		},
+		{
+			MethodName: "SetDeployWindows",
+			Handler:    _ProjectService_SetDeployWindows_Handler,
+		},
+		{
+			MethodName: "SetDeployFreeze",
+			Handler:    _ProjectService_SetDeployFreeze_Handler,
+		},
+		{
+			MethodName: "CheckDeploy",
+			Handler:    _ProjectService_CheckDeploy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/project.proto",
@@ -0,0 +1,358 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/rs/zerolog"
+)
+
+// Volume labels used to find and evict cache volumes
+const (
+	labelCache     = "nexus.cache"
+	labelProjectID = "nexus.project_id"
+	labelKind      = "nexus.cache_kind"
+	labelKey       = "nexus.cache_key"
+)
+
+// Defaults used when the config leaves a limit unset
+const (
+	DefaultMaxSize = 10 * 1024 * 1024 * 1024 // 10GB
+	DefaultMaxAge  = 7 * 24 * time.Hour
+)
+
+// entry describes a dependency directory cached for a preset
+type entry struct {
+	Kind      string   // Short name used in the volume name, e.g. "gomod"
+	Path      string   // Mount target inside the build container
+	Lockfiles []string // Files hashed into the cache key; the entry is skipped if none exist
+}
+
+// presets maps a project preset to its cached directories
+var presets = map[string][]entry{
+	"node": {
+		{Kind: "node_modules", Path: "/app/node_modules", Lockfiles: []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml"}},
+	},
+	"python": {
+		{Kind: "pip", Path: "/root/.cache/pip", Lockfiles: []string{"requirements.txt", "poetry.lock", "Pipfile.lock"}},
+	},
+	"go": {
+		{Kind: "gomod", Path: "/go/pkg/mod", Lockfiles: []string{"go.sum"}},
+		{Kind: "gobuild", Path: "/root/.cache/go-build", Lockfiles: []string{"go.sum"}},
+	},
+	"java": {
+		{Kind: "m2", Path: "/root/.m2", Lockfiles: []string{"pom.xml"}},
+		{Kind: "gradle", Path: "/root/.gradle", Lockfiles: []string{"build.gradle", "build.gradle.kts", "gradle.lockfile"}},
+	},
+	"ruby": {
+		{Kind: "bundle", Path: "/usr/local/bundle", Lockfiles: []string{"Gemfile.lock"}},
+	},
+}
+
+// Config holds cache limits
+type Config struct {
+	MaxSizeBytes int64         // Total size of all cache volumes before the least recently used are evicted
+	MaxAge       time.Duration // Volumes unused for longer than this are evicted
+}
+
+// Manager creates and evicts dependency cache volumes
+type Manager struct {
+	client  *client.Client
+	maxSize int64
+	maxAge  time.Duration
+	log     zerolog.Logger
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time     // Volume name -> last build that mounted it
+	locks    map[string]chan struct{} // Volume name -> held while a container has it mounted
+	evicting bool
+}
+
+// NewManager creates a cache manager using the given Docker client
+func NewManager(cli *client.Client, cfg Config, log zerolog.Logger) *Manager {
+	if cfg.MaxSizeBytes <= 0 {
+		cfg.MaxSizeBytes = DefaultMaxSize
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = DefaultMaxAge
+	}
+	return &Manager{
+		client:   cli,
+		maxSize:  cfg.MaxSizeBytes,
+		maxAge:   cfg.MaxAge,
+		log:      log,
+		lastUsed: make(map[string]time.Time),
+		locks:    make(map[string]chan struct{}),
+	}
+}
+
+// Mounts returns the cache volumes to mount for a build, creating the ones that
+// do not exist yet. Each volume is keyed by a hash of the lockfiles in the
+// workspace, so a dependency change starts from a fresh volume. Builds of a matrix
+// variant, "" for none, get volumes of their own.
+func (m *Manager) Mounts(ctx context.Context, projectID, preset, variant, workspace string, logCb func(string)) []mount.Mount {
+	entries := presets[normalizePreset(preset)]
+	mounts := make([]mount.Mount, 0, len(entries))
+
+	for _, e := range entries {
+		key, files, err := hashLockfiles(workspace, e.Lockfiles)
+		if err != nil {
+			logCb(fmt.Sprintf("[cache] Warning: failed to hash %s lockfiles: %v", e.Kind, err))
+			continue
+		}
+		if key == "" {
+			logCb(fmt.Sprintf("[cache] skip: %s (no lockfile)", e.Kind))
+			continue
+		}
+		// Variants run other toolchain versions, whose dependencies may not be
+		// compatible, e.g. native Node.js modules
+		if variant != "" {
+			key += "-" + variant
+		}
+
+		name := volumeName(projectID, e.Kind, key)
+		if _, err := m.client.VolumeInspect(ctx, name); err == nil {
+			logCb(fmt.Sprintf("[cache] hit: %s (%s)", e.Kind, strings.Join(files, ", ")))
+		} else {
+			_, err := m.client.VolumeCreate(ctx, volume.CreateOptions{
+				Name: name,
+				Labels: map[string]string{
+					labelCache:     "true",
+					labelProjectID: projectID,
+					labelKind:      e.Kind,
+					labelKey:       key,
+				},
+			})
+			if err != nil {
+				logCb(fmt.Sprintf("[cache] Warning: failed to create %s volume: %v", e.Kind, err))
+				continue
+			}
+			logCb(fmt.Sprintf("[cache] miss: %s (%s)", e.Kind, strings.Join(files, ", ")))
+		}
+
+		m.touch(name)
+		mounts = append(mounts, mount.Mount{
+			Type:   mount.TypeVolume,
+			Source: name,
+			Target: e.Path,
+		})
+	}
+	return mounts
+}
+
+// Lock takes the cache volumes in mounts, waiting while a container of another
+// build or step has one of them mounted: package managers do not support
+// concurrent writers, e.g. two npm installs into the same node_modules. onWait is
+// called once if a volume is busy. The returned func releases the volumes.
+func (m *Manager) Lock(ctx context.Context, mounts []mount.Mount, onWait func()) (func(), error) {
+	names := make([]string, 0, len(mounts))
+	for _, mt := range mounts {
+		names = append(names, mt.Source)
+	}
+	// A fixed order, so two builds never hold a volume the other waits for
+	sort.Strings(names)
+
+	var held []chan struct{}
+	unlock := func() {
+		for _, l := range held {
+			<-l
+		}
+	}
+	waited := false
+	for _, name := range names {
+		l := m.volumeLock(name)
+		select {
+		case l <- struct{}{}:
+		default:
+			if !waited && onWait != nil {
+				onWait()
+				waited = true
+			}
+			select {
+			case l <- struct{}{}:
+			case <-ctx.Done():
+				unlock()
+				return nil, ctx.Err()
+			}
+		}
+		held = append(held, l)
+	}
+	return unlock, nil
+}
+
+func (m *Manager) volumeLock(name string) chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.locks[name]
+	if !ok {
+		l = make(chan struct{}, 1)
+		m.locks[name] = l
+	}
+	return l
+}
+
+// Evict removes cache volumes unused for longer than the max age, then the least
+// recently used ones until the total size fits. Volumes mounted by a running
+// build are never removed.
+func (m *Manager) Evict(ctx context.Context) error {
+	m.mu.Lock()
+	if m.evicting {
+		m.mu.Unlock()
+		return nil
+	}
+	m.evicting = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.evicting = false
+		m.mu.Unlock()
+	}()
+
+	// DiskUsage is the only API that reports volume sizes
+	usage, err := m.client.DiskUsage(ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.VolumeObject},
+	})
+	if err != nil {
+		return fmt.Errorf("disk usage: %w", err)
+	}
+
+	type candidate struct {
+		name     string
+		size     int64
+		inUse    bool
+		lastUsed time.Time
+	}
+	var (
+		volumes []candidate
+		total   int64
+	)
+	for _, v := range usage.Volumes {
+		if v == nil || v.Labels[labelCache] != "true" {
+			continue
+		}
+		c := candidate{name: v.Name, lastUsed: m.lastUsedAt(v)}
+		if v.UsageData != nil {
+			c.size = v.UsageData.Size
+			c.inUse = v.UsageData.RefCount > 0
+		}
+		if c.size > 0 {
+			total += c.size
+		}
+		volumes = append(volumes, c)
+	}
+
+	// Oldest first
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].lastUsed.Before(volumes[j].lastUsed)
+	})
+
+	cutoff := time.Now().Add(-m.maxAge)
+	for _, v := range volumes {
+		if v.inUse {
+			continue
+		}
+		expired := v.lastUsed.Before(cutoff)
+		if !expired && total <= m.maxSize {
+			break
+		}
+		if err := m.client.VolumeRemove(ctx, v.name, false); err != nil {
+			m.log.Warn().Err(err).Str("volume", v.name).Msg("Failed to evict cache volume")
+			continue
+		}
+		if v.size > 0 {
+			total -= v.size
+		}
+		m.forget(v.name)
+		m.log.Info().
+			Str("volume", v.name).
+			Int64("size_bytes", v.size).
+			Bool("expired", expired).
+			Msg("Evicted cache volume")
+	}
+	return nil
+}
+
+func (m *Manager) touch(name string) {
+	m.mu.Lock()
+	m.lastUsed[name] = time.Now()
+	m.mu.Unlock()
+}
+
+func (m *Manager) forget(name string) {
+	m.mu.Lock()
+	delete(m.lastUsed, name)
+	delete(m.locks, name)
+	m.mu.Unlock()
+}
+
+// lastUsedAt returns when a volume was last mounted by this runner, falling back
+// to its creation time (e.g. after a restart)
+func (m *Manager) lastUsedAt(v *volume.Volume) time.Time {
+	m.mu.Lock()
+	t, ok := m.lastUsed[v.Name]
+	m.mu.Unlock()
+	if ok {
+		return t
+	}
+	created, err := time.Parse(time.RFC3339, v.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return created
+}
+
+// hashLockfiles returns a short hash of the lockfiles present in the workspace
+// and their names. The key is empty when none of them exist.
+func hashLockfiles(workspace string, names []string) (string, []string, error) {
+	h := sha256.New()
+	var found []string
+	for _, name := range names {
+		f, err := os.Open(filepath.Join(workspace, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		io.WriteString(h, name+"\x00")
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", nil, err
+		}
+		found = append(found, name)
+	}
+	if len(found) == 0 {
+		return "", nil, nil
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12], found, nil
+}
+
+// volumeName builds the cache volume name for a project, kind and key
+func volumeName(projectID, kind, key string) string {
+	return fmt.Sprintf("nexus-cache-%s-%s-%s", projectID, strings.ReplaceAll(kind, "_", "-"), key)
+}
+
+func normalizePreset(preset string) string {
+	switch p := strings.ToLower(preset); p {
+	case "nodejs":
+		return "node"
+	case "golang":
+		return "go"
+	default:
+		return p
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	grpcpkg "github.com/nexusdeploy/backend/pkg/grpc"
 	buildpb "github.com/nexusdeploy/backend/services/build-service/proto"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
 	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -14,23 +15,26 @@ import (
 
 // Clients holds all gRPC clients needed by Runner Service
 type Clients struct {
-	Build   buildpb.BuildServiceClient
-	Project projectpb.ProjectServiceClient
+	Build      buildpb.BuildServiceClient
+	Project    projectpb.ProjectServiceClient
+	Deployment deploymentpb.DeploymentServiceClient
 
-	buildConn   *grpc.ClientConn
-	projectConn *grpc.ClientConn
-	log         zerolog.Logger
+	buildConn      *grpc.ClientConn
+	projectConn    *grpc.ClientConn
+	deploymentConn *grpc.ClientConn
+	log            zerolog.Logger
 }
 
 // ClientsConfig holds configuration for gRPC clients
 type ClientsConfig struct {
-	BuildServiceAddr   string
-	ProjectServiceAddr string
-	Timeout            time.Duration
-	MaxRetries         int
-	TLSEnabled         bool
-	TLSCertPath        string
-	InsecureSkipVerify bool
+	BuildServiceAddr      string
+	ProjectServiceAddr    string
+	DeploymentServiceAddr string
+	Timeout               time.Duration
+	MaxRetries            int
+	TLSEnabled            bool
+	TLSCertPath           string
+	InsecureSkipVerify    bool
 }
 
 // NewClients creates and connects all gRPC clients
@@ -64,17 +68,36 @@ func NewClients(ctx context.Context, cfg ClientsConfig, log zerolog.Logger) (*Cl
 		return nil, fmt.Errorf("connect to project service: %w", err)
 	}
 
+	// Connect to Deployment Service, used by projects that deploy automatically
+	deploymentConn, err := grpcpkg.NewClient(ctx, grpcpkg.ClientConfig{
+		Address:            cfg.DeploymentServiceAddr,
+		Timeout:            cfg.Timeout,
+		MaxRetries:         cfg.MaxRetries,
+		ServiceName:        "deployment-service",
+		TLSEnabled:         cfg.TLSEnabled,
+		TLSCertPath:        cfg.TLSCertPath,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	})
+	if err != nil {
+		buildConn.Close()
+		projectConn.Close()
+		return nil, fmt.Errorf("connect to deployment service: %w", err)
+	}
+
 	log.Info().
 		Str("build_service", cfg.BuildServiceAddr).
 		Str("project_service", cfg.ProjectServiceAddr).
+		Str("deployment_service", cfg.DeploymentServiceAddr).
 		Msg("Connected to gRPC services")
 
 	return &Clients{
-		Build:       buildpb.NewBuildServiceClient(buildConn),
-		Project:     projectpb.NewProjectServiceClient(projectConn),
-		buildConn:   buildConn,
-		projectConn: projectConn,
-		log:         log,
+		Build:          buildpb.NewBuildServiceClient(buildConn),
+		Project:        projectpb.NewProjectServiceClient(projectConn),
+		Deployment:     deploymentpb.NewDeploymentServiceClient(deploymentConn),
+		buildConn:      buildConn,
+		projectConn:    projectConn,
+		deploymentConn: deploymentConn,
+		log:            log,
 	}, nil
 }
 
@@ -94,6 +117,12 @@ func (c *Clients) Close() error {
 		}
 	}
 
+	if c.deploymentConn != nil {
+		if err := c.deploymentConn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close deployment conn: %w", err))
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("close errors: %v", errs)
 	}
@@ -118,6 +147,39 @@ func (c *Clients) UpdateBuildStatus(ctx context.Context, buildID string, status
 	return nil
 }
 
+// UpdateBuildDeployment sets the status of a build that was deployed, with the
+// image and, when the deploy succeeded, the deployment it created
+func (c *Clients) UpdateBuildDeployment(ctx context.Context, buildID string, status buildpb.BuildStatus, imageTag, deploymentID string, logLines []string) error {
+	resp, err := c.Build.UpdateBuildStatus(ctx, &buildpb.UpdateBuildStatusRequest{
+		BuildId:      buildID,
+		Status:       status,
+		LogLines:     logLines,
+		ImageTag:     imageTag,
+		DeploymentId: deploymentID,
+	})
+	if err != nil {
+		return fmt.Errorf("update build status: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("build service error: %s", resp.Error)
+	}
+	return nil
+}
+
+// GetBuildStatus returns the current status of a build
+func (c *Clients) GetBuildStatus(ctx context.Context, buildID string) (buildpb.BuildStatus, error) {
+	resp, err := c.Build.GetBuild(ctx, &buildpb.GetBuildRequest{
+		BuildId: buildID,
+	})
+	if err != nil {
+		return buildpb.BuildStatus_BUILD_STATUS_UNSPECIFIED, fmt.Errorf("get build: %w", err)
+	}
+	if resp.Error != "" {
+		return buildpb.BuildStatus_BUILD_STATUS_UNSPECIFIED, fmt.Errorf("build service error: %s", resp.Error)
+	}
+	return resp.Build.GetStatus(), nil
+}
+
 // AppendBuildLogs appends logs to a build
 func (c *Clients) AppendBuildLogs(ctx context.Context, buildID string, logLines []string) error {
 	resp, err := c.Build.AppendBuildLogs(ctx, &buildpb.AppendBuildLogsRequest{
@@ -133,10 +195,12 @@ func (c *Clients) AppendBuildLogs(ctx context.Context, buildID string, logLines
 	return nil
 }
 
-// GetProjectSecrets fetches decrypted secrets for a project
-func (c *Clients) GetProjectSecrets(ctx context.Context, projectID string) (map[string]string, error) {
+// GetProjectSecrets fetches decrypted secrets for an environment of a project,
+// "" for production
+func (c *Clients) GetProjectSecrets(ctx context.Context, projectID, environment string) (map[string]string, error) {
 	resp, err := c.Project.GetSecrets(ctx, &projectpb.GetSecretsRequest{
-		ProjectId: projectID,
+		ProjectId:   projectID,
+		Environment: environment,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("get secrets: %w", err)
@@ -161,3 +225,108 @@ func (c *Clients) GetProject(ctx context.Context, projectID, userID string) (*pr
 	}
 	return resp.Project, nil
 }
+
+// GetRepoToken fetches the GitHub token to clone the repository of a project,
+// "" if the project owner has none
+func (c *Clients) GetRepoToken(ctx context.Context, projectID string) (string, error) {
+	resp, err := c.Project.GetRepoToken(ctx, &projectpb.GetRepoTokenRequest{
+		ProjectId: projectID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("get repo token: %w", err)
+	}
+	if resp.Error != "" {
+		return "", fmt.Errorf("project service error: %s", resp.Error)
+	}
+	return resp.Token, nil
+}
+
+// SetCommitStatus sets a status on a commit of the repository of a project,
+// through Project Service
+func (c *Clients) SetCommitStatus(ctx context.Context, req *projectpb.SetCommitStatusRequest) error {
+	resp, err := c.Project.SetCommitStatus(ctx, req)
+	if err != nil {
+		return fmt.Errorf("set commit status: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("project service error: %s", resp.Error)
+	}
+	return nil
+}
+
+// UpdateBuildStep records the status of a pipeline step on the build
+func (c *Clients) UpdateBuildStep(ctx context.Context, buildID, stepName, status string, duration time.Duration) error {
+	resp, err := c.Build.UpdateBuildStep(ctx, &buildpb.UpdateBuildStepRequest{
+		BuildId:    buildID,
+		StepName:   stepName,
+		Status:     status,
+		DurationMs: int32(duration.Milliseconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("update build step: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("build service error: %s", resp.Error)
+	}
+	return nil
+}
+
+// ReportTestResults stores the parsed test results of a build
+func (c *Clients) ReportTestResults(ctx context.Context, buildID string, results []*buildpb.TestCase) error {
+	resp, err := c.Build.ReportTestResults(ctx, &buildpb.ReportTestResultsRequest{
+		BuildId: buildID,
+		Results: results,
+	})
+	if err != nil {
+		return fmt.Errorf("report test results: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("build service error: %s", resp.Error)
+	}
+	return nil
+}
+
+// RecordDeadLetter records a build job the queue archived instead of retrying
+func (c *Clients) RecordDeadLetter(ctx context.Context, req *buildpb.RecordDeadLetterRequest) error {
+	resp, err := c.Build.RecordDeadLetter(ctx, req)
+	if err != nil {
+		return fmt.Errorf("record dead letter: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("build service error: %s", resp.Error)
+	}
+	return nil
+}
+
+// CheckDeploy asks Project Service whether the deploy policy of a project allows
+// deploying an environment now. A rejection is returned as an error starting
+// with its code, e.g. "deploy_frozen".
+func (c *Clients) CheckDeploy(ctx context.Context, projectID, userID, environment string) error {
+	resp, err := c.Project.CheckDeploy(ctx, &projectpb.CheckDeployRequest{
+		ProjectId:   projectID,
+		UserId:      userID,
+		Environment: environment,
+	})
+	if err != nil {
+		return fmt.Errorf("check deploy: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("project service error: %s", resp.Error)
+	}
+	if !resp.Allowed {
+		return fmt.Errorf("%s: %s", resp.Code, resp.Reason)
+	}
+	return nil
+}
+
+// Deploy deploys a built image through Deployment Service
+func (c *Clients) Deploy(ctx context.Context, spec *deploymentpb.DeploymentSpec) (*deploymentpb.DeployResponse, error) {
+	resp, err := c.Deployment.Deploy(ctx, &deploymentpb.DeployRequest{Spec: spec})
+	if err != nil {
+		return nil, fmt.Errorf("deploy: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("deployment service error: %s", resp.Error)
+	}
+	return resp, nil
+}
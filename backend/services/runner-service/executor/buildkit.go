@@ -0,0 +1,342 @@
+package executor
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// buildkitTraceID marks BuildKit progress messages in the image build stream.
+// Their aux field holds a protobuf-encoded moby.buildkit.v1.StatusResponse.
+const buildkitTraceID = "moby.buildkit.trace"
+
+// buildContextTar streams the workspace as a tar archive, honouring .dockerignore
+// the same way the docker CLI does
+func buildContextTar(workspace string) (io.ReadCloser, error) {
+	var excludes []string
+	if f, err := os.Open(filepath.Join(workspace, ".dockerignore")); err == nil {
+		excludes, err = ignorefile.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read .dockerignore: %w", err)
+		}
+	}
+	pm, err := patternmatcher.New(excludes)
+	if err != nil {
+		return nil, fmt.Errorf("parse .dockerignore: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := filepath.Walk(workspace, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(workspace, path)
+			if err != nil || relPath == "." {
+				return err
+			}
+			relPath = filepath.ToSlash(relPath)
+
+			// The Dockerfile and .dockerignore are always sent, the daemon needs them
+			if relPath != "Dockerfile" && relPath != ".dockerignore" {
+				excluded, err := pm.MatchesOrParentMatches(relPath)
+				if err != nil {
+					return err
+				}
+				if excluded {
+					if info.IsDir() && !pm.Exclusions() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+			}
+
+			link := ""
+			if info.Mode()&os.ModeSymlink != 0 {
+				if link, err = os.Readlink(path); err != nil {
+					return err
+				}
+			}
+			header, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			header.Name = relPath
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(tw, file)
+			return err
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// buildProgress turns an image build response stream into log lines, in the
+// format of `docker build --progress=plain`
+type buildProgress struct {
+	logCb    LogCallback
+	vertexes map[string]*buildVertex
+	next     int
+	imageID  string
+}
+
+type buildVertex struct {
+	index     int
+	name      string
+	started   bool
+	completed bool
+	partial   string // Log output not yet terminated by a newline
+}
+
+func newBuildProgress(logCb LogCallback) *buildProgress {
+	return &buildProgress{logCb: logCb, vertexes: make(map[string]*buildVertex)}
+}
+
+// Read consumes the stream until it ends and returns the first build error
+func (p *buildProgress) Read(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read build output: %w", err)
+		}
+
+		// Errors reported by the build itself come from the Dockerfile, e.g. a RUN
+		// instruction that failed
+		if msg.Error != nil {
+			return &UserError{Err: errors.New(msg.Error.Message)}
+		}
+		if msg.ErrorMessage != "" {
+			return &UserError{Err: errors.New(msg.ErrorMessage)}
+		}
+
+		switch {
+		case msg.ID == buildkitTraceID && msg.Aux != nil:
+			var data []byte
+			if err := json.Unmarshal(*msg.Aux, &data); err != nil {
+				continue
+			}
+			p.status(data)
+		case msg.ID == "moby.image.id" && msg.Aux != nil:
+			var aux struct {
+				ID string `json:"ID"`
+			}
+			if err := json.Unmarshal(*msg.Aux, &aux); err == nil {
+				p.imageID = aux.ID
+			}
+		case msg.Stream != "":
+			// Classic builder output
+			for _, line := range strings.Split(strings.TrimRight(msg.Stream, "\n"), "\n") {
+				if strings.TrimSpace(line) != "" {
+					p.logCb(line)
+				}
+			}
+		}
+	}
+}
+
+// status decodes a StatusResponse: vertexes = 1, logs = 3, warnings = 4
+func (p *buildProgress) status(data []byte) {
+	forEachField(data, func(num protowire.Number, typ protowire.Type, v []byte) {
+		if typ != protowire.BytesType {
+			return
+		}
+		switch num {
+		case 1:
+			p.vertex(v)
+		case 3:
+			p.vertexLog(v)
+		case 4:
+			p.vertexWarning(v)
+		}
+	})
+}
+
+// vertex handles a Vertex: digest = 1, name = 3, cached = 4, started = 5,
+// completed = 6, error = 7
+func (p *buildProgress) vertex(data []byte) {
+	var (
+		digest, name, errMsg string
+		cached               bool
+		started, completed   time.Time
+	)
+	forEachField(data, func(num protowire.Number, typ protowire.Type, v []byte) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			digest = string(v)
+		case num == 3 && typ == protowire.BytesType:
+			name = string(v)
+		case num == 4 && typ == protowire.VarintType:
+			n, _ := protowire.ConsumeVarint(v)
+			cached = n != 0
+		case num == 5 && typ == protowire.BytesType:
+			started = decodeTimestamp(v)
+		case num == 6 && typ == protowire.BytesType:
+			completed = decodeTimestamp(v)
+		case num == 7 && typ == protowire.BytesType:
+			errMsg = string(v)
+		}
+	})
+	if digest == "" {
+		return
+	}
+
+	vx := p.lookup(digest)
+	if name != "" {
+		vx.name = name
+	}
+	if !vx.started && (!started.IsZero() || cached) {
+		vx.started = true
+		p.logCb(fmt.Sprintf("#%d %s", vx.index, vx.name))
+	}
+	if vx.completed || (completed.IsZero() && !cached) {
+		return
+	}
+	vx.completed = true
+	p.flushPartial(vx)
+
+	switch {
+	case errMsg != "":
+		p.logCb(fmt.Sprintf("#%d ERROR: %s", vx.index, errMsg))
+	case cached:
+		p.logCb(fmt.Sprintf("#%d CACHED", vx.index))
+	default:
+		p.logCb(fmt.Sprintf("#%d DONE %.1fs", vx.index, completed.Sub(started).Seconds()))
+	}
+}
+
+// vertexLog handles a VertexLog: vertex = 1, msg = 4
+func (p *buildProgress) vertexLog(data []byte) {
+	var digest string
+	var msg []byte
+	forEachField(data, func(num protowire.Number, typ protowire.Type, v []byte) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			digest = string(v)
+		case num == 4 && typ == protowire.BytesType:
+			msg = v
+		}
+	})
+	if digest == "" || len(msg) == 0 {
+		return
+	}
+
+	vx := p.lookup(digest)
+	lines := strings.Split(vx.partial+string(msg), "\n")
+	vx.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+			p.logCb(fmt.Sprintf("#%d %s", vx.index, line))
+		}
+	}
+}
+
+// vertexWarning handles a VertexWarning: vertex = 1, short = 3
+func (p *buildProgress) vertexWarning(data []byte) {
+	var short string
+	forEachField(data, func(num protowire.Number, typ protowire.Type, v []byte) {
+		if num == 3 && typ == protowire.BytesType {
+			short = string(v)
+		}
+	})
+	if short != "" {
+		p.logCb("WARNING: " + short)
+	}
+}
+
+func (p *buildProgress) lookup(digest string) *buildVertex {
+	vx, ok := p.vertexes[digest]
+	if !ok {
+		p.next++
+		vx = &buildVertex{index: p.next}
+		p.vertexes[digest] = vx
+	}
+	return vx
+}
+
+func (p *buildProgress) flushPartial(vx *buildVertex) {
+	if strings.TrimSpace(vx.partial) != "" {
+		p.logCb(fmt.Sprintf("#%d %s", vx.index, strings.TrimRight(vx.partial, "\r")))
+	}
+	vx.partial = ""
+}
+
+// forEachField walks the top-level fields of a protobuf message. Varint values are
+// passed still encoded; length-delimited values are passed without their length.
+func forEachField(data []byte, fn func(num protowire.Number, typ protowire.Type, v []byte)) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return
+		}
+		data = data[n:]
+
+		var v []byte
+		switch typ {
+		case protowire.BytesType:
+			b, m := protowire.ConsumeBytes(data)
+			if m < 0 {
+				return
+			}
+			v, n = b, m
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return
+			}
+			v = data[:n]
+		}
+		fn(num, typ, v)
+		data = data[n:]
+	}
+}
+
+// decodeTimestamp decodes a google.protobuf.Timestamp: seconds = 1, nanos = 2
+func decodeTimestamp(data []byte) time.Time {
+	var secs, nanos uint64
+	forEachField(data, func(num protowire.Number, typ protowire.Type, v []byte) {
+		if typ != protowire.VarintType {
+			return
+		}
+		switch num {
+		case 1:
+			secs, _ = protowire.ConsumeVarint(v)
+		case 2:
+			nanos, _ = protowire.ConsumeVarint(v)
+		}
+	})
+	if secs == 0 && nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(secs), int64(nanos))
+}
@@ -4,18 +4,26 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/nexusdeploy/backend/services/runner-service/cache"
 	"github.com/rs/zerolog"
 )
 
@@ -27,6 +35,8 @@ type DockerExecutor struct {
 	registryUser string
 	registryPass string
 	workDir      string
+	cache        *cache.Manager // nil when dependency caching is disabled
+	noDiskQuota  atomic.Bool    // Set once the daemon rejected a storage-opt size
 }
 
 // ExecutorConfig holds configuration for the executor
@@ -35,6 +45,9 @@ type ExecutorConfig struct {
 	RegistryUser string
 	RegistryPass string
 	WorkDir      string // Base directory for build workspaces
+
+	CacheEnabled bool         // Mount dependency cache volumes into build containers
+	Cache        cache.Config // Cache eviction limits
 }
 
 // NewDockerExecutor creates a new Docker executor
@@ -62,9 +75,15 @@ func NewDockerExecutor(cfg ExecutorConfig, log zerolog.Logger) (*DockerExecutor,
 		return nil, fmt.Errorf("create work dir: %w", err)
 	}
 
+	var cacheManager *cache.Manager
+	if cfg.CacheEnabled {
+		cacheManager = cache.NewManager(cli, cfg.Cache, log)
+	}
+
 	log.Info().
 		Str("registry", cfg.RegistryURL).
 		Str("work_dir", workDir).
+		Bool("cache_enabled", cfg.CacheEnabled).
 		Msg("Docker executor initialized")
 
 	return &DockerExecutor{
@@ -74,6 +93,7 @@ func NewDockerExecutor(cfg ExecutorConfig, log zerolog.Logger) (*DockerExecutor,
 		registryUser: cfg.RegistryUser,
 		registryPass: cfg.RegistryPass,
 		workDir:      workDir,
+		cache:        cacheManager,
 	}, nil
 }
 
@@ -91,10 +111,24 @@ type BuildContext struct {
 	CommitSHA    string
 	BuildCommand string
 	StartCommand string
+	TestCommand  string
 	Preset       string
 	Port         int
 	Secrets      map[string]string
 	GitHubToken  string // For private repos
+	RootDir      string // Subdirectory of the repository to build, "" = repository root
+	Clone        CloneOptions
+
+	// CacheMounts are the dependency cache volumes set by PrepareCache
+	CacheMounts []mount.Mount
+
+	// Resources are the limits from the project build settings
+	Resources Resources
+
+	// Variant of the build matrix, built on its own base image with its env
+	Variant   string
+	BaseImage string            // Overrides the image of the preset, "" = none
+	Env       map[string]string // Not secret, so not masked in logs
 }
 
 // BuildResult contains the result of a build
@@ -120,78 +154,91 @@ func (e *DockerExecutor) CloneRepository(ctx context.Context, bc *BuildContext,
 
 	logCb(fmt.Sprintf("[clone] Creating workspace: %s", workspace))
 
-	// Build git clone URL with token for private repos
-	repoURL := bc.RepoURL
-	if bc.GitHubToken != "" && strings.Contains(repoURL, "github.com") {
-		// Insert token into HTTPS URL
-		repoURL = strings.Replace(repoURL, "https://github.com",
-			fmt.Sprintf("https://%s@github.com", bc.GitHubToken), 1)
+	auth, err := newGitAuth(bc.GitHubToken)
+	if err != nil {
+		return "", err
 	}
+	defer auth.Close()
 
-	// Clone repository directly into workspace using git clone with . as target
-	// This avoids creating a subdirectory
-	logCb(fmt.Sprintf("[clone] Cloning %s branch %s", bc.RepoURL, bc.Branch))
+	opts := bc.Clone
+	env := auth.env
+	depthArgs := opts.depthArgs()
 
-	// Use git clone directly into workspace directory
-	cloneArgs := []string{"clone", "--depth", "1", "--branch", bc.Branch, repoURL, "."}
-	cloneCmd := exec.CommandContext(ctx, "git", cloneArgs...)
-	cloneCmd.Dir = workspace
-	output, err := cloneCmd.CombinedOutput()
-	targetBranch := bc.Branch
+	// Clone directly into the workspace with "." as target, falling back to the
+	// usual default branches and finally to the remote HEAD
+	logCb(fmt.Sprintf("[clone] Cloning %s branch %s", redactURL(bc.RepoURL), bc.Branch))
+	if opts.FullHistory {
+		logCb("[clone] Fetching full history")
+	}
 
-	if err != nil {
-		// Try default branch (usually main or master)
-		logCb(fmt.Sprintf("[clone] Branch %s not found, trying default branch...", bc.Branch))
-		for _, defaultBranch := range []string{"main", "master"} {
-			cloneArgs = []string{"clone", "--depth", "1", "--branch", defaultBranch, repoURL, "."}
-			cloneCmd = exec.CommandContext(ctx, "git", cloneArgs...)
-			cloneCmd.Dir = workspace
-			output, err = cloneCmd.CombinedOutput()
-			if err == nil {
-				targetBranch = defaultBranch
-				logCb(fmt.Sprintf("[clone] Cloned default branch: %s", defaultBranch))
-				break
-			}
+	for i, branch := range []string{bc.Branch, "main", "master", ""} {
+		if i > 0 && branch == bc.Branch {
+			continue
 		}
-		if err != nil {
-			// If branch-specific clone fails, try without branch (will get default)
+		switch {
+		case i == 1:
+			logCb(fmt.Sprintf("[clone] Branch %s not found, trying default branch...", bc.Branch))
+		case branch == "":
 			logCb("[clone] Branch-specific clone failed, trying without branch...")
-			cloneArgs = []string{"clone", "--depth", "1", repoURL, "."}
-			cloneCmd = exec.CommandContext(ctx, "git", cloneArgs...)
-			cloneCmd.Dir = workspace
-			output, err = cloneCmd.CombinedOutput()
-			if err != nil {
-				logCb(fmt.Sprintf("[clone] Error: %s", string(output)))
-				return "", fmt.Errorf("git clone: %w", err)
-			}
-			// Determine which branch was cloned
-			branchCmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
-			branchCmd.Dir = workspace
-			if branchOutput, err := branchCmd.Output(); err == nil {
-				targetBranch = strings.TrimSpace(string(branchOutput))
-				logCb("[clone] Cloned branch: " + targetBranch)
-			}
 		}
+
+		args := append([]string{"clone"}, depthArgs...)
+		if branch != "" {
+			args = append(args, "--branch", branch)
+		}
+		args = append(args, bc.RepoURL, ".")
+		if err = runGit(ctx, workspace, env, logCb, args...); err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("git clone: %w", err)
 	}
 
-	if len(output) > 0 {
-		logCb(fmt.Sprintf("[clone] %s", strings.TrimSpace(string(output))))
+	if branch, err := gitOutput(ctx, workspace, env, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		logCb(fmt.Sprintf("[clone] Checked out branch: %s", branch))
 	}
-	logCb(fmt.Sprintf("[clone] Checked out branch: %s", targetBranch))
 
 	// Checkout specific commit if provided
 	if bc.CommitSHA != "" {
-		logCb(fmt.Sprintf("[clone] Checking out commit %s", bc.CommitSHA))
-		checkoutCmd := exec.CommandContext(ctx, "git", "checkout", bc.CommitSHA)
-		checkoutCmd.Dir = workspace
-		if output, err := checkoutCmd.CombinedOutput(); err != nil {
-			logCb(fmt.Sprintf("[clone] Checkout error: %s", string(output)))
+		if err := checkoutCommit(ctx, workspace, env, bc.CommitSHA, depthArgs, logCb); err != nil {
 			// Non-fatal, continue with branch HEAD
+			logCb(fmt.Sprintf("[clone] Warning: %v, building branch HEAD", err))
+		}
+	}
+
+	if opts.Tags {
+		logCb("[clone] Fetching tags")
+		if err := runGit(ctx, workspace, env, logCb, "fetch", "--tags", "--force", "origin"); err != nil {
+			return "", fmt.Errorf("fetch tags: %w", err)
+		}
+		if !opts.FullHistory {
+			logCb("[clone] Note: the clone is shallow, git describe may not find tags outside the fetched history")
+		}
+	}
+
+	if opts.Submodules {
+		logCb("[clone] Updating submodules")
+		args := append([]string{"submodule", "update", "--init", "--recursive"}, depthArgs...)
+		if err := runGit(ctx, workspace, env, logCb, args...); err != nil {
+			return "", fmt.Errorf("update submodules: %w", err)
+		}
+	}
+
+	if opts.LFS {
+		logCb("[clone] Pulling Git LFS objects")
+		if err := runGit(ctx, workspace, env, logCb, "lfs", "pull"); err != nil {
+			return "", fmt.Errorf("git lfs pull: %w", err)
+		}
+		if opts.Submodules {
+			if err := runGit(ctx, workspace, env, logCb, "submodule", "foreach", "--recursive", "git lfs pull"); err != nil {
+				return "", fmt.Errorf("git lfs pull in submodules: %w", err)
+			}
 		}
 	}
 
 	// Verify package.json exists (for debugging)
-	if _, err := os.Stat(filepath.Join(workspace, "package.json")); err == nil {
+	if _, err := os.Stat(filepath.Join(workspace, bc.RootDir, "package.json")); err == nil {
 		logCb("[clone] Verified: package.json found in workspace")
 	} else {
 		logCb("[clone] Warning: package.json not found in workspace (this may cause build to fail)")
@@ -202,6 +249,86 @@ func (e *DockerExecutor) CloneRepository(ctx context.Context, bc *BuildContext,
 	return workspace, nil
 }
 
+// checkoutCommit checks out the commit of the build. A shallow clone may not
+// contain it yet, in which case it is fetched first.
+func checkoutCommit(ctx context.Context, workspace string, env []string, sha string, depthArgs []string, logCb LogCallback) error {
+	if head, err := gitOutput(ctx, workspace, env, "rev-parse", "HEAD"); err == nil && strings.HasPrefix(head, sha) {
+		return nil
+	}
+
+	logCb(fmt.Sprintf("[clone] Checking out commit %s", sha))
+	if err := runGit(ctx, workspace, env, logCb, "checkout", "-q", sha); err == nil {
+		return nil
+	}
+
+	args := append([]string{"fetch"}, depthArgs...)
+	args = append(args, "origin", sha)
+	if err := runGit(ctx, workspace, env, logCb, args...); err != nil {
+		return fmt.Errorf("fetch commit %s: %w", sha, err)
+	}
+	if err := runGit(ctx, workspace, env, logCb, "checkout", "-q", sha); err != nil {
+		return fmt.Errorf("checkout commit %s: %w", sha, err)
+	}
+	return nil
+}
+
+// BuildDir returns the directory of the cloned workspace to build, which is
+// bc.RootDir for monorepo projects. Symlinks are resolved so the directory cannot
+// point outside the clone.
+func (e *DockerExecutor) BuildDir(bc *BuildContext, workspace string) (string, error) {
+	if bc.RootDir == "" {
+		return workspace, nil
+	}
+
+	root, err := filepath.EvalSymlinks(workspace)
+	if err != nil {
+		return "", fmt.Errorf("resolve workspace: %w", err)
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(bc.RootDir)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", userErrorf("root directory %q not found in repository", bc.RootDir)
+		}
+		return "", fmt.Errorf("resolve root directory: %w", err)
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", userErrorf("root directory %q is outside the repository", bc.RootDir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", userErrorf("root directory %q is not a directory", bc.RootDir)
+	}
+	return dir, nil
+}
+
+// PrepareCache resolves the dependency cache volumes for the cloned workspace and
+// stores them in bc.CacheMounts
+func (e *DockerExecutor) PrepareCache(ctx context.Context, bc *BuildContext, workspace string, logCb LogCallback) {
+	if e.cache == nil {
+		return
+	}
+	bc.CacheMounts = e.cache.Mounts(ctx, bc.ProjectID, bc.Preset, bc.Variant, workspace, logCb)
+}
+
+// EvictCache removes expired cache volumes and enforces the cache size limit
+func (e *DockerExecutor) EvictCache(ctx context.Context) error {
+	if e.cache == nil {
+		return nil
+	}
+	return e.cache.Evict(ctx)
+}
+
+// lockCache waits until no other build or step of this runner has the cache
+// volumes of the build mounted. The returned func releases them.
+func (e *DockerExecutor) lockCache(ctx context.Context, bc *BuildContext, logCb LogCallback) (func(), error) {
+	if e.cache == nil || len(bc.CacheMounts) == 0 {
+		return func() {}, nil
+	}
+	return e.cache.Lock(ctx, bc.CacheMounts, func() {
+		logCb("[cache] Waiting for another build or step using the same cache volumes...")
+	})
+}
+
 // RunBuildCommand executes the build command in a container
 func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext, workspace string, logCb LogCallback) error {
 	if bc.BuildCommand == "" {
@@ -237,7 +364,7 @@ func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext,
 	logCb(fmt.Sprintf("[build] Mounting workspace: %s -> /app", workspace))
 
 	// Get base image based on preset
-	baseImage := e.getBaseImage(bc.Preset)
+	baseImage := e.getBaseImage(bc)
 	logCb(fmt.Sprintf("[build] Using base image: %s", baseImage))
 
 	// Pull the base image
@@ -249,33 +376,42 @@ func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext,
 	reader.Close()
 
 	// Build environment variables
-	envVars := make([]string, 0, len(bc.Secrets)+2)
+	envVars := make([]string, 0, len(bc.Secrets)+len(bc.Env)+2)
 	for k, v := range bc.Secrets {
 		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
 	}
+	for k, v := range bc.Env {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
 
-	// Add Node.js memory limit for Node.js projects
+	// Size the Node.js heap to the memory limit of the build
 	if strings.ToLower(bc.Preset) == "nodejs" || strings.ToLower(bc.Preset) == "node" {
-		envVars = append(envVars, "NODE_OPTIONS=--max-old-space-size=4096")
+		envVars = append(envVars, fmt.Sprintf("NODE_OPTIONS=--max-old-space-size=%d", bc.Resources.nodeHeapMB()))
+	}
+
+	logCb(fmt.Sprintf("[build] Limits: %d MB memory, %g CPUs", bc.Resources.Memory(), bc.Resources.CPU()))
+
+	unlock, err := e.lockCache(ctx, bc, logCb)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
 	// Create container and copy workspace files
 	// Since workspace is in a named volume, we need to copy files into the container
-	resp, err := e.client.ContainerCreate(ctx,
+	resp, err := e.createContainer(ctx, bc,
 		&container.Config{
 			Image:      baseImage,
 			Cmd:        []string{"sh", "-c", "sleep infinity"}, // Keep running to copy files
 			WorkingDir: "/app",
 			Env:        envVars,
+			Labels:     map[string]string{"nexus.build_id": bc.BuildID},
 		},
 		&container.HostConfig{
-			Resources: container.Resources{
-				Memory:   4 * 1024 * 1024 * 1024, // 4GB (increased for Node.js builds)
-				NanoCPUs: 1000000000,             // 1 CPU
-			},
+			Mounts: bc.CacheMounts,
 		},
-		nil, nil,
 		fmt.Sprintf("nexus-build-%s", bc.BuildID),
+		logCb,
 	)
 	if err != nil {
 		return fmt.Errorf("create container: %w", err)
@@ -353,8 +489,14 @@ func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext,
 		}
 
 		if execInspect.ExitCode != 0 {
+			// The container keeps running when an exec process is OOM killed, so
+			// OOMKilled is not set; SIGKILL is the only sign of it
+			oom := execInspect.ExitCode == exitCodeKilled && ctx.Err() == nil
 			e.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
-			return fmt.Errorf("install dependencies failed with exit code %d", execInspect.ExitCode)
+			if oom {
+				return bc.Resources.oomError("install dependencies")
+			}
+			return userErrorf("install dependencies failed with exit code %d", execInspect.ExitCode)
 		}
 		logCb("[build] Dependencies installed successfully")
 	}
@@ -383,21 +525,19 @@ func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext,
 	e.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
 
 	// Create final container from committed image
-	resp, err = e.client.ContainerCreate(ctx,
+	resp, err = e.createContainer(ctx, bc,
 		&container.Config{
 			Image:      commitResp.ID,
 			Cmd:        []string{"sh", "-c", bc.BuildCommand},
 			WorkingDir: "/app",
 			Env:        envVars,
+			Labels:     map[string]string{"nexus.build_id": bc.BuildID},
 		},
 		&container.HostConfig{
-			Resources: container.Resources{
-				Memory:   512 * 1024 * 1024,
-				NanoCPUs: 1000000000,
-			},
+			Mounts: bc.CacheMounts, // Volumes are not part of the committed image
 		},
-		nil, nil,
 		fmt.Sprintf("nexus-build-%s", bc.BuildID),
+		logCb,
 	)
 	if err != nil {
 		return fmt.Errorf("create build container: %w", err)
@@ -414,8 +554,9 @@ func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext,
 		return fmt.Errorf("start container: %w", err)
 	}
 
-	// Stream logs
-	go e.streamContainerLogs(ctx, containerID, logCb)
+	// Stream logs, the deferred wait runs before the container is removed
+	waitLogs := e.streamLogs(ctx, containerID, logCb)
+	defer waitLogs()
 
 	// Wait for container to finish
 	statusCh, errCh := e.client.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
@@ -426,7 +567,10 @@ func (e *DockerExecutor) RunBuildCommand(ctx context.Context, bc *BuildContext,
 		}
 	case status := <-statusCh:
 		if status.StatusCode != 0 {
-			return fmt.Errorf("build failed with exit code %d", status.StatusCode)
+			if e.oomKilled(containerID) {
+				return bc.Resources.oomError("build command")
+			}
+			return userErrorf("build failed with exit code %d", status.StatusCode)
 		}
 	case <-ctx.Done():
 		return ctx.Err()
@@ -516,7 +660,7 @@ func (e *DockerExecutor) getInstallCommand(preset string, workspace string) stri
 	case "python":
 		// Check if requirements.txt exists
 		if _, err := os.Stat(filepath.Join(workspace, "requirements.txt")); err == nil {
-			return "pip install --no-cache-dir -r requirements.txt"
+			return "pip install -r requirements.txt"
 		}
 		// Check if pyproject.toml exists (for poetry)
 		if _, err := os.Stat(filepath.Join(workspace, "pyproject.toml")); err == nil {
@@ -561,18 +705,57 @@ func (e *DockerExecutor) BuildDockerImage(ctx context.Context, bc *BuildContext,
 		}
 	}
 
-	// Build using docker build command (simpler than Docker SDK for buildkit)
-	args := []string{"build", "-t", imageTag, workspace}
-	cmd := exec.CommandContext(ctx, "docker", args...)
+	buildCtx, err := buildContextTar(workspace)
+	if err != nil {
+		return "", fmt.Errorf("create build context: %w", err)
+	}
+	defer buildCtx.Close()
+
+	opts := types.ImageBuildOptions{
+		Tags:        []string{imageTag},
+		Dockerfile:  "Dockerfile",
+		Remove:      true,
+		ForceRemove: true,
+		Version:     types.BuilderBuildKit,
+		BuildID:     bc.BuildID,
+		Labels: map[string]string{
+			"nexus.project_id": bc.ProjectID,
+			"nexus.build_id":   bc.BuildID,
+		},
+	}
 
-	output, err := cmd.CombinedOutput()
-	for _, line := range strings.Split(string(output), "\n") {
-		if line != "" {
-			logCb(fmt.Sprintf("[docker] %s", line))
+	// Reuse layers from the previous build of the project. Only inline cache is
+	// supported: the BuildKit of the daemon cannot export a registry cache
+	// (--cache-to), which needs a buildx builder. The cache only holds the layers of
+	// the final stage and is pushed as an extra tag of the image.
+	if e.registryURL != "" {
+		cacheRef := cacheImageRef(imageTag)
+		inlineCache := "1"
+		opts.Tags = append(opts.Tags, cacheRef)
+		opts.CacheFrom = []string{cacheRef}
+		opts.BuildArgs = map[string]*string{"BUILDKIT_INLINE_CACHE": &inlineCache}
+		if e.registryUser != "" && e.registryPass != "" {
+			opts.AuthConfigs = map[string]registry.AuthConfig{
+				e.registryURL: e.registryAuth(),
+			}
 		}
+		logCb(fmt.Sprintf("[docker] Using build cache: %s", cacheRef))
 	}
+
+	resp, err := e.client.ImageBuild(ctx, buildCtx, opts)
 	if err != nil {
-		return "", fmt.Errorf("docker build: %w", err)
+		return "", fmt.Errorf("image build: %w", err)
+	}
+	defer resp.Body.Close()
+
+	progress := newBuildProgress(func(line string) {
+		logCb("[docker] " + line)
+	})
+	if err := progress.Read(resp.Body); err != nil {
+		return "", fmt.Errorf("image build: %w", err)
+	}
+	if progress.imageID != "" {
+		logCb(fmt.Sprintf("[docker] Image ID: %s", progress.imageID))
 	}
 
 	logCb(fmt.Sprintf("[docker] Image built successfully: %s", imageTag))
@@ -623,9 +806,52 @@ func (e *DockerExecutor) PushImage(ctx context.Context, imageTag string, logCb L
 	}
 
 	logCb("[push] Image pushed successfully")
+
+	// The cache tag shares its layers with the image, so this only uploads a manifest
+	cacheRef := cacheImageRef(imageTag)
+	if err := e.pushRef(ctx, cacheRef); err != nil {
+		logCb(fmt.Sprintf("[push] Warning: failed to push build cache %s: %v", cacheRef, err))
+	}
 	return nil
 }
 
+// registryAuth returns the credentials of the configured registry
+func (e *DockerExecutor) registryAuth() registry.AuthConfig {
+	return registry.AuthConfig{
+		Username:      e.registryUser,
+		Password:      e.registryPass,
+		ServerAddress: e.registryURL,
+	}
+}
+
+// pushRef pushes a tag through the Docker API with the registry credentials and
+// waits for the push to finish
+func (e *DockerExecutor) pushRef(ctx context.Context, ref string) error {
+	auth, err := registry.EncodeAuthConfig(e.registryAuth())
+	if err != nil {
+		return fmt.Errorf("encode registry auth: %w", err)
+	}
+	reader, err := e.client.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	dec := json.NewDecoder(reader)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read push output: %w", err)
+		}
+		if msg.Error != nil {
+			return errors.New(msg.Error.Message)
+		}
+	}
+}
+
 // Cleanup removes the workspace directory
 func (e *DockerExecutor) Cleanup(workspace string) error {
 	return os.RemoveAll(workspace)
@@ -652,6 +878,60 @@ func (e *DockerExecutor) CleanupWorkspaces(buildIDs []string) error {
 	return nil
 }
 
+// AbortBuild stops everything a build may still have running on the Docker host:
+// the image build, and the install, build, test and step containers. It is used
+// after the build context was cancelled, so it does not take one.
+func (e *DockerExecutor) AbortBuild(buildID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Only BuildKit builds can be cancelled, an unknown ID is not an error worth reporting
+	e.client.BuildCancel(ctx, buildID)
+
+	containers, err := e.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "nexus.build_id="+buildID)),
+	})
+	if err != nil {
+		e.log.Warn().Err(err).Str("build_id", buildID).Msg("Failed to list build containers")
+	}
+	for _, c := range containers {
+		if err := e.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true}); err != nil {
+			e.log.Warn().Err(err).Str("build_id", buildID).Str("container_id", c.ID).Msg("Failed to remove build container")
+		}
+	}
+
+	tempImageTag := fmt.Sprintf("nexus-build-temp-%s:latest", buildID)
+	if _, err := e.client.ImageRemove(ctx, tempImageTag, image.RemoveOptions{Force: true}); err != nil && !client.IsErrNotFound(err) {
+		e.log.Warn().Err(err).Str("build_id", buildID).Msg("Failed to remove temporary build image")
+	}
+
+	e.log.Info().
+		Str("build_id", buildID).
+		Int("containers_removed", len(containers)).
+		Msg("Build aborted")
+}
+
+// logDrainTimeout bounds the wait for the log stream of a container that exited
+const logDrainTimeout = 10 * time.Second
+
+// streamLogs streams the logs of a container in the background. The returned func
+// waits until the stream has ended, so the last lines are not lost when the
+// container is removed right after it exited.
+func (e *DockerExecutor) streamLogs(ctx context.Context, containerID string, logCb LogCallback) func() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.streamContainerLogs(ctx, containerID, logCb)
+	}()
+	return func() {
+		select {
+		case <-done:
+		case <-time.After(logDrainTimeout):
+		}
+	}
+}
+
 // streamContainerLogs streams container logs to the callback
 func (e *DockerExecutor) streamContainerLogs(ctx context.Context, containerID string, logCb LogCallback) {
 	reader, err := e.client.ContainerLogs(ctx, containerID, container.LogsOptions{
@@ -685,9 +965,13 @@ func (e *DockerExecutor) streamContainerLogs(ctx context.Context, containerID st
 	}
 }
 
-// getBaseImage returns the base image for a preset
-func (e *DockerExecutor) getBaseImage(preset string) string {
-	switch strings.ToLower(preset) {
+// getBaseImage returns the base image of a build: the image of its matrix variant,
+// or the one of its preset
+func (e *DockerExecutor) getBaseImage(bc *BuildContext) string {
+	if bc.BaseImage != "" {
+		return bc.BaseImage
+	}
+	switch strings.ToLower(bc.Preset) {
 	case "nodejs", "node":
 		return "node:20-alpine"
 	case "go", "golang":
@@ -726,9 +1010,18 @@ func (e *DockerExecutor) getImageTag(bc *BuildContext) string {
 	return fmt.Sprintf("nexus/%s:%s", bc.ProjectID, tag)
 }
 
+// cacheImageRef returns the registry tag holding the inline build cache of an image
+func cacheImageRef(imageTag string) string {
+	repo := imageTag
+	if i := strings.LastIndex(imageTag, ":"); i > strings.LastIndex(imageTag, "/") {
+		repo = imageTag[:i]
+	}
+	return repo + ":buildcache"
+}
+
 // generateDockerfile creates a Dockerfile based on preset
 func (e *DockerExecutor) generateDockerfile(bc *BuildContext) string {
-	baseImage := e.getBaseImage(bc.Preset)
+	baseImage := e.getBaseImage(bc)
 	preset := strings.ToLower(bc.Preset)
 	port := bc.Port
 	if port == 0 {
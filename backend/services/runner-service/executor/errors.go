@@ -0,0 +1,35 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UserError is a build failure caused by the project rather than the runner: a
+// command that exited non-zero, a Dockerfile that does not build, a missing root
+// directory. Running the build again gives the same result, so it is not retried.
+// Any other error, e.g. pulling an image, creating a container or reaching the
+// Docker daemon, is an infrastructure error.
+type UserError struct {
+	Err error
+}
+
+func (e *UserError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
+// userErrorf formats a UserError
+func userErrorf(format string, args ...any) error {
+	return &UserError{Err: fmt.Errorf(format, args...)}
+}
+
+// IsUserError reports whether a build failed because of the project. Builds killed
+// for reaching their memory limit are user errors too, the project has to raise it.
+func IsUserError(err error) bool {
+	var userErr *UserError
+	return errors.As(err, &userErr) || errors.Is(err, ErrOutOfMemory)
+}
@@ -0,0 +1,219 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DefaultCloneDepth is the history fetched when a project does not set a depth
+const DefaultCloneDepth = 1
+
+// CloneOptions control how CloneRepository fetches the repository
+type CloneOptions struct {
+	Depth       int  // Commits of history to fetch, 0 = DefaultCloneDepth
+	FullHistory bool // Fetch the whole history, Depth is ignored
+	Submodules  bool // Initialize submodules recursively
+	LFS         bool // Download Git LFS objects
+	Tags        bool // Fetch all tags, e.g. for git describe
+}
+
+// depthArgs returns the --depth flag for shallow clones and fetches
+func (o CloneOptions) depthArgs() []string {
+	if o.FullHistory {
+		return nil
+	}
+	depth := o.Depth
+	if depth <= 0 {
+		depth = DefaultCloneDepth
+	}
+	return []string{"--depth", strconv.Itoa(depth)}
+}
+
+// askPassScript answers git's credential prompts. The token is read from the
+// environment of the git process, so it never appears in a command line, a URL
+// or a file.
+const askPassScript = `#!/bin/sh
+case "$1" in
+Username*) echo x-access-token ;;
+*) echo "$NEXUS_GIT_TOKEN" ;;
+esac
+`
+
+// gitAuth is the environment of the git commands of one clone. Close removes the
+// askpass script; the token is only reachable while the clone runs.
+type gitAuth struct {
+	env     []string
+	askPass string
+}
+
+// newGitAuth prepares the git environment. GitHub SSH URLs, e.g. of submodules,
+// are rewritten to HTTPS so the token applies to them as well. Stored credential
+// helpers are disabled so the token is never persisted. LFS files are not
+// downloaded during checkout; CloneRepository pulls them explicitly when the
+// project asks for them.
+func newGitAuth(token string) (*gitAuth, error) {
+	auth := &gitAuth{
+		env: append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1"),
+	}
+
+	config := [][2]string{
+		{"credential.helper", ""},
+		{"url.https://github.com/.insteadOf", "git@github.com:"},
+		{"url.https://github.com/.insteadOf", "ssh://git@github.com/"},
+	}
+	auth.env = append(auth.env, "GIT_CONFIG_COUNT="+strconv.Itoa(len(config)))
+	for i, kv := range config {
+		auth.env = append(auth.env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, kv[0]),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, kv[1]),
+		)
+	}
+
+	if token == "" {
+		return auth, nil
+	}
+
+	f, err := os.CreateTemp("", "nexus-askpass-*")
+	if err != nil {
+		return nil, fmt.Errorf("create askpass script: %w", err)
+	}
+	auth.askPass = f.Name()
+	_, err = f.WriteString(askPassScript)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(auth.askPass, 0700)
+	}
+	if err != nil {
+		auth.Close()
+		return nil, fmt.Errorf("write askpass script: %w", err)
+	}
+
+	auth.env = append(auth.env, "GIT_ASKPASS="+auth.askPass, "NEXUS_GIT_TOKEN="+token)
+	return auth, nil
+}
+
+// Close removes the askpass script
+func (a *gitAuth) Close() {
+	if a.askPass != "" {
+		os.Remove(a.askPass)
+	}
+}
+
+// redactURL removes credentials embedded in a repository URL, for logging
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	u.User = nil
+	return u.String()
+}
+
+// gitUserErrors are the messages of git failures caused by the project: a ref
+// that does not exist or a repository the token cannot read. Network and other
+// failures are infrastructure errors and retried.
+var gitUserErrors = []string{
+	"not found in upstream origin", // clone --branch of a deleted branch
+	"couldn't find remote ref",     // fetch of a deleted branch
+	"not our ref",                  // fetch of an unreachable commit
+	"unknown revision",             // checkout of a missing commit
+	"did not match any file(s) known to git",
+	"repository not found",
+	"authentication failed",
+	"could not read username", // private repository without a token
+	"invalid username or password",
+	"the requested url returned error: 401",
+	"the requested url returned error: 403",
+	"the requested url returned error: 404",
+	"permission denied (publickey)",
+}
+
+// isGitUserError reports whether a line of git output is one of gitUserErrors
+func isGitUserError(line string) bool {
+	line = strings.ToLower(line)
+	for _, msg := range gitUserErrors {
+		if strings.Contains(line, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// runGit runs a git command in dir and streams its output through logCb as it is
+// written. A non-zero exit caused by the project is returned as a UserError.
+func runGit(ctx context.Context, dir string, env []string, logCb LogCallback, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	var userErr string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		scanner.Split(scanOutputLines)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				logCb("[clone] " + line)
+				if userErr == "" && isGitUserError(line) {
+					userErr = line
+				}
+			}
+		}
+		io.Copy(io.Discard, pr) // Keep git from blocking if the scanner gave up
+	}()
+
+	err := cmd.Run()
+	pw.Close()
+	<-done
+	if err != nil {
+		var exitErr *exec.ExitError
+		if userErr != "" && errors.As(err, &exitErr) && ctx.Err() == nil {
+			return userErrorf("git %s: %s: %w", args[0], userErr, err)
+		}
+		return fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// gitOutput runs a git command and returns its trimmed standard output
+func gitOutput(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// scanOutputLines splits on "\n" and on the "\r" git uses to redraw progress lines
+func scanOutputLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
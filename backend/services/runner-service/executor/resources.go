@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// Defaults used when a job carries no resource limits, e.g. jobs queued before
+// projects had build settings. Must match build-service/queue/producer.go
+const (
+	DefaultMemoryMB = 4096
+	DefaultCPUs     = 1.0
+)
+
+// exitCodeKilled is the exit code of a process stopped by SIGKILL, which is what
+// the kernel OOM killer sends
+const exitCodeKilled = 137
+
+// ErrOutOfMemory is wrapped by the errors of build containers killed for reaching
+// their memory limit
+var ErrOutOfMemory = errors.New("out of memory")
+
+// Resources are the limits applied to every container of a build
+type Resources struct {
+	MemoryMB int
+	CPUs     float64
+	DiskMB   int // Size of the container writable layer, 0 = no quota
+}
+
+// Memory returns the memory limit in MB
+func (r Resources) Memory() int {
+	if r.MemoryMB <= 0 {
+		return DefaultMemoryMB
+	}
+	return r.MemoryMB
+}
+
+// CPU returns the CPU limit in cores
+func (r Resources) CPU() float64 {
+	if r.CPUs <= 0 {
+		return DefaultCPUs
+	}
+	return r.CPUs
+}
+
+// nodeHeapMB is the V8 heap size for Node.js builds. A quarter of the limit is left
+// for native memory, so a large build fails with a heap error instead of an OOM kill.
+func (r Resources) nodeHeapMB() int {
+	return r.Memory() * 3 / 4
+}
+
+func (r Resources) containerResources() container.Resources {
+	memory := int64(r.Memory()) * 1024 * 1024
+	return container.Resources{
+		Memory:     memory,
+		MemorySwap: memory, // No swap on top of the limit
+		NanoCPUs:   int64(r.CPU() * 1e9),
+	}
+}
+
+func (r Resources) oomError(what string) error {
+	return fmt.Errorf("%w: %s was killed after reaching the %d MB memory limit, raise the project's build memory", ErrOutOfMemory, what, r.Memory())
+}
+
+// createContainer creates a build container with the resource limits of the build.
+// The disk quota needs a storage driver that supports it (overlay2 on xfs with
+// pquota); when the daemon rejects it, containers are created without a quota.
+func (e *DockerExecutor) createContainer(ctx context.Context, bc *BuildContext, cfg *container.Config, hostCfg *container.HostConfig, name string, logCb LogCallback) (container.CreateResponse, error) {
+	hostCfg.Resources = bc.Resources.containerResources()
+	if bc.Resources.DiskMB > 0 && !e.noDiskQuota.Load() {
+		hostCfg.StorageOpt = map[string]string{"size": fmt.Sprintf("%dM", bc.Resources.DiskMB)}
+	}
+
+	resp, err := e.client.ContainerCreate(ctx, cfg, hostCfg, nil, nil, name)
+	if err != nil && hostCfg.StorageOpt != nil && strings.Contains(err.Error(), "storage-opt") {
+		e.noDiskQuota.Store(true)
+		logCb(fmt.Sprintf("[build] Warning: the Docker storage driver does not support disk quotas, running without the %d MB limit", bc.Resources.DiskMB))
+		hostCfg.StorageOpt = nil
+		resp, err = e.client.ContainerCreate(ctx, cfg, hostCfg, nil, nil, name)
+	}
+	return resp, err
+}
+
+// oomKilled reports whether a stopped container was killed by the OOM killer
+func (e *DockerExecutor) oomKilled(containerID string) bool {
+	info, err := e.client.ContainerInspect(context.Background(), containerID)
+	return err == nil && info.State != nil && info.State.OOMKilled
+}
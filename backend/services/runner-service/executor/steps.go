@@ -0,0 +1,269 @@
+package executor
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/nexusdeploy/backend/services/runner-service/pipeline"
+)
+
+// stepHelperImage is used for short-lived containers that move files in and out of the step volume
+const stepHelperImage = "alpine:latest"
+
+// PrepareStepVolume creates the Docker volume shared by all pipeline steps of a build
+// and seeds it with the cloned workspace. Steps mount it at /app, so files written by
+// one step (node_modules, build output...) are visible to the next.
+func (e *DockerExecutor) PrepareStepVolume(ctx context.Context, bc *BuildContext, workspace string, logCb LogCallback) (string, error) {
+	volumeName := fmt.Sprintf("nexus-build-%s-workspace", bc.BuildID)
+
+	if _, err := e.client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   volumeName,
+		Labels: map[string]string{"nexus.build_id": bc.BuildID},
+	}); err != nil {
+		return "", fmt.Errorf("create step volume: %w", err)
+	}
+
+	containerID, err := e.createHelperContainer(ctx, volumeName)
+	if err != nil {
+		e.RemoveStepVolume(volumeName)
+		return "", err
+	}
+	defer e.client.ContainerRemove(context.Background(), containerID, container.RemoveOptions{Force: true})
+
+	if err := e.copyWorkspaceToContainer(ctx, containerID, workspace, func(string) {}); err != nil {
+		e.RemoveStepVolume(volumeName)
+		return "", fmt.Errorf("seed step volume: %w", err)
+	}
+
+	logCb("[pipeline] Workspace copied to shared step volume")
+	return volumeName, nil
+}
+
+// SyncStepVolume copies the step volume back into the workspace so that the image
+// build sees the files produced by the pipeline steps
+func (e *DockerExecutor) SyncStepVolume(ctx context.Context, volumeName, workspace string, logCb LogCallback) error {
+	containerID, err := e.createHelperContainer(ctx, volumeName)
+	if err != nil {
+		return err
+	}
+	defer e.client.ContainerRemove(context.Background(), containerID, container.RemoveOptions{Force: true})
+
+	reader, _, err := e.client.CopyFromContainer(ctx, containerID, "/app")
+	if err != nil {
+		return fmt.Errorf("copy from step volume: %w", err)
+	}
+	defer reader.Close()
+
+	if err := extractTar(reader, workspace, "app"); err != nil {
+		return fmt.Errorf("extract step volume: %w", err)
+	}
+
+	logCb("[pipeline] Step outputs synced back to workspace")
+	return nil
+}
+
+// RemoveStepVolume deletes the shared step volume of a build
+func (e *DockerExecutor) RemoveStepVolume(volumeName string) {
+	if err := e.client.VolumeRemove(context.Background(), volumeName, true); err != nil {
+		e.log.Warn().Err(err).Str("volume", volumeName).Msg("Failed to remove step volume")
+	}
+}
+
+// RunStep runs a user-defined pipeline step in its own container with the step volume
+// mounted at /app. The step fails on the first command that exits non-zero.
+func (e *DockerExecutor) RunStep(ctx context.Context, bc *BuildContext, volumeName string, step *pipeline.Step, logCb LogCallback) error {
+	prefix := fmt.Sprintf("[%s] ", step.Name)
+
+	stepImage := step.Image
+	if stepImage == "" {
+		stepImage = e.getBaseImage(bc)
+	}
+	logCb(fmt.Sprintf("%sUsing image: %s", prefix, stepImage))
+
+	if err := e.pullImage(ctx, stepImage); err != nil {
+		return err
+	}
+
+	// Secrets and variant env first so that step env can override them
+	envVars := make([]string, 0, len(bc.Secrets)+len(bc.Env)+len(step.Env))
+	for k, v := range bc.Secrets {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+	for k, v := range bc.Env {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+	for k, v := range step.Env {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	for _, cmd := range step.Commands {
+		logCb(fmt.Sprintf("%s$ %s", prefix, cmd))
+	}
+	script := "set -e\n" + strings.Join(step.Commands, "\n")
+
+	timeout := step.TimeoutDuration()
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	unlock, err := e.lockCache(ctx, bc, logCb)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	resp, err := e.createContainer(stepCtx, bc,
+		&container.Config{
+			Image:      stepImage,
+			Cmd:        []string{"sh", "-c", script},
+			WorkingDir: "/app",
+			Env:        envVars,
+			Labels: map[string]string{
+				"nexus.build_id": bc.BuildID,
+				"nexus.step":     step.Name,
+			},
+		},
+		&container.HostConfig{
+			Mounts: append([]mount.Mount{
+				{Type: mount.TypeVolume, Source: volumeName, Target: "/app"},
+			}, bc.CacheMounts...),
+		},
+		fmt.Sprintf("nexus-build-%s-%s", bc.BuildID, step.Name),
+		logCb,
+	)
+	if err != nil {
+		return fmt.Errorf("create step container: %w", err)
+	}
+
+	containerID := resp.ID
+	defer e.client.ContainerRemove(context.Background(), containerID, container.RemoveOptions{Force: true})
+
+	if err := e.client.ContainerStart(stepCtx, containerID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("start step container: %w", err)
+	}
+
+	// The deferred wait runs before the container is removed
+	waitLogs := e.streamLogs(stepCtx, containerID, func(line string) {
+		logCb(prefix + line)
+	})
+	defer waitLogs()
+
+	statusCh, errCh := e.client.ContainerWait(stepCtx, containerID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		if err != nil {
+			if errors.Is(stepCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+				return userErrorf("timed out after %s", timeout)
+			}
+			return fmt.Errorf("container wait: %w", err)
+		}
+	case status := <-statusCh:
+		if status.StatusCode != 0 {
+			if e.oomKilled(containerID) {
+				return bc.Resources.oomError("step")
+			}
+			return userErrorf("exited with code %d", status.StatusCode)
+		}
+	case <-stepCtx.Done():
+		if errors.Is(stepCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return userErrorf("timed out after %s", timeout)
+		}
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// pullImage pulls an image and waits for the pull to finish
+func (e *DockerExecutor) pullImage(ctx context.Context, ref string) error {
+	reader, err := e.client.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("pull image %s: %w", ref, err)
+	}
+	defer reader.Close()
+	io.Copy(io.Discard, reader)
+	return nil
+}
+
+// createHelperContainer creates (without starting) a container with the step volume mounted at /app
+func (e *DockerExecutor) createHelperContainer(ctx context.Context, volumeName string) (string, error) {
+	if err := e.pullImage(ctx, stepHelperImage); err != nil {
+		return "", err
+	}
+
+	resp, err := e.client.ContainerCreate(ctx,
+		&container.Config{
+			Image: stepHelperImage,
+			Cmd:   []string{"true"},
+		},
+		&container.HostConfig{
+			Mounts: []mount.Mount{
+				{Type: mount.TypeVolume, Source: volumeName, Target: "/app"},
+			},
+		},
+		nil, nil, "",
+	)
+	if err != nil {
+		return "", fmt.Errorf("create helper container: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// extractTar extracts a tar stream into dest, stripping the given top-level directory
+func extractTar(r io.Reader, dest, stripDir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(filepath.Clean(header.Name), stripDir)
+		name = strings.TrimPrefix(name, string(filepath.Separator))
+		if name == "" || name == "." {
+			continue
+		}
+
+		target := filepath.Join(dest, name)
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(file, tr); err != nil {
+				file.Close()
+				return err
+			}
+			file.Close()
+		case tar.TypeSymlink:
+			os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
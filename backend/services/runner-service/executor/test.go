@@ -0,0 +1,195 @@
+package executor
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/nexusdeploy/backend/services/runner-service/testreport"
+)
+
+// maxReportSize skips XML files that are too large to be a test report we want to store
+const maxReportSize = 10 * 1024 * 1024
+
+// TestRun is the outcome of the project test command
+type TestRun struct {
+	ExitCode int
+	Output   []byte            // Raw stdout/stderr, used for `go test -json`
+	Reports  map[string][]byte // JUnit XML files found under /app after the run
+}
+
+// RunTestCommand runs the project's test command in a container. It reuses the image
+// committed by RunBuildCommand (dependencies already installed) when there is one.
+// A non-zero exit code is reported in TestRun, not as an error.
+func (e *DockerExecutor) RunTestCommand(ctx context.Context, bc *BuildContext, workspace string, logCb LogCallback) (*TestRun, error) {
+	logCb(fmt.Sprintf("[test] Running: %s", bc.TestCommand))
+
+	script := bc.TestCommand
+	testImage := fmt.Sprintf("nexus-build-temp-%s:latest", bc.BuildID)
+	needsWorkspace := false
+	if _, _, err := e.client.ImageInspectWithRaw(ctx, testImage); err != nil {
+		testImage = e.getBaseImage(bc)
+		if err := e.pullImage(ctx, testImage); err != nil {
+			return nil, err
+		}
+		if installCmd := e.getInstallCommand(bc.Preset, workspace); installCmd != "" {
+			logCb(fmt.Sprintf("[test] Installing dependencies: %s", installCmd))
+			script = installCmd + " && " + script
+		}
+		needsWorkspace = true
+	}
+	logCb(fmt.Sprintf("[test] Using image: %s", testImage))
+
+	envVars := make([]string, 0, len(bc.Secrets)+len(bc.Env)+1)
+	for k, v := range bc.Secrets {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+	for k, v := range bc.Env {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+	}
+	envVars = append(envVars, "CI=true")
+
+	unlock, err := e.lockCache(ctx, bc, logCb)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	resp, err := e.createContainer(ctx, bc,
+		&container.Config{
+			Image:      testImage,
+			Cmd:        []string{"sh", "-c", script},
+			WorkingDir: "/app",
+			Env:        envVars,
+			Labels:     map[string]string{"nexus.build_id": bc.BuildID},
+		},
+		&container.HostConfig{
+			Mounts: bc.CacheMounts,
+		},
+		fmt.Sprintf("nexus-build-%s-test", bc.BuildID),
+		logCb,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create test container: %w", err)
+	}
+
+	containerID := resp.ID
+	defer e.client.ContainerRemove(context.Background(), containerID, container.RemoveOptions{Force: true})
+
+	if needsWorkspace {
+		if err := e.copyWorkspaceToContainer(ctx, containerID, workspace, func(string) {}); err != nil {
+			return nil, fmt.Errorf("copy workspace to container: %w", err)
+		}
+	}
+
+	if err := e.client.ContainerStart(ctx, containerID, container.StartOptions{}); err != nil {
+		return nil, fmt.Errorf("start test container: %w", err)
+	}
+
+	// Show only the output text of `go test -json` events, raw lines otherwise
+	waitLogs := e.streamLogs(ctx, containerID, func(chunk string) {
+		for _, line := range strings.Split(chunk, "\n") {
+			if ev, ok := testreport.ParseGoTestEvent(strings.TrimSpace(line)); ok {
+				if ev.Action != "output" {
+					continue
+				}
+				line = ev.Output
+			}
+			if line = strings.TrimRight(line, "\r\n"); strings.TrimSpace(line) != "" {
+				logCb(line)
+			}
+		}
+	})
+	defer waitLogs()
+
+	run := &TestRun{}
+	statusCh, errCh := e.client.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return nil, fmt.Errorf("container wait: %w", err)
+		}
+	case status := <-statusCh:
+		run.ExitCode = int(status.StatusCode)
+		if run.ExitCode != 0 && e.oomKilled(containerID) {
+			return nil, bc.Resources.oomError("test command")
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	output, err := e.containerOutput(ctx, containerID)
+	if err != nil {
+		logCb(fmt.Sprintf("[test] Warning: failed to read test output: %v", err))
+	}
+	run.Output = output
+
+	reports, err := e.findJUnitReports(ctx, containerID)
+	if err != nil {
+		logCb(fmt.Sprintf("[test] Warning: failed to collect test reports: %v", err))
+	}
+	run.Reports = reports
+
+	return run, nil
+}
+
+// containerOutput returns the complete, demultiplexed output of a finished container
+func (e *DockerExecutor) containerOutput(ctx context.Context, containerID string) ([]byte, error) {
+	reader, err := e.client.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, reader); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// findJUnitReports reads JUnit XML files from /app of a (stopped) container,
+// skipping dependency directories
+func (e *DockerExecutor) findJUnitReports(ctx context.Context, containerID string) (map[string][]byte, error) {
+	reader, _, err := e.client.CopyFromContainer(ctx, containerID, "/app")
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	reports := make(map[string][]byte)
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return reports, nil
+		}
+		if err != nil {
+			return reports, err
+		}
+
+		name := header.Name
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(name, ".xml") || header.Size > maxReportSize {
+			continue
+		}
+		if strings.Contains(name, "/node_modules/") || strings.Contains(name, "/.git/") || strings.Contains(name, "/vendor/") {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return reports, err
+		}
+		if testreport.IsJUnit(data) {
+			reports[strings.TrimPrefix(name, "app/")] = data
+		}
+	}
+}
@@ -5,17 +5,22 @@ go 1.24.0
 require (
 	github.com/docker/docker v27.3.1+incompatible
 	github.com/hibiken/asynq v0.24.1
+	github.com/moby/patternmatcher v0.6.0
 	github.com/nexusdeploy/backend/pkg/config v0.0.0
 	github.com/nexusdeploy/backend/pkg/grpc v0.0.0
 	github.com/nexusdeploy/backend/pkg/logger v0.0.0
 	github.com/nexusdeploy/backend/services/build-service/proto v0.0.0
+	github.com/nexusdeploy/backend/services/deployment-service/proto v0.0.0
 	github.com/nexusdeploy/backend/services/project-service/proto v0.0.0
 	github.com/redis/go-redis/v9 v9.0.3
 	github.com/rs/zerolog v1.33.0
 	google.golang.org/grpc v1.77.0
+	google.golang.org/protobuf v1.36.10
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/Azure/go-ansiterm v0.0.0-20250102033503-faa5f7b0171c // indirect
 	github.com/Microsoft/go-winio v0.4.21 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/containerd/log v0.1.0 // indirect
@@ -50,9 +55,7 @@ require (
 	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	golang.org/x/time v0.0.0-20190308202827-9d24e82272b4 // indirect
-	google.golang.org/genproto/googleapis/api v0.0.0-20251202230838-ff82c1b0f217 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
-	google.golang.org/protobuf v1.36.10 // indirect
 	gotest.tools/v3 v3.5.2 // indirect
 )
 
@@ -61,5 +64,6 @@ replace (
 	github.com/nexusdeploy/backend/pkg/grpc => ../../pkg/grpc
 	github.com/nexusdeploy/backend/pkg/logger => ../../pkg/logger
 	github.com/nexusdeploy/backend/services/build-service/proto => ../build-service/proto
+	github.com/nexusdeploy/backend/services/deployment-service/proto => ../deployment-service/proto
 	github.com/nexusdeploy/backend/services/project-service/proto => ../project-service/proto
 )
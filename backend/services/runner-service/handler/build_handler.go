@@ -2,23 +2,30 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	buildpb "github.com/nexusdeploy/backend/services/build-service/proto"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
 	"github.com/nexusdeploy/backend/services/runner-service/clients"
 	"github.com/nexusdeploy/backend/services/runner-service/executor"
+	"github.com/nexusdeploy/backend/services/runner-service/pipeline"
 	"github.com/nexusdeploy/backend/services/runner-service/pubsub"
 	"github.com/nexusdeploy/backend/services/runner-service/queue"
+	"github.com/nexusdeploy/backend/services/runner-service/testreport"
 	"github.com/rs/zerolog"
 )
 
 // BuildHandler handles build jobs from the queue
 type BuildHandler struct {
-	clients   *clients.Clients
-	executor  *executor.DockerExecutor
-	publisher *pubsub.Publisher
-	log       zerolog.Logger
+	clients            *clients.Clients
+	executor           *executor.DockerExecutor
+	publisher          *pubsub.Publisher
+	maxStepConcurrency int // Upper bound for parallel nexus.yaml steps per build
+	log                zerolog.Logger
 }
 
 // NewBuildHandler creates a new build handler
@@ -26,13 +33,18 @@ func NewBuildHandler(
 	clients *clients.Clients,
 	executor *executor.DockerExecutor,
 	publisher *pubsub.Publisher,
+	maxStepConcurrency int,
 	log zerolog.Logger,
 ) *BuildHandler {
+	if maxStepConcurrency < 1 {
+		maxStepConcurrency = 1
+	}
 	return &BuildHandler{
-		clients:   clients,
-		executor:  executor,
-		publisher: publisher,
-		log:       log,
+		clients:            clients,
+		executor:           executor,
+		publisher:          publisher,
+		maxStepConcurrency: maxStepConcurrency,
+		log:                log,
 	}
 }
 
@@ -57,7 +69,13 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 	// Helper function to log and publish
 	logLine := func(line string) {
 		logCollector.Add(ctx, line)
-		h.log.Debug().Str("build_id", buildID).Msg(line)
+		h.log.Debug().Str("build_id", buildID).Msg(logCollector.Redact(line))
+	}
+
+	// The build may have been cancelled or superseded while the job was dequeued
+	if _, cancelled := h.isCancelled(buildID); cancelled {
+		h.log.Info().Str("build_id", buildID).Msg("Build was cancelled before it started, skipping")
+		return nil
 	}
 
 	// Notify build started
@@ -78,9 +96,29 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 		CommitSHA:    payload.CommitSHA,
 		BuildCommand: payload.BuildCommand,
 		StartCommand: payload.StartCommand,
+		TestCommand:  payload.TestCommand,
 		Preset:       payload.Preset,
 		Port:         payload.Port,
 		Secrets:      payload.Secrets,
+		RootDir:      payload.RootDir,
+		Clone: executor.CloneOptions{
+			Depth:       payload.CloneDepth,
+			FullHistory: payload.FullHistory,
+			Submodules:  payload.Submodules,
+			LFS:         payload.LFS,
+			Tags:        payload.FetchTags,
+		},
+		Resources: executor.Resources{
+			MemoryMB: payload.MemoryMB,
+			CPUs:     payload.CPUs,
+			DiskMB:   payload.DiskMB,
+		},
+		Variant:   payload.Variant,
+		BaseImage: payload.BaseImage,
+		Env:       payload.VariantEnv,
+	}
+	if bc.Variant != "" {
+		logLine(fmt.Sprintf("[setup] Building matrix variant %s", bc.Variant))
 	}
 
 	// Fetch project info from Project Service if missing
@@ -94,8 +132,8 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 			if bc.RepoURL == "" && project.RepoUrl != "" {
 				bc.RepoURL = project.RepoUrl
 			}
-			if bc.Branch == "" && project.Branch != "" {
-				bc.Branch = project.Branch
+			if bc.Branch == "" {
+				bc.Branch = projectBranch(project, payload.Environment)
 			}
 			if bc.Preset == "" && project.Preset != "" {
 				bc.Preset = project.Preset
@@ -109,13 +147,19 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 			if bc.StartCommand == "" && project.StartCommand != "" {
 				bc.StartCommand = project.StartCommand
 			}
+			if bc.TestCommand == "" && project.TestCommand != "" {
+				bc.TestCommand = project.TestCommand
+			}
+			if bc.RootDir == "" && project.RootDirectory != "" {
+				bc.RootDir = project.RootDirectory
+			}
 		}
 	}
 
 	// Fetch secrets from Project Service if not provided
 	if len(bc.Secrets) == 0 {
 		logLine("[setup] Fetching secrets from Project Service...")
-		secrets, err := h.clients.GetProjectSecrets(ctx, payload.ProjectID)
+		secrets, err := h.clients.GetProjectSecrets(ctx, payload.ProjectID, payload.Environment)
 		if err != nil {
 			logLine(fmt.Sprintf("[setup] Warning: Failed to fetch secrets: %v", err))
 			// Non-fatal, continue without secrets
@@ -125,8 +169,74 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 		}
 	}
 
+	// The token is fetched for every job and only lives in memory, so it is never
+	// stored in the queue; public repositories clone without one
+	if token, err := h.clients.GetRepoToken(ctx, payload.ProjectID); err != nil {
+		logLine(fmt.Sprintf("[setup] Warning: Failed to fetch repository token: %v", err))
+	} else {
+		bc.GitHubToken = token
+	}
+
+	// Build output may echo the token or secret values, e.g. from env or a failing
+	// command line; they are masked before logs are published or stored
+	masked := []string{bc.GitHubToken}
+	for name, value := range bc.Secrets {
+		masked = append(masked, value)
+		if v := strings.TrimSpace(value); v != "" && len(v) < pubsub.MinRedactLength {
+			h.log.Warn().Str("build_id", buildID).Str("secret", name).Msg("Secret is too short to be masked in build logs")
+			logLine(fmt.Sprintf("[setup] Warning: secret %s is shorter than %d characters and is not masked in the logs", name, pubsub.MinRedactLength))
+		}
+	}
+	logCollector.Mask(masked...)
+
 	// Execute build pipeline
-	result := h.executePipeline(ctx, bc, logLine)
+	result := h.executePipeline(ctx, bc, logLine, logCollector.Redact)
+
+	// CancelBuild, or a newer build of the branch, has already marked the build and
+	// asynq cancelled our context
+	if ctx.Err() != nil {
+		if status, cancelled := h.isCancelled(buildID); cancelled {
+			h.finishCancelled(buildID, status, result, logCollector)
+			// A superseded preview is reported by the build that superseded it
+			if payload.PullRequest > 0 && status == buildpb.BuildStatus_BUILD_STATUS_CANCELLED {
+				h.reportPreview(context.Background(), payload, nil, status, "Build cancelled")
+			}
+			return nil
+		}
+	}
+
+	// The job deadline is the project build timeout. The rest of the job reports
+	// the failure, which needs a context that is not already done.
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		h.executor.AbortBuild(buildID)
+		result.Success = false
+		result.Error = &executor.UserError{Err: fmt.Errorf("build timed out after %s", time.Since(startTime).Round(time.Minute))}
+		logLine(fmt.Sprintf("[timeout] %v, raise the project's build timeout if it needs longer", result.Error))
+	}
+
+	if errors.Is(result.Error, executor.ErrOutOfMemory) {
+		logLine(fmt.Sprintf("[oom] %v", result.Error))
+	}
+
+	// A build failed by the runner rather than the project, e.g. the Docker daemon
+	// restarting, goes back to pending while the queue has attempts left for it.
+	// Failures of the project are final, running them again gives the same result.
+	retry := !result.Success && !executor.IsUserError(result.Error) && queue.WillRetry(ctx)
+	if retry {
+		logLine(fmt.Sprintf("[retry] Build failed on an infrastructure error, it will be retried: %v", result.Error))
+	}
+
+	// Continuous deployment: the image of a successful build goes live right away.
+	// Pull requests always get a preview.
+	var deployment *deploymentpb.DeployResponse
+	var deployErr error
+	if result.Success && (payload.AutoDeploy || payload.PullRequest > 0) {
+		deployment, deployErr = h.deploy(ctx, bc, payload, result.ImageTag, logLine)
+	}
 
 	// Calculate duration
 	duration := time.Since(startTime)
@@ -141,20 +251,52 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 	var finalStatus buildpb.BuildStatus
 	var statusMessage string
 
-	if result.Success {
+	switch {
+	case errors.Is(deployErr, errDeploySkipped):
+		// The build itself succeeded, so its image can be deployed manually once
+		// the deploy policy allows it
+		finalStatus = buildpb.BuildStatus_BUILD_STATUS_SUCCESS
+		statusMessage = fmt.Sprintf("Build successful, image %s %v", result.ImageTag, deployErr)
+		h.publisher.PublishBuildCompleted(ctx, buildID, "success", statusMessage)
+	case deployErr != nil:
+		finalStatus = buildpb.BuildStatus_BUILD_STATUS_DEPLOY_FAILED
+		statusMessage = logCollector.Redact(fmt.Sprintf("Build successful, deploy failed: %v", deployErr))
+		h.publisher.PublishBuildCompleted(ctx, buildID, "deploy_failed", statusMessage)
+	case deployment != nil:
+		finalStatus = buildpb.BuildStatus_BUILD_STATUS_SUCCESS
+		statusMessage = fmt.Sprintf("Build successful, image %s deployed to %s", result.ImageTag, deployment.PublicUrl)
+		h.publisher.PublishBuildCompleted(ctx, buildID, "success", statusMessage)
+	case result.Success && bc.Variant != "":
+		finalStatus = buildpb.BuildStatus_BUILD_STATUS_SUCCESS
+		statusMessage = fmt.Sprintf("Build successful, variant %s passed", bc.Variant)
+		h.publisher.PublishBuildCompleted(ctx, buildID, "success", statusMessage)
+	case result.Success:
 		finalStatus = buildpb.BuildStatus_BUILD_STATUS_SUCCESS
 		statusMessage = fmt.Sprintf("Build successful, image: %s", result.ImageTag)
 		h.publisher.PublishBuildCompleted(ctx, buildID, "success", statusMessage)
-	} else {
+	case retry:
+		finalStatus = buildpb.BuildStatus_BUILD_STATUS_PENDING
+		statusMessage = logCollector.Redact(fmt.Sprintf("Build failed, retrying: %v", result.Error))
+	default:
 		finalStatus = buildpb.BuildStatus_BUILD_STATUS_FAILED
-		statusMessage = fmt.Sprintf("Build failed: %v", result.Error)
+		statusMessage = logCollector.Redact(fmt.Sprintf("Build failed: %v", result.Error))
 		h.publisher.PublishBuildCompleted(ctx, buildID, "failed", statusMessage)
 	}
 
-	if err := h.clients.UpdateBuildStatus(ctx, buildID, finalStatus, []string{statusMessage}); err != nil {
+	var err error
+	if deployment != nil {
+		err = h.clients.UpdateBuildDeployment(ctx, buildID, finalStatus, result.ImageTag, deployment.DeploymentId, []string{statusMessage})
+	} else {
+		err = h.clients.UpdateBuildStatus(ctx, buildID, finalStatus, []string{statusMessage})
+	}
+	if err != nil {
 		h.log.Error().Err(err).Msg("Failed to update final build status")
 	}
 
+	if payload.PullRequest > 0 && !retry {
+		h.reportPreview(ctx, payload, deployment, finalStatus, statusMessage)
+	}
+
 	// Cleanup workspace
 	if result.WorkDir != "" {
 		if err := h.executor.Cleanup(result.WorkDir); err != nil {
@@ -162,72 +304,395 @@ func (h *BuildHandler) HandleBuildJob(ctx context.Context, payload *queue.BuildJ
 		}
 	}
 
+	// Evict cache volumes in the background, the build result is already reported
+	go func() {
+		if err := h.executor.EvictCache(context.Background()); err != nil {
+			h.log.Warn().Err(err).Msg("Failed to evict dependency cache")
+		}
+	}()
+
 	if result.Success {
 		return nil
 	}
+	if executor.IsUserError(result.Error) {
+		return queue.SkipRetry(result.Error)
+	}
 	return result.Error
 }
 
-// executePipeline runs the full build pipeline
-func (h *BuildHandler) executePipeline(ctx context.Context, bc *executor.BuildContext, logLine func(string)) *executor.BuildResult {
+// errDeploySkipped is returned by deploy when the deploy policy of the project
+// does not allow an automatic deploy now
+var errDeploySkipped = errors.New("not deployed")
+
+// deploy deploys the image of a successful build of a project with auto deploy
+// enabled, or of a pull request as its preview. The build is moved to deploying;
+// the caller sets the final status. Builds the deploy policy blocks, or that it
+// cannot be checked for, are not moved and return errDeploySkipped.
+func (h *BuildHandler) deploy(ctx context.Context, bc *executor.BuildContext, payload *queue.BuildJobPayload, imageTag string, logLine func(string)) (*deploymentpb.DeployResponse, error) {
+	if payload.PullRequest > 0 {
+		logLine(fmt.Sprintf("[deploy] Deploying the preview of pull request #%d...", payload.PullRequest))
+	} else if payload.Environment != "" {
+		logLine(fmt.Sprintf("[deploy] Auto deploy is enabled for %s, deploying the new image...", payload.Environment))
+	} else {
+		logLine("[deploy] Auto deploy is enabled, deploying the new image...")
+	}
+
+	// Automatic deploys follow the deploy windows and freeze like manual ones,
+	// there is no override here
+	if payload.PullRequest == 0 {
+		if err := h.clients.CheckDeploy(ctx, bc.ProjectID, payload.UserID, payload.Environment); err != nil {
+			logLine(fmt.Sprintf("[deploy] Not deployed: %v", err))
+			h.setStepStatus(ctx, bc.BuildID, "deploy", "skipped", 0)
+			return nil, fmt.Errorf("%w: %v", errDeploySkipped, err)
+		}
+	}
+	if err := h.clients.UpdateBuildDeployment(ctx, bc.BuildID, buildpb.BuildStatus_BUILD_STATUS_DEPLOYING, imageTag, "", nil); err != nil {
+		h.log.Error().Err(err).Msg("Failed to update build status to Deploying")
+	}
+	h.setStepStatus(ctx, bc.BuildID, "deploy", "running", 0)
+	stepStart := time.Now()
+
+	// Same spec as a manual deploy from the API Gateway; the domain is generated
+	// by Deployment Service
+	resp, err := h.clients.Deploy(ctx, &deploymentpb.DeploymentSpec{
+		ProjectId: bc.ProjectID,
+		BuildId:   bc.BuildID,
+		ImageTag:  imageTag,
+		Port:      int32(bc.Port),
+		Secrets:   bc.Secrets,
+		Resources: deployResources(payload),
+		UserId:    payload.UserID,
+		HealthCheck: &deploymentpb.HealthCheck{
+			Type:            payload.HealthCheck.Type,
+			Path:            payload.HealthCheck.Path,
+			ExpectedStatus:  int32(payload.HealthCheck.ExpectedStatus),
+			IntervalSeconds: int32(payload.HealthCheck.IntervalSeconds),
+			TimeoutSeconds:  int32(payload.HealthCheck.TimeoutSeconds),
+			Retries:         int32(payload.HealthCheck.Retries),
+		},
+		Replicas:      int32(payload.Replicas),
+		CustomDomains: payload.CustomDomains,
+		PullRequest:   int32(payload.PullRequest),
+		Environment:   payload.Environment,
+	})
+	if err != nil {
+		logLine(fmt.Sprintf("[deploy] Deploy failed: %v", err))
+		h.setStepStatus(ctx, bc.BuildID, "deploy", "failed", time.Since(stepStart))
+		return nil, err
+	}
+
+	logLine(fmt.Sprintf("[deploy] Deployment %s is %s at %s", resp.DeploymentId, resp.Status, resp.PublicUrl))
+	h.setStepStatus(ctx, bc.BuildID, "deploy", "success", time.Since(stepStart))
+	return resp, nil
+}
+
+// projectBranch returns the branch built for an environment of a project, "" for
+// production
+func projectBranch(project *projectpb.Project, environment string) string {
+	if environment == "" {
+		return project.Branch
+	}
+	for _, env := range project.Environments {
+		if env.Name == environment {
+			return env.Branch
+		}
+	}
+	return ""
+}
+
+// deployResources returns the container resources of the deployment of a build,
+// those of its environment or else 512 MB and 1 CPU
+func deployResources(payload *queue.BuildJobPayload) *deploymentpb.ResourceLimits {
+	resources := &deploymentpb.ResourceLimits{
+		MemoryMb: 512,
+		CpuCores: 1,
+	}
+	if payload.DeployMemoryMB > 0 {
+		resources.MemoryMb = int64(payload.DeployMemoryMB)
+	}
+	if payload.DeployCPUCores > 0 {
+		resources.CpuCores = int32(payload.DeployCPUCores)
+	}
+	return resources
+}
+
+// isCancelled reports whether the build was cancelled or superseded through Build
+// Service, and which of the two. It does not use the job context, which is already
+// done after a cancellation.
+func (h *BuildHandler) isCancelled(buildID string) (buildpb.BuildStatus, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, err := h.clients.GetBuildStatus(ctx, buildID)
+	if err != nil {
+		h.log.Warn().Err(err).Str("build_id", buildID).Msg("Failed to get build status")
+		return status, false
+	}
+	return status, status == buildpb.BuildStatus_BUILD_STATUS_CANCELLED || status == buildpb.BuildStatus_BUILD_STATUS_SUPERSEDED
+}
+
+// finishCancelled removes what the cancelled build left behind. The build status
+// is not updated, Build Service set it when the build was cancelled.
+func (h *BuildHandler) finishCancelled(buildID string, status buildpb.BuildStatus, result *executor.BuildResult, logCollector *pubsub.LogCollector) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	h.log.Info().Str("build_id", buildID).Msg("Build cancelled, cleaning up")
+
+	h.executor.AbortBuild(buildID)
+	if result.WorkDir != "" {
+		if err := h.executor.Cleanup(result.WorkDir); err != nil {
+			h.log.Warn().Err(err).Str("workspace", result.WorkDir).Msg("Failed to cleanup workspace")
+		}
+	}
+
+	logCollector.Add(ctx, "[cancel] Build stopped, containers and workspace removed")
+	if err := logCollector.Flush(ctx); err != nil {
+		h.log.Error().Err(err).Msg("Failed to flush remaining build logs")
+	}
+	if status == buildpb.BuildStatus_BUILD_STATUS_SUPERSEDED {
+		h.publisher.PublishBuildCompleted(ctx, buildID, "superseded", "Build superseded by a newer build of the branch")
+		return
+	}
+	h.publisher.PublishBuildCompleted(ctx, buildID, "cancelled", "Build cancelled")
+}
+
+// executePipeline runs the full build pipeline. redact masks secrets in output
+// that is stored outside of the build log.
+func (h *BuildHandler) executePipeline(ctx context.Context, bc *executor.BuildContext, logLine func(string), redact func(string) string) *executor.BuildResult {
 	result := &executor.BuildResult{
 		Success: false,
 	}
 
 	// Step 1: Clone repository
-	logLine("[step 1/4] Cloning repository...")
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "clone", "running")
+	logLine("[step 1/5] Cloning repository...")
+	h.setStepStatus(ctx, bc.BuildID, "clone", "running", 0)
+	stepStart := time.Now()
 
 	workspace, err := h.executor.CloneRepository(ctx, bc, logLine)
 	if err != nil {
 		result.Error = fmt.Errorf("clone repository: %w", err)
-		h.publisher.PublishStepComplete(ctx, bc.BuildID, "clone", "failed")
+		h.setStepStatus(ctx, bc.BuildID, "clone", "failed", time.Since(stepStart))
 		return result
 	}
 	result.WorkDir = workspace
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "clone", "success")
 
-	// Step 2: Run build command
-	logLine("[step 2/4] Running build command...")
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "build", "running")
+	// Monorepo projects build a subdirectory of the clone
+	buildDir, err := h.executor.BuildDir(bc, workspace)
+	if err != nil {
+		logLine(fmt.Sprintf("[clone] Error: %v", err))
+		result.Error = err
+		h.setStepStatus(ctx, bc.BuildID, "clone", "failed", time.Since(stepStart))
+		return result
+	}
+	if bc.RootDir != "" {
+		logLine(fmt.Sprintf("[clone] Building from root directory %s", bc.RootDir))
+	}
+	h.setStepStatus(ctx, bc.BuildID, "clone", "success", time.Since(stepStart))
+
+	// Mount dependency caches keyed by the lockfiles of this commit
+	h.executor.PrepareCache(ctx, bc, buildDir, logLine)
 
-	if err := h.executor.RunBuildCommand(ctx, bc, workspace, logLine); err != nil {
-		result.Error = fmt.Errorf("build command: %w", err)
-		h.publisher.PublishStepComplete(ctx, bc.BuildID, "build", "failed")
+	// Step 2: Run the steps from nexus.yaml, or the project build command if the repo has none
+	p, err := pipeline.Load(buildDir)
+	if err != nil {
+		logLine(fmt.Sprintf("[pipeline] Invalid pipeline file: %v", err))
+		result.Error = &executor.UserError{Err: fmt.Errorf("load pipeline: %w", err)}
+		return result
+	}
+
+	if p != nil {
+		logLine(fmt.Sprintf("[step 2/5] Running %d steps from %s...", len(p.Steps), p.File))
+		if err := h.runPipelineSteps(ctx, bc, buildDir, p, logLine); err != nil {
+			result.Error = fmt.Errorf("pipeline: %w", err)
+			return result
+		}
+	} else {
+		logLine("[step 2/5] Running build command...")
+		h.setStepStatus(ctx, bc.BuildID, "build", "running", 0)
+		stepStart = time.Now()
+
+		if err := h.executor.RunBuildCommand(ctx, bc, buildDir, logLine); err != nil {
+			result.Error = fmt.Errorf("build command: %w", err)
+			h.setStepStatus(ctx, bc.BuildID, "build", "failed", time.Since(stepStart))
+			return result
+		}
+		h.setStepStatus(ctx, bc.BuildID, "build", "success", time.Since(stepStart))
+	}
+
+	// Step 3: Run tests, failing tests block the image build
+	if bc.TestCommand != "" {
+		logLine("[step 3/5] Running tests...")
+		h.setStepStatus(ctx, bc.BuildID, "test", "running", 0)
+		stepStart = time.Now()
+
+		if err := h.runTests(ctx, bc, buildDir, logLine, redact); err != nil {
+			result.Error = fmt.Errorf("test: %w", err)
+			h.setStepStatus(ctx, bc.BuildID, "test", "failed", time.Since(stepStart))
+			return result
+		}
+		h.setStepStatus(ctx, bc.BuildID, "test", "success", time.Since(stepStart))
+	} else {
+		logLine("[step 3/5] No test command specified, skipping tests")
+		h.setStepStatus(ctx, bc.BuildID, "test", "skipped", 0)
+	}
+
+	// Matrix variants only check that the project builds and passes its tests on
+	// their base image. The image is built and deployed by the job of their parent
+	// build once they passed.
+	if bc.Variant != "" {
+		logLine(fmt.Sprintf("[step 4/5] Matrix variant %s, skipping image build and push", bc.Variant))
+		result.Success = true
 		return result
 	}
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "build", "success")
 
 	// Update status to BuildingImage
 	h.clients.UpdateBuildStatus(ctx, bc.BuildID, buildpb.BuildStatus_BUILD_STATUS_BUILDING_IMAGE, nil)
 
-	// Step 3: Build Docker image
-	logLine("[step 3/4] Building Docker image...")
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "docker_build", "running")
+	// Step 4: Build Docker image
+	logLine("[step 4/5] Building Docker image...")
+	h.setStepStatus(ctx, bc.BuildID, "docker_build", "running", 0)
+	stepStart = time.Now()
 
-	imageTag, err := h.executor.BuildDockerImage(ctx, bc, workspace, logLine)
+	imageTag, err := h.executor.BuildDockerImage(ctx, bc, buildDir, logLine)
 	if err != nil {
 		result.Error = fmt.Errorf("build docker image: %w", err)
-		h.publisher.PublishStepComplete(ctx, bc.BuildID, "docker_build", "failed")
+		h.setStepStatus(ctx, bc.BuildID, "docker_build", "failed", time.Since(stepStart))
 		return result
 	}
 	result.ImageTag = imageTag
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "docker_build", "success")
+	h.setStepStatus(ctx, bc.BuildID, "docker_build", "success", time.Since(stepStart))
 
 	// Update status to PushingImage
 	h.clients.UpdateBuildStatus(ctx, bc.BuildID, buildpb.BuildStatus_BUILD_STATUS_PUSHING_IMAGE, nil)
 
-	// Step 4: Push image to registry
-	logLine("[step 4/4] Pushing image to registry...")
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "docker_push", "running")
+	// Step 5: Push image to registry
+	logLine("[step 5/5] Pushing image to registry...")
+	h.setStepStatus(ctx, bc.BuildID, "docker_push", "running", 0)
+	stepStart = time.Now()
 
 	if err := h.executor.PushImage(ctx, imageTag, logLine); err != nil {
 		result.Error = fmt.Errorf("push image: %w", err)
-		h.publisher.PublishStepComplete(ctx, bc.BuildID, "docker_push", "failed")
+		h.setStepStatus(ctx, bc.BuildID, "docker_push", "failed", time.Since(stepStart))
 		return result
 	}
-	h.publisher.PublishStepComplete(ctx, bc.BuildID, "docker_push", "success")
+	h.setStepStatus(ctx, bc.BuildID, "docker_push", "success", time.Since(stepStart))
 
 	result.Success = true
 	return result
 }
+
+// runPipelineSteps runs the steps declared in nexus.yaml, each in its own container
+// on a volume shared by all steps. Steps with depends_on are scheduled as a DAG with
+// bounded concurrency; the first failure cancels the steps still running. The
+// declared steps replace the built-in install/build steps; the project test command
+// still runs afterwards.
+func (h *BuildHandler) runPipelineSteps(ctx context.Context, bc *executor.BuildContext, workspace string, p *pipeline.Pipeline, logLine func(string)) error {
+	for _, name := range []string{"install", "build"} {
+		h.setStepStatus(ctx, bc.BuildID, name, "skipped", 0)
+	}
+	// Register all declared steps up front so the UI shows the whole pipeline
+	for _, step := range p.Steps {
+		if err := h.clients.UpdateBuildStep(ctx, bc.BuildID, step.Name, "pending", 0); err != nil {
+			h.log.Warn().Err(err).Str("build_id", bc.BuildID).Str("step", step.Name).Msg("Failed to register pipeline step")
+		}
+	}
+
+	volumeName, err := h.executor.PrepareStepVolume(ctx, bc, workspace, logLine)
+	if err != nil {
+		return err
+	}
+	defer h.executor.RemoveStepVolume(volumeName)
+
+	concurrency := 1
+	if p.IsDAG() {
+		concurrency = h.maxStepConcurrency
+		if p.Concurrency > 0 && p.Concurrency < concurrency {
+			concurrency = p.Concurrency
+		}
+		logLine(fmt.Sprintf("[pipeline] Running steps as a DAG, up to %d in parallel", concurrency))
+	}
+
+	runStep := func(stepCtx context.Context, step *pipeline.Step) error {
+		logLine(fmt.Sprintf("[pipeline] Starting step: %s", step.Name))
+		h.setStepStatus(ctx, bc.BuildID, step.Name, "running", 0)
+		stepStart := time.Now()
+
+		err := h.executor.RunStep(stepCtx, bc, volumeName, step, logLine)
+		switch {
+		case err == nil:
+			h.setStepStatus(ctx, bc.BuildID, step.Name, "success", time.Since(stepStart))
+		case stepCtx.Err() != nil && ctx.Err() == nil:
+			// Stopped because a sibling step failed
+			logLine(fmt.Sprintf("[%s] Cancelled", step.Name))
+			h.setStepStatus(ctx, bc.BuildID, step.Name, "skipped", time.Since(stepStart))
+		default:
+			logLine(fmt.Sprintf("[%s] Step failed: %v", step.Name, err))
+			h.setStepStatus(ctx, bc.BuildID, step.Name, "failed", time.Since(stepStart))
+		}
+		return err
+	}
+	skipStep := func(step *pipeline.Step) {
+		h.setStepStatus(ctx, bc.BuildID, step.Name, "skipped", 0)
+	}
+
+	if err := p.Run(ctx, concurrency, runStep, skipStep); err != nil {
+		return err
+	}
+
+	return h.executor.SyncStepVolume(ctx, volumeName, workspace, logLine)
+}
+
+// runTests runs the project test command and stores the parsed per-test results.
+// The step fails when the command exits non-zero or any reported test failed.
+// Test names and failure messages may echo secrets, so they are redacted like the log.
+func (h *BuildHandler) runTests(ctx context.Context, bc *executor.BuildContext, workspace string, logLine func(string), redact func(string) string) error {
+	run, err := h.executor.RunTestCommand(ctx, bc, workspace, logLine)
+	if err != nil {
+		return err
+	}
+
+	results, err := testreport.Collect(run.Reports, run.Output)
+	if err != nil {
+		logLine(fmt.Sprintf("[test] Warning: failed to parse test report: %v", err))
+	}
+
+	summary := testreport.Summarize(results)
+	if summary.Total > 0 {
+		logLine(fmt.Sprintf("[test] %d tests: %d passed, %d failed, %d skipped",
+			summary.Total, summary.Passed, summary.Failed, summary.Skipped))
+
+		cases := make([]*buildpb.TestCase, len(results))
+		for i, r := range results {
+			cases[i] = &buildpb.TestCase{
+				Suite:          redact(r.Suite),
+				Name:           redact(r.Name),
+				Status:         r.Status,
+				DurationMs:     int32(r.DurationMs),
+				FailureMessage: redact(r.FailureMessage),
+			}
+		}
+		if err := h.clients.ReportTestResults(ctx, bc.BuildID, cases); err != nil {
+			h.log.Warn().Err(err).Str("build_id", bc.BuildID).Msg("Failed to report test results")
+		}
+	} else {
+		logLine("[test] No JUnit XML or go test -json output found, only the exit code is used")
+	}
+
+	if run.ExitCode != 0 {
+		return &executor.UserError{Err: fmt.Errorf("test command failed with exit code %d", run.ExitCode)}
+	}
+	if summary.Failed > 0 {
+		return &executor.UserError{Err: fmt.Errorf("%d tests failed", summary.Failed)}
+	}
+	logLine("[test] All tests passed")
+	return nil
+}
+
+// setStepStatus publishes a step event and records the status on the build step row
+func (h *BuildHandler) setStepStatus(ctx context.Context, buildID, stepName, status string, duration time.Duration) {
+	h.publisher.PublishStepComplete(ctx, buildID, stepName, status)
+	if err := h.clients.UpdateBuildStep(ctx, buildID, stepName, status, duration); err != nil {
+		h.log.Warn().Err(err).Str("build_id", buildID).Str("step", stepName).Msg("Failed to update build step")
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	buildpb "github.com/nexusdeploy/backend/services/build-service/proto"
+	deploymentpb "github.com/nexusdeploy/backend/services/deployment-service/proto"
+	projectpb "github.com/nexusdeploy/backend/services/project-service/proto"
+	"github.com/nexusdeploy/backend/services/runner-service/queue"
+)
+
+// previewStatusContext names the commit status of pull request previews. API
+// Gateway sets it to pending when the build is triggered.
+const previewStatusContext = "nexusdeploy/preview"
+
+// reportPreview sets the preview commit status of the head of a pull request to
+// the outcome of its build, with the URL of the preview once it is deployed.
+// Failures are logged, the build result is already stored.
+func (h *BuildHandler) reportPreview(ctx context.Context, payload *queue.BuildJobPayload, deployment *deploymentpb.DeployResponse, status buildpb.BuildStatus, message string) {
+	req := &projectpb.SetCommitStatusRequest{
+		ProjectId: payload.ProjectID,
+		CommitSha: payload.CommitSHA,
+		Context:   previewStatusContext,
+	}
+	switch {
+	case deployment != nil:
+		req.State = "success"
+		req.TargetUrl = deployment.PublicUrl
+		req.Description = fmt.Sprintf("Preview of #%d is live", payload.PullRequest)
+	case status == buildpb.BuildStatus_BUILD_STATUS_CANCELLED:
+		req.State = "error"
+		req.Description = message
+	default:
+		req.State = "failure"
+		req.Description = message
+	}
+
+	if err := h.clients.SetCommitStatus(ctx, req); err != nil {
+		h.log.Warn().Err(err).
+			Str("build_id", payload.BuildID).
+			Int("pull_request", payload.PullRequest).
+			Msg("Failed to set preview commit status")
+	}
+}
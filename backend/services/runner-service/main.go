@@ -12,6 +12,8 @@ import (
 
 	cfgpkg "github.com/nexusdeploy/backend/pkg/config"
 	"github.com/nexusdeploy/backend/pkg/logger"
+	buildpb "github.com/nexusdeploy/backend/services/build-service/proto"
+	"github.com/nexusdeploy/backend/services/runner-service/cache"
 	"github.com/nexusdeploy/backend/services/runner-service/clients"
 	"github.com/nexusdeploy/backend/services/runner-service/executor"
 	"github.com/nexusdeploy/backend/services/runner-service/handler"
@@ -56,13 +58,14 @@ func main() {
 
 	// Initialize gRPC clients
 	grpcClients, err := clients.NewClients(ctx, clients.ClientsConfig{
-		BuildServiceAddr:   cfg.BuildServiceAddr,
-		ProjectServiceAddr: cfg.ProjectServiceAddr,
-		Timeout:            10 * time.Second,
-		MaxRetries:         3,
-		TLSEnabled:         cfg.GRPCTLSEnabled,
-		TLSCertPath:        cfg.GRPCTLSCertPath,
-		InsecureSkipVerify: cfg.GRPCInsecureSkipVerify,
+		BuildServiceAddr:      cfg.BuildServiceAddr,
+		ProjectServiceAddr:    cfg.ProjectServiceAddr,
+		DeploymentServiceAddr: cfg.DeploymentServiceAddr,
+		Timeout:               10 * time.Second,
+		MaxRetries:            3,
+		TLSEnabled:            cfg.GRPCTLSEnabled,
+		TLSCertPath:           cfg.GRPCTLSCertPath,
+		InsecureSkipVerify:    cfg.GRPCInsecureSkipVerify,
 	}, log)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize gRPC clients")
@@ -86,6 +89,11 @@ func main() {
 		RegistryUser: getEnv("REGISTRY_USER", ""),
 		RegistryPass: getEnv("REGISTRY_PASSWORD", ""),
 		WorkDir:      getEnv("BUILD_WORK_DIR", "/tmp/nexus-builds"),
+		CacheEnabled: getEnv("CACHE_ENABLED", "true") == "true",
+		Cache: cache.Config{
+			MaxSizeBytes: int64(getEnvAsInt("CACHE_MAX_SIZE_MB", 10240)) * 1024 * 1024,
+			MaxAge:       getEnvAsDuration("CACHE_MAX_AGE", 7*24*time.Hour),
+		},
 	}, log)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize Docker executor")
@@ -93,7 +101,7 @@ func main() {
 	defer dockerExec.Close()
 
 	// Create build handler
-	buildHandler := handler.NewBuildHandler(grpcClients, dockerExec, publisher, log)
+	buildHandler := handler.NewBuildHandler(grpcClients, dockerExec, publisher, getEnvAsInt("RUNNER_STEP_CONCURRENCY", 2), log)
 
 	// Store dockerExec for HTTP handlers
 	globalDockerExec = dockerExec
@@ -101,8 +109,25 @@ func main() {
 	// Initialize queue consumer
 	concurrency := getEnvAsInt("RUNNER_CONCURRENCY", 2)
 	consumer := queue.NewConsumer(queue.ConsumerConfig{
-		RedisAddr:   cfg.GetRedisAddr(),
-		Concurrency: concurrency,
+		RedisAddr:        cfg.GetRedisAddr(),
+		Concurrency:      concurrency,
+		MaxBuildsPerUser: getEnvAsInt("RUNNER_MAX_BUILDS_PER_USER", 2),
+		OnArchived: func(_ context.Context, job *queue.ArchivedJob) {
+			// The task context may be done already
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := grpcClients.RecordDeadLetter(ctx, &buildpb.RecordDeadLetterRequest{
+				BuildId:  job.BuildID,
+				Queue:    job.Queue,
+				TaskId:   job.TaskID,
+				Kind:     job.Kind,
+				Reason:   job.Reason,
+				Attempts: int32(job.Attempts),
+			})
+			if err != nil {
+				log.Error().Err(err).Str("build_id", job.BuildID).Msg("Failed to record dead letter")
+			}
+		},
 	}, buildHandler, log)
 
 	// Start queue consumer in background
@@ -238,3 +263,12 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,183 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// StepFunc runs a single step. The context is cancelled when a sibling step fails.
+type StepFunc func(ctx context.Context, step *Step) error
+
+// IsDAG reports whether any step declares dependencies. Without them the steps run
+// sequentially in file order, as in version 1 pipelines.
+func (p *Pipeline) IsDAG() bool {
+	for _, step := range p.Steps {
+		if len(step.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencies returns the effective dependencies of each step
+func (p *Pipeline) dependencies() map[string][]string {
+	deps := make(map[string][]string, len(p.Steps))
+	dag := p.IsDAG()
+	for i, step := range p.Steps {
+		switch {
+		case dag:
+			deps[step.Name] = step.DependsOn
+		case i > 0:
+			deps[step.Name] = []string{p.Steps[i-1].Name}
+		default:
+			deps[step.Name] = nil
+		}
+	}
+	return deps
+}
+
+// validateGraph checks that dependencies exist and that there is no cycle
+func (p *Pipeline) validateGraph() error {
+	names := make(map[string]bool, len(p.Steps))
+	for _, step := range p.Steps {
+		names[step.Name] = true
+	}
+	for _, step := range p.Steps {
+		for _, dep := range step.DependsOn {
+			if !names[dep] {
+				return fmt.Errorf("step %q: depends on unknown step %q", step.Name, dep)
+			}
+			if dep == step.Name {
+				return fmt.Errorf("step %q: depends on itself", step.Name)
+			}
+		}
+	}
+
+	// Kahn's algorithm: every step must become ready eventually
+	deps := p.dependencies()
+	remaining := make(map[string]int, len(deps))
+	dependents := make(map[string][]string)
+	for name, d := range deps {
+		remaining[name] = len(d)
+		for _, dep := range d {
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+	var ready []string
+	for name, n := range remaining {
+		if n == 0 {
+			ready = append(ready, name)
+		}
+	}
+	visited := 0
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		visited++
+		for _, next := range dependents[name] {
+			remaining[next]--
+			if remaining[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	if visited != len(deps) {
+		return errors.New("steps have a dependency cycle")
+	}
+	return nil
+}
+
+// Run schedules the steps as a DAG. A step starts once all of its dependencies have
+// succeeded, with at most concurrency steps running at once. The first failure
+// cancels the running siblings and no new step is started; that error is returned.
+// Steps that never started are reported through onSkip.
+func (p *Pipeline) Run(ctx context.Context, concurrency int, run StepFunc, onSkip func(step *Step)) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	deps := p.dependencies()
+	remaining := make(map[string]int, len(p.Steps))
+	dependents := make(map[string][]*Step)
+	for i := range p.Steps {
+		step := &p.Steps[i]
+		remaining[step.Name] = len(deps[step.Name])
+		for _, dep := range deps[step.Name] {
+			dependents[dep] = append(dependents[dep], step)
+		}
+	}
+
+	type outcome struct {
+		step *Step
+		err  error
+	}
+
+	var (
+		wg       sync.WaitGroup
+		done     = make(chan outcome)
+		ready    []*Step
+		started  = make(map[string]bool, len(p.Steps))
+		running  int
+		firstErr error
+	)
+
+	// Keep file order among steps that become ready at the same time
+	for i := range p.Steps {
+		if remaining[p.Steps[i].Name] == 0 {
+			ready = append(ready, &p.Steps[i])
+		}
+	}
+
+	for {
+		for firstErr == nil && running < concurrency && len(ready) > 0 {
+			step := ready[0]
+			ready = ready[1:]
+			started[step.Name] = true
+			running++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				done <- outcome{step: step, err: run(ctx, step)}
+			}()
+		}
+
+		if running == 0 {
+			break
+		}
+
+		res := <-done
+		running--
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("step %s: %w", res.step.Name, res.err)
+				cancel()
+			}
+			continue
+		}
+		for _, next := range dependents[res.step.Name] {
+			remaining[next.Name]--
+			if remaining[next.Name] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	wg.Wait()
+
+	if onSkip != nil {
+		for i := range p.Steps {
+			if !started[p.Steps[i].Name] {
+				onSkip(&p.Steps[i])
+			}
+		}
+	}
+
+	if firstErr == nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return firstErr
+}
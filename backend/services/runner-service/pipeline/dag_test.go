@@ -0,0 +1,207 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newPipeline builds a pipeline from steps given as their name followed by
+// their dependencies
+func newPipeline(steps ...[]string) *Pipeline {
+	p := &Pipeline{}
+	for _, s := range steps {
+		p.Steps = append(p.Steps, Step{Name: s[0], Commands: []string{"true"}, DependsOn: s[1:]})
+	}
+	return p
+}
+
+func TestValidateGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   [][]string
+		wantErr string
+	}{
+		{
+			name:  "sequential",
+			steps: [][]string{{"lint"}, {"unit"}, {"e2e"}},
+		},
+		{
+			name:  "diamond",
+			steps: [][]string{{"deps"}, {"lint", "deps"}, {"unit", "deps"}, {"package", "lint", "unit"}},
+		},
+		{
+			name:  "dependency declared later",
+			steps: [][]string{{"package", "unit"}, {"unit"}},
+		},
+		{
+			name:    "unknown dependency",
+			steps:   [][]string{{"lint"}, {"unit", "deps"}},
+			wantErr: `step "unit": depends on unknown step "deps"`,
+		},
+		{
+			name:    "self dependency",
+			steps:   [][]string{{"lint", "lint"}},
+			wantErr: `step "lint": depends on itself`,
+		},
+		{
+			name:    "two step cycle",
+			steps:   [][]string{{"lint", "unit"}, {"unit", "lint"}},
+			wantErr: "dependency cycle",
+		},
+		{
+			name:    "cycle behind a root",
+			steps:   [][]string{{"deps"}, {"a", "deps", "c"}, {"b", "a"}, {"c", "b"}},
+			wantErr: "dependency cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newPipeline(tt.steps...).validateGraph()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("validateGraph() = %v, want nil", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("validateGraph() = nil, want %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("validateGraph() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       [][]string
+		concurrency int
+		fail        string   // Step that fails, "" for none
+		wantOrder   []string // Start order, only checked with a concurrency of 1
+		wantSkipped []string
+		wantErr     bool
+	}{
+		{
+			name:        "sequential steps keep file order",
+			steps:       [][]string{{"lint"}, {"unit"}, {"e2e"}},
+			concurrency: 4,
+			wantOrder:   []string{"lint", "unit", "e2e"},
+		},
+		{
+			name:        "ready steps start in file order",
+			steps:       [][]string{{"package", "lint", "unit"}, {"unit"}, {"lint"}},
+			concurrency: 1,
+			wantOrder:   []string{"unit", "lint", "package"},
+		},
+		{
+			name:        "diamond in parallel",
+			steps:       [][]string{{"deps"}, {"lint", "deps"}, {"unit", "deps"}, {"e2e", "deps"}, {"package", "lint", "unit", "e2e"}},
+			concurrency: 2,
+		},
+		{
+			name:        "failure skips dependents",
+			steps:       [][]string{{"deps"}, {"lint", "deps"}, {"unit", "deps"}, {"package", "lint", "unit"}},
+			concurrency: 1,
+			fail:        "lint",
+			wantOrder:   []string{"deps", "lint"},
+			wantSkipped: []string{"unit", "package"},
+			wantErr:     true,
+		},
+		{
+			name:        "failing root skips everything",
+			steps:       [][]string{{"deps"}, {"lint", "deps"}, {"unit", "deps"}},
+			concurrency: 3,
+			fail:        "deps",
+			wantSkipped: []string{"lint", "unit"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPipeline(tt.steps...)
+			deps := p.dependencies()
+
+			var (
+				mu       sync.Mutex
+				order    []string
+				finished = make(map[string]bool)
+				running  int
+				maxRun   int
+				skipped  []string
+			)
+			run := func(ctx context.Context, step *Step) error {
+				mu.Lock()
+				for _, dep := range deps[step.Name] {
+					if !finished[dep] {
+						t.Errorf("step %s started before its dependency %s finished", step.Name, dep)
+					}
+				}
+				order = append(order, step.Name)
+				running++
+				if running > maxRun {
+					maxRun = running
+				}
+				mu.Unlock()
+
+				// Give parallel steps the chance to overlap
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				finished[step.Name] = true
+				mu.Unlock()
+				if step.Name == tt.fail {
+					return errors.New("exit code 1")
+				}
+				return nil
+			}
+			onSkip := func(step *Step) {
+				skipped = append(skipped, step.Name)
+			}
+
+			err := p.Run(context.Background(), tt.concurrency, run, onSkip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "step "+tt.fail) {
+				t.Errorf("Run() error = %v, want it to name step %s", err, tt.fail)
+			}
+			if maxRun > tt.concurrency {
+				t.Errorf("%d steps ran at once, want at most %d", maxRun, tt.concurrency)
+			}
+			if tt.wantOrder != nil && strings.Join(order, ",") != strings.Join(tt.wantOrder, ",") {
+				t.Errorf("start order = %v, want %v", order, tt.wantOrder)
+			}
+			if strings.Join(skipped, ",") != strings.Join(tt.wantSkipped, ",") {
+				t.Errorf("skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+			if !tt.wantErr && len(order) != len(p.Steps) {
+				t.Errorf("%d steps ran, want %d", len(order), len(p.Steps))
+			}
+		})
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	p := newPipeline([]string{"lint"}, []string{"unit", "lint"})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var skipped []string
+	err := p.Run(ctx, 1, func(ctx context.Context, step *Step) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}, func(step *Step) {
+		skipped = append(skipped, step.Name)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if len(skipped) != 1 || skipped[0] != "unit" {
+		t.Errorf("skipped = %v, want [unit]", skipped)
+	}
+}
@@ -0,0 +1,139 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the pipeline file names looked up in the repository root, in order
+var FileNames = []string{"nexus.yaml", "nexus.yml"}
+
+// DefaultStepTimeout is used when a step does not declare a timeout
+const DefaultStepTimeout = 15 * time.Minute
+
+// reservedStepNames are the built-in build steps of the runner. Build steps are
+// stored by name, so a declared step with one of these names would overwrite the
+// status of the built-in step.
+var reservedStepNames = map[string]bool{
+	"clone":        true,
+	"install":      true,
+	"build":        true,
+	"test":         true,
+	"docker_build": true,
+	"docker_push":  true,
+	"deploy":       true,
+}
+
+var stepNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Pipeline is the parsed content of a nexus.yaml file
+type Pipeline struct {
+	Version     int    `yaml:"version"`
+	Concurrency int    `yaml:"concurrency"` // Max parallel steps, capped by the runner
+	Steps       []Step `yaml:"steps"`
+
+	// File is the name of the file the pipeline was loaded from
+	File string `yaml:"-"`
+}
+
+// Step is a user-defined pipeline step
+type Step struct {
+	Name      string            `yaml:"name"`
+	Image     string            `yaml:"image"`    // Defaults to the preset base image
+	Commands  []string          `yaml:"commands"` // Run in order with `sh -e`
+	Env       map[string]string `yaml:"env"`
+	Timeout   string            `yaml:"timeout"`    // Go duration, e.g. "10m"
+	DependsOn []string          `yaml:"depends_on"` // Steps that must succeed first
+}
+
+// TimeoutDuration returns the parsed step timeout, or DefaultStepTimeout if none is set
+func (s *Step) TimeoutDuration() time.Duration {
+	if s.Timeout == "" {
+		return DefaultStepTimeout
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil || d <= 0 {
+		return DefaultStepTimeout
+	}
+	return d
+}
+
+// Load reads the pipeline file from the workspace.
+// It returns nil without error when the repository has no pipeline file.
+func Load(workspace string) (*Pipeline, error) {
+	for _, name := range FileNames {
+		data, err := os.ReadFile(filepath.Join(workspace, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+
+		p, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		p.File = name
+		return p, nil
+	}
+	return nil, nil
+}
+
+// Parse decodes and validates a pipeline definition
+func Parse(data []byte) (*Pipeline, error) {
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that the pipeline can be executed
+func (p *Pipeline) Validate() error {
+	if p.Version != 0 && p.Version != 1 {
+		return fmt.Errorf("unsupported version %d", p.Version)
+	}
+	if len(p.Steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+	if p.Concurrency < 0 {
+		return errors.New("concurrency must not be negative")
+	}
+
+	seen := make(map[string]bool, len(p.Steps))
+	for i, step := range p.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("step %d: name is required", i+1)
+		}
+		if !stepNamePattern.MatchString(step.Name) {
+			return fmt.Errorf("step %q: name must be lowercase letters, digits, '-' or '_'", step.Name)
+		}
+		if reservedStepNames[step.Name] {
+			return fmt.Errorf("step %q: name is reserved for a built-in step", step.Name)
+		}
+		if seen[step.Name] {
+			return fmt.Errorf("step %q: duplicate name", step.Name)
+		}
+		seen[step.Name] = true
+
+		if len(step.Commands) == 0 {
+			return fmt.Errorf("step %q: at least one command is required", step.Name)
+		}
+		if step.Timeout != "" {
+			if d, err := time.ParseDuration(step.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("step %q: invalid timeout %q", step.Name, step.Timeout)
+			}
+		}
+	}
+	return p.validateGraph()
+}
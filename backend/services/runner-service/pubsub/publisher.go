@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -18,6 +20,13 @@ const (
 
 	// EventChannelPrefix is the prefix for build event channels
 	EventChannelPrefix = "build_events:"
+
+	// RedactedValue replaces secret values in build logs
+	RedactedValue = "***"
+
+	// MinRedactLength is the shortest value that is masked; shorter values such as
+	// "1" or "on" would mask unrelated parts of every line
+	MinRedactLength = 4
 )
 
 // LogMessage represents a log message published to Redis
@@ -169,14 +178,19 @@ func (p *Publisher) PublishBuildCompleted(ctx context.Context, buildID, status,
 	})
 }
 
-// LogCollector collects logs and publishes them in batches
+// LogCollector collects logs and publishes them in batches.
+// It is safe for concurrent use: parallel pipeline steps share one collector.
 type LogCollector struct {
+	mu         sync.Mutex
 	publisher  *Publisher
 	projectID  string
 	buildID    string
 	logs       []string
 	batchSize  int
 	appendFunc func(ctx context.Context, buildID string, logs []string) error // Callback to save logs to database
+
+	masked   map[string]struct{}
+	redactor *strings.Replacer // nil until a value is masked
 }
 
 // NewLogCollector creates a log collector for a build
@@ -219,10 +233,61 @@ func sanitizeUTF8(s string) string {
 	return b.String()
 }
 
+// Mask registers values, such as tokens and secrets, that are replaced by
+// RedactedValue in every line added afterwards. Each line of a multi-line value
+// is masked on its own since logs are collected line by line.
+func (c *LogCollector) Mask(values ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.masked == nil {
+		c.masked = make(map[string]struct{})
+	}
+	for _, value := range values {
+		for _, part := range append(strings.Split(value, "\n"), value) {
+			if part = strings.TrimSpace(part); len(part) >= MinRedactLength {
+				c.masked[part] = struct{}{}
+			}
+		}
+	}
+
+	// Longest values first, so a value containing another is masked as a whole
+	parts := make([]string, 0, len(c.masked))
+	for part := range c.masked {
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool { return len(parts[i]) > len(parts[j]) })
+
+	oldnew := make([]string, 0, 2*len(parts))
+	for _, part := range parts {
+		oldnew = append(oldnew, part, RedactedValue)
+	}
+	if len(oldnew) > 0 {
+		c.redactor = strings.NewReplacer(oldnew...)
+	}
+}
+
+// Redact returns line with the masked values replaced
+func (c *LogCollector) Redact(line string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.redact(line)
+}
+
+func (c *LogCollector) redact(line string) string {
+	if c.redactor == nil {
+		return line
+	}
+	return c.redactor.Replace(line)
+}
+
 // Add adds a log line and publishes if batch is full
 func (c *LogCollector) Add(ctx context.Context, line string) error {
-	// Sanitize UTF-8 before storing
-	line = sanitizeUTF8(line)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Sanitize UTF-8 and mask secrets before storing or publishing
+	line = c.redact(sanitizeUTF8(line))
 	// Add to logs array first (for database storage later)
 	c.logs = append(c.logs, line)
 
@@ -251,6 +316,9 @@ func (c *LogCollector) Add(ctx context.Context, line string) error {
 
 // Flush publishes any remaining logs
 func (c *LogCollector) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Save any remaining logs to database
 	if c.appendFunc != nil && len(c.logs) > 0 {
 		batch := make([]string, len(c.logs))
@@ -267,5 +335,7 @@ func (c *LogCollector) Flush(ctx context.Context) error {
 
 // GetLogs returns all collected logs
 func (c *LogCollector) GetLogs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.logs
 }
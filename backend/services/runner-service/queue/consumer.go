@@ -3,9 +3,13 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
@@ -13,10 +17,25 @@ const (
 	// TaskTypeBuildJob matches the task type from Build Service
 	TaskTypeBuildJob = "build:execute"
 
-	// QueueBuilds is the queue name
+	// QueueBuilds is the queue of jobs enqueued before the plan queues existed
 	QueueBuilds = "builds"
+
+	// Plan queues, must match build-service/queue/producer.go
+	QueueBuildsPremium  = "builds:premium"
+	QueueBuildsStandard = "builds:standard"
 )
 
+// HealthCheckConfig is the health check of the deployments of a project, 0
+// values use the Deployment Service defaults
+type HealthCheckConfig struct {
+	Type            string `json:"type"` // "tcp" or "http"
+	Path            string `json:"path"`
+	ExpectedStatus  int    `json:"expected_status"`
+	IntervalSeconds int    `json:"interval_seconds"`
+	TimeoutSeconds  int    `json:"timeout_seconds"`
+	Retries         int    `json:"retries"`
+}
+
 // BuildJobPayload represents the job payload from Build Service
 // Must match build-service/queue/producer.go
 type BuildJobPayload struct {
@@ -27,9 +46,54 @@ type BuildJobPayload struct {
 	CommitSHA    string            `json:"commit_sha"`
 	BuildCommand string            `json:"build_command"`
 	StartCommand string            `json:"start_command"`
+	TestCommand  string            `json:"test_command"`
 	Preset       string            `json:"preset"`
 	Port         int               `json:"port"`
 	Secrets      map[string]string `json:"secrets"`
+	RootDir      string            `json:"root_dir"` // Subdirectory to build, "" = repository root
+
+	// Clone options
+	Submodules  bool `json:"submodules"`
+	LFS         bool `json:"lfs"`
+	CloneDepth  int  `json:"clone_depth"` // 0 = shallow clone of depth 1
+	FullHistory bool `json:"full_history"`
+	FetchTags   bool `json:"fetch_tags"`
+
+	// Deploy the image once the build succeeds, as the project owner
+	AutoDeploy  bool              `json:"auto_deploy"`
+	UserID      string            `json:"user_id"`
+	HealthCheck HealthCheckConfig `json:"health_check"`
+	Replicas    int               `json:"replicas"` // Already capped by the plan, 0 = 1
+
+	CustomDomains []string `json:"custom_domains,omitempty"` // Verified custom domains to route
+
+	// Builds of a pull request are deployed as a preview of it, whether or not
+	// the project auto-deploys
+	PullRequest int `json:"pull_request,omitempty"`
+
+	// Environment of the project whose branch is built, "" for production. Its
+	// deployments use its own secrets, domain and container resources.
+	Environment    string `json:"environment,omitempty"`
+	DeployMemoryMB int    `json:"deploy_memory_mb,omitempty"` // 0 = 512
+	DeployCPUCores int    `json:"deploy_cpu_cores,omitempty"` // 0 = 1
+
+	// Resource limits for the build containers, already capped by the plan
+	MemoryMB       int     `json:"memory_mb"`
+	CPUs           float64 `json:"cpus"`
+	TimeoutMinutes int     `json:"timeout_minutes"`
+	DiskMB         int     `json:"disk_mb"` // 0 = no disk quota
+
+	Plan string `json:"plan,omitempty"` // Plan of the project owner, selects the queue
+
+	// Builds of the project owner running at once, 0 uses ConsumerConfig.MaxBuildsPerUser
+	MaxConcurrentBuilds int `json:"max_concurrent_builds,omitempty"`
+
+	// Variant of the build matrix of the project. Variant builds only build and
+	// test the project on their own base image, they push no image and are not
+	// deployed.
+	Variant    string            `json:"variant,omitempty"`
+	BaseImage  string            `json:"base_image,omitempty"` // "" uses the image of the preset
+	VariantEnv map[string]string `json:"variant_env,omitempty"`
 }
 
 // ParseBuildJobPayload deserializes a build job payload
@@ -50,6 +114,8 @@ type BuildJobHandler interface {
 type Consumer struct {
 	server  *asynq.Server
 	handler BuildJobHandler
+	slots   *userSlots
+	redis   *redis.Client
 	log     zerolog.Logger
 }
 
@@ -57,6 +123,13 @@ type Consumer struct {
 type ConsumerConfig struct {
 	RedisAddr   string
 	Concurrency int // Number of concurrent workers
+
+	// Builds of one user running at once across all runners, for jobs that do not
+	// carry the limit of the plan. 0 = no limit.
+	MaxBuildsPerUser int
+
+	// OnArchived records the jobs that failed for good, optional
+	OnArchived ArchivedFunc
 }
 
 // NewConsumer creates a new queue consumer
@@ -65,10 +138,27 @@ func NewConsumer(cfg ConsumerConfig, handler BuildJobHandler, log zerolog.Logger
 		asynq.RedisClientOpt{Addr: cfg.RedisAddr},
 		asynq.Config{
 			Concurrency: cfg.Concurrency,
+			// Weighted priority: premium jobs are picked about twice as often as
+			// standard ones, neither queue starves
 			Queues: map[string]int{
-				QueueBuilds: 10, // Priority weight
+				QueueBuildsPremium:  6,
+				QueueBuildsStandard: 3,
+				QueueBuilds:         1,
+			},
+			// A job waiting for a slot of its user is put back, not failed
+			IsFailure: func(err error) bool {
+				return !errors.Is(err, ErrNoUserSlot)
+			},
+			RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
+				if errors.Is(err, ErrNoUserSlot) {
+					return slotRetryDelay + time.Duration(rand.Int63n(int64(slotRetryDelay)))
+				}
+				return asynq.DefaultRetryDelayFunc(n, err, task)
 			},
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
+				if errors.Is(err, ErrNoUserSlot) {
+					return
+				}
 				taskID := "unknown"
 				if task != nil {
 					if rw := task.ResultWriter(); rw != nil {
@@ -80,13 +170,29 @@ func NewConsumer(cfg ConsumerConfig, handler BuildJobHandler, log zerolog.Logger
 					Str("task_type", task.Type()).
 					Str("task_id", taskID).
 					Msg("Task processing failed")
+
+				// Jobs out of attempts, or failed by the project, are archived by
+				// asynq and kept for requeueing
+				if job := archivedJob(ctx, task, err); job != nil {
+					log.Warn().
+						Str("build_id", job.BuildID).
+						Str("kind", job.Kind).
+						Int("attempts", job.Attempts).
+						Msg("Build job archived")
+					if cfg.OnArchived != nil {
+						cfg.OnArchived(ctx, job)
+					}
+				}
 			}),
 		},
 	)
 
+	client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
 	return &Consumer{
 		server:  srv,
 		handler: handler,
+		slots:   &userSlots{client: client, defaultLimit: cfg.MaxBuildsPerUser},
+		redis:   client,
 		log:     log,
 	}
 }
@@ -104,6 +210,7 @@ func (c *Consumer) Start() error {
 func (c *Consumer) Shutdown() {
 	c.log.Info().Msg("Shutting down Asynq consumer")
 	c.server.Shutdown()
+	c.redis.Close()
 }
 
 // handleBuildTask is the Asynq handler for build tasks
@@ -114,6 +221,14 @@ func (c *Consumer) handleBuildTask(ctx context.Context, task *asynq.Task) error
 		return fmt.Errorf("parse payload: %w", err)
 	}
 
+	if payload.UserID != "" {
+		release, err := c.takeUserSlot(ctx, payload)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
 	c.log.Info().
 		Str("build_id", payload.BuildID).
 		Str("project_id", payload.ProjectID).
@@ -136,3 +251,43 @@ func (c *Consumer) handleBuildTask(ctx context.Context, task *asynq.Task) error
 	return nil
 }
 
+// takeUserSlot takes a build slot of the user of a job, or returns ErrNoUserSlot.
+// If Redis fails the job runs anyway, fairness is not worth a stalled queue.
+func (c *Consumer) takeUserSlot(ctx context.Context, payload *BuildJobPayload) (release func(), err error) {
+	release = func() {}
+
+	limit := c.slots.limitFor(payload)
+	if limit <= 0 {
+		return release, nil
+	}
+
+	// asynq sets the deadline of the task from its timeout
+	expiresAt, ok := ctx.Deadline()
+	if !ok {
+		expiresAt = time.Now().Add(time.Duration(payload.TimeoutMinutes) * time.Minute)
+	}
+
+	taken, err := c.slots.acquire(ctx, payload.UserID, payload.BuildID, limit, expiresAt)
+	if err != nil {
+		c.log.Warn().Err(err).
+			Str("build_id", payload.BuildID).
+			Str("user_id", payload.UserID).
+			Msg("Failed to take a build slot of the user, running the build anyway")
+		return release, nil
+	}
+	if !taken {
+		c.log.Debug().
+			Str("build_id", payload.BuildID).
+			Str("user_id", payload.UserID).
+			Int("max_builds_per_user", limit).
+			Msg("User has no free build slot, putting the build back")
+		return nil, ErrNoUserSlot
+	}
+
+	return func() {
+		// The task context may be cancelled already
+		if err := c.slots.release(context.Background(), payload.UserID, payload.BuildID); err != nil {
+			c.log.Warn().Err(err).Str("build_id", payload.BuildID).Msg("Failed to release the build slot of the user")
+		}
+	}, nil
+}
@@ -0,0 +1,76 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hibiken/asynq"
+)
+
+// Kinds of archived build jobs, must match build-service/models/dead_letter.go
+const (
+	FailureKindUser           = "user"           // The project failed the build, not retried
+	FailureKindInfrastructure = "infrastructure" // The runner failed the build on every attempt
+)
+
+// ArchivedJob is a build job that asynq archived instead of retrying
+type ArchivedJob struct {
+	BuildID  string
+	Queue    string
+	TaskID   string
+	Kind     string
+	Reason   string
+	Attempts int
+}
+
+// ArchivedFunc is called for every build job asynq archives
+type ArchivedFunc func(ctx context.Context, job *ArchivedJob)
+
+// SkipRetry wraps the error of a job that running again would fail the same way.
+// asynq archives the job right away instead of retrying it.
+func SkipRetry(err error) error {
+	return fmt.Errorf("%w: %w", asynq.SkipRetry, err)
+}
+
+// WillRetry reports whether asynq runs a failed job again, i.e. it has attempts
+// left. Jobs put back for a slot of their user do not use up attempts.
+func WillRetry(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return false
+	}
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	return ok && retried < maxRetry
+}
+
+// archivedJob returns the job asynq archives for the error of a task, or nil if the
+// task is retried. It mirrors the decision of the asynq processor.
+func archivedJob(ctx context.Context, task *asynq.Task, err error) *ArchivedJob {
+	if errors.Is(err, ErrNoUserSlot) {
+		return nil
+	}
+	skipped := errors.Is(err, asynq.SkipRetry)
+	if !skipped && WillRetry(ctx) {
+		return nil
+	}
+
+	job := &ArchivedJob{
+		Kind:   FailureKindInfrastructure,
+		Reason: strings.TrimPrefix(err.Error(), asynq.SkipRetry.Error()+": "),
+	}
+	if skipped {
+		job.Kind = FailureKindUser
+	}
+	job.TaskID, _ = asynq.GetTaskID(ctx)
+	job.Queue, _ = asynq.GetQueueName(ctx)
+	retried, _ := asynq.GetRetryCount(ctx)
+	job.Attempts = retried + 1
+	if payload, err := ParseBuildJobPayload(task.Payload()); err == nil {
+		job.BuildID = payload.BuildID
+	} else {
+		job.BuildID = job.TaskID // Build jobs are enqueued with the build ID as task ID
+	}
+	return job
+}
@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// userSlotsKeyPrefix holds the builds of a user running on any runner, a
+	// sorted set of build IDs scored by the Unix time their slot expires
+	userSlotsKeyPrefix = "runner:user_slots:"
+
+	// slotGrace keeps a slot past the build timeout, so that only a runner that
+	// died with the build frees it by expiry
+	slotGrace = time.Minute
+
+	// slotRetryDelay is how long a build waits before it asks for a slot again
+	slotRetryDelay = 10 * time.Second
+)
+
+// ErrNoUserSlot is returned for a build whose user already runs as many builds as
+// allowed. The task is put back without counting as a failed attempt.
+var ErrNoUserSlot = errors.New("waiting for a free build slot of the user")
+
+// acquireSlotScript drops expired slots, then takes a slot for the build if the
+// user holds fewer than the limit. Taking the slot of a build again refreshes it.
+var acquireSlotScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+if not redis.call("ZSCORE", KEYS[1], ARGV[3]) and redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[4]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+local last = redis.call("ZRANGE", KEYS[1], -1, -1, "WITHSCORES")
+redis.call("EXPIREAT", KEYS[1], last[2])
+return 1
+`)
+
+// userSlots limits how many builds of one user run at once across all runners,
+// so that a user pushing many commits cannot take every worker
+type userSlots struct {
+	client       *redis.Client
+	defaultLimit int // For jobs without the limit of the plan, 0 = no limit
+}
+
+// limitFor returns the slots of the user of a job, the limit of their plan if the
+// job carries it
+func (s *userSlots) limitFor(payload *BuildJobPayload) int {
+	if payload.MaxConcurrentBuilds > 0 {
+		return payload.MaxConcurrentBuilds
+	}
+	return s.defaultLimit
+}
+
+// acquire takes one of limit slots of a user for a build until expiresAt. It
+// reports false if the user has no free slot.
+func (s *userSlots) acquire(ctx context.Context, userID, buildID string, limit int, expiresAt time.Time) (bool, error) {
+	now := time.Now().Unix()
+	taken, err := acquireSlotScript.Run(ctx, s.client, []string{userSlotsKeyPrefix + userID},
+		now, expiresAt.Add(slotGrace).Unix(), buildID, limit).Int()
+	if err != nil {
+		return false, err
+	}
+	return taken == 1, nil
+}
+
+// release frees the slot of a build
+func (s *userSlots) release(ctx context.Context, userID, buildID string) error {
+	return s.client.ZRem(ctx, userSlotsKeyPrefix+userID, buildID).Err()
+}
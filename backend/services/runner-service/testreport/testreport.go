@@ -0,0 +1,259 @@
+package testreport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Status values match the build-service TestStatus
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// maxFailureMessage caps the failure text stored per test case
+const maxFailureMessage = 4000
+
+// Result is a single test case outcome
+type Result struct {
+	Suite          string
+	Name           string
+	Status         string
+	DurationMs     int
+	FailureMessage string
+}
+
+// Summary counts results by status
+type Summary struct {
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+}
+
+// Summarize counts the results by status
+func Summarize(results []Result) Summary {
+	s := Summary{Total: len(results)}
+	for _, r := range results {
+		switch r.Status {
+		case StatusPassed:
+			s.Passed++
+		case StatusFailed:
+			s.Failed++
+		case StatusSkipped:
+			s.Skipped++
+		}
+	}
+	return s
+}
+
+// ==================== JUnit XML ====================
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName xml.Name         `xml:"testsuite"`
+	Name    string           `xml:"name,attr"`
+	Cases   []junitTestCase  `xml:"testcase"`
+	Suites  []junitTestSuite `xml:"testsuite"` // Some reporters nest suites
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// IsJUnit reports whether data looks like a JUnit XML report
+func IsJUnit(data []byte) bool {
+	head := data
+	if len(head) > 2048 {
+		head = head[:2048]
+	}
+	return bytes.Contains(head, []byte("<testsuite"))
+}
+
+// ParseJUnit parses a JUnit XML report with either a <testsuites> or <testsuite> root
+func ParseJUnit(data []byte) ([]Result, error) {
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err == nil {
+		var results []Result
+		for _, suite := range suites.Suites {
+			results = appendSuite(results, suite)
+		}
+		return results, nil
+	}
+
+	var suite junitTestSuite
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("parse junit xml: %w", err)
+	}
+	return appendSuite(nil, suite), nil
+}
+
+func appendSuite(results []Result, suite junitTestSuite) []Result {
+	for _, tc := range suite.Cases {
+		r := Result{
+			Suite:      suite.Name,
+			Name:       tc.Name,
+			Status:     StatusPassed,
+			DurationMs: parseSeconds(tc.Time),
+		}
+		if r.Suite == "" {
+			r.Suite = tc.Classname
+		}
+
+		switch {
+		case tc.Failure != nil:
+			r.Status = StatusFailed
+			r.FailureMessage = failureText(tc.Failure)
+		case tc.Error != nil:
+			r.Status = StatusFailed
+			r.FailureMessage = failureText(tc.Error)
+		case tc.Skipped != nil:
+			r.Status = StatusSkipped
+		}
+		results = append(results, r)
+	}
+	for _, nested := range suite.Suites {
+		results = appendSuite(results, nested)
+	}
+	return results
+}
+
+func failureText(m *junitMessage) string {
+	text := strings.TrimSpace(m.Body)
+	if text == "" {
+		text = m.Message
+	} else if m.Message != "" && !strings.Contains(text, m.Message) {
+		text = m.Message + "\n" + text
+	}
+	return truncate(text)
+}
+
+func parseSeconds(s string) int {
+	if s == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return int(f * 1000)
+}
+
+// ==================== go test -json ====================
+
+// GoTestEvent is a line of `go test -json` (test2json) output
+type GoTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// ParseGoTestEvent decodes a single test2json line. ok is false for other output.
+func ParseGoTestEvent(line string) (GoTestEvent, bool) {
+	var ev GoTestEvent
+	if !strings.HasPrefix(line, "{") || !strings.Contains(line, `"Action"`) {
+		return ev, false
+	}
+	if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Action == "" {
+		return ev, false
+	}
+	return ev, true
+}
+
+// ParseGoTestJSON builds results from `go test -json` output. The output of failed
+// tests is kept as the failure message.
+func ParseGoTestJSON(data []byte) []Result {
+	type key struct{ pkg, test string }
+	output := make(map[key]*strings.Builder)
+	var results []Result
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		ev, ok := ParseGoTestEvent(strings.TrimSpace(scanner.Text()))
+		if !ok || ev.Test == "" {
+			continue
+		}
+		k := key{ev.Package, ev.Test}
+
+		switch ev.Action {
+		case "output":
+			b, ok := output[k]
+			if !ok {
+				b = &strings.Builder{}
+				output[k] = b
+			}
+			if b.Len() < maxFailureMessage {
+				b.WriteString(ev.Output)
+			}
+		case "pass", "fail", "skip":
+			r := Result{
+				Suite:      ev.Package,
+				Name:       ev.Test,
+				DurationMs: int(ev.Elapsed * 1000),
+			}
+			switch ev.Action {
+			case "pass":
+				r.Status = StatusPassed
+			case "fail":
+				r.Status = StatusFailed
+				if b, ok := output[k]; ok {
+					r.FailureMessage = truncate(strings.TrimSpace(b.String()))
+				}
+			case "skip":
+				r.Status = StatusSkipped
+			}
+			delete(output, k)
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// Collect parses the JUnit reports found in the workspace. When there are none,
+// the test command output is parsed as `go test -json`.
+func Collect(reports map[string][]byte, output []byte) ([]Result, error) {
+	if len(reports) == 0 {
+		return ParseGoTestJSON(output), nil
+	}
+
+	var results []Result
+	for name, data := range reports {
+		parsed, err := ParseJUnit(data)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", name, err)
+		}
+		results = append(results, parsed...)
+	}
+	return results, nil
+}
+
+func truncate(s string) string {
+	if len(s) > maxFailureMessage {
+		s = s[:maxFailureMessage] + "..."
+	}
+	// Cutting may split a multi-byte rune, and Postgres rejects invalid UTF-8
+	return strings.ToValidUTF8(s, "")
+}
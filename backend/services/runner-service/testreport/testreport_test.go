@@ -0,0 +1,278 @@
+package testreport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseJUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name: "testsuites root",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="api" tests="2">
+    <testcase name="creates a user" classname="api.users" time="0.25"/>
+    <testcase name="rejects a duplicate" classname="api.users" time="1.5">
+      <failure message="expected 409">AssertionError: expected 409, got 500
+    at users.test.js:42</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="web">
+    <testcase name="renders" time="0.5"><skipped/></testcase>
+  </testsuite>
+</testsuites>`,
+			want: []Result{
+				{Suite: "api", Name: "creates a user", Status: StatusPassed, DurationMs: 250},
+				{Suite: "api", Name: "rejects a duplicate", Status: StatusFailed, DurationMs: 1500,
+					FailureMessage: "AssertionError: expected 409, got 500\n    at users.test.js:42"},
+				{Suite: "web", Name: "renders", Status: StatusSkipped, DurationMs: 500},
+			},
+		},
+		{
+			name: "testsuite root falls back to the classname",
+			data: `<testsuite>
+  <testcase name="test_login" classname="tests.test_auth" time="0.25"/>
+  <testcase name="test_logout" classname="tests.test_auth">
+    <error message="ConnectionError: database is down"/>
+  </testcase>
+</testsuite>`,
+			want: []Result{
+				{Suite: "tests.test_auth", Name: "test_login", Status: StatusPassed, DurationMs: 250},
+				{Suite: "tests.test_auth", Name: "test_logout", Status: StatusFailed,
+					FailureMessage: "ConnectionError: database is down"},
+			},
+		},
+		{
+			name: "nested suites",
+			data: `<testsuites>
+  <testsuite name="outer">
+    <testcase name="a"/>
+    <testsuite name="inner">
+      <testcase name="b"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`,
+			want: []Result{
+				{Suite: "outer", Name: "a", Status: StatusPassed},
+				{Suite: "inner", Name: "b", Status: StatusPassed},
+			},
+		},
+		{
+			name: "message is prepended when the body lacks it",
+			data: `<testsuite name="s">
+  <testcase name="t"><failure message="timeout">stack trace</failure></testcase>
+</testsuite>`,
+			want: []Result{
+				{Suite: "s", Name: "t", Status: StatusFailed, FailureMessage: "timeout\nstack trace"},
+			},
+		},
+		{
+			name: "time with a thousands separator",
+			data: `<testsuite name="s"><testcase name="slow" time="1,234.5"/></testsuite>`,
+			want: []Result{
+				{Suite: "s", Name: "slow", Status: StatusPassed, DurationMs: 1234500},
+			},
+		},
+		{
+			name:    "not xml",
+			data:    `<testsuite name="s"><testcase`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJUnit([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJUnit() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseJUnit() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGoTestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Result
+	}{
+		{
+			name: "pass, fail and skip",
+			data: `{"Action":"start","Package":"example.com/app/store"}
+{"Action":"run","Package":"example.com/app/store","Test":"TestGet"}
+{"Action":"output","Package":"example.com/app/store","Test":"TestGet","Output":"=== RUN   TestGet\n"}
+{"Action":"output","Package":"example.com/app/store","Test":"TestGet","Output":"--- PASS: TestGet (0.25s)\n"}
+{"Action":"pass","Package":"example.com/app/store","Test":"TestGet","Elapsed":0.25}
+{"Action":"run","Package":"example.com/app/store","Test":"TestPut"}
+{"Action":"output","Package":"example.com/app/store","Test":"TestPut","Output":"=== RUN   TestPut\n"}
+{"Action":"output","Package":"example.com/app/store","Test":"TestPut","Output":"    store_test.go:21: got 1, want 2\n"}
+{"Action":"output","Package":"example.com/app/store","Test":"TestPut","Output":"--- FAIL: TestPut (1.50s)\n"}
+{"Action":"fail","Package":"example.com/app/store","Test":"TestPut","Elapsed":1.5}
+{"Action":"run","Package":"example.com/app/store","Test":"TestSlow"}
+{"Action":"skip","Package":"example.com/app/store","Test":"TestSlow","Elapsed":0}
+{"Action":"fail","Package":"example.com/app/store","Elapsed":1.75}`,
+			want: []Result{
+				{Suite: "example.com/app/store", Name: "TestGet", Status: StatusPassed, DurationMs: 250},
+				{Suite: "example.com/app/store", Name: "TestPut", Status: StatusFailed, DurationMs: 1500,
+					FailureMessage: "=== RUN   TestPut\n    store_test.go:21: got 1, want 2\n--- FAIL: TestPut (1.50s)"},
+				{Suite: "example.com/app/store", Name: "TestSlow", Status: StatusSkipped},
+			},
+		},
+		{
+			name: "same test name in two packages",
+			data: `{"Action":"output","Package":"a","Test":"TestX","Output":"a failed\n"}
+{"Action":"pass","Package":"b","Test":"TestX","Elapsed":0.5}
+{"Action":"fail","Package":"a","Test":"TestX","Elapsed":0.25}`,
+			want: []Result{
+				{Suite: "b", Name: "TestX", Status: StatusPassed, DurationMs: 500},
+				{Suite: "a", Name: "TestX", Status: StatusFailed, DurationMs: 250, FailureMessage: "a failed"},
+			},
+		},
+		{
+			name: "subtests",
+			data: `{"Action":"pass","Package":"p","Test":"TestParse/empty","Elapsed":0}
+{"Action":"pass","Package":"p","Test":"TestParse","Elapsed":0}`,
+			want: []Result{
+				{Suite: "p", Name: "TestParse/empty", Status: StatusPassed},
+				{Suite: "p", Name: "TestParse", Status: StatusPassed},
+			},
+		},
+		{
+			name: "interleaved plain output",
+			data: `go: downloading example.com/dep v1.0.0
+  {"Action":"pass","Package":"p","Test":"TestA","Elapsed":0.5}
+{not json "Action"}
+ok  	p	0.5s`,
+			want: []Result{
+				{Suite: "p", Name: "TestA", Status: StatusPassed, DurationMs: 500},
+			},
+		},
+		{
+			name: "plain go test output",
+			data: "--- FAIL: TestA (0.00s)\nFAIL\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseGoTestJSON([]byte(tt.data))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGoTestJSON() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGoTestEvent(t *testing.T) {
+	tests := []struct {
+		line   string
+		wantOK bool
+	}{
+		{`{"Action":"pass","Package":"p","Test":"TestA"}`, true},
+		{`{"Action":"output","Package":"p","Output":"ok\n"}`, true},
+		{`{"Package":"p"}`, false},
+		{`{"Action":""}`, false},
+		{`{"Action":"pass"`, false},
+		{`PASS`, false},
+		{``, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if _, ok := ParseGoTestEvent(tt.line); ok != tt.wantOK {
+				t.Errorf("ParseGoTestEvent(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	junit := []byte(`<testsuite name="s"><testcase name="t"/></testsuite>`)
+	goJSON := []byte(`{"Action":"fail","Package":"p","Test":"TestA","Elapsed":0}`)
+
+	tests := []struct {
+		name    string
+		reports map[string][]byte
+		output  []byte
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name:    "junit reports win over the output",
+			reports: map[string][]byte{"report.xml": junit},
+			output:  goJSON,
+			want:    []Result{{Suite: "s", Name: "t", Status: StatusPassed}},
+		},
+		{
+			name:   "output without reports",
+			output: goJSON,
+			want:   []Result{{Suite: "p", Name: "TestA", Status: StatusFailed}},
+		},
+		{
+			name:    "invalid report",
+			reports: map[string][]byte{"report.xml": []byte("<testsuite")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Collect(tt.reports, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Collect() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	got := Summarize([]Result{
+		{Status: StatusPassed},
+		{Status: StatusPassed},
+		{Status: StatusFailed},
+		{Status: StatusSkipped},
+	})
+	want := Summary{Total: 4, Passed: 2, Failed: 1, Skipped: 1}
+	if got != want {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantLen int
+	}{
+		{"short", "boom", 4},
+		{"long", strings.Repeat("x", maxFailureMessage+10), maxFailureMessage + len("...")},
+		// The cut falls inside the 3-byte rune, which is dropped
+		{"multi-byte rune at the cut", strings.Repeat("x", maxFailureMessage-1) + "€", maxFailureMessage - 1 + len("...")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.in)
+			if len(got) != tt.wantLen {
+				t.Errorf("len(truncate()) = %d, want %d", len(got), tt.wantLen)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate() returned invalid UTF-8")
+			}
+		})
+	}
+}
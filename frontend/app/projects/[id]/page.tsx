@@ -10,11 +10,13 @@ import { Sidebar } from "@/components/layout/Sidebar";
 import { Card } from "@/components/common/Card";
 import { useAuthStore } from "@/lib/store/authStore";
 import { Project } from "@/lib/store/projectStore";
-import { projectApi } from "@/lib/api/projects";
+import { projectApi, Domain } from "@/lib/api/projects";
 import { buildsApi, Build } from "@/lib/api/builds";
-import { deploymentsApi, Deployment } from "@/lib/api/deployments";
+import { deploymentsApi, Deployment, Preview } from "@/lib/api/deployments";
 import { BuildCard } from "@/components/projects/BuildCard";
 import { BuildLogs } from "@/components/projects/BuildLogs";
+import { BuildStatusBadge } from "@/components/projects/BuildStatusBadge";
+import { TestReport } from "@/components/projects/TestReport";
 import {
   ArrowLeft,
   GitBranch,
@@ -34,9 +36,12 @@ import {
   Plus,
   Sparkles,
   X,
+  RotateCcw,
+  Minus,
+  Globe,
 } from "lucide-react";
 
-type TabType = "overview" | "builds" | "settings" | "secrets";
+type TabType = "overview" | "builds" | "settings" | "secrets" | "domains";
 
 const statusConfig = {
   running: {
@@ -91,12 +96,16 @@ export default function ProjectDetailPage() {
   const [expandedBuildId, setExpandedBuildId] = useState<string | null>(null);
   const [triggeringBuild, setTriggeringBuild] = useState(false);
   const [logsClearedTimestamp, setLogsClearedTimestamp] = useState<number>(0);
+  const [buildVariants, setBuildVariants] = useState<Record<string, Build[]>>({});
+  const [expandedVariantId, setExpandedVariantId] = useState<string | null>(null);
 
   // Deployment state
   const [deployment, setDeployment] = useState<Deployment | null>(null);
   const [deploymentLoading, setDeploymentLoading] = useState(false);
   const [isDeploying, setIsDeploying] = useState(false);
   const [isStopping, setIsStopping] = useState(false);
+  const [isRollingBack, setIsRollingBack] = useState(false);
+  const [isScaling, setIsScaling] = useState(false);
   const [isRebuilding, setIsRebuilding] = useState(false);
 
   // Build & Deploy workflow state
@@ -114,6 +123,20 @@ export default function ProjectDetailPage() {
   const [newSecretValue, setNewSecretValue] = useState("");
   const [isAddingSecret, setIsAddingSecret] = useState(false);
 
+  // Custom domains state
+  const [domains, setDomains] = useState<Domain[]>([]);
+  const [domainsLoading, setDomainsLoading] = useState(false);
+  const [newDomainName, setNewDomainName] = useState("");
+  const [isAddingDomain, setIsAddingDomain] = useState(false);
+  const [verifyingDomainId, setVerifyingDomainId] = useState<string | null>(null);
+
+  // Pull request previews state
+  const [previews, setPreviews] = useState<Preview[]>([]);
+  const [stoppingPreview, setStoppingPreview] = useState<number | null>(null);
+
+  // Cancel build state
+  const [cancellingBuildId, setCancellingBuildId] = useState<string | null>(null);
+
   // AI Analysis state
   const [analyzingBuildId, setAnalyzingBuildId] = useState<string | null>(null);
   const [analysisResult, setAnalysisResult] = useState<{
@@ -188,6 +211,19 @@ export default function ProjectDetailPage() {
     fetchProject();
   }, [params.id, accessToken, authLoading, project]);
 
+  // Load the variant builds of an expanded matrix build, again as it progresses
+  const expandedBuild = builds.find((b) => b.id === expandedBuildId);
+  useEffect(() => {
+    if (!accessToken || !expandedBuild?.matrix) {
+      return;
+    }
+    const buildId = expandedBuild.id;
+    buildsApi
+      .getBuild(accessToken, buildId)
+      .then((details) => setBuildVariants((prev) => ({ ...prev, [buildId]: details.variants })))
+      .catch((err) => console.error("Failed to fetch build variants:", err));
+  }, [accessToken, expandedBuild?.id, expandedBuild?.matrix, expandedBuild?.status]);
+
   // Poll project status if project is building or pending
   useEffect(() => {
     if (!accessToken || authLoading || !project || isLoading) {
@@ -335,6 +371,27 @@ export default function ProjectDetailPage() {
     }
   }, [activeTab, accessToken, projectId]);
 
+  // Fetch custom domains when domains tab is active
+  useEffect(() => {
+    if (activeTab === "domains" && accessToken && projectId) {
+      const fetchDomains = async () => {
+        setDomainsLoading(true);
+        try {
+          const currentToken = useAuthStore.getState().accessToken;
+          if (!currentToken) return;
+          const domainsList = await projectApi.listDomains(currentToken, projectId);
+          setDomains(domainsList);
+        } catch (err: any) {
+          console.error("Failed to fetch domains:", err);
+          setError(err.message || "Failed to fetch domains");
+        } finally {
+          setDomainsLoading(false);
+        }
+      };
+      fetchDomains();
+    }
+  }, [activeTab, accessToken, projectId]);
+
   // Fetch latest build for overview (always fetch first build)
   useEffect(() => {
     const fetchLatestBuild = async () => {
@@ -410,6 +467,35 @@ export default function ProjectDetailPage() {
     }
   }, [projectId, accessToken, authLoading, activeTab]);
 
+  // Fetch pull request previews when builds tab is active
+  useEffect(() => {
+    if (activeTab !== "builds" || !accessToken || authLoading) return;
+
+    deploymentsApi
+      .listPreviews(accessToken, projectId)
+      .then((list) => setPreviews(list || []))
+      .catch((err) => {
+        console.error("Failed to fetch previews:", err);
+        setPreviews([]);
+      });
+  }, [projectId, accessToken, authLoading, activeTab]);
+
+  const handleStopPreview = async (pullRequest: number) => {
+    if (!accessToken) return;
+    if (!confirm(`Stop the preview of pull request #${pullRequest}? The next push to it deploys it again.`)) return;
+
+    setStoppingPreview(pullRequest);
+    try {
+      await deploymentsApi.stopPreview(accessToken, projectId, pullRequest);
+      setPreviews((prev) => prev.filter((p) => p.pull_request !== pullRequest));
+    } catch (err: any) {
+      console.error("Failed to stop preview:", err);
+      setError(err.message || "Failed to stop preview");
+    } finally {
+      setStoppingPreview(null);
+    }
+  };
+
   const handleDelete = async () => {
     if (!accessToken) {
       setError("Not authenticated");
@@ -513,10 +599,15 @@ export default function ProjectDetailPage() {
             if (
               buildDetails.status === "success" ||
               buildDetails.status === "failed" ||
-              buildDetails.status === "deploy_failed"
+              buildDetails.status === "deploy_failed" ||
+              buildDetails.status === "cancelled" ||
+              buildDetails.status === "superseded"
             ) {
               clearInterval(poll);
-              if (buildDetails.status === "failed" || buildDetails.status === "deploy_failed") {
+              if (buildDetails.status === "cancelled" || buildDetails.status === "superseded") {
+                setBuildAndDeployStep("idle");
+                setCurrentBuildId(null);
+              } else if (buildDetails.status === "failed" || buildDetails.status === "deploy_failed") {
                 setError("Build failed. Please check build logs for details.");
                 setBuildAndDeployStep("failed");
               } else if (buildDetails.status === "success") {
@@ -583,6 +674,57 @@ export default function ProjectDetailPage() {
     }
   };
 
+  const handleRollback = async () => {
+    if (!accessToken) {
+      setError("Not authenticated");
+      return;
+    }
+
+    if (!confirm("Roll back to the previously deployed build?")) {
+      return;
+    }
+
+    setIsRollingBack(true);
+    setError(null);
+    try {
+      const rolledBack = await deploymentsApi.rollback(accessToken, projectId);
+      setDeployment(rolledBack);
+    } catch (err: any) {
+      console.error("Failed to roll back deployment:", err);
+      setError(err.message || "Failed to roll back deployment");
+    } finally {
+      setIsRollingBack(false);
+    }
+  };
+
+  const handleScale = async (replicas: number) => {
+    if (!accessToken) {
+      setError("Not authenticated");
+      return;
+    }
+
+    setIsScaling(true);
+    setError(null);
+    try {
+      const scaled = await deploymentsApi.scale(accessToken, projectId, replicas);
+      setProject((prev) => (prev ? { ...prev, replicas: scaled.replicas } : prev));
+      setDeployment((prev) =>
+        prev && scaled.deployment_id
+          ? {
+              ...prev,
+              replicas: scaled.replicas,
+              running_replicas: scaled.running_replicas,
+            }
+          : prev
+      );
+    } catch (err: any) {
+      console.error("Failed to scale deployment:", err);
+      setError(err.message || "Failed to scale deployment");
+    } finally {
+      setIsScaling(false);
+    }
+  };
+
   const handleRebuild = async () => {
     if (!accessToken) {
       setError("Not authenticated");
@@ -620,7 +762,9 @@ export default function ProjectDetailPage() {
             if (
               buildDetails.status === "success" ||
               buildDetails.status === "failed" ||
-              buildDetails.status === "deploy_failed"
+              buildDetails.status === "deploy_failed" ||
+              buildDetails.status === "cancelled" ||
+              buildDetails.status === "superseded"
             ) {
               clearInterval(poll);
               // If successful, auto-deploy
@@ -710,6 +854,7 @@ export default function ProjectDetailPage() {
     { id: "builds", label: "Builds", icon: RefreshCw },
     { id: "settings", label: "Settings", icon: Settings },
     { id: "secrets", label: "Secrets", icon: Key },
+    { id: "domains", label: "Domains", icon: Globe },
   ] as const;
 
   // Show loading while auth is rehydrating or project is loading
@@ -971,6 +1116,19 @@ export default function ProjectDetailPage() {
 
                     <div className="flex items-center gap-2">
                       {deployment?.status === "running" ? (
+                        <>
+                        <button
+                          onClick={handleRollback}
+                          disabled={isRollingBack || isStopping || buildAndDeployStep !== "idle"}
+                          className="inline-flex items-center gap-2 rounded-lg border border-surface-700 px-4 py-2 text-sm font-medium text-foreground transition-colors hover:bg-surface-800 disabled:opacity-50"
+                        >
+                          {isRollingBack ? (
+                            <Loader2 className="h-4 w-4 animate-spin" />
+                          ) : (
+                            <RotateCcw className="h-4 w-4" />
+                          )}
+                          Rollback
+                        </button>
                         <button
                           onClick={handleStop}
                           disabled={isStopping || buildAndDeployStep !== "idle"}
@@ -983,6 +1141,7 @@ export default function ProjectDetailPage() {
                           )}
                           Stop
                         </button>
+                        </>
                       ) : (
                         <>
                           {/* Build button - only show if no builds yet (first build or after history clear) */}
@@ -1326,6 +1485,60 @@ export default function ProjectDetailPage() {
                           </span>
                         </dd>
                       </div>
+                      {deployment.health && (
+                        <div>
+                          <dt className="text-sm text-surface-400">Health</dt>
+                          <dd className="mt-1 text-sm">
+                            <span
+                              className={
+                                deployment.health === "healthy"
+                                  ? "text-accent-emerald"
+                                  : deployment.health === "unhealthy"
+                                  ? "text-accent-rose"
+                                  : "text-accent-amber"
+                              }
+                            >
+                              {deployment.health === "healthy"
+                                ? "Healthy"
+                                : deployment.health === "unhealthy"
+                                ? "Unhealthy"
+                                : "Starting"}
+                            </span>
+                            {(deployment.restart_count ?? 0) > 0 && (
+                              <span className="ml-2 text-surface-400">
+                                {deployment.restart_count} restart{deployment.restart_count === 1 ? "" : "s"}
+                              </span>
+                            )}
+                          </dd>
+                        </div>
+                      )}
+                      {deployment.status === "running" && (
+                        <div>
+                          <dt className="text-sm text-surface-400">Replicas</dt>
+                          <dd className="mt-1 flex items-center gap-2 text-sm text-foreground">
+                            <button
+                              onClick={() => handleScale((deployment.replicas || 1) - 1)}
+                              disabled={isScaling || (deployment.replicas || 1) <= 1}
+                              className="rounded-md bg-surface-800 p-1 text-surface-300 hover:bg-surface-700 disabled:opacity-50"
+                              title="Remove a replica"
+                            >
+                              <Minus className="h-3 w-3" />
+                            </button>
+                            <span>
+                              {deployment.running_replicas ?? 0}/{deployment.replicas || 1} running
+                            </span>
+                            <button
+                              onClick={() => handleScale((deployment.replicas || 1) + 1)}
+                              disabled={isScaling}
+                              className="rounded-md bg-surface-800 p-1 text-surface-300 hover:bg-surface-700 disabled:opacity-50"
+                              title="Add a replica"
+                            >
+                              <Plus className="h-3 w-3" />
+                            </button>
+                            {isScaling && <Loader2 className="h-3 w-3 animate-spin text-surface-400" />}
+                          </dd>
+                        </div>
+                      )}
                       {/* Show public_url if available, even if status is failed (container might still be running) */}
                       {deployment.public_url && (
                         <div>
@@ -1418,6 +1631,96 @@ export default function ProjectDetailPage() {
                         {project.start_command || "npm start"}
                       </dd>
                     </div>
+                    {project.test_command && (
+                      <div>
+                        <dt className="text-sm text-surface-400">Test Command</dt>
+                        <dd className="mt-1 rounded-lg bg-surface-800 px-3 py-2 font-mono text-sm text-foreground">
+                          {project.test_command}
+                        </dd>
+                      </div>
+                    )}
+                    {project.root_directory && (
+                      <div>
+                        <dt className="text-sm text-surface-400">Root Directory</dt>
+                        <dd className="mt-1 font-mono text-sm text-foreground">
+                          {project.root_directory}
+                        </dd>
+                      </div>
+                    )}
+                    {project.watch_paths && project.watch_paths.length > 0 && (
+                      <div>
+                        <dt className="text-sm text-surface-400">Watch Paths</dt>
+                        <dd className="mt-1 font-mono text-sm text-foreground">
+                          {project.watch_paths.join(", ")}
+                        </dd>
+                      </div>
+                    )}
+                    <div>
+                      <dt className="text-sm text-surface-400">Build Limits</dt>
+                      <dd className="mt-1 text-foreground">
+                        {project.build_memory_mb || 4096} MB memory, {project.build_cpus || 1} CPU,{" "}
+                        {project.build_timeout_minutes || 30} min timeout
+                        {project.build_disk_mb ? `, ${project.build_disk_mb} MB disk` : ""}
+                      </dd>
+                    </div>
+                    <div>
+                      <dt className="text-sm text-surface-400">Clone</dt>
+                      <dd className="mt-1 text-foreground">
+                        {project.clone_full_history
+                          ? "Full history"
+                          : `Depth ${project.clone_depth || 1}`}
+                        {project.clone_fetch_tags ? ", tags" : ""}
+                        {project.clone_submodules ? ", submodules" : ""}
+                        {project.clone_lfs ? ", Git LFS" : ""}
+                      </dd>
+                    </div>
+                    <div>
+                      <dt className="text-sm text-surface-400">Auto Deploy</dt>
+                      <dd className="mt-1 text-foreground">
+                        {project.auto_deploy ? "Every successful build is deployed" : "Off"}
+                      </dd>
+                    </div>
+                    <div>
+                      <dt className="text-sm text-surface-400">Auto Cancel</dt>
+                      <dd className="mt-1 text-foreground">
+                        {project.auto_cancel_running
+                          ? "New pushes supersede queued and running builds of the branch"
+                          : project.auto_cancel_pending
+                            ? "New pushes supersede queued builds of the branch"
+                            : "Off"}
+                      </dd>
+                    </div>
+                    {project.build_matrix && project.build_matrix.length > 0 && (
+                      <div>
+                        <dt className="text-sm text-surface-400">Build Matrix</dt>
+                        <dd className="mt-1 font-mono text-sm text-foreground">
+                          {project.build_matrix
+                            .map((v) => (v.optional ? `${v.name} (optional)` : v.name))
+                            .join(", ")}
+                        </dd>
+                      </div>
+                    )}
+                    <div>
+                      <dt className="text-sm text-surface-400">Health Check</dt>
+                      <dd className="mt-1 text-foreground">
+                        {project.health_check_type === "http"
+                          ? `HTTP GET ${project.health_check_path || "/"}${
+                              project.health_check_expected_status
+                                ? ` expecting ${project.health_check_expected_status}`
+                                : ""
+                            }`
+                          : "TCP connect"}
+                        , every {project.health_check_interval_seconds || 10}s,{" "}
+                        {project.health_check_timeout_seconds || 3}s timeout,{" "}
+                        {project.health_check_retries || 3} retries
+                      </dd>
+                    </div>
+                    <div>
+                      <dt className="text-sm text-surface-400">Replicas</dt>
+                      <dd className="mt-1 text-foreground">
+                        {project.replicas || 1} container{(project.replicas || 1) === 1 ? "" : "s"} per deployment
+                      </dd>
+                    </div>
                   </dl>
                 </Card>
               </div>
@@ -1425,6 +1728,51 @@ export default function ProjectDetailPage() {
 
             {activeTab === "builds" && (
               <div className="space-y-4">
+                {previews.length > 0 && (
+                  <Card variant="elevated">
+                    <h3 className="mb-3 text-lg font-semibold text-foreground">
+                      Pull Request Previews
+                    </h3>
+                    <div className="space-y-2">
+                      {previews.map((preview) => (
+                        <div
+                          key={preview.pull_request}
+                          className="flex items-center justify-between rounded-lg border border-surface-700 bg-surface-900/50 px-4 py-3"
+                        >
+                          <div className="flex items-center gap-3">
+                            <span className="text-sm font-medium text-foreground">
+                              PR #{preview.pull_request}
+                            </span>
+                            {preview.public_url && (
+                              <a
+                                href={preview.public_url}
+                                target="_blank"
+                                rel="noopener noreferrer"
+                                className="inline-flex items-center gap-1 font-mono text-xs text-primary hover:underline"
+                              >
+                                {preview.public_url}
+                                <ExternalLink className="h-3 w-3" />
+                              </a>
+                            )}
+                          </div>
+                          <button
+                            onClick={() => handleStopPreview(preview.pull_request)}
+                            disabled={stoppingPreview === preview.pull_request}
+                            className="rounded-lg p-2 text-surface-400 transition-colors hover:bg-surface-800 hover:text-accent-rose disabled:opacity-50"
+                            title="Stop preview"
+                          >
+                            {stoppingPreview === preview.pull_request ? (
+                              <Loader2 className="h-4 w-4 animate-spin" />
+                            ) : (
+                              <Square className="h-4 w-4" />
+                            )}
+                          </button>
+                        </div>
+                      ))}
+                    </div>
+                  </Card>
+                )}
+
                 <div className="flex items-center justify-between">
                   <h3 className="text-lg font-semibold text-foreground">
                     Build History
@@ -1474,6 +1822,49 @@ export default function ProjectDetailPage() {
                         />
                         {expandedBuildId === build.id && accessToken && (
                           <div className="ml-4 space-y-3">
+                            {/* Cancel button - only for builds that have not finished */}
+                            {["pending", "running", "building_image", "pushing_image"].includes(build.status) && (
+                              <Card variant="elevated">
+                                <div className="flex items-center justify-between">
+                                  <div className="flex items-center gap-2">
+                                    <Loader2 className="h-4 w-4 animate-spin text-primary" />
+                                    <span className="text-sm text-surface-400">
+                                      Build in progress
+                                    </span>
+                                  </div>
+                                  <button
+                                    onClick={async () => {
+                                      if (!accessToken) return;
+                                      setCancellingBuildId(build.id);
+                                      try {
+                                        const cancelled = await buildsApi.cancelBuild(accessToken, build.id);
+                                        setBuilds((prev) =>
+                                          prev.map((b) => (b.id === build.id ? { ...b, ...cancelled } : b))
+                                        );
+                                      } catch (err: any) {
+                                        setError(err.message || "Failed to cancel build");
+                                      } finally {
+                                        setCancellingBuildId(null);
+                                      }
+                                    }}
+                                    disabled={cancellingBuildId === build.id}
+                                    className="inline-flex items-center gap-2 rounded-lg bg-red-500/10 px-4 py-2 text-sm font-medium text-red-500 transition-colors hover:bg-red-500/20 disabled:opacity-50"
+                                  >
+                                    {cancellingBuildId === build.id ? (
+                                      <>
+                                        <Loader2 className="h-4 w-4 animate-spin" />
+                                        Cancelling...
+                                      </>
+                                    ) : (
+                                      <>
+                                        <XCircle className="h-4 w-4" />
+                                        Cancel build
+                                      </>
+                                    )}
+                                  </button>
+                                </div>
+                              </Card>
+                            )}
                             {/* Tell me why button - only for failed builds */}
                             {(build.status === "failed" || build.status === "deploy_failed") && (
                               <Card variant="elevated" className="border-accent-amber/30">
@@ -1522,6 +1913,49 @@ export default function ProjectDetailPage() {
                                 </div>
                               </Card>
                             )}
+                            {build.matrix && buildVariants[build.id] && (
+                              <Card variant="elevated">
+                                <h4 className="mb-3 text-sm font-medium text-foreground">
+                                  Matrix variants
+                                </h4>
+                                <div className="space-y-2">
+                                  {buildVariants[build.id].map((variant) => (
+                                    <div key={variant.id} className="space-y-2">
+                                      <button
+                                        onClick={() =>
+                                          setExpandedVariantId(
+                                            expandedVariantId === variant.id ? null : variant.id
+                                          )
+                                        }
+                                        className="flex w-full items-center justify-between rounded-lg px-2 py-1.5 text-left transition-colors hover:bg-surface-800/50"
+                                      >
+                                        <span className="font-mono text-sm text-foreground">
+                                          {variant.variant}
+                                          {variant.optional && (
+                                            <span className="ml-2 text-xs text-surface-500">optional</span>
+                                          )}
+                                        </span>
+                                        <BuildStatusBadge status={variant.status} />
+                                      </button>
+                                      {expandedVariantId === variant.id && (
+                                        <BuildLogs
+                                          key={`${variant.id}-${logsClearedTimestamp}`}
+                                          buildId={variant.id}
+                                          projectId={projectId}
+                                          token={accessToken}
+                                          buildStatus={variant.status}
+                                        />
+                                      )}
+                                    </div>
+                                  ))}
+                                </div>
+                              </Card>
+                            )}
+                            <TestReport
+                              buildId={build.id}
+                              token={accessToken}
+                              buildStatus={build.status}
+                            />
                             <Card variant="elevated">
                               <BuildLogs
                                 key={`${build.id}-${logsClearedTimestamp}`} // Force remount when logs are cleared
@@ -1687,6 +2121,154 @@ export default function ProjectDetailPage() {
                 )}
               </Card>
             )}
+
+            {activeTab === "domains" && (
+              <Card variant="elevated">
+                <h3 className="mb-1 text-lg font-semibold text-foreground">
+                  Custom Domains
+                </h3>
+                <p className="mb-4 text-sm text-surface-400">
+                  Available on the Premium plan. Add a TXT record to prove you own the
+                  domain and point it to this server; verified domains are served with
+                  their own certificate from the next deployment.
+                </p>
+
+                {/* Add Domain Form */}
+                <div className="mb-6 flex gap-3">
+                  <input
+                    type="text"
+                    value={newDomainName}
+                    onChange={(e) => setNewDomainName(e.target.value)}
+                    placeholder="app.example.com"
+                    className="flex-1 rounded-lg border border-surface-700 bg-surface-900 px-3 py-2 font-mono text-sm text-foreground placeholder:text-surface-500 focus:border-primary focus:outline-none focus:ring-1 focus:ring-primary"
+                  />
+                  <button
+                    onClick={async () => {
+                      if (!newDomainName || !accessToken) return;
+                      setIsAddingDomain(true);
+                      try {
+                        const domain = await projectApi.addDomain(accessToken, projectId, newDomainName);
+                        setDomains((prev) => [...prev, domain]);
+                        setNewDomainName("");
+                      } catch (err: any) {
+                        console.error("Failed to add domain:", err);
+                        setError(err.message || "Failed to add domain");
+                      } finally {
+                        setIsAddingDomain(false);
+                      }
+                    }}
+                    disabled={!newDomainName || isAddingDomain}
+                    className="rounded-lg bg-primary px-4 py-2 text-sm font-medium text-white transition-colors hover:bg-primary-600 disabled:cursor-not-allowed disabled:opacity-50"
+                  >
+                    {isAddingDomain ? (
+                      <Loader2 className="inline h-4 w-4 animate-spin" />
+                    ) : (
+                      <>
+                        <Plus className="mr-2 inline h-4 w-4" />
+                        Add Domain
+                      </>
+                    )}
+                  </button>
+                </div>
+
+                {/* Domains List */}
+                {domainsLoading ? (
+                  <div className="flex items-center justify-center py-8">
+                    <Loader2 className="h-5 w-5 animate-spin text-primary" />
+                  </div>
+                ) : domains.length === 0 ? (
+                  <div className="text-center py-12">
+                    <Globe className="mx-auto mb-4 h-12 w-12 text-surface-600" />
+                    <p className="text-surface-400">No custom domains</p>
+                  </div>
+                ) : (
+                  <div className="space-y-2">
+                    {domains.map((domain) => (
+                      <div
+                        key={domain.id}
+                        className="rounded-lg border border-surface-700 bg-surface-900/50 px-4 py-3"
+                      >
+                        <div className="flex items-center justify-between">
+                          <div className="flex items-center gap-3">
+                            <p className="font-mono text-sm font-medium text-foreground">
+                              {domain.name}
+                            </p>
+                            <span
+                              className={`text-xs ${
+                                domain.status === "verified"
+                                  ? "text-accent-emerald"
+                                  : domain.status === "failed"
+                                  ? "text-accent-rose"
+                                  : "text-accent-amber"
+                              }`}
+                            >
+                              {domain.status === "verified"
+                                ? "Verified"
+                                : domain.status === "failed"
+                                ? "Verification failed"
+                                : "Pending verification"}
+                            </span>
+                          </div>
+                          <div className="flex items-center gap-1">
+                            <button
+                              onClick={async () => {
+                                if (!accessToken) return;
+                                setVerifyingDomainId(domain.id);
+                                try {
+                                  const verified = await projectApi.verifyDomain(accessToken, projectId, domain.id);
+                                  setDomains((prev) => prev.map((d) => (d.id === verified.id ? verified : d)));
+                                } catch (err: any) {
+                                  console.error("Failed to verify domain:", err);
+                                  setError(err.message || "Failed to verify domain");
+                                } finally {
+                                  setVerifyingDomainId(null);
+                                }
+                              }}
+                              disabled={verifyingDomainId === domain.id}
+                              className="rounded-lg p-2 text-surface-400 transition-colors hover:bg-surface-800 hover:text-foreground disabled:opacity-50"
+                              title="Check the TXT record"
+                            >
+                              {verifyingDomainId === domain.id ? (
+                                <Loader2 className="h-4 w-4 animate-spin" />
+                              ) : (
+                                <RefreshCw className="h-4 w-4" />
+                              )}
+                            </button>
+                            <button
+                              onClick={async () => {
+                                if (!confirm(`Remove domain "${domain.name}"? It is served until the next deployment.`)) return;
+                                if (!accessToken) return;
+                                try {
+                                  await projectApi.removeDomain(accessToken, projectId, domain.id);
+                                  setDomains((prev) => prev.filter((d) => d.id !== domain.id));
+                                } catch (err: any) {
+                                  console.error("Failed to remove domain:", err);
+                                  setError(err.message || "Failed to remove domain");
+                                }
+                              }}
+                              className="rounded-lg p-2 text-surface-400 transition-colors hover:bg-surface-800 hover:text-accent-rose"
+                            >
+                              <Trash2 className="h-4 w-4" />
+                            </button>
+                          </div>
+                        </div>
+                        {domain.status !== "verified" && (
+                          <div className="mt-2 space-y-1 text-xs text-surface-400">
+                            <p>
+                              TXT <span className="font-mono text-surface-300">{domain.txt_record_name}</span>
+                            </p>
+                            <p>
+                              Value <span className="font-mono text-surface-300">{domain.txt_record_value}</span>
+                            </p>
+                            {domain.error && <p className="text-accent-rose">{domain.error}</p>}
+                          </div>
+                        )}
+                      </div>
+                    ))}
+                  </div>
+                )}
+              </Card>
+            )}
               </>
             )}
           </div>
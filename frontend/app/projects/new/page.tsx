@@ -14,6 +14,7 @@ import {
   Folder,
   Terminal,
   Play,
+  FlaskConical,
   Plus,
   Trash2,
   Loader2,
@@ -52,6 +53,10 @@ export default function NewProjectPage() {
     preset: "nodejs",
     build_command: "npm run build",
     start_command: "npm start",
+    test_command: "",
+    root_directory: "",
+    watch_paths: "",
+    auto_deploy: false,
     env_vars: [{ key: "", value: "" }],
     github_repo_id: 0,
     is_private: false,
@@ -158,8 +163,15 @@ export default function NewProjectPage() {
         preset: formData.preset,
         build_command: formData.build_command,
         start_command: formData.start_command,
+        test_command: formData.test_command.trim() || undefined,
+        root_directory: formData.root_directory.trim() || undefined,
+        watch_paths: formData.watch_paths
+          .split(",")
+          .map((p) => p.trim())
+          .filter(Boolean),
         github_repo_id: formData.github_repo_id || undefined,
         is_private: formData.is_private,
+        auto_deploy: formData.auto_deploy,
       };
 
       await projectApi.createProject(accessToken, payload);
@@ -472,6 +484,75 @@ export default function NewProjectPage() {
                       />
                     </div>
                   </div>
+
+                  <div>
+                    <label className="mb-2 block text-sm font-medium text-foreground">
+                      Test Command
+                    </label>
+                    <div className="relative">
+                      <FlaskConical className="absolute left-3 top-1/2 h-5 w-5 -translate-y-1/2 text-surface-500" />
+                      <input
+                        type="text"
+                        value={formData.test_command}
+                        onChange={(e) => handleInputChange("test_command", e.target.value)}
+                        placeholder="npm test"
+                        className="w-full rounded-lg border border-surface-700 bg-surface-900 py-3 pl-10 pr-4 font-mono text-sm text-foreground placeholder:text-surface-500 focus:border-primary focus:outline-none focus:ring-1 focus:ring-primary"
+                      />
+                    </div>
+                    <p className="mt-1 text-xs text-surface-500">
+                      Optional. Runs after the build, failing tests stop the deploy. JUnit XML and go test -json output show per-test results.
+                    </p>
+                  </div>
+
+                  <div>
+                    <label className="mb-2 block text-sm font-medium text-foreground">
+                      Root Directory
+                    </label>
+                    <div className="relative">
+                      <Folder className="absolute left-3 top-1/2 h-5 w-5 -translate-y-1/2 text-surface-500" />
+                      <input
+                        type="text"
+                        value={formData.root_directory}
+                        onChange={(e) => handleInputChange("root_directory", e.target.value)}
+                        placeholder="Repository root"
+                        className="w-full rounded-lg border border-surface-700 bg-surface-900 py-3 pl-10 pr-4 font-mono text-sm text-foreground placeholder:text-surface-500 focus:border-primary focus:outline-none focus:ring-1 focus:ring-primary"
+                      />
+                    </div>
+                    <p className="mt-1 text-xs text-surface-500">
+                      For monorepos: the directory of this service, e.g. services/api
+                    </p>
+                  </div>
+
+                  <div>
+                    <label className="mb-2 block text-sm font-medium text-foreground">
+                      Watch Paths
+                    </label>
+                    <input
+                      type="text"
+                      value={formData.watch_paths}
+                      onChange={(e) => handleInputChange("watch_paths", e.target.value)}
+                      placeholder="services/api/**, libs/shared/**"
+                      className="w-full rounded-lg border border-surface-700 bg-surface-900 px-4 py-3 font-mono text-sm text-foreground placeholder:text-surface-500 focus:border-primary focus:outline-none focus:ring-1 focus:ring-primary"
+                    />
+                    <p className="mt-1 text-xs text-surface-500">
+                      Comma-separated globs. Pushes that change no matching file are not built. Defaults to the root directory.
+                    </p>
+                  </div>
+
+                  <label className="flex items-start gap-3">
+                    <input
+                      type="checkbox"
+                      checked={formData.auto_deploy}
+                      onChange={(e) => setFormData((prev) => ({ ...prev, auto_deploy: e.target.checked }))}
+                      className="mt-1 h-4 w-4 rounded border-surface-700 bg-surface-900 text-primary focus:ring-primary"
+                    />
+                    <span>
+                      <span className="block text-sm font-medium text-foreground">Auto Deploy</span>
+                      <span className="block text-xs text-surface-500">
+                        Deploy every successful build automatically.
+                      </span>
+                    </span>
+                  </label>
                 </div>
 
                 <div className="mt-8 flex justify-between">
@@ -20,7 +20,7 @@ import {
   Loader2,
   AlertCircle,
 } from "lucide-react";
-import { authApi } from "@/lib/api/auth";
+import { authApi, BuildUsage } from "@/lib/api/auth";
 
 interface PlanLimits {
   maxProjects: number;
@@ -59,6 +59,7 @@ export default function SettingsPage() {
   const [isLoading, setIsLoading] = useState(true);
   const [isUpdatingPlan, setIsUpdatingPlan] = useState(false);
   const [planUpdateError, setPlanUpdateError] = useState<string | null>(null);
+  const [usage, setUsage] = useState<BuildUsage | null>(null);
 
   useEffect(() => {
     if (authLoading) {
@@ -74,6 +75,15 @@ export default function SettingsPage() {
     setIsLoading(false);
   }, [accessToken, authLoading, isAuthenticated, router]);
 
+  // Build quota, refreshed when the plan changes
+  useEffect(() => {
+    if (!accessToken) return;
+    authApi
+      .getBuildUsage(accessToken)
+      .then(setUsage)
+      .catch((err) => console.error("Failed to load build usage:", err));
+  }, [accessToken, user?.plan]);
+
   if (isLoading || !user) {
     return (
       <div className="relative min-h-screen bg-background">
@@ -237,6 +247,25 @@ export default function SettingsPage() {
                         <label className="text-sm text-surface-400">Max CPU per App</label>
                         <p className="mt-1 text-foreground">{limits.maxCpuCores} Core{limits.maxCpuCores > 1 ? "s" : ""}</p>
                       </div>
+                      {usage && (
+                        <>
+                          <div>
+                            <label className="text-sm text-surface-400">Builds This Month</label>
+                            <p className="mt-1 text-foreground">
+                              {usage.max_builds_per_month > 0
+                                ? `${usage.builds_used} / ${usage.max_builds_per_month}, ${usage.builds_remaining} left until ${new Date(usage.resets_at).toLocaleDateString()}`
+                                : `${usage.builds_used}, unlimited`}
+                            </p>
+                          </div>
+                          <div>
+                            <label className="text-sm text-surface-400">Running Builds</label>
+                            <p className="mt-1 text-foreground">
+                              {usage.active_builds}
+                              {usage.max_concurrent_builds > 0 ? ` / ${usage.max_concurrent_builds}` : ""}
+                            </p>
+                          </div>
+                        </>
+                      )}
                       <div>
                         <label className="text-sm text-surface-400">AI Analysis</label>
                         <p className="mt-1 text-foreground">{limits.aiAnalysis}</p>
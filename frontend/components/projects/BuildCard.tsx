@@ -49,6 +49,16 @@ export function BuildCard({ build, onClick, isExpanded }: BuildCardProps) {
               <GitBranch className="h-3.5 w-3.5" />
               <span className="font-mono">{shortSha}</span>
             </div>
+            {build.pull_request ? (
+              <span className="rounded bg-surface-800 px-1.5 py-0.5 text-xs text-surface-300">
+                PR #{build.pull_request}
+              </span>
+            ) : null}
+            {build.matrix ? (
+              <span className="rounded bg-surface-800 px-1.5 py-0.5 text-xs text-surface-300">
+                Matrix
+              </span>
+            ) : null}
           </div>
 
           <div className="flex items-center gap-4 text-xs text-surface-500">
@@ -175,7 +175,7 @@ export function BuildLogs({
   // Check if build is still running (non-terminal states)
   const isBuildRunning = (status?: string): boolean => {
     if (!status) return false;
-    const terminalStates = ["success", "failed", "deploy_failed"];
+    const terminalStates = ["success", "failed", "deploy_failed", "cancelled", "superseded"];
     return !terminalStates.includes(status.toLowerCase());
   };
 
@@ -205,12 +205,8 @@ export function BuildLogs({
         }
         setIsStreaming(true);
         const channel = `build_logs:${projectId}:${buildId}`;
-        ws.subscribe(channel, (message) => {
-          if (!mounted) return;
-          // Only add logs if build is still running
-          if (!isBuildRunning(buildStatus)) {
-            return;
-          }
+        // Add a live log line with an ID after the historical logs
+        const appendLog = (line: string) => {
           // Add new log line with unique ID
           // Use timestamp + counter to ensure uniqueness
           wsLogCounterRef.current++;
@@ -223,7 +219,7 @@ export function BuildLogs({
             id: uniqueId,
             build_id: buildId,
             timestamp: new Date().toISOString(),
-            log_line: message.message,
+            log_line: line,
           };
           setLogs((prev) => {
             // Ensure logs are sorted by ID (ascending)
@@ -235,6 +231,23 @@ export function BuildLogs({
             }
             return sorted;
           });
+        };
+
+        ws.subscribe(channel, (message) => {
+          if (!mounted) return;
+          // Only add logs if build is still running
+          if (!isBuildRunning(buildStatus)) {
+            return;
+          }
+          appendLog(message.message);
+        });
+
+        // Build events, the runner stops sending logs once a build is cancelled or
+        // superseded
+        ws.subscribe(`events:${projectId}:${buildId}`, (message) => {
+          if (!mounted || message.event !== "cancelled") return;
+          appendLog(`[cancel] ${message.message}`);
+          setIsStreaming(false);
         });
       })
       .catch((err) => {
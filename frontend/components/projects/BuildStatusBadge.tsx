@@ -8,7 +8,9 @@ export type BuildStatus =
   | "pushing_image"
   | "deploying"
   | "success"
-  | "deploy_failed";
+  | "deploy_failed"
+  | "cancelled"
+  | "superseded";
 
 interface BuildStatusBadgeProps {
   status: string;
@@ -52,6 +54,14 @@ export function BuildStatusBadge({ status, className }: BuildStatusBadgeProps) {
       label: "Deploy Failed",
       className: "bg-red-500/10 text-red-500 border-red-500/20",
     },
+    cancelled: {
+      label: "Cancelled",
+      className: "bg-surface-800 text-surface-400 border-surface-700",
+    },
+    superseded: {
+      label: "Superseded",
+      className: "bg-surface-800 text-surface-400 border-surface-700",
+    },
   };
 
   const config = statusConfig[status.toLowerCase()] || {
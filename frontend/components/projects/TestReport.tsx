@@ -0,0 +1,142 @@
+"use client";
+
+import { ReactNode, useEffect, useState } from "react";
+import { buildsApi, TestCase, TestReport as TestReportData } from "@/lib/api/builds";
+import { Card } from "@/components/common/Card";
+import { cn } from "@/lib/utils/cn";
+import { CheckCircle2, ChevronDown, ChevronRight, MinusCircle, XCircle } from "lucide-react";
+
+interface TestReportProps {
+  buildId: string;
+  token: string;
+  buildStatus?: string; // Refetched when the status changes, e.g. once the test step ran
+}
+
+const statusIcons: Record<TestCase["status"], ReactNode> = {
+  passed: <CheckCircle2 className="h-3.5 w-3.5 shrink-0 text-green-500" />,
+  failed: <XCircle className="h-3.5 w-3.5 shrink-0 text-red-500" />,
+  skipped: <MinusCircle className="h-3.5 w-3.5 shrink-0 text-surface-500" />,
+};
+
+function formatDuration(ms: number): string {
+  if (ms < 1000) {
+    return `${ms}ms`;
+  }
+  return `${(ms / 1000).toFixed(2)}s`;
+}
+
+// Card with the per-test results of the test step. Renders nothing for builds
+// without reported tests.
+export function TestReport({ buildId, token, buildStatus }: TestReportProps) {
+  const [report, setReport] = useState<TestReportData | null>(null);
+  const [isLoading, setIsLoading] = useState(true);
+  const [showAll, setShowAll] = useState(false);
+  const [expandedTest, setExpandedTest] = useState<number | null>(null);
+
+  useEffect(() => {
+    if (!buildId || !token) {
+      setIsLoading(false);
+      return;
+    }
+
+    let cancelled = false;
+    buildsApi
+      .getTestReport(token, buildId)
+      .then((data) => {
+        if (!cancelled) setReport(data);
+      })
+      .catch((err) => {
+        // A build without a test step has no report
+        console.warn(`[TestReport] Failed to fetch test report for build ${buildId}:`, err);
+        if (!cancelled) setReport(null);
+      })
+      .finally(() => {
+        if (!cancelled) setIsLoading(false);
+      });
+
+    return () => {
+      cancelled = true;
+    };
+  }, [buildId, token, buildStatus]);
+
+  // Most builds have no tests, so nothing is shown until a report arrived
+  if (isLoading || !report || report.summary.total === 0) {
+    return null;
+  }
+
+  const { summary } = report;
+  // Failed tests first, the others only on demand
+  const tests = [...report.tests].sort(
+    (a, b) => Number(b.status === "failed") - Number(a.status === "failed")
+  );
+  const visible = showAll ? tests : tests.filter((t) => t.status === "failed");
+
+  return (
+    <Card variant="elevated" className="space-y-3">
+      <div className="flex items-center justify-between">
+        <h4 className="text-sm font-medium text-foreground">Tests</h4>
+        <div className="flex items-center gap-3 text-xs">
+          <span className="text-green-500">{summary.passed} passed</span>
+          <span className={cn(summary.failed > 0 ? "text-red-500" : "text-surface-500")}>
+            {summary.failed} failed
+          </span>
+          <span className="text-surface-500">{summary.skipped} skipped</span>
+          <span className="text-surface-600">{formatDuration(summary.duration_ms)}</span>
+        </div>
+      </div>
+
+      {visible.length > 0 && (
+        <div className="max-h-96 space-y-1 overflow-y-auto rounded-lg border border-surface-800 bg-surface-950 p-2">
+          {visible.map((test, index) => {
+            const isExpanded = expandedTest === index;
+            const hasMessage = !!test.failure_message;
+            return (
+              <div key={`${test.suite}-${test.name}-${index}`}>
+                <button
+                  onClick={() => hasMessage && setExpandedTest(isExpanded ? null : index)}
+                  className={cn(
+                    "flex w-full items-center gap-2 rounded px-2 py-1 text-left text-xs",
+                    hasMessage && "hover:bg-surface-800/50"
+                  )}
+                >
+                  {hasMessage ? (
+                    isExpanded ? (
+                      <ChevronDown className="h-3 w-3 shrink-0 text-surface-500" />
+                    ) : (
+                      <ChevronRight className="h-3 w-3 shrink-0 text-surface-500" />
+                    )
+                  ) : (
+                    <span className="w-3 shrink-0" />
+                  )}
+                  {statusIcons[test.status]}
+                  <span className="flex-1 truncate font-mono text-surface-300">
+                    {test.suite && <span className="text-surface-500">{test.suite} › </span>}
+                    {test.name}
+                  </span>
+                  <span className="shrink-0 text-surface-600">{formatDuration(test.duration_ms)}</span>
+                </button>
+                {isExpanded && hasMessage && (
+                  <pre className="mx-2 mb-2 mt-1 whitespace-pre-wrap break-words rounded bg-surface-900 p-2 font-mono text-xs text-red-400">
+                    {test.failure_message}
+                  </pre>
+                )}
+              </div>
+            );
+          })}
+        </div>
+      )}
+
+      {tests.length > visible.length || showAll ? (
+        <button
+          onClick={() => {
+            setShowAll(!showAll);
+            setExpandedTest(null);
+          }}
+          className="text-xs text-surface-400 transition-colors hover:text-foreground"
+        >
+          {showAll ? "Show failed tests only" : `Show all ${tests.length} tests`}
+        </button>
+      ) : null}
+    </Card>
+  );
+}
@@ -22,6 +22,17 @@ interface UserInfo {
   plan: "standard" | "premium";
 }
 
+export interface BuildUsage {
+  plan: string;
+  period_start: string;
+  resets_at: string;
+  builds_used: number; // Builds triggered this month across all projects
+  max_builds_per_month: number; // 0 = no limit
+  builds_remaining: number; // -1 when the plan has no monthly limit
+  active_builds: number; // Builds pending or running across all projects
+  max_concurrent_builds: number; // 0 = no limit
+}
+
 export const authApi = {
   // Redirect to GitHub login
   loginWithGitHub: () => {
@@ -55,6 +66,11 @@ export const authApi = {
     return apiClient.post("/api/auth/logout", {}, { token });
   },
 
+  // Get the builds used this month and running, against the plan limits
+  getBuildUsage: async (token: string): Promise<BuildUsage> => {
+    return apiClient.get<BuildUsage>("/api/user/usage", { token });
+  },
+
   // Update plan
   updatePlan: async (token: string, plan: "standard" | "premium"): Promise<{ success: boolean; message: string; plan: string }> => {
     return apiClient.put("/api/user/plan", { plan }, { token });
@@ -9,6 +9,15 @@ export interface Build {
   finished_at?: string;
   created_at: string;
   updated_at: string;
+  deployment_id?: string; // Set when the build was deployed automatically
+  pull_request?: number; // Set for preview builds of a pull request
+  branch?: string;
+  superseded_by?: string; // Newer build of the branch that superseded this one
+  // Build matrix, the parent build aggregates its variant builds
+  parent_id?: string;
+  variant?: string;
+  optional?: boolean; // A failed variant does not fail the parent
+  matrix?: boolean;
 }
 
 export interface BuildStep {
@@ -28,6 +37,7 @@ export interface BuildLog {
 
 export interface BuildDetails extends Build {
   steps: BuildStep[];
+  variants: Build[]; // Variant builds of a matrix build
 }
 
 export interface BuildLogsResponse {
@@ -35,6 +45,49 @@ export interface BuildLogsResponse {
   has_more: boolean;
 }
 
+export interface TestCase {
+  suite?: string;
+  name: string;
+  status: "passed" | "failed" | "skipped";
+  duration_ms: number;
+  failure_message?: string;
+}
+
+export interface TestReport {
+  summary: {
+    total: number;
+    passed: number;
+    failed: number;
+    skipped: number;
+    duration_ms: number;
+  };
+  tests: TestCase[];
+}
+
+export interface QueuePosition {
+  build_id: string;
+  queued: boolean; // False once a runner took the build
+  queue?: string; // "builds:premium" or "builds:standard"
+  state?: string; // "pending", or "retry" while waiting for a build slot
+  position: number; // 1-based, 0 when not pending
+  queue_length: number;
+  reason?: string;
+}
+
+// A build job the queue archived instead of retrying, admins only
+export interface DeadLetter {
+  id: string;
+  build_id: string;
+  project_id: string;
+  queue: string;
+  kind: "user" | "infrastructure";
+  reason: string;
+  attempts: number;
+  archived_at: string;
+  requeued_at?: string;
+  requeued_by?: string;
+}
+
 export interface AnalysisResult {
   analysis: string;
   suggestions: string[];
@@ -58,13 +111,14 @@ export const buildsApi = {
 
   // Get single build with details
   getBuild: async (token: string, buildId: string): Promise<BuildDetails> => {
-    const response = await apiClient.get<{ build: Build; steps: BuildStep[] }>(
+    const response = await apiClient.get<{ build: Build; steps: BuildStep[]; variants: Build[] }>(
       `/api/builds/${buildId}`,
       { token }
     );
     return {
       ...response.build,
       steps: response.steps || [],
+      variants: response.variants || [],
     };
   },
 
@@ -88,6 +142,25 @@ export const buildsApi = {
     return response.build;
   },
 
+  // Cancel a pending or running build
+  cancelBuild: async (token: string, buildId: string): Promise<Build> => {
+    const response = await apiClient.post<{ build: Build }>(
+      `/api/builds/${buildId}/cancel`,
+      {},
+      { token }
+    );
+    return response.build;
+  },
+
+  // Get the position of a pending build in its queue
+  getQueuePosition: async (token: string, buildId: string): Promise<QueuePosition> => {
+    const response = await apiClient.get<QueuePosition>(
+      `/api/builds/${buildId}/queue`,
+      { token }
+    );
+    return response;
+  },
+
   // Get build logs with pagination
   getBuildLogs: async (
     token: string,
@@ -109,6 +182,18 @@ export const buildsApi = {
     return response;
   },
 
+  // Get per-test results of the build's test step
+  getTestReport: async (token: string, buildId: string): Promise<TestReport> => {
+    const response = await apiClient.get<TestReport>(
+      `/api/builds/${buildId}/tests`,
+      { token }
+    );
+    return {
+      ...response,
+      tests: response.tests || [],
+    };
+  },
+
   // Clear all build logs for a project
   clearBuildLogs: async (
     token: string,
@@ -120,6 +205,48 @@ export const buildsApi = {
     );
   },
 
+  // List archived build jobs (admins only)
+  listDeadLetters: async (
+    token: string,
+    kind?: "user" | "infrastructure",
+    includeRequeued: boolean = false,
+    page: number = 1,
+    pageSize: number = 20
+  ): Promise<{ dead_letters: DeadLetter[]; total: number }> => {
+    const params = new URLSearchParams({
+      page: page.toString(),
+      page_size: pageSize.toString(),
+    });
+    if (kind) {
+      params.append("kind", kind);
+    }
+    if (includeRequeued) {
+      params.append("include_requeued", "true");
+    }
+
+    const response = await apiClient.get<{ dead_letters: DeadLetter[]; total: number }>(
+      `/api/admin/dead-letters?${params.toString()}`,
+      { token }
+    );
+    return {
+      ...response,
+      dead_letters: response.dead_letters || [],
+    };
+  },
+
+  // Run an archived build job again (admins only)
+  requeueDeadLetter: async (
+    token: string,
+    deadLetterId: string
+  ): Promise<{ dead_letter: DeadLetter; build: Build }> => {
+    const response = await apiClient.post<{ dead_letter: DeadLetter; build: Build }>(
+      `/api/admin/dead-letters/${deadLetterId}/requeue`,
+      {},
+      { token }
+    );
+    return response;
+  },
+
   // Analyze build errors using AI
   analyzeBuild: async (
     token: string,
@@ -6,13 +6,73 @@ export interface Deployment {
   container_id: string;
   status: string;
   public_url: string;
+  health?: "starting" | "healthy" | "unhealthy";
+  restart_count?: number;
+  replicas?: number;
+  running_replicas?: number;
+  environment?: string;
+}
+
+export interface DeploymentEvent {
+  status: string;
+  message?: string;
+  created_at: string;
+}
+
+export interface DeploymentRecord {
+  id: string;
+  project_id: string;
+  build_id?: string;
+  image_tag: string;
+  status: string;
+  container_id?: string;
+  host_port?: number;
+  domain?: string;
+  public_url?: string;
+  error?: string;
+  started_at: string;
+  stopped_at?: string;
+  created_at: string;
+  updated_at: string;
+  history: DeploymentEvent[];
+  rollback_of?: string;
+  health?: "starting" | "healthy" | "unhealthy";
+  restart_count?: number;
+  replicas?: number;
+  pull_request?: number;
+  environment?: string;
+  promoted_from?: string;
+}
+
+// Running preview of a pull request, created and removed by the GitHub webhook
+export interface Preview {
+  pull_request: number;
+  deployment_id: string;
+  build_id?: string;
+  status: string;
+  public_url?: string;
+  created_at: string;
 }
 
 export const deploymentsApi = {
-  // Deploy from latest successful build
-  deploy: async (token: string, projectId: string): Promise<Deployment> => {
+  // Deploy from latest successful build of the environment (production if empty).
+  // Admins pass overrideReason to deploy production during a freeze or outside
+  // the deploy windows.
+  deploy: async (
+    token: string,
+    projectId: string,
+    environment = "",
+    overrideReason = ""
+  ): Promise<Deployment> => {
+    const params = new URLSearchParams();
+    if (environment) params.set("environment", environment);
+    if (overrideReason) {
+      params.set("override", "true");
+      params.set("override_reason", overrideReason);
+    }
+    const query = params.toString() ? `?${params}` : "";
     const response = await apiClient.post<{ deployment: Deployment }>(
-      `/api/projects/${projectId}/deploy`,
+      `/api/projects/${projectId}/deploy${query}`,
       {},
       { token }
     );
@@ -55,5 +115,89 @@ export const deploymentsApi = {
     );
     return response.deployment || null;
   },
+
+  // Roll back to an earlier build ("previous" = build before the serving one)
+  rollback: async (
+    token: string,
+    projectId: string,
+    buildId = "previous"
+  ): Promise<Deployment> => {
+    const response = await apiClient.post<{ deployment: Deployment }>(
+      `/api/projects/${projectId}/rollback`,
+      { build_id: buildId },
+      { token }
+    );
+    return response.deployment;
+  },
+
+  // Deploy the image running in one environment to another, without a build
+  promote: async (
+    token: string,
+    projectId: string,
+    from: string,
+    to: string
+  ): Promise<Deployment> => {
+    const response = await apiClient.post<{ deployment: Deployment }>(
+      `/api/projects/${projectId}/promote`,
+      { from, to },
+      { token }
+    );
+    return response.deployment;
+  },
+
+  // Set the number of containers, checked against the plan. Applied to the
+  // running deployment, or to the next one if nothing is running.
+  scale: async (
+    token: string,
+    projectId: string,
+    replicas: number
+  ): Promise<{
+    replicas: number;
+    deployment_id?: string;
+    running_replicas?: number;
+  }> => {
+    return apiClient.post(
+      `/api/projects/${projectId}/scale`,
+      { replicas },
+      { token }
+    );
+  },
+
+  // List deployment history, newest first
+  listDeployments: async (
+    token: string,
+    projectId: string,
+    page = 1,
+    pageSize = 20
+  ): Promise<{ deployments: DeploymentRecord[]; total: number }> => {
+    const response = await apiClient.get<{
+      deployments: DeploymentRecord[];
+      total: number;
+    }>(
+      `/api/projects/${projectId}/deployments?page=${page}&page_size=${pageSize}`,
+      { token }
+    );
+    return response;
+  },
+
+  // List the running pull request previews
+  listPreviews: async (token: string, projectId: string): Promise<Preview[]> => {
+    const response = await apiClient.get<{ previews: Preview[] }>(
+      `/api/projects/${projectId}/previews`,
+      { token }
+    );
+    return response.previews;
+  },
+
+  // Stop the preview of a pull request, the next push to it deploys it again
+  stopPreview: async (
+    token: string,
+    projectId: string,
+    pullRequest: number
+  ): Promise<void> => {
+    await apiClient.delete(`/api/projects/${projectId}/previews/${pullRequest}`, {
+      token,
+    });
+  },
 };
 
@@ -1,5 +1,5 @@
 import { apiClient } from "./client";
-import { Project } from "@/lib/store/projectStore";
+import { MatrixVariant, Project } from "@/lib/store/projectStore";
 
 export interface Repository {
   id: number;
@@ -20,8 +20,41 @@ export interface CreateProjectRequest {
   preset: string;
   build_command?: string;
   start_command?: string;
+  test_command?: string;
   github_repo_id?: number;
   is_private?: boolean;
+  // Build settings, omitted or 0 uses the default, capped by the plan
+  build_memory_mb?: number;
+  build_cpus?: number;
+  build_timeout_minutes?: number;
+  build_disk_mb?: number;
+  // Monorepo: directory to build and globs a push must touch to trigger a build
+  root_directory?: string;
+  watch_paths?: string[];
+  // Clone options, a depth of 0 is a shallow clone of depth 1
+  clone_submodules?: boolean;
+  clone_lfs?: boolean;
+  clone_depth?: number;
+  clone_full_history?: boolean;
+  clone_fetch_tags?: boolean;
+  // Deploy every successful build
+  auto_deploy?: boolean;
+  // Auto-cancel: a new build of a branch supersedes its queued, and optionally
+  // running, older builds
+  auto_cancel_pending?: boolean;
+  auto_cancel_running?: boolean;
+  // Build matrix: every trigger builds and tests each variant, the build succeeds
+  // if every required variant passes
+  build_matrix?: MatrixVariant[];
+  // Health check of the deployments, omitted or 0 uses the default
+  health_check_type?: "tcp" | "http";
+  health_check_path?: string;
+  health_check_expected_status?: number;
+  health_check_interval_seconds?: number;
+  health_check_timeout_seconds?: number;
+  health_check_retries?: number;
+  // Containers per deployment, capped by the plan
+  replicas?: number;
 }
 
 interface Build {
@@ -36,10 +69,73 @@ interface Build {
 interface Secret {
   id: string;
   name: string;
+  environment?: string;
   created_at: string;
   updated_at: string;
 }
 
+// Deployment of a project next to production, e.g. staging
+export interface Environment {
+  id: string;
+  project_id: string;
+  name: string;
+  branch: string;
+  domain?: string;
+  memory_mb: number;
+  cpu_cores: number;
+  auto_deploy: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export type EnvironmentInput = Pick<
+  Environment,
+  "branch" | "memory_mb" | "cpu_cores" | "auto_deploy"
+> & { domain?: string };
+
+// Recurring period in which production deploys are allowed, opened by a cron
+// expression
+export interface DeployWindow {
+  schedule: string;
+  duration_minutes: number;
+  timezone?: string;
+}
+
+export interface DeployAuditEntry {
+  id: string;
+  user_id: string;
+  action: "freeze" | "unfreeze" | "windows" | "override";
+  environment?: string;
+  reason?: string;
+  created_at: string;
+}
+
+export interface DeployPolicy {
+  project_id: string;
+  windows: DeployWindow[];
+  frozen: boolean;
+  freeze_reason?: string;
+  frozen_by?: string;
+  frozen_at?: string;
+  // Whether production can be deployed now
+  open: boolean;
+  next_window?: string;
+  audit: DeployAuditEntry[];
+}
+
+export interface Domain {
+  id: string;
+  project_id: string;
+  name: string;
+  status: "pending" | "verified" | "failed";
+  // TXT record proving ownership of the domain
+  txt_record_name: string;
+  txt_record_value: string;
+  error?: string;
+  verified_at?: string;
+  created_at: string;
+}
+
 export const projectsApi = {
   // List all projects
   listProjects: async (token: string): Promise<Project[]> => {
@@ -105,11 +201,12 @@ export const projectsApi = {
     token: string,
     projectId: string,
     name: string,
-    value: string
+    value: string,
+    environment = ""
   ): Promise<Secret> => {
     const response = await apiClient.post<{ secret: Secret }>(
       `/api/projects/${projectId}/secrets`,
-      { name, value },
+      { name, value, environment },
       { token }
     );
     return response.secret;
@@ -125,6 +222,151 @@ export const projectsApi = {
       token,
     });
   },
+
+  // Custom domains (Premium), routed from the next deployment once verified
+  listDomains: async (token: string, projectId: string): Promise<Domain[]> => {
+    const response = await apiClient.get<{ domains: Domain[] }>(
+      `/api/projects/${projectId}/domains`,
+      { token }
+    );
+    return response.domains || [];
+  },
+
+  addDomain: async (
+    token: string,
+    projectId: string,
+    name: string
+  ): Promise<Domain> => {
+    const response = await apiClient.post<{ domain: Domain }>(
+      `/api/projects/${projectId}/domains`,
+      { name },
+      { token }
+    );
+    return response.domain;
+  },
+
+  // Look up the TXT record, a missing record returns the domain as "failed"
+  verifyDomain: async (
+    token: string,
+    projectId: string,
+    domainId: string
+  ): Promise<Domain> => {
+    const response = await apiClient.post<{ domain: Domain }>(
+      `/api/projects/${projectId}/domains/${domainId}/verify`,
+      {},
+      { token }
+    );
+    return response.domain;
+  },
+
+  removeDomain: async (
+    token: string,
+    projectId: string,
+    domainId: string
+  ): Promise<void> => {
+    return apiClient.delete(`/api/projects/${projectId}/domains/${domainId}`, {
+      token,
+    });
+  },
+
+  listEnvironments: async (
+    token: string,
+    projectId: string
+  ): Promise<Environment[]> => {
+    const response = await apiClient.get<{ environments: Environment[] }>(
+      `/api/projects/${projectId}/environments`,
+      { token }
+    );
+    return response.environments;
+  },
+
+  createEnvironment: async (
+    token: string,
+    projectId: string,
+    name: string,
+    input: EnvironmentInput
+  ): Promise<Environment> => {
+    const response = await apiClient.post<{ environment: Environment }>(
+      `/api/projects/${projectId}/environments`,
+      { name, ...input },
+      { token }
+    );
+    return response.environment;
+  },
+
+  updateEnvironment: async (
+    token: string,
+    projectId: string,
+    name: string,
+    input: EnvironmentInput
+  ): Promise<Environment> => {
+    const response = await apiClient.put<{ environment: Environment }>(
+      `/api/projects/${projectId}/environments/${name}`,
+      input,
+      { token }
+    );
+    return response.environment;
+  },
+
+  // Stops the deployment of the environment and removes it
+  deleteEnvironment: async (
+    token: string,
+    projectId: string,
+    name: string
+  ): Promise<void> => {
+    return apiClient.delete(`/api/projects/${projectId}/environments/${name}`, {
+      token,
+    });
+  },
+
+  getDeployPolicy: async (
+    token: string,
+    projectId: string
+  ): Promise<DeployPolicy> => {
+    const response = await apiClient.get<{ policy: DeployPolicy }>(
+      `/api/projects/${projectId}/deploy-policy`,
+      { token }
+    );
+    return response.policy;
+  },
+
+  // Replaces the deploy windows, none allows deploys at any time
+  setDeployWindows: async (
+    token: string,
+    projectId: string,
+    windows: DeployWindow[]
+  ): Promise<DeployPolicy> => {
+    const response = await apiClient.put<{ policy: DeployPolicy }>(
+      `/api/projects/${projectId}/deploy-policy`,
+      { windows },
+      { token }
+    );
+    return response.policy;
+  },
+
+  freezeDeploys: async (
+    token: string,
+    projectId: string,
+    reason: string
+  ): Promise<DeployPolicy> => {
+    const response = await apiClient.post<{ policy: DeployPolicy }>(
+      `/api/projects/${projectId}/freeze`,
+      { reason },
+      { token }
+    );
+    return response.policy;
+  },
+
+  unfreezeDeploys: async (
+    token: string,
+    projectId: string
+  ): Promise<DeployPolicy> => {
+    const response = await apiClient.delete<{ policy: DeployPolicy }>(
+      `/api/projects/${projectId}/freeze`,
+      { token }
+    );
+    return response.policy;
+  },
 };
 
 // Backward compatibility alias
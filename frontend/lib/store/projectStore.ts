@@ -2,6 +2,15 @@ import { create } from "zustand";
 
 export type ProjectStatus = "running" | "stopped" | "building" | "failed" | "pending";
 
+// One combination of a build matrix, e.g. a Node.js version, built and tested on
+// its own base image
+export interface MatrixVariant {
+  name: string;
+  base_image?: string; // Omitted uses the image of the preset
+  env?: Record<string, string>;
+  optional?: boolean; // A failure does not fail the build
+}
+
 export interface Project {
   id: string;
   name: string;
@@ -11,7 +20,30 @@ export interface Project {
   status: ProjectStatus;
   build_command?: string;
   start_command?: string;
+  test_command?: string;
   port?: number;
+  build_memory_mb?: number;
+  build_cpus?: number;
+  build_timeout_minutes?: number;
+  build_disk_mb?: number;
+  root_directory?: string;
+  watch_paths?: string[];
+  clone_submodules?: boolean;
+  clone_lfs?: boolean;
+  clone_depth?: number;
+  clone_full_history?: boolean;
+  clone_fetch_tags?: boolean;
+  auto_deploy?: boolean;
+  auto_cancel_pending?: boolean;
+  auto_cancel_running?: boolean;
+  build_matrix?: MatrixVariant[];
+  health_check_type?: string;
+  health_check_path?: string;
+  health_check_expected_status?: number;
+  health_check_interval_seconds?: number;
+  health_check_timeout_seconds?: number;
+  health_check_retries?: number;
+  replicas?: number;
   domain?: string;
   last_build_at?: string;
   created_at: string;
@@ -3,6 +3,8 @@ type LogMessage = {
   message: string;
   project_id?: string;
   build_id?: string;
+  event?: string; // Set for build events, e.g. "cancelled"
+  status?: string;
 };
 
 type SubscribeMessage = {
@@ -115,10 +117,15 @@ export class BuildLogsWebSocket {
                   message: data.message || data.line || line,
                   project_id: data.project_id || this.projectId,
                   build_id: data.build_id || this.buildId,
+                  event: data.event,
+                  status: data.status,
                 };
 
                 // Notify all listeners for this channel
-                const channel = `build_logs:${this.projectId}:${this.buildId}`;
+                const channel =
+                  data.type === "event"
+                    ? `events:${this.projectId}:${this.buildId}`
+                    : `build_logs:${this.projectId}:${this.buildId}`;
                 const channelListeners = this.listeners.get(channel);
                 if (channelListeners) {
                   channelListeners.forEach((listener) => listener(logMessage));
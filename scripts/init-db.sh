@@ -5,4 +5,5 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
     CREATE DATABASE auth_db;
     CREATE DATABASE project_db;
     CREATE DATABASE build_db;
+    CREATE DATABASE deployment_db;
 EOSQL
\ No newline at end of file